@@ -57,6 +57,9 @@ func (a *alphabillApp) addSubcommands(opts []interface{}) {
 	a.baseCmd.AddCommand(newEvmGenesisCmd(a.baseConfig))
 	a.baseCmd.AddCommand(newOrchestrationNodeCmd(a.baseConfig))
 	a.baseCmd.AddCommand(newOrchestrationGenesisCmd(a.baseConfig))
+	a.baseCmd.AddCommand(newStateCmd())
+	a.baseCmd.AddCommand(newDbCmd())
+	a.baseCmd.AddCommand(newPredicateCmd(a.baseConfig))
 }
 
 func newBaseCmd(obsF Factory) (*cobra.Command, *baseConfiguration) {
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alphabill-org/alphabill-go-base/util"
+	"github.com/alphabill-org/alphabill/keyvaluedb"
+	"github.com/alphabill-org/alphabill/keyvaluedb/boltdb"
+	"github.com/alphabill-org/alphabill/partition"
+	"github.com/alphabill-org/alphabill/rootchain/consensus/abdrc/storage"
+)
+
+type dbMigrateConfig struct {
+	DbFile string
+	Schema string
+	DryRun bool
+}
+
+// dbSchemas maps the schema names used on the command line to the migrations of the databases.
+var dbSchemas = map[string][]keyvaluedb.Migration{
+	"block-store": partition.BlockStoreMigrations,
+	"proof-index": partition.ProofIndexMigrations,
+	"rootchain":   storage.Migrations,
+}
+
+// newDbCmd creates a new cobra command for working with the node databases.
+func newDbCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "db",
+		Short: "Tools for maintaining the node databases",
+	}
+	cmd.AddCommand(newDbMigrateCmd())
+	return cmd
+}
+
+func newDbMigrateCmd() *cobra.Command {
+	config := &dbMigrateConfig{}
+	var cmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrates the database to the latest schema version",
+		Long:  "Migrates the database to the latest schema version. Nodes migrate their databases on startup, this command can be used to check the pending migrations (--dry-run) or to migrate the database in advance. The node must be stopped while the command is running.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return dbMigrateRunFun(cmd.OutOrStdout(), config)
+		},
+	}
+	cmd.Flags().StringVarP(&config.DbFile, "db", "f", "", "path to the database file")
+	cmd.Flags().StringVar(&config.Schema, "schema", "", fmt.Sprintf("schema of the database, one of [%s]", strings.Join(dbSchemaNames(), " | ")))
+	cmd.Flags().BoolVar(&config.DryRun, "dry-run", false, "print the pending migrations without applying them")
+	if err := cmd.MarkFlagRequired("db"); err != nil {
+		panic(err)
+	}
+	if err := cmd.MarkFlagRequired("schema"); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
+func dbMigrateRunFun(w io.Writer, config *dbMigrateConfig) (err error) {
+	migrations, ok := dbSchemas[config.Schema]
+	if !ok {
+		return fmt.Errorf("unknown schema %q", config.Schema)
+	}
+	if !util.FileExists(config.DbFile) {
+		return fmt.Errorf("database file '%s' not found", config.DbFile)
+	}
+	db, err := boltdb.New(config.DbFile)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer func() { err = errors.Join(err, db.Close()) }()
+
+	var plan *keyvaluedb.MigrationPlan
+	if config.DryRun {
+		plan, err = keyvaluedb.PlanMigrations(db, migrations)
+	} else {
+		plan, err = keyvaluedb.Migrate(db, migrations)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Schema version: %d, latest version: %d\n", plan.CurrentVersion, plan.LatestVersion)
+	switch {
+	case plan.Empty:
+		fmt.Fprintf(w, "Database is empty, no migrations needed\n")
+	case len(plan.Pending) == 0:
+		fmt.Fprintf(w, "Database is up to date\n")
+	default:
+		fmt.Fprintf(w, "Migrations:\n")
+		for _, m := range plan.Pending {
+			fmt.Fprintf(w, "  %d: %s\n", m.Version, m.Description)
+		}
+	}
+	if config.DryRun {
+		fmt.Fprintf(w, "Dry run, database was not modified\n")
+	}
+	return nil
+}
+
+func dbSchemaNames() []string {
+	names := make([]string, 0, len(dbSchemas))
+	for name := range dbSchemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill-go-base/util"
+	testobserve "github.com/alphabill-org/alphabill/internal/testutils/observability"
+	"github.com/alphabill-org/alphabill/keyvaluedb"
+	"github.com/alphabill-org/alphabill/keyvaluedb/boltdb"
+)
+
+func TestDbMigrate(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "blocks.db")
+	db, err := boltdb.New(dbFile)
+	require.NoError(t, err)
+	require.NoError(t, db.Write(util.Uint64ToBytes(1), "block"))
+	require.NoError(t, db.Close())
+
+	runMigrate := func(args ...string) (string, error) {
+		out := &bytes.Buffer{}
+		cmd := New(testobserve.NewFactory(t))
+		cmd.baseCmd.SetOut(out)
+		cmd.baseCmd.SetArgs(append([]string{"db", "migrate", "--db", dbFile}, args...))
+		err := cmd.Execute(context.Background())
+		return out.String(), err
+	}
+
+	out, err := runMigrate("--schema", "block-store", "--dry-run")
+	require.NoError(t, err)
+	require.Contains(t, out, "Schema version: 0, latest version: 1\n")
+	require.Contains(t, out, "  1: initial schema\n")
+	require.Contains(t, out, "Dry run, database was not modified\n")
+
+	out, err = runMigrate("--schema", "block-store")
+	require.NoError(t, err)
+	require.Contains(t, out, "  1: initial schema\n")
+	require.NotContains(t, out, "Dry run")
+
+	out, err = runMigrate("--schema", "block-store", "--dry-run")
+	require.NoError(t, err)
+	require.Contains(t, out, "Schema version: 1, latest version: 1\n")
+	require.Contains(t, out, "Database is up to date\n")
+
+	db, err = boltdb.New(dbFile)
+	require.NoError(t, err)
+	version, found, err := keyvaluedb.ReadSchemaVersion(db)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 1, version)
+	require.NoError(t, db.Close())
+
+	_, err = runMigrate("--schema", "foo")
+	require.ErrorContains(t, err, `unknown schema "foo"`)
+
+	cmd := New(testobserve.NewFactory(t))
+	cmd.baseCmd.SetArgs([]string{"db", "migrate", "--db", filepath.Join(t.TempDir(), "missing.db"), "--schema", "rootchain"})
+	require.ErrorContains(t, cmd.Execute(context.Background()), "not found")
+}
@@ -69,7 +69,7 @@ func runEvmNode(ctx context.Context, cfg *evmConfiguration) error {
 	if stateFilePath == "" {
 		stateFilePath = filepath.Join(cfg.Base.HomeDir, evmDir, evmGenesisStateFileName)
 	}
-	state, err := loadStateFile(stateFilePath, evm.NewUnitData)
+	state, err := loadStateFile(stateFilePath, evm.NewUnitData, cfg.Node.StateSnapshotCount)
 	if err != nil {
 		return fmt.Errorf("loading state (file %s): %w", cfg.Node.StateFile, err)
 	}
@@ -81,12 +81,12 @@ func runEvmNode(ctx context.Context, cfg *evmConfiguration) error {
 		}
 	}
 
-	blockStore, err := initStore(cfg.Node.DbFile)
+	blockStore, err := initStore(cfg.Node.DbFile, partition.BlockStoreMigrations)
 	if err != nil {
 		return fmt.Errorf("unable to initialize block DB: %w", err)
 	}
 
-	proofStore, err := initStore(cfg.Node.TxIndexerDBFile)
+	proofStore, err := initStore(cfg.Node.TxIndexerDBFile, partition.ProofIndexMigrations)
 	if err != nil {
 		return fmt.Errorf("unable to initialize proof DB: %w", err)
 	}
@@ -116,7 +116,7 @@ func runEvmNode(ctx context.Context, cfg *evmConfiguration) error {
 		log,
 		evm.WithBlockGasLimit(params.BlockGasLimit),
 		evm.WithGasPrice(params.GasUnitPrice),
-		evm.WithBlockDB(blockStore),
+		evm.WithBlockDB(partition.BlockStoreBlocks(blockStore)),
 		evm.WithTrustBase(trustBase),
 		evm.WithState(state),
 	)
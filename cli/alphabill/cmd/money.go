@@ -6,13 +6,15 @@ import (
 	"fmt"
 	"path/filepath"
 
-	moneysdk "github.com/alphabill-org/alphabill-go-base/txsystem/money"
 	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill-go-base/util"
 
 	"github.com/alphabill-org/alphabill/logger"
 	"github.com/alphabill-org/alphabill/network/protocol/genesis"
 	"github.com/alphabill-org/alphabill/observability"
 	"github.com/alphabill-org/alphabill/partition"
+	"github.com/alphabill-org/alphabill/predicates"
+	"github.com/alphabill-org/alphabill/predicates/templates"
 	"github.com/alphabill-org/alphabill/rpc"
 	"github.com/alphabill-org/alphabill/txsystem/money"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -24,6 +26,8 @@ type (
 		baseNodeConfiguration
 		Node      *startNodeConfiguration
 		rpcServer *rpc.ServerConfiguration
+		// check the money supply invariant in the end of every block
+		AuditMoneySupply bool
 	}
 
 	// moneyNodeRunnable is the function that is run after configuration is loaded.
@@ -55,6 +59,7 @@ func newMoneyNodeCmd(baseConfig *baseConfiguration, nodeRunFunc moneyNodeRunnabl
 
 	addCommonNodeConfigurationFlags(nodeCmd, config.Node, "money")
 	addRPCServerConfigurationFlags(nodeCmd, config.rpcServer)
+	nodeCmd.Flags().BoolVar(&config.AuditMoneySupply, "audit-money-supply", false, "check the money supply against genesis in the end of every block (debug, traverses the entire state)")
 	return nodeCmd
 }
 
@@ -68,12 +73,16 @@ func runMoneyNode(ctx context.Context, cfg *moneyNodeConfiguration) error {
 	if err := types.Cbor.Unmarshal(pg.Params, params); err != nil {
 		return fmt.Errorf("failed to unmarshal money partition params: %w", err)
 	}
+	gasSchedule, err := genesisGasSchedule(params.GasSchedule)
+	if err != nil {
+		return err
+	}
 
 	stateFilePath := cfg.Node.StateFile
 	if stateFilePath == "" {
 		stateFilePath = filepath.Join(cfg.Base.HomeDir, moneyPartitionDir, moneyGenesisStateFileName)
 	}
-	state, err := loadStateFile(stateFilePath, moneysdk.NewUnitData)
+	state, err := loadStateFile(stateFilePath, money.NewUnitData, cfg.Node.StateSnapshotCount)
 	if err != nil {
 		return fmt.Errorf("loading state (file %s): %w", cfg.Node.StateFile, err)
 	}
@@ -103,25 +112,41 @@ func runMoneyNode(ctx context.Context, cfg *moneyNodeConfiguration) error {
 	log := cfg.Base.observe.Logger().With(logger.NodeID(nodeID))
 	obs := observability.WithLogger(cfg.Base.observe, log)
 
-	blockStore, err := initStore(cfg.Node.DbFile)
+	blockStore, err := initStore(cfg.Node.DbFile, partition.BlockStoreMigrations)
 	if err != nil {
 		return fmt.Errorf("unable to initialize block DB: %w", err)
 	}
 
-	proofStore, err := initStore(cfg.Node.TxIndexerDBFile)
+	proofStore, err := initStore(cfg.Node.TxIndexerDBFile, partition.ProofIndexMigrations)
 	if err != nil {
 		return fmt.Errorf("unable to initialize proof DB: %w", err)
 	}
 
-	txs, err := money.NewTxSystem(
-		*pg.PartitionDescription,
-		types.ShardID{},
-		obs,
+	optEngines, err := predicateEngines(params.PredicateEngines, gasSchedule)
+	if err != nil {
+		return err
+	}
+	predEng, err := predicates.Dispatcher(append(optEngines, templates.New(templates.WithGasSchedule(gasSchedule)))...)
+	if err != nil {
+		return fmt.Errorf("creating predicate executor: %w", err)
+	}
+
+	txsOpts := []money.Option{
 		money.WithHashAlgorithm(crypto.SHA256),
 		money.WithPartitionDescriptionRecords(params.Partitions),
 		money.WithTrustBase(trustBase),
 		money.WithState(state),
-	)
+		money.WithPredicateExecutor(predEng.Execute),
+		money.WithGasSchedule(gasSchedule),
+		money.WithGasScheduleUpdates(params.GasScheduleUpdates),
+		money.WithRewardPolicies(params.RewardPolicies),
+		money.WithFeeCreditRecordExpiry(params.FeeCreditRecordExpiryInterval),
+	}
+	if cfg.AuditMoneySupply {
+		// summary value of the genesis state is the initial bill + DC money supply
+		txsOpts = append(txsOpts, money.WithSupplyAudit(util.BytesToUint64(pg.Certificate.InputRecord.SummaryValue)))
+	}
+	txs, err := money.NewTxSystem(*pg.PartitionDescription, types.ShardID{}, obs, txsOpts...)
 	if err != nil {
 		return fmt.Errorf("creating money transaction system: %w", err)
 	}
@@ -60,6 +60,11 @@ type moneyGenesisConfig struct {
 	InitialBillOwnerPredicate []byte
 	DCMoneySupplyValue        uint64
 	SDRFiles                  []string // system description record filenames
+	GasScheduleFile           string
+	GasScheduleUpdatesFile    string
+	RewardPoliciesFile        string
+	FCRExpiryInterval         uint64
+	PredicateEngines          []string
 }
 
 // newMoneyGenesisCmd creates a new cobra command for the alphabill money partition genesis.
@@ -84,6 +89,11 @@ func newMoneyGenesisCmd(baseConfig *baseConfiguration) *cobra.Command {
 	cmd.Flags().Uint64Var(&config.InitialBillValue, "initial-bill-value", defaultInitialBillValue, "the initial bill value")
 	cmd.Flags().BytesHexVar(&config.InitialBillOwnerPredicate, "initial-bill-owner-predicate", defaultInitialBillOwnerPredicate, "the initial bill owner predicate")
 	cmd.Flags().Uint64Var(&config.DCMoneySupplyValue, "dc-money-supply-value", defaultDCMoneySupplyValue, "the initial value for Dust Collector money supply. Total money sum is initial bill + DC money supply.")
+	cmd.Flags().StringVar(&config.GasScheduleFile, "gas-schedule", "", "filename (full path) from where to read the gas schedule (default: built-in gas schedule)")
+	cmd.Flags().StringVar(&config.GasScheduleUpdatesFile, "gas-schedule-updates", "", "filename (full path) from where to read the gas schedules replacing the gas schedule from their activation round")
+	cmd.Flags().StringVar(&config.RewardPoliciesFile, "reward-policies", "", "filename (full path) from where to read the fee reward distribution policies of the partitions (default: fees are not distributed)")
+	cmd.Flags().Uint64Var(&config.FCRExpiryInterval, "fcr-expiry-interval", 0, "the interval (in rounds) of checking the expired fee credit records, zero disables the expiry")
+	addPredicateEnginesFlag(cmd, &config.PredicateEngines)
 	cmd.Flags().StringSliceVarP(&config.SDRFiles, "system-description-record-files", "c", nil, "path to SDR files (one for each partition, including money partition itself; defaults to single money partition only SDR)")
 	config.Keys.addCmdFlags(cmd)
 	_ = cmd.MarkFlagRequired("partition-description")
@@ -177,8 +187,28 @@ func (c *moneyGenesisConfig) getPartitionParams() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	gasSchedule, err := readGasSchedule(c.GasScheduleFile)
+	if err != nil {
+		return nil, err
+	}
+	gasScheduleUpdates, err := readGasScheduleUpdates(c.GasScheduleUpdatesFile)
+	if err != nil {
+		return nil, err
+	}
+	rewardPolicies, err := c.getRewardPolicies()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := predicateEngines(c.PredicateEngines, gasSchedule); err != nil {
+		return nil, err
+	}
 	src := &genesis.MoneyPartitionParams{
-		Partitions: sdrs,
+		Partitions:                    sdrs,
+		GasSchedule:                   gasSchedule,
+		RewardPolicies:                rewardPolicies,
+		FeeCreditRecordExpiryInterval: c.FCRExpiryInterval,
+		PredicateEngines:              c.PredicateEngines,
+		GasScheduleUpdates:            gasScheduleUpdates,
 	}
 	res, err := types.Cbor.Marshal(src)
 	if err != nil {
@@ -203,6 +233,22 @@ func (c *moneyGenesisConfig) getSDRs() ([]*types.PartitionDescriptionRecord, err
 	return sdrs, nil
 }
 
+func (c *moneyGenesisConfig) getRewardPolicies() ([]*genesis.RewardPolicy, error) {
+	if c.RewardPoliciesFile == "" {
+		return nil, nil
+	}
+	policies, err := util.ReadJsonFile(c.RewardPoliciesFile, &[]*genesis.RewardPolicy{})
+	if err != nil {
+		return nil, fmt.Errorf("loading reward policies: %w", err)
+	}
+	for i, p := range *policies {
+		if err := p.IsValid(); err != nil {
+			return nil, fmt.Errorf("invalid reward policy %d: %w", i, err)
+		}
+	}
+	return *policies, nil
+}
+
 func newGenesisState(config *moneyGenesisConfig) (*state.State, error) {
 	s := state.NewEmptyState()
 
@@ -152,6 +152,29 @@ func Test_MoneyGenesis(t *testing.T) {
 		require.Equal(t, sdr, params.Partitions[0])
 	})
 
+	t.Run("RewardPolicies", func(t *testing.T) {
+		homeDir := t.TempDir()
+		policies := []*genesis.RewardPolicy{{
+			SystemIdentifier: moneysdk.DefaultSystemID,
+			Policy:           genesis.RewardPolicyBlocksProposed,
+			EpochLength:      1000,
+			Validators:       []*genesis.RewardRecipient{{NodeIdentifier: "node1", OwnerPredicate: templates.AlwaysTrueBytes()}},
+		}}
+		policiesFile := filepath.Join(homeDir, "reward-policies.json")
+		require.NoError(t, util.WriteJsonFile(policiesFile, &policies))
+
+		cmd := New(testobserve.NewFactory(t))
+		args := "money-genesis --gen-keys --home " + homeDir + " --reward-policies " + policiesFile + pdrArgument
+		cmd.baseCmd.SetArgs(strings.Split(args, " "))
+		require.NoError(t, cmd.Execute(context.Background()))
+
+		pg, err := util.ReadJsonFile(filepath.Join(homeDir, moneyPartitionDir, moneyGenesisFileName), &genesis.PartitionGenesis{})
+		require.NoError(t, err)
+		params := &genesis.MoneyPartitionParams{}
+		require.NoError(t, types.Cbor.Unmarshal(pg.Params, params))
+		require.Equal(t, policies, params.RewardPolicies)
+	})
+
 	t.Run("InvalidFeeCreditBill_SameAsInitialBill", func(t *testing.T) {
 		homeDir := t.TempDir()
 		require.NoError(t, os.MkdirAll(filepath.Join(homeDir, moneyPartitionDir), 0700))
@@ -28,6 +28,8 @@ import (
 	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
 	test "github.com/alphabill-org/alphabill/internal/testutils/time"
 	"github.com/alphabill-org/alphabill/network/protocol/genesis"
+	"github.com/alphabill-org/alphabill/predicates"
+	"github.com/alphabill-org/alphabill/predicates/script"
 	rootgenesis "github.com/alphabill-org/alphabill/rootchain/genesis"
 	"github.com/alphabill-org/alphabill/rpc"
 )
@@ -440,3 +442,18 @@ func buildRpcUrl(url string) string {
 	}
 	return url
 }
+
+func Test_predicateEngines(t *testing.T) {
+	engines, err := predicateEngines(nil, predicates.DefaultGasSchedule())
+	require.NoError(t, err)
+	require.Empty(t, engines)
+
+	engines, err = predicateEngines([]string{"script"}, predicates.DefaultGasSchedule())
+	require.NoError(t, err)
+	require.Len(t, engines, 1)
+	require.EqualValues(t, script.PredicateEngineID, engines[0].ID())
+
+	engines, err = predicateEngines([]string{"script", "foo"}, predicates.DefaultGasSchedule())
+	require.EqualError(t, err, `unknown predicate engine "foo"`)
+	require.Nil(t, engines)
+}
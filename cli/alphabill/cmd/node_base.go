@@ -5,10 +5,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/alphabill-org/alphabill-go-base/types"
 	"github.com/alphabill-org/alphabill-go-base/util"
@@ -20,6 +22,8 @@ import (
 	"github.com/alphabill-org/alphabill/network"
 	"github.com/alphabill-org/alphabill/network/protocol/genesis"
 	"github.com/alphabill-org/alphabill/partition"
+	"github.com/alphabill-org/alphabill/predicates"
+	"github.com/alphabill-org/alphabill/predicates/script"
 	"github.com/alphabill-org/alphabill/rpc"
 	"github.com/alphabill-org/alphabill/state"
 	"github.com/alphabill-org/alphabill/txsystem"
@@ -50,6 +54,7 @@ type startNodeConfiguration struct {
 	WithOwnerIndex             bool
 	LedgerReplicationMaxBlocks uint64
 	LedgerReplicationMaxTx     uint32
+	StateSnapshotCount         int
 	BootStrapAddresses         string // boot strap addresses (libp2p multiaddress format)
 }
 
@@ -175,7 +180,7 @@ func createNode(ctx context.Context,
 		return nil, errors.New("root validator info is missing")
 	}
 	if blockStore == nil {
-		blockStore, err = initStore(cfg.DbFile)
+		blockStore, err = initStore(cfg.DbFile, partition.BlockStoreMigrations)
 		if err != nil {
 			return nil, err
 		}
@@ -205,11 +210,23 @@ func createNode(ctx context.Context,
 	return node, nil
 }
 
-func initStore(dbFile string) (keyvaluedb.KeyValueDB, error) {
+// initStore opens the database and migrates it to the latest schema version.
+func initStore(dbFile string, migrations []keyvaluedb.Migration) (db keyvaluedb.KeyValueDB, err error) {
 	if dbFile != "" {
-		return boltdb.New(dbFile)
+		db, err = boltdb.New(dbFile)
+	} else {
+		db, err = memorydb.New()
 	}
-	return memorydb.New()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := keyvaluedb.Migrate(db, migrations); err != nil {
+		if c, ok := db.(io.Closer); ok {
+			err = errors.Join(err, c.Close())
+		}
+		return nil, fmt.Errorf("migrating database %s: %w", dbFile, err)
+	}
+	return db, nil
 }
 
 func loadPartitionGenesis(genesisPath string) (*genesis.PartitionGenesis, error) {
@@ -220,7 +237,7 @@ func loadPartitionGenesis(genesisPath string) (*genesis.PartitionGenesis, error)
 	return pg, nil
 }
 
-func loadStateFile(stateFilePath string, unitDataConstructor state.UnitDataConstructor) (*state.State, error) {
+func loadStateFile(stateFilePath string, unitDataConstructor state.UnitDataConstructor, snapshotCount int) (*state.State, error) {
 	if !util.FileExists(stateFilePath) {
 		return nil, fmt.Errorf("state file '%s' not found", stateFilePath)
 	}
@@ -231,7 +248,7 @@ func loadStateFile(stateFilePath string, unitDataConstructor state.UnitDataConst
 	}
 	defer stateFile.Close()
 
-	state, err := state.NewRecoveredState(stateFile, unitDataConstructor)
+	state, err := state.NewRecoveredState(stateFile, unitDataConstructor, state.WithSnapshotCount(snapshotCount))
 	if err != nil {
 		return nil, err
 	}
@@ -251,6 +268,100 @@ func addCommonNodeConfigurationFlags(nodeCmd *cobra.Command, config *startNodeCo
 	nodeCmd.Flags().BoolVar(&config.WithOwnerIndex, "with-owner-index", true, "enable/disable owner indexer")
 	nodeCmd.Flags().Uint64Var(&config.LedgerReplicationMaxBlocks, "ledger-replication-max-blocks", 1000, "maximum number of blocks to return in a single replication response")
 	nodeCmd.Flags().Uint32Var(&config.LedgerReplicationMaxTx, "ledger-replication-max-transactions", 10000, "maximum number of transactions to return in a single replication response")
+	nodeCmd.Flags().IntVar(&config.StateSnapshotCount, "state-snapshot-count", state.DefaultSnapshotCount, "number of last committed state snapshots kept in memory for RPC reads")
+}
+
+/*
+optionalPredicateEngines are the predicate engines which partition may enable in
+addition to the predicate templates. The engines are set in the partition params in
+genesis (see --predicate-engines flag of the genesis commands) so all the validators
+of the partition use the same engines.
+*/
+var optionalPredicateEngines = map[string]func(gs *predicates.GasSchedule) predicates.PredicateEngine{
+	"script": func(gs *predicates.GasSchedule) predicates.PredicateEngine {
+		return script.New(script.WithGasSchedule(gs))
+	},
+}
+
+func addPredicateEnginesFlag(cmd *cobra.Command, engines *[]string) {
+	cmd.Flags().StringSliceVar(engines, "predicate-engines", nil,
+		fmt.Sprintf("additional predicate engines to enable in the partition, any of [%s]", strings.Join(optionalPredicateEngineNames(), ", ")))
+}
+
+// optionalPredicateEngineNames returns the sorted names of the optional predicate engines.
+func optionalPredicateEngineNames() []string {
+	names := make([]string, 0, len(optionalPredicateEngines))
+	for name := range optionalPredicateEngines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+/*
+predicateEngines returns the optional predicate engines with given names, the engines
+charge gas according to the gas schedule "gs".
+*/
+func predicateEngines(names []string, gs *predicates.GasSchedule) ([]predicates.PredicateEngine, error) {
+	engines := make([]predicates.PredicateEngine, 0, len(names))
+	for _, name := range names {
+		newEngine, ok := optionalPredicateEngines[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown predicate engine %q", name)
+		}
+		engines = append(engines, newEngine(gs))
+	}
+	return engines, nil
+}
+
+/*
+readGasSchedule reads the gas schedule from the JSON file, when the filename is
+empty the default gas schedule is returned.
+*/
+func readGasSchedule(filename string) (*predicates.GasSchedule, error) {
+	if filename == "" {
+		return predicates.DefaultGasSchedule(), nil
+	}
+	gs, err := util.ReadJsonFile(filename, &predicates.GasSchedule{})
+	if err != nil {
+		return nil, fmt.Errorf("loading gas schedule: %w", err)
+	}
+	if err := gs.IsValid(); err != nil {
+		return nil, fmt.Errorf("invalid gas schedule: %w", err)
+	}
+	return gs, nil
+}
+
+/*
+readGasScheduleUpdates reads the list of the gas schedule updates from the JSON file,
+when the filename is empty there are no updates.
+*/
+func readGasScheduleUpdates(filename string) (predicates.GasScheduleUpdates, error) {
+	if filename == "" {
+		return nil, nil
+	}
+	updates, err := util.ReadJsonFile(filename, &predicates.GasScheduleUpdates{})
+	if err != nil {
+		return nil, fmt.Errorf("loading gas schedule updates: %w", err)
+	}
+	if err := updates.IsValid(); err != nil {
+		return nil, fmt.Errorf("invalid gas schedule updates: %w", err)
+	}
+	return *updates, nil
+}
+
+/*
+genesisGasSchedule returns the gas schedule of the partition genesis or the default
+gas schedule when the genesis doesn't define it (created by older version of the node).
+*/
+func genesisGasSchedule(gs *predicates.GasSchedule) (*predicates.GasSchedule, error) {
+	if gs == nil {
+		return predicates.DefaultGasSchedule(), nil
+	}
+	if err := gs.IsValid(); err != nil {
+		return nil, fmt.Errorf("invalid gas schedule in partition genesis: %w", err)
+	}
+	return gs, nil
 }
 
 func addRPCServerConfigurationFlags(cmd *cobra.Command, c *rpc.ServerConfiguration) {
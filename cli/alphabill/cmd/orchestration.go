@@ -66,7 +66,7 @@ func runOrchestrationNode(ctx context.Context, cfg *orchestrationConfiguration)
 	if stateFilePath == "" {
 		stateFilePath = filepath.Join(cfg.Base.HomeDir, orchestrationPartitionDir, orchestrationGenesisFileName)
 	}
-	state, err := loadStateFile(stateFilePath, sdkorchestration.NewVarData)
+	state, err := loadStateFile(stateFilePath, sdkorchestration.NewVarData, cfg.Node.StateSnapshotCount)
 	if err != nil {
 		return fmt.Errorf("loading state (file %s): %w", cfg.Node.StateFile, err)
 	}
@@ -96,12 +96,12 @@ func runOrchestrationNode(ctx context.Context, cfg *orchestrationConfiguration)
 	log := cfg.Base.observe.Logger().With(logger.NodeID(nodeID))
 	obs := observability.WithLogger(cfg.Base.observe, log)
 
-	blockStore, err := initStore(cfg.Node.DbFile)
+	blockStore, err := initStore(cfg.Node.DbFile, partition.BlockStoreMigrations)
 	if err != nil {
 		return fmt.Errorf("unable to initialize block DB: %w", err)
 	}
 
-	proofStore, err := initStore(cfg.Node.TxIndexerDBFile)
+	proofStore, err := initStore(cfg.Node.TxIndexerDBFile, partition.ProofIndexMigrations)
 	if err != nil {
 		return fmt.Errorf("unable to initialize proof DB: %w", err)
 	}
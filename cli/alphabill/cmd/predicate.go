@@ -0,0 +1,415 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	sdkpredicates "github.com/alphabill-org/alphabill-go-base/predicates"
+	sdkwasm "github.com/alphabill-org/alphabill-go-base/predicates/wasm"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/logger"
+	"github.com/alphabill-org/alphabill/network/protocol/genesis"
+	"github.com/alphabill-org/alphabill/observability"
+	"github.com/alphabill-org/alphabill/predicates"
+	"github.com/alphabill-org/alphabill/predicates/templates"
+	"github.com/alphabill-org/alphabill/predicates/wasm"
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm"
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/encoder"
+	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
+	"github.com/alphabill-org/alphabill/txsystem/fc"
+	"github.com/alphabill-org/alphabill/txsystem/money"
+	"github.com/alphabill-org/alphabill/txsystem/tokens"
+
+	// register WASM encoders of all the tx systems
+	_ "github.com/alphabill-org/alphabill/txsystem/evm/encoder"
+	_ "github.com/alphabill-org/alphabill/txsystem/fc/encoder"
+	_ "github.com/alphabill-org/alphabill/txsystem/money/encoder"
+	_ "github.com/alphabill-org/alphabill/txsystem/orchestration/encoder"
+	_ "github.com/alphabill-org/alphabill/txsystem/tokens/encoder"
+)
+
+type predicateRunConfig struct {
+	Base *baseConfiguration
+
+	Predicate     []byte
+	WasmFile      string
+	Entrypoint    string
+	PredicateConf []byte
+	Args          []byte
+	TxFile        string
+	Units         []string
+	Partition     string
+	Round         uint64
+	Gas           uint64
+	TrustBaseFile string
+	GenesisFile   string
+	Trace         bool
+}
+
+// predicatePartition describes the unit data types and the genesis params of the
+// partition the predicate is evaluated for.
+type predicatePartition struct {
+	unitData func(types.UnitID) (types.UnitData, error)
+	params   func(data []byte) (*predicateParams, error)
+}
+
+// predicateParams are the partition genesis params which affect predicate evaluation.
+type predicateParams struct {
+	GasSchedule        *predicates.GasSchedule
+	GasScheduleUpdates predicates.GasScheduleUpdates
+	PredicateEngines   []string
+}
+
+var predicatePartitions = map[string]predicatePartition{
+	"money": {
+		unitData: money.NewUnitData,
+		params: func(data []byte) (*predicateParams, error) {
+			params := &genesis.MoneyPartitionParams{}
+			if err := types.Cbor.Unmarshal(data, params); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal money partition params: %w", err)
+			}
+			return &predicateParams{GasSchedule: params.GasSchedule, GasScheduleUpdates: params.GasScheduleUpdates, PredicateEngines: params.PredicateEngines}, nil
+		},
+	},
+	"tokens": {
+		unitData: tokens.NewUnitData,
+		params: func(data []byte) (*predicateParams, error) {
+			params := &genesis.TokensPartitionParams{}
+			if err := types.Cbor.Unmarshal(data, params); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal tokens partition params: %w", err)
+			}
+			return &predicateParams{GasSchedule: params.GasSchedule, GasScheduleUpdates: params.GasScheduleUpdates, PredicateEngines: params.PredicateEngines}, nil
+		},
+	},
+}
+
+// newPredicateCmd creates a new cobra command for debugging predicates.
+func newPredicateCmd(baseConfig *baseConfiguration) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "predicate",
+		Short: "Tools for developing and debugging predicates",
+	}
+	cmd.AddCommand(newPredicateRunCmd(baseConfig))
+	return cmd
+}
+
+func newPredicateRunCmd(baseConfig *baseConfiguration) *cobra.Command {
+	config := &predicateRunConfig{Base: baseConfig}
+	var cmd = &cobra.Command{
+		Use:   "run",
+		Short: "Evaluates the predicate offline",
+		Long: `Evaluates the predicate offline, without submitting transactions to the network.
+The predicate is evaluated in a mock transaction execution context built from the
+command line arguments, the result, gas used, log messages of the predicate and the
+host API calls are printed. Trace mode additionally prints gas usage per function.`,
+		Example: `alphabill predicate run --wasm pred.wasm --entrypoint type_bearer --tx tx.cbor --partition tokens --unit 2400...01=8a0c...`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return predicateRunFun(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+	cmd.Flags().BytesHexVar(&config.Predicate, "predicate", nil, "the CBOR encoded predicate (ie template or WASM predicate) as hex")
+	cmd.Flags().StringVar(&config.WasmFile, "wasm", "", "path to the WASM binary of the predicate")
+	cmd.Flags().StringVar(&config.Entrypoint, "entrypoint", "", "the function to call in the WASM binary")
+	cmd.Flags().BytesHexVar(&config.PredicateConf, "predicate-conf", nil, "the configuration (fixed arguments) of the WASM predicate as hex")
+	cmd.Flags().BytesHexVar(&config.Args, "args", nil, "the arguments of the predicate (ie owner proof) as hex")
+	cmd.Flags().StringVar(&config.TxFile, "tx", "", "path to the file containing CBOR encoded transaction order which triggered the predicate")
+	cmd.Flags().StringArrayVar(&config.Units, "unit", nil, "unit available to the predicate as <unit id hex>=<CBOR encoded unit data hex>, can be repeated")
+	cmd.Flags().StringVar(&config.Partition, "partition", "tokens", fmt.Sprintf("the partition the predicate is evaluated for, one of [%s]", strings.Join(predicatePartitionNames(), " | ")))
+	cmd.Flags().Uint64Var(&config.Round, "round", 1, "the current round number")
+	cmd.Flags().Uint64Var(&config.Gas, "gas", 100*fc.GasUnitsPerTema, "the gas available for the predicate")
+	cmd.Flags().StringVar(&config.TrustBaseFile, "trust-base", "", "path to the root trust base file")
+	cmd.Flags().StringVar(&config.GenesisFile, "genesis", "", "path to the partition genesis file, the gas schedule and predicate engines of the partition are used (by default the default gas schedule and all the predicate engines)")
+	cmd.Flags().BoolVar(&config.Trace, "trace", false, "print gas usage per function")
+	cmd.MarkFlagsMutuallyExclusive("predicate", "wasm")
+	cmd.MarkFlagsMutuallyExclusive("predicate", "entrypoint")
+	cmd.MarkFlagsMutuallyExclusive("predicate", "predicate-conf")
+	cmd.MarkFlagsOneRequired("predicate", "wasm")
+	cmd.MarkFlagsRequiredTogether("wasm", "entrypoint")
+	return cmd
+}
+
+func predicateRunFun(ctx context.Context, w io.Writer, config *predicateRunConfig) (err error) {
+	partition, ok := predicatePartitions[config.Partition]
+	if !ok {
+		return fmt.Errorf("unknown partition %q", config.Partition)
+	}
+	predicate, err := config.predicateBytes()
+	if err != nil {
+		return fmt.Errorf("loading predicate: %w", err)
+	}
+	env, err := config.evalEnvironment(partition.unitData)
+	if err != nil {
+		return err
+	}
+	params, err := config.partitionParams(partition)
+	if err != nil {
+		return err
+	}
+	gasSchedule, err := genesisGasSchedule(params.GasSchedule)
+	if err != nil {
+		return err
+	}
+	gasSchedule = params.GasScheduleUpdates.ScheduleOf(config.Round, gasSchedule)
+
+	// predicate log messages and debug output of the engines are printed to the output of the command
+	h, err := (&logger.LogConfiguration{Level: "debug", Format: "wallet"}).Handler(w)
+	if err != nil {
+		return fmt.Errorf("creating log handler: %w", err)
+	}
+	obs := observability.WithLogger(config.Base.observe, slog.New(h))
+
+	enc, err := encoder.NewFromRegistry()
+	if err != nil {
+		return fmt.Errorf("creating encoders for WASM predicate engine: %w", err)
+	}
+	templateEng := templates.New(templates.WithGasSchedule(gasSchedule))
+	tpe, err := predicates.Dispatcher(templateEng)
+	if err != nil {
+		return fmt.Errorf("creating predicate executor for WASM engine: %w", err)
+	}
+	optEngines, err := predicateEngines(params.PredicateEngines, gasSchedule)
+	if err != nil {
+		return err
+	}
+	tracer := wvm.NewGasTracer()
+	wasmEng := wasm.New(enc, tpe.Execute, obs, wvm.WithModuleCacheSize(0), wvm.WithFunctionListener(tracer), wvm.WithGasSchedule(gasSchedule))
+	defer func() { err = errors.Join(err, wasmEng.Close(ctx)) }()
+	predEng, err := predicates.Dispatcher(append(optEngines, templateEng, wasmEng)...)
+	if err != nil {
+		return fmt.Errorf("creating predicate executor: %w", err)
+	}
+
+	res, evalErr := predEng.Execute(ctx, predicate, config.Args, env.sigBytes, env)
+
+	switch {
+	case evalErr != nil:
+		fmt.Fprintf(w, "result: error: %v\n", evalErr)
+	default:
+		fmt.Fprintf(w, "result: %t\n", res)
+	}
+	if code := tracer.Result(); len(code) == 1 {
+		fmt.Fprintf(w, "result code: 0x%x\n", code[0])
+	}
+	fmt.Fprintf(w, "gas used: %d\n", env.initialGas-env.remainingGas)
+
+	if calls := tracer.HostCalls(); len(calls) > 0 {
+		fmt.Fprintln(w, "host API calls:")
+		for _, c := range calls {
+			fmt.Fprintf(w, "  %s%s", c.Name, formatUint64s(c.Params))
+			if c.Err != nil {
+				fmt.Fprintf(w, " aborted: %v", c.Err)
+			} else if len(c.Results) > 0 {
+				fmt.Fprintf(w, " -> %s", formatUint64s(c.Results))
+			}
+			fmt.Fprintf(w, " gas: %d\n", c.Gas)
+		}
+	}
+	if config.Trace {
+		fmt.Fprintln(w, "gas usage per function (including the functions it called):")
+		fmt.Fprintf(w, "  %-40s %8s %10s\n", "FUNCTION", "CALLS", "GAS")
+		for _, f := range tracer.Functions() {
+			fmt.Fprintf(w, "  %-40s %8d %10d\n", f.Name, f.Calls, f.Gas)
+		}
+	}
+	return nil
+}
+
+// predicateBytes returns the predicate to evaluate, the WASM binary is wrapped into WASM predicate.
+func (c *predicateRunConfig) predicateBytes() (types.PredicateBytes, error) {
+	if c.WasmFile == "" {
+		if len(c.Predicate) == 0 {
+			return nil, errors.New("predicate is not provided")
+		}
+		return c.Predicate, nil
+	}
+
+	code, err := os.ReadFile(c.WasmFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading WASM binary: %w", err)
+	}
+	par := sdkwasm.PredicateParams{Entrypoint: c.Entrypoint, Args: c.PredicateConf}
+	if err := par.IsValid(); err != nil {
+		return nil, fmt.Errorf("invalid WASM predicate parameters: %w", err)
+	}
+	params, err := types.Cbor.Marshal(par)
+	if err != nil {
+		return nil, fmt.Errorf("encoding WASM predicate parameters: %w", err)
+	}
+	return sdkpredicates.Predicate{Tag: sdkwasm.PredicateEngineID, Code: code, Params: params}.AsBytes()
+}
+
+/*
+partitionParams returns the predicate related params of the partition genesis, without
+the genesis file the default gas schedule and all the optional predicate engines are used.
+*/
+func (c *predicateRunConfig) partitionParams(partition predicatePartition) (*predicateParams, error) {
+	if c.GenesisFile == "" {
+		return &predicateParams{PredicateEngines: optionalPredicateEngineNames()}, nil
+	}
+	pg, err := loadPartitionGenesis(c.GenesisFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading partition genesis: %w", err)
+	}
+	return partition.params(pg.Params)
+}
+
+func (c *predicateRunConfig) evalEnvironment(unitData func(types.UnitID) (types.UnitData, error)) (*predicateRunEnv, error) {
+	env := &predicateRunEnv{
+		units:        make(map[string]*state.Unit),
+		storage:      make(map[string][]byte),
+		round:        c.Round,
+		initialGas:   c.Gas,
+		remainingGas: c.Gas,
+	}
+
+	if c.TxFile != "" {
+		buf, err := os.ReadFile(c.TxFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading transaction order: %w", err)
+		}
+		env.txo = &types.TransactionOrder{}
+		if err := types.Cbor.Unmarshal(buf, env.txo); err != nil {
+			return nil, fmt.Errorf("decoding transaction order: %w", err)
+		}
+	}
+
+	if c.TrustBaseFile != "" {
+		tb, err := types.NewTrustBaseFromFile(c.TrustBaseFile)
+		if err != nil {
+			return nil, err
+		}
+		env.trustBase = tb
+	}
+
+	for _, u := range c.Units {
+		id, data, err := parseUnitFixture(u, unitData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid unit %q: %w", u, err)
+		}
+		env.units[string(id)] = state.NewUnit(data)
+	}
+	return env, nil
+}
+
+func parseUnitFixture(s string, unitData func(types.UnitID) (types.UnitData, error)) (types.UnitID, types.UnitData, error) {
+	idHex, dataHex, ok := strings.Cut(s, "=")
+	if !ok {
+		return nil, nil, errors.New("expected <unit id hex>=<unit data hex>")
+	}
+	id, err := hex.DecodeString(strings.TrimPrefix(idHex, "0x"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding unit id: %w", err)
+	}
+	buf, err := hex.DecodeString(strings.TrimPrefix(dataHex, "0x"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding unit data: %w", err)
+	}
+	data, err := unitData(id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating unit data: %w", err)
+	}
+	if err := types.Cbor.Unmarshal(buf, data); err != nil {
+		return nil, nil, fmt.Errorf("decoding unit data: %w", err)
+	}
+	return id, data, nil
+}
+
+func predicatePartitionNames() []string {
+	names := make([]string, 0, len(predicatePartitions))
+	for k := range predicatePartitions {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func formatUint64s(v []uint64) string {
+	s := make([]string, len(v))
+	for i, x := range v {
+		s[i] = fmt.Sprintf("0x%x", x)
+	}
+	return "(" + strings.Join(s, ", ") + ")"
+}
+
+/*
+predicateRunEnv is the mock transaction execution context the predicate is
+evaluated in by the "predicate run" command.
+*/
+type predicateRunEnv struct {
+	units        map[string]*state.Unit
+	storage      map[string][]byte
+	round        uint64
+	trustBase    types.RootTrustBase
+	txo          *types.TransactionOrder
+	initialGas   uint64
+	remainingGas uint64
+}
+
+func (env *predicateRunEnv) GetUnit(id types.UnitID, committed bool) (*state.Unit, error) {
+	if u, ok := env.units[string(id)]; ok {
+		return u, nil
+	}
+	return nil, fmt.Errorf("unit %s: %w", id, avl.ErrNotFound)
+}
+
+func (env *predicateRunEnv) CurrentRound() uint64 { return env.round }
+
+func (env *predicateRunEnv) TrustBase(epoch uint64) (types.RootTrustBase, error) {
+	if env.trustBase == nil {
+		return nil, errors.New("trust base is not provided (--trust-base)")
+	}
+	return env.trustBase, nil
+}
+
+func (env *predicateRunEnv) TransactionOrder() (*types.TransactionOrder, error) {
+	if env.txo == nil {
+		return nil, types.ErrTransactionOrderIsNil
+	}
+	return env.txo, nil
+}
+
+func (env *predicateRunEnv) sigBytes() ([]byte, error) {
+	if env.txo == nil {
+		return nil, errors.New("transaction order is not provided (--tx)")
+	}
+	return env.txo.AuthProofSigBytes()
+}
+
+func (env *predicateRunEnv) GasAvailable() uint64 { return env.remainingGas }
+
+func (env *predicateRunEnv) SpendGas(gas uint64) error {
+	if gas > env.remainingGas {
+		env.remainingGas = 0
+		return types.ErrOutOfGas
+	}
+	env.remainingGas -= gas
+	return nil
+}
+
+func (env *predicateRunEnv) CalculateCost() uint64 {
+	gasUsed := env.initialGas - env.remainingGas
+	return (gasUsed + fc.GasUnitsPerTema/2) / fc.GasUnitsPerTema
+}
+
+func (env *predicateRunEnv) ReadPredicateData(predicateHash, key []byte) ([]byte, error) {
+	return env.storage[string(predicateHash)+string(key)], nil
+}
+
+func (env *predicateRunEnv) WritePredicateData(predicateHash, key, value []byte) error {
+	if len(value) == 0 {
+		delete(env.storage, string(predicateHash)+string(key))
+	} else {
+		env.storage[string(predicateHash)+string(key)] = value
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
+	tokenssdk "github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill-go-base/util"
+	testobserve "github.com/alphabill-org/alphabill/internal/testutils/observability"
+	"github.com/alphabill-org/alphabill/network/protocol/genesis"
+	"github.com/alphabill-org/alphabill/predicates"
+	"github.com/alphabill-org/alphabill/predicates/script"
+	"github.com/alphabill-org/alphabill/tree/avl"
+)
+
+func TestPredicateRun(t *testing.T) {
+	runPredicate := func(args ...string) (string, error) {
+		out := &bytes.Buffer{}
+		cmd := New(testobserve.NewFactory(t))
+		cmd.baseCmd.SetOut(out)
+		cmd.baseCmd.SetArgs(append([]string{"predicate", "run"}, args...))
+		err := cmd.Execute(context.Background())
+		return out.String(), err
+	}
+
+	t.Run("always true", func(t *testing.T) {
+		out, err := runPredicate("--predicate", hex.EncodeToString(templates.AlwaysTrueBytes()))
+		require.NoError(t, err)
+		require.Contains(t, out, "result: true\n")
+		require.Contains(t, out, "gas used: 100\n")
+		require.NotContains(t, out, "result code")
+	})
+
+	t.Run("always false", func(t *testing.T) {
+		out, err := runPredicate("--predicate", hex.EncodeToString(templates.AlwaysFalseBytes()))
+		require.NoError(t, err)
+		require.Contains(t, out, "result: false\n")
+	})
+
+	t.Run("out of gas", func(t *testing.T) {
+		out, err := runPredicate("--predicate", hex.EncodeToString(templates.AlwaysTrueBytes()), "--gas", "10")
+		require.NoError(t, err)
+		require.Contains(t, out, "result: error: ")
+		require.Contains(t, out, "gas used: 10\n")
+	})
+
+	t.Run("p2pkh without tx", func(t *testing.T) {
+		out, err := runPredicate("--predicate", hex.EncodeToString(templates.NewP2pkh256BytesFromKey(make([]byte, 33))))
+		require.NoError(t, err)
+		require.Contains(t, out, "transaction order is not provided (--tx)")
+	})
+
+	t.Run("script predicate", func(t *testing.T) {
+		code, err := (&script.Builder{}).Op(script.OpRound).PushU64(10).Op(script.OpLess).Bytes()
+		require.NoError(t, err)
+		predicate, err := script.NewPredicateBytes(code)
+		require.NoError(t, err)
+
+		out, err := runPredicate("--predicate", hex.EncodeToString(predicate), "--round", "5")
+		require.NoError(t, err)
+		require.Contains(t, out, "result: true\n")
+
+		out, err = runPredicate("--predicate", hex.EncodeToString(predicate), "--round", "10")
+		require.NoError(t, err)
+		require.Contains(t, out, "result: false\n")
+	})
+
+	t.Run("partition genesis", func(t *testing.T) {
+		gasSchedule := predicates.DefaultGasSchedule()
+		gasSchedule.AlwaysTrue = 250
+		update := predicates.DefaultGasSchedule()
+		update.AlwaysTrue = 300
+		params, err := types.Cbor.Marshal(&genesis.TokensPartitionParams{
+			GasSchedule:        gasSchedule,
+			GasScheduleUpdates: predicates.GasScheduleUpdates{{ActivationRound: 20, Schedule: update}},
+		})
+		require.NoError(t, err)
+		genesisFile := filepath.Join(t.TempDir(), "genesis.json")
+		require.NoError(t, util.WriteJsonFile(genesisFile, &genesis.PartitionGenesis{Params: params}))
+
+		out, err := runPredicate("--predicate", hex.EncodeToString(templates.AlwaysTrueBytes()), "--genesis", genesisFile)
+		require.NoError(t, err)
+		require.Contains(t, out, "gas used: 250\n")
+
+		out, err = runPredicate("--predicate", hex.EncodeToString(templates.AlwaysTrueBytes()), "--genesis", genesisFile, "--round", "20")
+		require.NoError(t, err)
+		require.Contains(t, out, "gas used: 300\n")
+
+		// script engine is not enabled in the partition
+		code, err := (&script.Builder{}).Op(script.OpRound).Bytes()
+		require.NoError(t, err)
+		predicate, err := script.NewPredicateBytes(code)
+		require.NoError(t, err)
+		out, err = runPredicate("--predicate", hex.EncodeToString(predicate), "--genesis", genesisFile)
+		require.NoError(t, err)
+		require.Contains(t, out, "result: error: ")
+
+		_, err = runPredicate("--predicate", hex.EncodeToString(predicate), "--genesis", filepath.Join(t.TempDir(), "missing.json"))
+		require.ErrorContains(t, err, "loading partition genesis")
+	})
+
+	t.Run("WASM predicate", func(t *testing.T) {
+		wasmFile := filepath.Join("..", "..", "..", "predicates", "wasm", "wvm", "testdata", "infinite", "infinite.wasm")
+		out, err := runPredicate("--wasm", wasmFile, "--entrypoint", "ab_main", "--trace")
+		require.NoError(t, err)
+		require.Contains(t, out, "__heap_base is not exported from the predicate module")
+		require.Contains(t, out, "gas usage per function")
+	})
+
+	t.Run("invalid flags", func(t *testing.T) {
+		_, err := runPredicate()
+		require.ErrorContains(t, err, "at least one of the flags in the group [predicate wasm] is required")
+
+		_, err = runPredicate("--wasm", "pred.wasm")
+		require.ErrorContains(t, err, "if any flags in the group [wasm entrypoint] are set they must all be set")
+
+		_, err = runPredicate("--predicate", "00", "--partition", "foo")
+		require.EqualError(t, err, `unknown partition "foo"`)
+
+		_, err = runPredicate("--predicate", "00", "--unit", "0102")
+		require.ErrorContains(t, err, `invalid unit "0102": expected <unit id hex>=<unit data hex>`)
+	})
+}
+
+func Test_parseUnitFixture(t *testing.T) {
+	unitID := tokenssdk.NewFungibleTokenID(nil, []byte{1, 2, 3})
+	data := &tokenssdk.FungibleTokenData{
+		TokenType:      tokenssdk.NewFungibleTokenTypeID(nil, []byte{4}),
+		Value:          100,
+		OwnerPredicate: templates.AlwaysTrueBytes(),
+		Counter:        5,
+	}
+	buf, err := types.Cbor.Marshal(data)
+	require.NoError(t, err)
+
+	id, ud, err := parseUnitFixture(fmt.Sprintf("0x%x=%x", []byte(unitID), buf), tokenssdk.NewUnitData)
+	require.NoError(t, err)
+	require.EqualValues(t, unitID, id)
+	require.Equal(t, data, ud)
+
+	_, _, err = parseUnitFixture("zz=00", tokenssdk.NewUnitData)
+	require.ErrorContains(t, err, "decoding unit id")
+
+	_, _, err = parseUnitFixture(fmt.Sprintf("%x=a0", []byte(unitID)), tokenssdk.NewUnitData)
+	require.ErrorContains(t, err, "decoding unit data")
+}
+
+func Test_predicateRunEnv(t *testing.T) {
+	unitID := tokenssdk.NewFungibleTokenID(nil, []byte{1})
+	buf, err := types.Cbor.Marshal(&tokenssdk.FungibleTokenData{Value: 1})
+	require.NoError(t, err)
+	cfg := &predicateRunConfig{Round: 7, Gas: 1500, Units: []string{fmt.Sprintf("%x=%x", []byte(unitID), buf)}}
+	env, err := cfg.evalEnvironment(tokenssdk.NewUnitData)
+	require.NoError(t, err)
+
+	require.EqualValues(t, 7, env.CurrentRound())
+	u, err := env.GetUnit(unitID, false)
+	require.NoError(t, err)
+	require.NotNil(t, u)
+	_, err = env.GetUnit(tokenssdk.NewFungibleTokenID(nil, []byte{2}), false)
+	require.ErrorIs(t, err, avl.ErrNotFound)
+
+	txo, err := env.TransactionOrder()
+	require.ErrorIs(t, err, types.ErrTransactionOrderIsNil)
+	require.Nil(t, txo)
+	_, err = env.TrustBase(0)
+	require.EqualError(t, err, "trust base is not provided (--trust-base)")
+
+	require.NoError(t, env.SpendGas(1000))
+	require.EqualValues(t, 500, env.GasAvailable())
+	require.EqualValues(t, 1, env.CalculateCost())
+	require.ErrorIs(t, env.SpendGas(501), types.ErrOutOfGas)
+	require.Zero(t, env.GasAvailable())
+
+	hash, key := []byte{1, 2}, []byte{3}
+	require.NoError(t, env.WritePredicateData(hash, key, []byte{4}))
+	v, err := env.ReadPredicateData(hash, key)
+	require.NoError(t, err)
+	require.Equal(t, []byte{4}, v)
+	require.NoError(t, env.WritePredicateData(hash, key, nil))
+	v, err = env.ReadPredicateData(hash, key)
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -32,6 +33,7 @@ import (
 	"github.com/alphabill-org/alphabill/rootchain"
 	"github.com/alphabill-org/alphabill/rootchain/consensus"
 	"github.com/alphabill-org/alphabill/rootchain/consensus/abdrc"
+	"github.com/alphabill-org/alphabill/rootchain/consensus/abdrc/storage"
 	"github.com/alphabill-org/alphabill/rootchain/consensus/trustbase"
 	"github.com/alphabill-org/alphabill/rootchain/partitions"
 )
@@ -150,10 +152,17 @@ func getBootStrapNodes(bootNodesStr string) ([]peer.AddrInfo, error) {
 }
 
 func initRootStore(dbPath string) (*boltdb.BoltDB, error) {
-	if dbPath != "" {
-		return boltdb.New(filepath.Join(dbPath, boltRootChainStoreFileName))
+	if dbPath == "" {
+		return nil, fmt.Errorf("persistent storage path not set")
 	}
-	return nil, fmt.Errorf("persistent storage path not set")
+	db, err := boltdb.New(filepath.Join(dbPath, boltRootChainStoreFileName))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := keyvaluedb.Migrate(db, storage.Migrations); err != nil {
+		return nil, errors.Join(fmt.Errorf("migrating root store: %w", err), db.Close())
+	}
+	return db, nil
 }
 
 func initTrustBaseStore(dbPath string) (*boltdb.BoltDB, error) {
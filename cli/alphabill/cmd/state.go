@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/txsystem/money"
+)
+
+type stateConvertConfig struct {
+	Input       string
+	Output      string
+	Version     uint32
+	ChunkSize   uint64
+	Compression string
+}
+
+// newStateCmd creates a new cobra command for working with the state files.
+func newStateCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "state",
+		Short: "Tools for inspecting and converting state files",
+	}
+	cmd.AddCommand(newStateInspectCmd())
+	cmd.AddCommand(newStateConvertCmd())
+	cmd.AddCommand(newStateAuditMoneyCmd())
+	return cmd
+}
+
+func newStateInspectCmd() *cobra.Command {
+	var file string
+	var typePartLength int
+	var cmd = &cobra.Command{
+		Use:   "inspect",
+		Short: "Prints the header, unicity certificate and unit type statistics of the state file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return stateInspectRunFun(cmd.OutOrStdout(), file, typePartLength)
+		},
+	}
+	cmd.Flags().StringVarP(&file, cmdFlagState, "s", "", "path to the state file")
+	cmd.Flags().IntVar(&typePartLength, "type-part-length", 1, "length of the type part of the unit identifier (in bytes)")
+	if err := cmd.MarkFlagRequired(cmdFlagState); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
+func newStateConvertCmd() *cobra.Command {
+	config := &stateConvertConfig{}
+	var cmd = &cobra.Command{
+		Use:   "convert",
+		Short: "Converts the state file between v1 and v2 formats",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return stateConvertRunFun(config)
+		},
+	}
+	cmd.Flags().StringVarP(&config.Input, "input", "i", "", "path to the input state file")
+	cmd.Flags().StringVarP(&config.Output, "output", "o", "", "path to the output state file")
+	cmd.Flags().Uint32Var(&config.Version, "version", state.SnapshotVersion2, "version of the output state file format (1 or 2)")
+	cmd.Flags().Uint64Var(&config.ChunkSize, "chunk-size", state.DefaultChunkSize, "maximum number of units in a chunk (v2 only)")
+	cmd.Flags().StringVar(&config.Compression, "compression", state.CompressionNone.String(), "compression of the chunks, one of [none | gzip] (v2 only)")
+	if err := cmd.MarkFlagRequired("input"); err != nil {
+		panic(err)
+	}
+	if err := cmd.MarkFlagRequired("output"); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
+func newStateAuditMoneyCmd() *cobra.Command {
+	var file string
+	var initialBillValue, dcMoneySupplyValue uint64
+	var cmd = &cobra.Command{
+		Use:   "audit-money",
+		Short: "Checks that the total value of the bills in the money partition state file equals to the genesis money supply",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return stateAuditMoneyRunFun(cmd.OutOrStdout(), file, initialBillValue, dcMoneySupplyValue)
+		},
+	}
+	cmd.Flags().StringVarP(&file, cmdFlagState, "s", "", "path to the money partition state file")
+	cmd.Flags().Uint64Var(&initialBillValue, "initial-bill-value", defaultInitialBillValue, "the initial bill value used in genesis")
+	cmd.Flags().Uint64Var(&dcMoneySupplyValue, "dc-money-supply-value", defaultDCMoneySupplyValue, "the initial value of the Dust Collector money supply used in genesis")
+	if err := cmd.MarkFlagRequired(cmdFlagState); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
+func stateInspectRunFun(w io.Writer, file string, typePartLength int) error {
+	if typePartLength < 1 {
+		return fmt.Errorf("invalid type part length: %d", typePartLength)
+	}
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return fmt.Errorf("failed to open state file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := state.InspectSnapshot(f, typePartLength)
+	if err != nil {
+		return fmt.Errorf("failed to read state file %s: %w", file, err)
+	}
+
+	fmt.Fprintf(w, "Version: %d\n", info.Version)
+	fmt.Fprintf(w, "Units: %d\n", info.NodeRecordCount)
+	if info.Version == state.SnapshotVersion2 {
+		fmt.Fprintf(w, "Chunks: %d (chunk size %d, compression %s)\n", info.ChunkCount, info.ChunkSize, info.Compression)
+	}
+	if uc := info.UnicityCertificate; uc != nil && uc.InputRecord != nil {
+		fmt.Fprintf(w, "Unicity certificate:\n")
+		fmt.Fprintf(w, "  Round: %d\n", uc.GetRoundNumber())
+		fmt.Fprintf(w, "  Epoch: %d\n", uc.InputRecord.Epoch)
+		fmt.Fprintf(w, "  State hash: %X\n", uc.GetStateHash())
+		fmt.Fprintf(w, "  Summary value: %X\n", uc.InputRecord.SummaryValue)
+		fmt.Fprintf(w, "  Root round: %d\n", uc.GetRootRoundNumber())
+	} else {
+		fmt.Fprintf(w, "Unicity certificate: none\n")
+	}
+
+	unitTypes := make([]string, 0, len(info.UnitTypes))
+	for k := range info.UnitTypes {
+		unitTypes = append(unitTypes, k)
+	}
+	sort.Strings(unitTypes)
+	fmt.Fprintf(w, "Unit types:\n")
+	for _, k := range unitTypes {
+		stats := info.UnitTypes[k]
+		fmt.Fprintf(w, "  %s: %d units, %d bytes of unit data\n", k, stats.UnitCount, stats.UnitDataSize)
+	}
+	return nil
+}
+
+func stateAuditMoneyRunFun(w io.Writer, file string, initialBillValue, dcMoneySupplyValue uint64) error {
+	supply := initialBillValue + dcMoneySupplyValue
+	if supply < initialBillValue {
+		return errors.New("genesis money supply overflows uint64")
+	}
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return fmt.Errorf("failed to open state file: %w", err)
+	}
+	defer f.Close()
+
+	s, err := state.NewRecoveredState(f, money.NewUnitData)
+	if err != nil {
+		return fmt.Errorf("failed to read state file %s: %w", file, err)
+	}
+	if err := money.AuditMoneySupply(s, supply); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Money supply OK: %d (round %d)\n", supply, s.CommittedUC().GetRoundNumber())
+	return nil
+}
+
+func stateConvertRunFun(config *stateConvertConfig) (err error) {
+	in, err := os.Open(filepath.Clean(config.Input))
+	if err != nil {
+		return fmt.Errorf("failed to open input state file: %w", err)
+	}
+	defer in.Close()
+
+	var convert func(r io.Reader, w io.Writer) error
+	switch config.Version {
+	case state.SnapshotVersion1:
+		convert = state.ConvertSnapshotV2ToV1
+	case state.SnapshotVersion2:
+		compression, err := parseCompression(config.Compression)
+		if err != nil {
+			return err
+		}
+		convert = func(r io.Reader, w io.Writer) error {
+			return state.ConvertSnapshotV1ToV2(r, w, state.WithChunkSize(config.ChunkSize), state.WithCompression(compression))
+		}
+	default:
+		return fmt.Errorf("unsupported state file version: %d", config.Version)
+	}
+
+	out, err := os.OpenFile(filepath.Clean(config.Output), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create output state file: %w", err)
+	}
+	defer func() {
+		if cerr := out.Close(); cerr != nil {
+			err = errors.Join(err, cerr)
+		}
+		if err != nil {
+			// partially written file is not a valid state file
+			_ = os.Remove(out.Name())
+		}
+	}()
+
+	if err := convert(in, out); err != nil {
+		return fmt.Errorf("failed to convert state file: %w", err)
+	}
+	return nil
+}
+
+func parseCompression(s string) (state.Compression, error) {
+	switch s {
+	case state.CompressionNone.String():
+		return state.CompressionNone, nil
+	case state.CompressionGzip.String():
+		return state.CompressionGzip, nil
+	default:
+		return 0, fmt.Errorf("unsupported compression: %q", s)
+	}
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	moneysdk "github.com/alphabill-org/alphabill-go-base/txsystem/money"
+	testobserve "github.com/alphabill-org/alphabill/internal/testutils/observability"
+	"github.com/alphabill-org/alphabill/state"
+)
+
+func TestStateInspectAndConvert(t *testing.T) {
+	dir := t.TempDir()
+	v1File := filepath.Join(dir, "state-v1.cbor")
+	v2File := filepath.Join(dir, "state-v2.cbor")
+	convertedV1File := filepath.Join(dir, "state-v1-converted.cbor")
+
+	s := state.NewEmptyState()
+	for i := byte(1); i <= 5; i++ {
+		id := moneysdk.NewBillID(nil, []byte{i})
+		require.NoError(t, s.Apply(state.AddUnit(id, &moneysdk.BillData{Value: uint64(i)})))
+		require.NoError(t, s.AddUnitLog(id, []byte{i}))
+	}
+	fcrID := moneysdk.NewFeeCreditRecordID(nil, []byte{1})
+	require.NoError(t, s.Apply(state.AddUnit(fcrID, &moneysdk.BillData{Value: 1})))
+	require.NoError(t, s.AddUnitLog(fcrID, []byte{1}))
+	_, _, err := s.CalculateRoot()
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	require.NoError(t, s.Serialize(buf, false))
+	require.NoError(t, os.WriteFile(v1File, buf.Bytes(), 0600))
+
+	out := &bytes.Buffer{}
+	cmd := New(testobserve.NewFactory(t))
+	cmd.baseCmd.SetOut(out)
+	cmd.baseCmd.SetArgs([]string{"state", "inspect", "--state", v1File})
+	require.NoError(t, cmd.Execute(context.Background()))
+	require.Contains(t, out.String(), "Version: 1\n")
+	require.Contains(t, out.String(), "Units: 6\n")
+	require.Contains(t, out.String(), "Unicity certificate: none\n")
+	require.Contains(t, out.String(), "  01: 5 units")
+	require.Contains(t, out.String(), "  10: 1 units")
+
+	cmd = New(testobserve.NewFactory(t))
+	cmd.baseCmd.SetArgs([]string{"state", "convert", "-i", v1File, "-o", v2File, "--chunk-size", "2", "--compression", "gzip"})
+	require.NoError(t, cmd.Execute(context.Background()))
+
+	out.Reset()
+	cmd = New(testobserve.NewFactory(t))
+	cmd.baseCmd.SetOut(out)
+	cmd.baseCmd.SetArgs([]string{"state", "inspect", "--state", v2File})
+	require.NoError(t, cmd.Execute(context.Background()))
+	require.Contains(t, out.String(), "Version: 2\n")
+	require.Contains(t, out.String(), "Chunks: 3 (chunk size 2, compression gzip)\n")
+
+	cmd = New(testobserve.NewFactory(t))
+	cmd.baseCmd.SetArgs([]string{"state", "convert", "-i", v2File, "-o", convertedV1File, "--version", "1"})
+	require.NoError(t, cmd.Execute(context.Background()))
+	convertedV1, err := os.ReadFile(convertedV1File)
+	require.NoError(t, err)
+	require.Equal(t, buf.Bytes(), convertedV1)
+
+	// output file must not exist
+	cmd = New(testobserve.NewFactory(t))
+	cmd.baseCmd.SetArgs([]string{"state", "convert", "-i", v2File, "-o", convertedV1File, "--version", "1"})
+	require.ErrorContains(t, cmd.Execute(context.Background()), "failed to create output state file")
+}
+
+func TestStateAuditMoney(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "state.cbor")
+	s := state.NewEmptyState()
+	for i := byte(1); i <= 3; i++ {
+		id := moneysdk.NewBillID(nil, []byte{i})
+		require.NoError(t, s.Apply(state.AddUnit(id, &moneysdk.BillData{Value: uint64(i) * 10})))
+		require.NoError(t, s.AddUnitLog(id, []byte{i}))
+	}
+	_, _, err := s.CalculateRoot()
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	require.NoError(t, s.Serialize(buf, false))
+	require.NoError(t, os.WriteFile(file, buf.Bytes(), 0600))
+
+	out := &bytes.Buffer{}
+	cmd := New(testobserve.NewFactory(t))
+	cmd.baseCmd.SetOut(out)
+	cmd.baseCmd.SetArgs([]string{"state", "audit-money", "--state", file, "--initial-bill-value", "50", "--dc-money-supply-value", "10"})
+	require.NoError(t, cmd.Execute(context.Background()))
+	require.Equal(t, "Money supply OK: 60 (round 0)\n", out.String())
+
+	cmd = New(testobserve.NewFactory(t))
+	cmd.baseCmd.SetArgs([]string{"state", "audit-money", "--state", file, "--initial-bill-value", "50", "--dc-money-supply-value", "11"})
+	require.EqualError(t, cmd.Execute(context.Background()), "money supply drift in round 0: expected 61, actual 60")
+}
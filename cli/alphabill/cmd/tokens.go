@@ -5,11 +5,11 @@ import (
 	"crypto"
 	"fmt"
 	"path/filepath"
+	"slices"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/spf13/cobra"
 
-	tokenssdk "github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
 	"github.com/alphabill-org/alphabill-go-base/types"
 
 	"github.com/alphabill-org/alphabill/logger"
@@ -19,8 +19,11 @@ import (
 	"github.com/alphabill-org/alphabill/predicates"
 	"github.com/alphabill-org/alphabill/predicates/templates"
 	"github.com/alphabill-org/alphabill/predicates/wasm"
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm"
 	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/encoder"
 	"github.com/alphabill-org/alphabill/rpc"
+	fcenc "github.com/alphabill-org/alphabill/txsystem/fc/encoder"
+	"github.com/alphabill-org/alphabill/txsystem/predicatestore"
 	"github.com/alphabill-org/alphabill/txsystem/tokens"
 	tokenc "github.com/alphabill-org/alphabill/txsystem/tokens/encoder"
 )
@@ -30,6 +33,10 @@ type (
 		baseNodeConfiguration
 		Node      *startNodeConfiguration
 		RPCServer *rpc.ServerConfiguration
+		// number of compiled WASM predicate modules kept in memory
+		WasmModuleCacheSize int
+		// directory of the on-disk WASM compilation cache, empty disables the cache
+		WasmCompilationCacheDir string
 	}
 )
 
@@ -53,6 +60,8 @@ func newTokensNodeCmd(baseConfig *baseConfiguration) *cobra.Command {
 
 	addCommonNodeConfigurationFlags(nodeCmd, config.Node, "tokens")
 	addRPCServerConfigurationFlags(nodeCmd, config.RPCServer)
+	nodeCmd.Flags().IntVar(&config.WasmModuleCacheSize, "wasm-module-cache-size", wvm.DefaultModuleCacheSize, "number of compiled WASM predicates kept in memory, 0 disables the cache")
+	nodeCmd.Flags().StringVar(&config.WasmCompilationCacheDir, "wasm-compilation-cache-dir", "", "directory for caching compiled WASM predicates across restarts (disabled when not set)")
 
 	return nodeCmd
 }
@@ -67,12 +76,17 @@ func runTokensNode(ctx context.Context, cfg *tokensConfiguration) error {
 	if err := types.Cbor.Unmarshal(pg.Params, params); err != nil {
 		return fmt.Errorf("failed to unmarshal tokens partition params: %w", err)
 	}
+	gasSchedule, err := genesisGasSchedule(params.GasSchedule)
+	if err != nil {
+		return err
+	}
 
 	stateFilePath := cfg.Node.StateFile
 	if stateFilePath == "" {
 		stateFilePath = filepath.Join(cfg.Base.HomeDir, utDir, utGenesisStateFileName)
 	}
-	state, err := loadStateFile(stateFilePath, tokenssdk.NewUnitData)
+	udc := predicatestore.NewUnitDataConstructor(tokens.NewUnitData, tokens.PredicateStorageUnitType)
+	state, err := loadStateFile(stateFilePath, udc, cfg.Node.StateSnapshotCount)
 	if err != nil {
 		return fmt.Errorf("loading state (file %s): %w", cfg.Node.StateFile, err)
 	}
@@ -102,18 +116,18 @@ func runTokensNode(ctx context.Context, cfg *tokensConfiguration) error {
 	log := cfg.Base.observe.Logger().With(logger.NodeID(nodeID))
 	obs := observability.WithLogger(cfg.Base.observe, log)
 
-	blockStore, err := initStore(cfg.Node.DbFile)
+	blockStore, err := initStore(cfg.Node.DbFile, partition.BlockStoreMigrations)
 	if err != nil {
 		return fmt.Errorf("unable to initialize block DB: %w", err)
 	}
 
-	proofStore, err := initStore(cfg.Node.TxIndexerDBFile)
+	proofStore, err := initStore(cfg.Node.TxIndexerDBFile, partition.ProofIndexMigrations)
 	if err != nil {
 		return fmt.Errorf("unable to initialize proof DB: %w", err)
 	}
 
-	// register all unit- and attribute types from token tx system
-	enc, err := encoder.New(tokenc.RegisterTxAttributeEncoders, tokenc.RegisterUnitDataEncoders)
+	// register all unit- and attribute types from token tx system and fee credit module
+	enc, err := encoder.New(tokenc.RegisterTxAttributeEncoders, tokenc.RegisterUnitDataEncoders, fcenc.RegisterTxAttributeEncoders, fcenc.RegisterUnitDataEncoders)
 	if err != nil {
 		return fmt.Errorf("creating encoders for WASM predicate engine: %w", err)
 	}
@@ -123,12 +137,21 @@ func runTokensNode(ctx context.Context, cfg *tokensConfiguration) error {
 	// tx system engine.
 	// it's safe to share template engine as it doesn't have internal state and
 	// predicate executions happen in serialized manner anyway
-	templateEng := templates.New()
+	templateEng := templates.New(templates.WithGasSchedule(gasSchedule))
 	tpe, err := predicates.Dispatcher(templateEng)
 	if err != nil {
 		return fmt.Errorf("creating predicate executor for WASM engine: %w", err)
 	}
-	predEng, err := predicates.Dispatcher(templateEng, wasm.New(enc, tpe.Execute, obs))
+	optEngines, err := predicateEngines(params.PredicateEngines, gasSchedule)
+	if err != nil {
+		return err
+	}
+	predEng, err := predicates.Dispatcher(append(optEngines, templateEng, wasm.New(enc, tpe.Execute, obs,
+		wvm.WithModuleCacheSize(cfg.WasmModuleCacheSize),
+		wvm.WithCompilationCacheDir(cfg.WasmCompilationCacheDir),
+		wvm.WithGasSchedule(gasSchedule),
+		wvm.WithPartitionDescriptions(append(slices.Clone(params.Partitions), pg.PartitionDescription)...),
+	))...)
 	if err != nil {
 		return fmt.Errorf("creating predicate executor: %w", err)
 	}
@@ -143,6 +166,11 @@ func runTokensNode(ctx context.Context, cfg *tokensConfiguration) error {
 		tokens.WithPredicateExecutor(predEng.Execute),
 		tokens.WithAdminOwnerPredicate(params.AdminOwnerPredicate),
 		tokens.WithFeelessMode(params.FeelessMode),
+		tokens.WithPredicateStorage(true),
+		tokens.WithGasSchedule(gasSchedule),
+		tokens.WithGasScheduleUpdates(params.GasScheduleUpdates),
+		tokens.WithMaxBatchMintSize(params.MaxBatchMintSize),
+		tokens.WithFeeCreditRecordExpiry(params.FeeCreditRecordExpiryInterval),
 	)
 	if err != nil {
 		return fmt.Errorf("creating transaction system: %w", err)
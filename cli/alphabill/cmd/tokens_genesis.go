@@ -14,6 +14,8 @@ import (
 	"github.com/alphabill-org/alphabill/network/protocol/genesis"
 	"github.com/alphabill-org/alphabill/partition"
 	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/txsystem/fc/permissioned"
+	"github.com/alphabill-org/alphabill/txsystem/tokens"
 )
 
 const (
@@ -23,13 +25,19 @@ const (
 )
 
 type userTokenPartitionGenesisConfig struct {
-	Base                *baseConfiguration
-	Keys                *keysConfig
-	PDRFilename         string
-	Output              string
-	OutputState         string
-	AdminOwnerPredicate []byte
-	FeelessMode         bool
+	Base                   *baseConfiguration
+	Keys                   *keysConfig
+	PDRFilename            string
+	Output                 string
+	OutputState            string
+	AdminOwnerPredicate    []byte
+	FeelessMode            bool
+	GasScheduleFile        string
+	GasScheduleUpdatesFile string
+	MaxBatchMintSize       uint32
+	FCRExpiryInterval      uint64
+	PredicateEngines       []string
+	PDRFiles               []string
 }
 
 func newUserTokenGenesisCmd(baseConfig *baseConfiguration) *cobra.Command {
@@ -48,6 +56,12 @@ func newUserTokenGenesisCmd(baseConfig *baseConfiguration) *cobra.Command {
 	config.Keys.addCmdFlags(cmd)
 	cmd.Flags().BytesHexVar(&config.AdminOwnerPredicate, "admin-owner-predicate", nil, "the admin owner predicate for permissioned mode")
 	cmd.Flags().BoolVar(&config.FeelessMode, "feeless-mode", false, "if true then fees are not charged, if false then fees are charged as normal; applies only for permissioned mode")
+	cmd.Flags().StringVar(&config.GasScheduleFile, "gas-schedule", "", "filename (full path) from where to read the gas schedule (default: built-in gas schedule)")
+	cmd.Flags().StringVar(&config.GasScheduleUpdatesFile, "gas-schedule-updates", "", "filename (full path) from where to read the gas schedules replacing the gas schedule from their activation round")
+	cmd.Flags().Uint32Var(&config.MaxBatchMintSize, "max-batch-mint-size", tokens.DefaultMaxBatchMintSize, "the maximum number of NFTs minted by a single batch mint transaction")
+	cmd.Flags().Uint64Var(&config.FCRExpiryInterval, "fcr-expiry-interval", 0, "the interval (in rounds) of checking the expired fee credit records, zero disables the expiry; applies only for permissionless mode")
+	addPredicateEnginesFlag(cmd, &config.PredicateEngines)
+	cmd.Flags().StringSliceVar(&config.PDRFiles, "partition-description-files", nil, "path to the partition description files of the other partitions whose unicity certificates the WASM predicates can verify")
 	_ = cmd.MarkFlagRequired("partition-description")
 	return cmd
 }
@@ -83,6 +97,11 @@ func utGenesisRunFun(_ context.Context, config *userTokenPartitionGenesisConfig)
 	}
 
 	genesisState := state.NewEmptyState()
+	if len(config.AdminOwnerPredicate) > 0 {
+		if err := addAdminConfig(genesisState, config.AdminOwnerPredicate); err != nil {
+			return fmt.Errorf("could not set admin configuration: %w", err)
+		}
+	}
 
 	peerID, err := peer.IDFromPublicKey(keys.EncryptionPrivateKey.GetPublic())
 	if err != nil {
@@ -116,6 +135,18 @@ func utGenesisRunFun(_ context.Context, config *userTokenPartitionGenesisConfig)
 	return util.WriteJsonFile(nodeGenesisFile, nodeGenesis)
 }
 
+/*
+addAdminConfig adds the admin configuration unit of the permissioned mode to the genesis
+state, the unit holds the admin predicate which can be replaced with the rotate-admin tx.
+*/
+func addAdminConfig(s *state.State, adminOwnerPredicate []byte) error {
+	err := s.Apply(state.AddUnit(tokens.AdminConfigUnitID, &permissioned.AdminConfigData{AdminPredicate: adminOwnerPredicate}))
+	if err == nil {
+		err = s.AddUnitLog(tokens.AdminConfigUnitID, zeroHash)
+	}
+	return err
+}
+
 func (c *userTokenPartitionGenesisConfig) getNodeGenesisFileLocation(utHomePath string) string {
 	if c.Output != "" {
 		return c.Output
@@ -131,9 +162,34 @@ func (c *userTokenPartitionGenesisConfig) getNodeGenesisStateFileLocation(utHome
 }
 
 func (c *userTokenPartitionGenesisConfig) getPartitionParams() ([]byte, error) {
+	gasSchedule, err := readGasSchedule(c.GasScheduleFile)
+	if err != nil {
+		return nil, err
+	}
+	gasScheduleUpdates, err := readGasScheduleUpdates(c.GasScheduleUpdatesFile)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := predicateEngines(c.PredicateEngines, gasSchedule); err != nil {
+		return nil, err
+	}
+	var pdrs []*types.PartitionDescriptionRecord
+	for _, pdrFile := range c.PDRFiles {
+		pdr, err := util.ReadJsonFile(pdrFile, &types.PartitionDescriptionRecord{})
+		if err != nil {
+			return nil, fmt.Errorf("loading partition description: %w", err)
+		}
+		pdrs = append(pdrs, pdr)
+	}
 	src := &genesis.TokensPartitionParams{
-		AdminOwnerPredicate: c.AdminOwnerPredicate,
-		FeelessMode:         c.FeelessMode,
+		AdminOwnerPredicate:           c.AdminOwnerPredicate,
+		FeelessMode:                   c.FeelessMode,
+		GasSchedule:                   gasSchedule,
+		MaxBatchMintSize:              c.MaxBatchMintSize,
+		FeeCreditRecordExpiryInterval: c.FCRExpiryInterval,
+		PredicateEngines:              c.PredicateEngines,
+		Partitions:                    pdrs,
+		GasScheduleUpdates:            gasScheduleUpdates,
 	}
 	res, err := types.Cbor.Marshal(src)
 	if err != nil {
@@ -14,6 +14,10 @@ import (
 	"github.com/alphabill-org/alphabill-go-base/util"
 	testobserve "github.com/alphabill-org/alphabill/internal/testutils/observability"
 	"github.com/alphabill-org/alphabill/network/protocol/genesis"
+	"github.com/alphabill-org/alphabill/predicates"
+	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/txsystem/fc/permissioned"
+	"github.com/alphabill-org/alphabill/txsystem/tokens"
 	"github.com/stretchr/testify/require"
 )
 
@@ -91,7 +95,7 @@ func Test_TokensGenesis(t *testing.T) {
 		adminOwnerPredicate := "830041025820f34a250bf4f2d3a432a43381cecc4ab071224d9ceccb6277b5779b937f59055f"
 
 		cmd := New(testobserve.NewFactory(t))
-		args := fmt.Sprintf("tokens-genesis -g --home %s %s --admin-owner-predicate %s --feeless-mode true", homeDir, pdrArgument, adminOwnerPredicate)
+		args := fmt.Sprintf("tokens-genesis -g --home %s %s --admin-owner-predicate %s --feeless-mode true --max-batch-mint-size 500 --fcr-expiry-interval 1000 --predicate-engines script", homeDir, pdrArgument, adminOwnerPredicate)
 		cmd.baseCmd.SetArgs(strings.Split(args, " "))
 		require.NoError(t, cmd.Execute(context.Background()))
 
@@ -103,6 +107,55 @@ func Test_TokensGenesis(t *testing.T) {
 		require.NotNil(t, params)
 		require.Equal(t, adminOwnerPredicate, hex.EncodeToString(params.AdminOwnerPredicate))
 		require.True(t, params.FeelessMode)
+		require.Equal(t, predicates.DefaultGasSchedule(), params.GasSchedule)
+		require.EqualValues(t, 500, params.MaxBatchMintSize)
+		require.EqualValues(t, 1000, params.FeeCreditRecordExpiryInterval)
+		require.Equal(t, []string{"script"}, params.PredicateEngines)
+
+		stateFile, err := os.Open(filepath.Join(homeDir, utDirectory, utGenesisStateFileName))
+		require.NoError(t, err)
+		defer stateFile.Close()
+		s, err := state.NewRecoveredState(stateFile, tokens.NewUnitData)
+		require.NoError(t, err)
+		u, err := s.GetUnit(tokens.AdminConfigUnitID, true)
+		require.NoError(t, err)
+		require.Equal(t, &permissioned.AdminConfigData{AdminPredicate: params.AdminOwnerPredicate}, u.Data())
+	})
+
+	t.Run("unknown predicate engine", func(t *testing.T) {
+		homeDir := t.TempDir()
+		cmd := New(testobserve.NewFactory(t))
+		args := fmt.Sprintf("tokens-genesis -g --home %s%s --predicate-engines foo", homeDir, pdrArgument)
+		cmd.baseCmd.SetArgs(strings.Split(args, " "))
+		require.ErrorContains(t, cmd.Execute(context.Background()), `unknown predicate engine "foo"`)
+	})
+
+	t.Run("GasSchedule", func(t *testing.T) {
+		homeDir := t.TempDir()
+		gasSchedule := predicates.DefaultGasSchedule()
+		gasSchedule.P2PKH = 1500
+		gasSchedule.WasmInstruction = 2
+		gasFile := filepath.Join(homeDir, "gas-schedule.json")
+		require.NoError(t, util.WriteJsonFile(gasFile, gasSchedule))
+
+		cmd := New(testobserve.NewFactory(t))
+		args := fmt.Sprintf("tokens-genesis -g --home %s%s --gas-schedule %s", homeDir, pdrArgument, gasFile)
+		cmd.baseCmd.SetArgs(strings.Split(args, " "))
+		require.NoError(t, cmd.Execute(context.Background()))
+
+		pn, err := util.ReadJsonFile(filepath.Join(homeDir, utDirectory, utGenesisFileName), &genesis.PartitionNode{})
+		require.NoError(t, err)
+		params := &genesis.TokensPartitionParams{}
+		require.NoError(t, types.Cbor.Unmarshal(pn.Params, params))
+		require.Equal(t, gasSchedule, params.GasSchedule)
+
+		// invalid gas schedule is rejected
+		gasSchedule.Version = 2
+		require.NoError(t, util.WriteJsonFile(gasFile, gasSchedule))
+		cmd = New(testobserve.NewFactory(t))
+		args = fmt.Sprintf("tokens-genesis -g --home %s%s --gas-schedule %s", t.TempDir(), pdrArgument, gasFile)
+		cmd.baseCmd.SetArgs(strings.Split(args, " "))
+		require.ErrorContains(t, cmd.Execute(context.Background()), `invalid gas schedule: unsupported gas schedule version 2`)
 	})
 
 	t.Run("DefaultNodeGenesisExists", func(t *testing.T) {
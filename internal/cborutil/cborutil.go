@@ -0,0 +1,31 @@
+package cborutil
+
+import (
+	"fmt"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+)
+
+var cborNull = types.RawCBOR{0xf6}
+
+/*
+UnmarshalOptionalFields decodes struct encoded as CBOR array of "fieldCount" items into
+"v" (which must not implement cbor.Unmarshaler itself, ie use local type without methods).
+Structures encoded by the older versions of the software (or using the SDK types) lack
+the optional fields added later to the end of the array, these are decoded as CBOR null
+(zero value).
+*/
+func UnmarshalOptionalFields(data []byte, fieldCount int, v any) error {
+	var fields []types.RawCBOR
+	if err := types.Cbor.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for len(fields) < fieldCount {
+		fields = append(fields, cborNull)
+	}
+	buf, err := types.Cbor.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("encoding fields: %w", err)
+	}
+	return types.Cbor.Unmarshal(buf, v)
+}
@@ -0,0 +1,43 @@
+package cborutil
+
+import (
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalOptionalFields(t *testing.T) {
+	type legacy struct {
+		_ struct{} `cbor:",toarray"`
+		A uint64
+	}
+	type current struct {
+		_ struct{} `cbor:",toarray"`
+		A uint64
+		B []byte
+		C *legacy
+	}
+
+	t.Run("optional fields missing", func(t *testing.T) {
+		buf, err := types.Cbor.Marshal(legacy{A: 5})
+		require.NoError(t, err)
+		var v current
+		require.NoError(t, UnmarshalOptionalFields(buf, 3, &v))
+		require.Equal(t, current{A: 5}, v)
+	})
+
+	t.Run("all fields present", func(t *testing.T) {
+		src := current{A: 5, B: []byte{1}, C: &legacy{A: 6}}
+		buf, err := types.Cbor.Marshal(src)
+		require.NoError(t, err)
+		var v current
+		require.NoError(t, UnmarshalOptionalFields(buf, 3, &v))
+		require.Equal(t, src, v)
+	})
+
+	t.Run("not an array", func(t *testing.T) {
+		var v current
+		require.Error(t, UnmarshalOptionalFields([]byte{0x01}, 3, &v))
+	})
+}
@@ -2,6 +2,7 @@ package testtxsystem
 
 import (
 	"encoding/binary"
+	"fmt"
 	"io"
 	"sync"
 
@@ -67,6 +68,17 @@ func (m *CounterTxSystem) State() txsystem.StateReader {
 	return state.NewEmptyState().Clone()
 }
 
+func (m *CounterTxSystem) StateAt(roundNumber uint64) (txsystem.StateReader, error) {
+	if s, ok := m.FixedState.(*state.State); ok {
+		snapshot, err := s.Snapshot(roundNumber)
+		if err != nil {
+			return nil, err
+		}
+		return snapshot, nil
+	}
+	return nil, fmt.Errorf("round %d: %w", roundNumber, state.ErrSnapshotNotFound)
+}
+
 func (m *CounterTxSystem) StateSize() (uint64, error) {
 	return 0, nil
 }
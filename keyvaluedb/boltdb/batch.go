@@ -0,0 +1,68 @@
+package boltdb
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alphabill-org/alphabill/keyvaluedb"
+	bolt "go.etcd.io/bbolt"
+)
+
+type (
+	Batch struct {
+		db     *bolt.DB
+		bucket []byte
+		enc    EncodeFn
+		ops    []batchOp
+	}
+
+	batchOp struct {
+		key    []byte
+		value  []byte
+		delete bool
+	}
+)
+
+func (b *Batch) Write(key []byte, value any) error {
+	if err := keyvaluedb.CheckKeyAndValue(key, value); err != nil {
+		return err
+	}
+	data, err := b.enc(value)
+	if err != nil {
+		return err
+	}
+	b.ops = append(b.ops, batchOp{key: slices.Clone(key), value: data})
+	return nil
+}
+
+func (b *Batch) Delete(key []byte) error {
+	if err := keyvaluedb.CheckKey(key); err != nil {
+		return err
+	}
+	b.ops = append(b.ops, batchOp{key: slices.Clone(key), delete: true})
+	return nil
+}
+
+func (b *Batch) Len() int {
+	return len(b.ops)
+}
+
+func (b *Batch) Commit() error {
+	if err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(b.bucket)
+		for _, op := range b.ops {
+			if op.delete {
+				if err := bucket.Delete(op.key); err != nil {
+					return err
+				}
+			} else if err := bucket.Put(op.key, op.value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("bolt db batch commit failed, %w", err)
+	}
+	b.ops = nil
+	return nil
+}
@@ -0,0 +1,70 @@
+package boltdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoltBatch_Commit(t *testing.T) {
+	db := initBoltDB(t)
+	require.NoError(t, db.Write([]byte("a"), uint64(1)))
+	require.NoError(t, db.Write([]byte("b"), uint64(2)))
+
+	batch := db.NewBatch()
+	require.NoError(t, batch.Write([]byte("c"), uint64(3)))
+	require.NoError(t, batch.Write([]byte("a"), uint64(10)))
+	require.NoError(t, batch.Delete([]byte("b")))
+	require.Equal(t, 3, batch.Len())
+
+	// pending changes are not visible
+	var value uint64
+	found, err := db.Read([]byte("c"), &value)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, batch.Commit())
+	require.Equal(t, 0, batch.Len())
+	found, err = db.Read([]byte("a"), &value)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 10, value)
+	found, err = db.Read([]byte("b"), &value)
+	require.NoError(t, err)
+	require.False(t, found)
+	found, err = db.Read([]byte("c"), &value)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 3, value)
+
+	// batch can be reused
+	require.NoError(t, batch.Delete([]byte("c")))
+	require.NoError(t, batch.Commit())
+	found, err = db.Read([]byte("c"), &value)
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestBoltBatch_InvalidInput(t *testing.T) {
+	db := initBoltDB(t)
+	batch := db.NewBatch()
+	require.ErrorContains(t, batch.Write(nil, uint64(1)), "invalid key")
+	var value *uint64
+	require.ErrorContains(t, batch.Write([]byte("a"), value), "value is nil")
+	require.ErrorContains(t, batch.Delete(nil), "invalid key")
+	require.ErrorContains(t, batch.Write([]byte("a"), make(chan int)), "cbor: unsupported type")
+	require.Equal(t, 0, batch.Len())
+}
+
+func TestBoltBatch_KeyIsCopied(t *testing.T) {
+	db := initBoltDB(t)
+	batch := db.NewBatch()
+	key := []byte("a")
+	require.NoError(t, batch.Write(key, uint64(1)))
+	key[0] = 'b'
+	require.NoError(t, batch.Commit())
+	var value uint64
+	found, err := db.Read([]byte("a"), &value)
+	require.NoError(t, err)
+	require.True(t, found)
+}
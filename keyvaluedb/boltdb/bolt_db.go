@@ -135,6 +135,22 @@ func (db *BoltDB) StartTx() (keyvaluedb.DBTransaction, error) {
 	return tx, nil
 }
 
+func (db *BoltDB) WithPrefix(prefix []byte) keyvaluedb.KeyValueDB {
+	return keyvaluedb.NewPrefixDB(db, prefix)
+}
+
+func (db *BoltDB) NewBatch() keyvaluedb.Batch {
+	return &Batch{db: db.db, bucket: db.bucket, enc: db.encoder}
+}
+
+func (db *BoltDB) Snapshot() (keyvaluedb.Snapshot, error) {
+	s, err := NewSnapshot(db.db, db.bucket, db.decoder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Bolt snapshot, %w", err)
+	}
+	return s, nil
+}
+
 func (db *BoltDB) Close() error {
 	if db.db == nil {
 		return nil
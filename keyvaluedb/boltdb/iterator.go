@@ -13,6 +13,8 @@ type (
 		decoder DecodeFn
 		key     []byte
 		value   []byte
+		// shared is true if the transaction is owned by a snapshot and must not be released by the iterator
+		shared bool
 	}
 )
 
@@ -20,8 +22,11 @@ func (it *Itr) Close() error {
 	if it.tx == nil {
 		return nil
 	}
-	// cursor seems error is only returned if already closed
-	err := it.tx.Rollback()
+	var err error
+	if !it.shared {
+		// cursor seems error is only returned if already closed
+		err = it.tx.Rollback()
+	}
 	// release iterator, so cursor cannot be closed twice - hence this should never return error
 	it.tx = nil
 	it.key = nil
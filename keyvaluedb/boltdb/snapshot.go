@@ -0,0 +1,86 @@
+package boltdb
+
+import (
+	"fmt"
+
+	"github.com/alphabill-org/alphabill/keyvaluedb"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Snapshot is backed by a read-only bolt transaction. Not concurrency safe.
+// NB! bolt must re-map the DB file when it grows and it can not do that while read-only transactions are
+// open, i.e. a write may block until all the snapshots are released. Snapshots should be short-lived and
+// must not be held while writing to the DB in the same goroutine.
+type Snapshot struct {
+	tx     *bolt.Tx
+	bucket []byte
+	dec    DecodeFn
+}
+
+func NewSnapshot(db *bolt.DB, bucket []byte, d DecodeFn) (*Snapshot, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db is nil")
+	}
+	tx, err := db.Begin(false)
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{
+		tx:     tx,
+		bucket: bucket,
+		dec:    d,
+	}, nil
+}
+
+func (s *Snapshot) Read(key []byte, value any) (bool, error) {
+	if s.tx.DB() == nil {
+		return false, fmt.Errorf("bolt snapshot read failed, %w", bolt.ErrTxClosed)
+	}
+	if err := keyvaluedb.CheckKeyAndValue(key, value); err != nil {
+		return false, err
+	}
+	b := s.tx.Bucket(s.bucket).Get(key)
+	if b == nil {
+		return false, nil
+	}
+	return true, s.dec(b, value)
+}
+
+func (s *Snapshot) First() keyvaluedb.Iterator {
+	it := s.newIterator()
+	it.first()
+	return it
+}
+
+func (s *Snapshot) Last() keyvaluedb.Iterator {
+	it := s.newIterator()
+	it.last()
+	return it
+}
+
+func (s *Snapshot) Find(key []byte) keyvaluedb.Iterator {
+	it := s.newIterator()
+	it.seek(key)
+	return it
+}
+
+func (s *Snapshot) Release() error {
+	if s.tx.DB() == nil {
+		return nil
+	}
+	return s.tx.Rollback()
+}
+
+// newIterator returns an iterator that uses the transaction of the snapshot, closing the iterator does
+// not release the snapshot.
+func (s *Snapshot) newIterator() *Itr {
+	if s.tx.DB() == nil {
+		return &Itr{}
+	}
+	return &Itr{
+		tx:      s.tx,
+		cursor:  s.tx.Bucket(s.bucket).Cursor(),
+		decoder: s.dec,
+		shared:  true,
+	}
+}
@@ -0,0 +1,66 @@
+package boltdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoltSnapshot(t *testing.T) {
+	db := initDB(t, defaultsDBKeys)
+	growDB(t, db)
+	snapshot, err := db.Snapshot()
+	require.NoError(t, err)
+
+	// changes after the snapshot are not visible in the snapshot
+	require.NoError(t, db.Write([]byte("1"), "changed"))
+	require.NoError(t, db.Write([]byte("5"), "4"))
+	require.NoError(t, db.Delete([]byte("2")))
+
+	var value string
+	found, err := snapshot.Read([]byte("1"), &value)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "0", value)
+	found, err = snapshot.Read([]byte("2"), &value)
+	require.NoError(t, err)
+	require.True(t, found)
+	found, err = snapshot.Read([]byte("5"), &value)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	var keys []string
+	it := snapshot.First()
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, string(it.Key()))
+	}
+	require.NoError(t, it.Close())
+	require.Equal(t, defaultsDBKeys, keys)
+
+	// closing the iterator does not release the snapshot
+	it = snapshot.Last()
+	require.True(t, it.Valid())
+	require.Equal(t, []byte("4"), it.Key())
+	require.NoError(t, it.Close())
+	it = snapshot.Find([]byte("3"))
+	require.True(t, it.Valid())
+	require.NoError(t, it.Value(&value))
+	require.Equal(t, "2", value)
+	require.NoError(t, it.Close())
+
+	require.NoError(t, snapshot.Release())
+	require.NoError(t, snapshot.Release())
+	_, err = snapshot.Read([]byte("1"), &value)
+	require.ErrorContains(t, err, "tx closed")
+	it = snapshot.First()
+	require.False(t, it.Valid())
+	require.NoError(t, it.Close())
+}
+
+// growDB makes the DB file big enough for the following small writes so that bolt does not need to re-map
+// the file (which would block while the snapshot is held).
+func growDB(t *testing.T, db *BoltDB) {
+	t.Helper()
+	require.NoError(t, db.Write([]byte("grow"), make([]byte, 1<<20)))
+	require.NoError(t, db.Delete([]byte("grow")))
+}
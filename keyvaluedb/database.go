@@ -30,6 +30,16 @@ type KeyValueDB interface {
 	Writer
 	Iterable
 	DBTx
+	// WithPrefix returns a view of the DB where all the keys are prefixed with the given prefix. Keys
+	// returned by the iterators of the view do not include the prefix and the iterators of the view
+	// only visit the keys with the prefix.
+	WithPrefix(prefix []byte) KeyValueDB
+	// NewBatch creates a write batch, the writes and deletes of the batch are applied atomically
+	// when the batch is committed.
+	NewBatch() Batch
+	// Snapshot returns a read-only point-in-time view of the DB.
+	// NB! when done snapshot MUST be released with Release()
+	Snapshot() (Snapshot, error)
 }
 
 type Iterator interface {
@@ -74,12 +84,35 @@ type DBTransaction interface {
 	Rollback() error
 }
 
-// IsEmpty is returns true if the key value DB is empty
+// Batch collects writes and deletes in memory and applies them atomically on Commit. Reads of the DB
+// do not see the pending changes of the batch.
+type Batch interface {
+	Writer
+	// Len returns the number of pending operations in the batch.
+	Len() int
+	// Commit applies all the pending operations. Batch is empty after successful commit and can be reused.
+	Commit() error
+}
+
+// Snapshot is a read-only view of the DB at the time the snapshot was created, changes made after that
+// are not visible in the snapshot.
+type Snapshot interface {
+	Reader
+	Iterable
+	// Release releases the snapshot, snapshot must not be used after release. Release can be called
+	// multiple times.
+	Release() error
+}
+
+// IsEmpty is returns true if the key value DB is empty, the schema version entry is not counted
 func IsEmpty(db KeyValueDB) (empty bool, err error) {
 	if db == nil {
 		return true, fmt.Errorf("db is nil")
 	}
 	it := db.First()
 	defer func() { err = it.Close() }()
+	if it.Valid() && isSchemaVersionKey(it.Key()) {
+		it.Next()
+	}
 	return !it.Valid(), err
 }
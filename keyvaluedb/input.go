@@ -8,6 +8,8 @@ import (
 var (
 	errInvalidKey = errors.New("invalid key")
 	errValueIsNil = errors.New("value is nil")
+
+	errIteratorInvalid = errors.New("iterator invalid")
 )
 
 func CheckKey(key []byte) error {
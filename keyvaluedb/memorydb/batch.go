@@ -0,0 +1,59 @@
+package memorydb
+
+import (
+	"github.com/alphabill-org/alphabill/keyvaluedb"
+)
+
+type (
+	Batch struct {
+		mem *MemoryDB
+		ops []batchOp
+	}
+
+	batchOp struct {
+		key    string
+		value  []byte
+		delete bool
+	}
+)
+
+func (b *Batch) Write(key []byte, value any) error {
+	if err := keyvaluedb.CheckKeyAndValue(key, value); err != nil {
+		return err
+	}
+	data, err := b.mem.encoder(value)
+	if err != nil {
+		return err
+	}
+	b.ops = append(b.ops, batchOp{key: string(key), value: data})
+	return nil
+}
+
+func (b *Batch) Delete(key []byte) error {
+	if err := keyvaluedb.CheckKey(key); err != nil {
+		return err
+	}
+	b.ops = append(b.ops, batchOp{key: string(key), delete: true})
+	return nil
+}
+
+func (b *Batch) Len() int {
+	return len(b.ops)
+}
+
+func (b *Batch) Commit() error {
+	b.mem.lock.Lock()
+	defer b.mem.lock.Unlock()
+	if b.mem.writeErr != nil {
+		return b.mem.writeErr
+	}
+	for _, op := range b.ops {
+		if op.delete {
+			delete(b.mem.db, op.key)
+		} else {
+			b.mem.db[op.key] = op.value
+		}
+	}
+	b.ops = nil
+	return nil
+}
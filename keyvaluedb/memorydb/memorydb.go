@@ -111,6 +111,20 @@ func (db *MemoryDB) StartTx() (keyvaluedb.DBTransaction, error) {
 	return tx, nil
 }
 
+func (db *MemoryDB) WithPrefix(prefix []byte) keyvaluedb.KeyValueDB {
+	return keyvaluedb.NewPrefixDB(db, prefix)
+}
+
+func (db *MemoryDB) NewBatch() keyvaluedb.Batch {
+	return &Batch{mem: db}
+}
+
+func (db *MemoryDB) Snapshot() (keyvaluedb.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+	return &Snapshot{db: copyMap(db.db), decoder: db.decoder}, nil
+}
+
 func (db *MemoryDB) MockWriteError(err error) {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
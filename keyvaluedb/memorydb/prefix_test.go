@@ -0,0 +1,102 @@
+package memorydb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill/keyvaluedb"
+)
+
+func collectKeys(t *testing.T, it keyvaluedb.Iterator, forward bool) []string {
+	t.Helper()
+	var keys []string
+	for ; it.Valid(); func() {
+		if forward {
+			it.Next()
+		} else {
+			it.Prev()
+		}
+	}() {
+		keys = append(keys, string(it.Key()))
+	}
+	require.NoError(t, it.Close())
+	return keys
+}
+
+func TestMemDB_WithPrefix(t *testing.T) {
+	db := initDB(t, []string{"a", "b/1", "b/2", "b/3", "c"})
+	view := db.WithPrefix([]byte("b/"))
+
+	var value string
+	found, err := view.Read([]byte("2"), &value)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "2", value)
+	found, err = view.Read([]byte("a"), &value)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.Equal(t, []string{"1", "2", "3"}, collectKeys(t, view.First(), true))
+	require.Equal(t, []string{"3", "2", "1"}, collectKeys(t, view.Last(), false))
+	require.Equal(t, []string{"2", "3"}, collectKeys(t, view.Find([]byte("15")), true))
+
+	require.NoError(t, view.Write([]byte("4"), "x"))
+	require.NoError(t, view.Delete([]byte("1")))
+	found, err = db.Read([]byte("b/4"), &value)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "x", value)
+	require.Equal(t, []string{"a", "b/2", "b/3", "b/4", "c"}, collectKeys(t, db.First(), true))
+
+	// nested view
+	nested := db.WithPrefix([]byte("b")).WithPrefix([]byte("/"))
+	require.Equal(t, []string{"2", "3", "4"}, collectKeys(t, nested.First(), true))
+
+	// empty view
+	empty := db.WithPrefix([]byte("d"))
+	isEmpty, err := keyvaluedb.IsEmpty(empty)
+	require.NoError(t, err)
+	require.True(t, isEmpty)
+	require.Empty(t, collectKeys(t, empty.Last(), false))
+}
+
+func TestMemDB_WithPrefixLastKeyInDB(t *testing.T) {
+	db := initDB(t, []string{"a", "b\xff", "b\xff\xff"})
+	require.Equal(t, []string{"\xff", ""}, collectKeys(t, db.WithPrefix([]byte("b\xff")).Last(), false))
+	require.Equal(t, []string{"b\xff\xff", "b\xff", "a"}, collectKeys(t, db.WithPrefix(nil).Last(), false))
+}
+
+func TestMemDB_WithPrefixBatchTxAndSnapshot(t *testing.T) {
+	db := initDB(t, []string{"a"})
+	view := db.WithPrefix([]byte("p/"))
+
+	batch := view.NewBatch()
+	require.NoError(t, batch.Write([]byte("1"), "1"))
+	require.NoError(t, batch.Write([]byte("2"), "2"))
+	require.Equal(t, 2, batch.Len())
+	require.NoError(t, batch.Commit())
+	require.Equal(t, []string{"a", "p/1", "p/2"}, collectKeys(t, db.First(), true))
+
+	tx, err := view.StartTx()
+	require.NoError(t, err)
+	require.NoError(t, tx.Write([]byte("3"), "3"))
+	require.NoError(t, tx.Delete([]byte("1")))
+	var value string
+	found, err := tx.Read([]byte("3"), &value)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.NoError(t, tx.Commit())
+
+	snapshot, err := view.Snapshot()
+	require.NoError(t, err)
+	require.NoError(t, view.Write([]byte("4"), "4"))
+	found, err = snapshot.Read([]byte("2"), &value)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []string{"2", "3"}, collectKeys(t, snapshot.First(), true))
+	require.Equal(t, []string{"3", "2"}, collectKeys(t, snapshot.Last(), false))
+	require.Equal(t, []string{"3"}, collectKeys(t, snapshot.Find([]byte("3")), true))
+	require.NoError(t, snapshot.Release())
+	require.Equal(t, []string{"2", "3", "4"}, collectKeys(t, view.First(), true))
+}
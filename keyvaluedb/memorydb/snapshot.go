@@ -0,0 +1,49 @@
+package memorydb
+
+import (
+	"fmt"
+
+	"github.com/alphabill-org/alphabill/keyvaluedb"
+)
+
+// Snapshot is a copy of the memory DB, values are never modified in place so copying the map is enough.
+type Snapshot struct {
+	db      map[string][]byte
+	decoder DecodeFn
+}
+
+func (s *Snapshot) Read(key []byte, value any) (bool, error) {
+	if err := keyvaluedb.CheckKeyAndValue(key, value); err != nil {
+		return false, err
+	}
+	if s.db == nil {
+		return false, fmt.Errorf("memdb snapshot read failed, snapshot released")
+	}
+	if data, ok := s.db[string(key)]; ok {
+		return true, s.decoder(data, value)
+	}
+	return false, nil
+}
+
+func (s *Snapshot) First() keyvaluedb.Iterator {
+	it := NewIterator(s.db, s.decoder)
+	it.first()
+	return it
+}
+
+func (s *Snapshot) Last() keyvaluedb.Iterator {
+	it := NewIterator(s.db, s.decoder)
+	it.last()
+	return it
+}
+
+func (s *Snapshot) Find(key []byte) keyvaluedb.Iterator {
+	it := NewIterator(s.db, s.decoder)
+	it.seek(key)
+	return it
+}
+
+func (s *Snapshot) Release() error {
+	s.db = nil
+	return nil
+}
@@ -0,0 +1,129 @@
+package keyvaluedb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// SchemaVersionKey is the key of the schema version entry. The single zero byte key sorts before the keys
+// used by the node databases (i.e. before 8-byte big-endian round numbers and text prefixes), so iterators
+// started with Find do not visit it.
+var SchemaVersionKey = []byte{0}
+
+// ErrSchemaTooNew is returned when the database has been created or migrated by a newer version of the node.
+var ErrSchemaTooNew = errors.New("database schema version is newer than supported")
+
+type (
+	// Migration upgrades the database from the schema version of the previous migration to Version.
+	// Migrations must be idempotent: schema version is stored after the migration succeeds, so the
+	// migration is run again if the node stops before that.
+	Migration struct {
+		Version     uint64
+		Description string
+		Migrate     func(db KeyValueDB) error
+	}
+
+	// MigrationPlan describes the migrations that are required to bring the database to the latest
+	// schema version.
+	MigrationPlan struct {
+		// CurrentVersion is the schema version stored in the DB, 0 if the DB does not have schema version.
+		CurrentVersion uint64
+		// LatestVersion is the version of the last migration.
+		LatestVersion uint64
+		// Empty is true if the DB is empty, empty DB is not migrated, only the latest version is stored.
+		Empty bool
+		// Pending are the migrations to be applied, in order.
+		Pending []Migration
+	}
+)
+
+// ReadSchemaVersion returns the schema version stored in the db, found is false if the version has not
+// been stored.
+func ReadSchemaVersion(db Reader) (version uint64, found bool, err error) {
+	found, err = db.Read(SchemaVersionKey, &version)
+	if err != nil {
+		return 0, false, fmt.Errorf("reading schema version: %w", err)
+	}
+	return version, found, nil
+}
+
+// PlanMigrations returns the migrations that would be applied by Migrate, the database is not modified.
+func PlanMigrations(db KeyValueDB, migrations []Migration) (*MigrationPlan, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db is nil")
+	}
+	if err := validateMigrations(migrations); err != nil {
+		return nil, err
+	}
+	plan := &MigrationPlan{}
+	if len(migrations) > 0 {
+		plan.LatestVersion = migrations[len(migrations)-1].Version
+	}
+	version, found, err := ReadSchemaVersion(db)
+	if err != nil {
+		return nil, err
+	}
+	if version > plan.LatestVersion {
+		return nil, fmt.Errorf("%w: database version %d, latest supported version %d", ErrSchemaTooNew, version, plan.LatestVersion)
+	}
+	plan.CurrentVersion = version
+	if !found {
+		if plan.Empty, err = IsEmpty(db); err != nil {
+			return nil, fmt.Errorf("checking if database is empty: %w", err)
+		}
+		if plan.Empty {
+			return plan, nil
+		}
+	}
+	for _, m := range migrations {
+		if m.Version > version {
+			plan.Pending = append(plan.Pending, m)
+		}
+	}
+	return plan, nil
+}
+
+// Migrate brings the database to the latest schema version by applying the migrations with a version
+// greater than the stored schema version. Migrations must be sorted by version. Database without schema
+// version is considered to be of version 0, unless it is empty - then only the latest version is stored.
+// Returns ErrSchemaTooNew if the stored schema version is greater than the version of the last migration.
+func Migrate(db KeyValueDB, migrations []Migration) (*MigrationPlan, error) {
+	plan, err := PlanMigrations(db, migrations)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Empty {
+		if err := db.Write(SchemaVersionKey, plan.LatestVersion); err != nil {
+			return nil, fmt.Errorf("writing schema version: %w", err)
+		}
+		return plan, nil
+	}
+	for _, m := range plan.Pending {
+		if err := m.Migrate(db); err != nil {
+			return nil, fmt.Errorf("migration to version %d (%s) failed: %w", m.Version, m.Description, err)
+		}
+		if err := db.Write(SchemaVersionKey, m.Version); err != nil {
+			return nil, fmt.Errorf("writing schema version %d: %w", m.Version, err)
+		}
+	}
+	return plan, nil
+}
+
+func validateMigrations(migrations []Migration) error {
+	var prev uint64
+	for i, m := range migrations {
+		if m.Version <= prev {
+			return fmt.Errorf("migration %d: version %d must be greater than %d", i, m.Version, prev)
+		}
+		if m.Migrate == nil {
+			return fmt.Errorf("migration %d: migrate function is nil", i)
+		}
+		prev = m.Version
+	}
+	return nil
+}
+
+func isSchemaVersionKey(key []byte) bool {
+	return bytes.Equal(key, SchemaVersionKey)
+}
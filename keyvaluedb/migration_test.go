@@ -0,0 +1,145 @@
+package keyvaluedb_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill-go-base/util"
+	"github.com/alphabill-org/alphabill/keyvaluedb"
+	"github.com/alphabill-org/alphabill/keyvaluedb/memorydb"
+)
+
+func testMigrations(applied *[]uint64) []keyvaluedb.Migration {
+	migration := func(version uint64) keyvaluedb.Migration {
+		return keyvaluedb.Migration{
+			Version:     version,
+			Description: "test",
+			Migrate: func(db keyvaluedb.KeyValueDB) error {
+				*applied = append(*applied, version)
+				return db.Write([]byte("migrated"), version)
+			},
+		}
+	}
+	return []keyvaluedb.Migration{migration(1), migration(2), migration(5)}
+}
+
+func requireSchemaVersion(t *testing.T, db keyvaluedb.KeyValueDB, expected uint64) {
+	t.Helper()
+	version, found, err := keyvaluedb.ReadSchemaVersion(db)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, expected, version)
+}
+
+func TestMigrate_EmptyDB(t *testing.T) {
+	db, err := memorydb.New()
+	require.NoError(t, err)
+	var applied []uint64
+	plan, err := keyvaluedb.Migrate(db, testMigrations(&applied))
+	require.NoError(t, err)
+	require.True(t, plan.Empty)
+	require.Empty(t, plan.Pending)
+	require.Empty(t, applied)
+	requireSchemaVersion(t, db, 5)
+
+	// schema version is not counted as DB content
+	empty, err := keyvaluedb.IsEmpty(db)
+	require.NoError(t, err)
+	require.True(t, empty)
+}
+
+func TestMigrate_LegacyDB(t *testing.T) {
+	db, err := memorydb.New()
+	require.NoError(t, err)
+	require.NoError(t, db.Write(util.Uint64ToBytes(1), "block"))
+
+	var applied []uint64
+	migrations := testMigrations(&applied)
+	plan, err := keyvaluedb.PlanMigrations(db, migrations)
+	require.NoError(t, err)
+	require.False(t, plan.Empty)
+	require.EqualValues(t, 0, plan.CurrentVersion)
+	require.EqualValues(t, 5, plan.LatestVersion)
+	require.Len(t, plan.Pending, 3)
+	// planning does not modify the DB
+	require.Empty(t, applied)
+	_, found, err := keyvaluedb.ReadSchemaVersion(db)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	_, err = keyvaluedb.Migrate(db, migrations)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2, 5}, applied)
+	requireSchemaVersion(t, db, 5)
+
+	// already migrated
+	plan, err = keyvaluedb.Migrate(db, migrations)
+	require.NoError(t, err)
+	require.Empty(t, plan.Pending)
+	require.Equal(t, []uint64{1, 2, 5}, applied)
+
+	// schema version is not visited when iterating from the first block
+	it := db.Find(util.Uint64ToBytes(0))
+	require.True(t, it.Valid())
+	require.Equal(t, util.Uint64ToBytes(1), it.Key())
+	require.NoError(t, it.Close())
+}
+
+func TestMigrate_PartiallyMigrated(t *testing.T) {
+	db, err := memorydb.New()
+	require.NoError(t, err)
+	require.NoError(t, db.Write([]byte("data"), "data"))
+	require.NoError(t, db.Write(keyvaluedb.SchemaVersionKey, uint64(1)))
+
+	var applied []uint64
+	_, err = keyvaluedb.Migrate(db, testMigrations(&applied))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 5}, applied)
+	requireSchemaVersion(t, db, 5)
+}
+
+func TestMigrate_Errors(t *testing.T) {
+	t.Run("db is nil", func(t *testing.T) {
+		_, err := keyvaluedb.Migrate(nil, nil)
+		require.ErrorContains(t, err, "db is nil")
+	})
+	t.Run("schema too new", func(t *testing.T) {
+		db, err := memorydb.New()
+		require.NoError(t, err)
+		require.NoError(t, db.Write(keyvaluedb.SchemaVersionKey, uint64(6)))
+		var applied []uint64
+		_, err = keyvaluedb.Migrate(db, testMigrations(&applied))
+		require.ErrorIs(t, err, keyvaluedb.ErrSchemaTooNew)
+		require.ErrorContains(t, err, "database version 6, latest supported version 5")
+	})
+	t.Run("migrations not ordered", func(t *testing.T) {
+		db, err := memorydb.New()
+		require.NoError(t, err)
+		var applied []uint64
+		migrations := testMigrations(&applied)
+		migrations[1], migrations[2] = migrations[2], migrations[1]
+		_, err = keyvaluedb.Migrate(db, migrations)
+		require.ErrorContains(t, err, "migration 2: version 2 must be greater than 5")
+	})
+	t.Run("migrate func is nil", func(t *testing.T) {
+		db, err := memorydb.New()
+		require.NoError(t, err)
+		_, err = keyvaluedb.Migrate(db, []keyvaluedb.Migration{{Version: 1}})
+		require.ErrorContains(t, err, "migration 0: migrate function is nil")
+	})
+	t.Run("migration fails", func(t *testing.T) {
+		db, err := memorydb.New()
+		require.NoError(t, err)
+		require.NoError(t, db.Write([]byte("data"), "data"))
+		migrations := []keyvaluedb.Migration{
+			{Version: 1, Description: "ok", Migrate: func(keyvaluedb.KeyValueDB) error { return nil }},
+			{Version: 2, Description: "fails", Migrate: func(keyvaluedb.KeyValueDB) error { return errors.New("boom") }},
+		}
+		_, err = keyvaluedb.Migrate(db, migrations)
+		require.ErrorContains(t, err, "migration to version 2 (fails) failed: boom")
+		// version of the last successful migration is stored
+		requireSchemaVersion(t, db, 1)
+	})
+}
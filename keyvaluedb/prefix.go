@@ -0,0 +1,257 @@
+package keyvaluedb
+
+import (
+	"bytes"
+	"slices"
+)
+
+type (
+	// prefixDB is a view of the KeyValueDB where all the keys are prefixed with the prefix.
+	prefixDB struct {
+		db     KeyValueDB
+		prefix []byte
+	}
+
+	prefixTx struct {
+		tx     DBTransaction
+		prefix []byte
+	}
+
+	prefixBatch struct {
+		batch  Batch
+		prefix []byte
+	}
+
+	prefixSnapshot struct {
+		snapshot Snapshot
+		prefix   []byte
+	}
+
+	// prefixIterator iterates over the keys with the prefix and strips the prefix from the keys.
+	prefixIterator struct {
+		it     Iterator
+		prefix []byte
+	}
+
+	// readIterable is implemented by both the DB and the snapshot.
+	readIterable interface {
+		Reader
+		Iterable
+	}
+)
+
+// NewPrefixDB returns a view of the db where all the keys are prefixed with the prefix. Used by the
+// KeyValueDB implementations to implement WithPrefix.
+func NewPrefixDB(db KeyValueDB, prefix []byte) KeyValueDB {
+	// nested views are flattened so that every operation is prefixed only once
+	if p, ok := db.(*prefixDB); ok {
+		return &prefixDB{db: p.db, prefix: prefixedKey(p.prefix, prefix)}
+	}
+	return &prefixDB{db: db, prefix: slices.Clone(prefix)}
+}
+
+func (db *prefixDB) Read(key []byte, value any) (bool, error) {
+	if err := CheckKey(key); err != nil {
+		return false, err
+	}
+	return db.db.Read(prefixedKey(db.prefix, key), value)
+}
+
+func (db *prefixDB) Write(key []byte, value any) error {
+	if err := CheckKey(key); err != nil {
+		return err
+	}
+	return db.db.Write(prefixedKey(db.prefix, key), value)
+}
+
+func (db *prefixDB) Delete(key []byte) error {
+	if err := CheckKey(key); err != nil {
+		return err
+	}
+	return db.db.Delete(prefixedKey(db.prefix, key))
+}
+
+func (db *prefixDB) First() Iterator {
+	return prefixFirst(db.db, db.prefix)
+}
+
+func (db *prefixDB) Last() Iterator {
+	return prefixLast(db.db, db.prefix)
+}
+
+func (db *prefixDB) Find(key []byte) Iterator {
+	return prefixFind(db.db, db.prefix, key)
+}
+
+func (db *prefixDB) StartTx() (DBTransaction, error) {
+	tx, err := db.db.StartTx()
+	if err != nil {
+		return nil, err
+	}
+	return &prefixTx{tx: tx, prefix: db.prefix}, nil
+}
+
+func (db *prefixDB) WithPrefix(prefix []byte) KeyValueDB {
+	return NewPrefixDB(db, prefix)
+}
+
+func (db *prefixDB) NewBatch() Batch {
+	return &prefixBatch{batch: db.db.NewBatch(), prefix: db.prefix}
+}
+
+func (db *prefixDB) Snapshot() (Snapshot, error) {
+	snapshot, err := db.db.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &prefixSnapshot{snapshot: snapshot, prefix: db.prefix}, nil
+}
+
+func (t *prefixTx) Read(key []byte, value any) (bool, error) {
+	if err := CheckKey(key); err != nil {
+		return false, err
+	}
+	return t.tx.Read(prefixedKey(t.prefix, key), value)
+}
+
+func (t *prefixTx) Write(key []byte, value any) error {
+	if err := CheckKey(key); err != nil {
+		return err
+	}
+	return t.tx.Write(prefixedKey(t.prefix, key), value)
+}
+
+func (t *prefixTx) Delete(key []byte) error {
+	if err := CheckKey(key); err != nil {
+		return err
+	}
+	return t.tx.Delete(prefixedKey(t.prefix, key))
+}
+
+func (t *prefixTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *prefixTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+func (b *prefixBatch) Write(key []byte, value any) error {
+	if err := CheckKey(key); err != nil {
+		return err
+	}
+	return b.batch.Write(prefixedKey(b.prefix, key), value)
+}
+
+func (b *prefixBatch) Delete(key []byte) error {
+	if err := CheckKey(key); err != nil {
+		return err
+	}
+	return b.batch.Delete(prefixedKey(b.prefix, key))
+}
+
+func (b *prefixBatch) Len() int {
+	return b.batch.Len()
+}
+
+func (b *prefixBatch) Commit() error {
+	return b.batch.Commit()
+}
+
+func (s *prefixSnapshot) Read(key []byte, value any) (bool, error) {
+	if err := CheckKey(key); err != nil {
+		return false, err
+	}
+	return s.snapshot.Read(prefixedKey(s.prefix, key), value)
+}
+
+func (s *prefixSnapshot) First() Iterator {
+	return prefixFirst(s.snapshot, s.prefix)
+}
+
+func (s *prefixSnapshot) Last() Iterator {
+	return prefixLast(s.snapshot, s.prefix)
+}
+
+func (s *prefixSnapshot) Find(key []byte) Iterator {
+	return prefixFind(s.snapshot, s.prefix, key)
+}
+
+func (s *prefixSnapshot) Release() error {
+	return s.snapshot.Release()
+}
+
+func prefixFirst(db readIterable, prefix []byte) Iterator {
+	return &prefixIterator{it: db.Find(prefix), prefix: prefix}
+}
+
+func prefixLast(db readIterable, prefix []byte) Iterator {
+	end := prefixEnd(prefix)
+	if end == nil {
+		return &prefixIterator{it: db.Last(), prefix: prefix}
+	}
+	// the first key after the prefix range, the last key with the prefix is the one before it
+	it := db.Find(end)
+	if it.Valid() {
+		it.Prev()
+		return &prefixIterator{it: it, prefix: prefix}
+	}
+	// there are no keys after the prefix range
+	_ = it.Close()
+	return &prefixIterator{it: db.Last(), prefix: prefix}
+}
+
+func prefixFind(db readIterable, prefix []byte, key []byte) Iterator {
+	return &prefixIterator{it: db.Find(prefixedKey(prefix, key)), prefix: prefix}
+}
+
+func (it *prefixIterator) Next() {
+	if it.Valid() {
+		it.it.Next()
+	}
+}
+
+func (it *prefixIterator) Prev() {
+	if it.Valid() {
+		it.it.Prev()
+	}
+}
+
+func (it *prefixIterator) Valid() bool {
+	return it.it.Valid() && bytes.HasPrefix(it.it.Key(), it.prefix)
+}
+
+func (it *prefixIterator) Key() []byte {
+	if !it.Valid() {
+		return nil
+	}
+	return it.it.Key()[len(it.prefix):]
+}
+
+func (it *prefixIterator) Value(value any) error {
+	if !it.Valid() {
+		return errIteratorInvalid
+	}
+	return it.it.Value(value)
+}
+
+func (it *prefixIterator) Close() error {
+	return it.it.Close()
+}
+
+func prefixedKey(prefix, key []byte) []byte {
+	return append(slices.Clone(prefix), key...)
+}
+
+// prefixEnd returns the smallest key that is greater than all the keys with the prefix, or nil if there
+// is no such key (i.e. the prefix is empty or consists of 0xFF bytes only).
+func prefixEnd(prefix []byte) []byte {
+	end := slices.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xFF {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/alphabill-org/alphabill-go-base/crypto"
 	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/internal/cborutil"
 	"github.com/alphabill-org/alphabill/network/protocol/certification"
+	"github.com/alphabill-org/alphabill/predicates"
 )
 
 var (
@@ -30,6 +32,20 @@ type PartitionNode struct {
 type MoneyPartitionParams struct {
 	_          struct{} `cbor:",toarray"`
 	Partitions []*types.PartitionDescriptionRecord
+	// optional, predicates.DefaultGasSchedule is used when nil
+	GasSchedule *predicates.GasSchedule
+	// optional, the fee reward distribution policies of the partitions;
+	// fees are not distributed to the validators when empty
+	RewardPolicies []*RewardPolicy
+	// optional, the check interval (in rounds) of the expired fee credit records,
+	// the expired records with zero balance are deleted; the fee credit records
+	// don't expire when the interval is zero
+	FeeCreditRecordExpiryInterval uint64
+	// optional, the names of the predicate engines enabled in addition to the
+	// builtin ones, all the validators of the partition use the same engines
+	PredicateEngines []string
+	// optional, the gas schedules replacing GasSchedule from their activation round
+	GasScheduleUpdates predicates.GasScheduleUpdates
 }
 
 type EvmPartitionParams struct {
@@ -47,6 +63,33 @@ type TokensPartitionParams struct {
 	_                   struct{} `cbor:",toarray"`
 	AdminOwnerPredicate []byte
 	FeelessMode         bool
+	// optional, predicates.DefaultGasSchedule is used when nil
+	GasSchedule *predicates.GasSchedule
+	// optional, the maximum number of NFTs minted by a single batch mint
+	// transaction; tokens.DefaultMaxBatchMintSize is used when zero
+	MaxBatchMintSize uint32
+	// optional, the check interval (in rounds) of the expired fee credit records,
+	// the expired records with zero balance are deleted; the fee credit records
+	// don't expire when the interval is zero
+	FeeCreditRecordExpiryInterval uint64
+	// optional, the names of the predicate engines enabled in addition to the
+	// builtin ones, all the validators of the partition use the same engines
+	PredicateEngines []string
+	// optional, the description records of the other partitions whose unicity
+	// certificates the WASM predicates can verify
+	Partitions []*types.PartitionDescriptionRecord
+	// optional, the gas schedules replacing GasSchedule from their activation round
+	GasScheduleUpdates predicates.GasScheduleUpdates
+}
+
+func (p *MoneyPartitionParams) UnmarshalCBOR(data []byte) error {
+	type params MoneyPartitionParams
+	return cborutil.UnmarshalOptionalFields(data, 6, (*params)(p))
+}
+
+func (p *TokensPartitionParams) UnmarshalCBOR(data []byte) error {
+	type params TokensPartitionParams
+	return cborutil.UnmarshalOptionalFields(data, 8, (*params)(p))
 }
 
 func (x *PartitionNode) IsValid() error {
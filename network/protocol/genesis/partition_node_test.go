@@ -6,6 +6,7 @@ import (
 	"github.com/alphabill-org/alphabill-go-base/types"
 	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
 	"github.com/alphabill-org/alphabill/network/protocol/certification"
+	"github.com/alphabill-org/alphabill/predicates"
 	"github.com/stretchr/testify/require"
 )
 
@@ -129,3 +130,82 @@ func TestPartitionNode_IsValid_PartitionNodeIsNil(t *testing.T) {
 	var pn *PartitionNode
 	require.ErrorIs(t, ErrPartitionNodeIsNil, pn.IsValid())
 }
+
+func TestTokensPartitionParams_CBOR(t *testing.T) {
+	t.Run("without gas schedule", func(t *testing.T) {
+		// params created before the gas schedule was added
+		legacy := struct {
+			_                   struct{} `cbor:",toarray"`
+			AdminOwnerPredicate []byte
+			FeelessMode         bool
+		}{AdminOwnerPredicate: []byte{1, 2, 3}, FeelessMode: true}
+		buf, err := types.Cbor.Marshal(legacy)
+		require.NoError(t, err)
+
+		params := &TokensPartitionParams{}
+		require.NoError(t, types.Cbor.Unmarshal(buf, params))
+		require.Equal(t, legacy.AdminOwnerPredicate, params.AdminOwnerPredicate)
+		require.True(t, params.FeelessMode)
+		require.Nil(t, params.GasSchedule)
+		require.Zero(t, params.MaxBatchMintSize)
+		require.Zero(t, params.FeeCreditRecordExpiryInterval)
+		require.Nil(t, params.PredicateEngines)
+		require.Nil(t, params.Partitions)
+	})
+
+	t.Run("with gas schedule", func(t *testing.T) {
+		src := &TokensPartitionParams{
+			AdminOwnerPredicate:           []byte{1},
+			GasSchedule:                   predicates.DefaultGasSchedule(),
+			MaxBatchMintSize:              1000,
+			FeeCreditRecordExpiryInterval: 100,
+			PredicateEngines:              []string{"script"},
+			Partitions:                    []*types.PartitionDescriptionRecord{{NetworkIdentifier: 5, SystemIdentifier: 1, TypeIdLen: 8, UnitIdLen: 256}},
+		}
+		buf, err := types.Cbor.Marshal(src)
+		require.NoError(t, err)
+		params := &TokensPartitionParams{}
+		require.NoError(t, types.Cbor.Unmarshal(buf, params))
+		require.Equal(t, src, params)
+	})
+
+	t.Run("invalid encoding", func(t *testing.T) {
+		params := &TokensPartitionParams{}
+		require.Error(t, types.Cbor.Unmarshal([]byte{0x01}, params))
+	})
+}
+
+func TestMoneyPartitionParams_CBOR(t *testing.T) {
+	pdr := &types.PartitionDescriptionRecord{NetworkIdentifier: 5, SystemIdentifier: 1, TypeIdLen: 8, UnitIdLen: 256}
+	legacy := struct {
+		_          struct{} `cbor:",toarray"`
+		Partitions []*types.PartitionDescriptionRecord
+	}{Partitions: []*types.PartitionDescriptionRecord{pdr}}
+	buf, err := types.Cbor.Marshal(legacy)
+	require.NoError(t, err)
+	params := &MoneyPartitionParams{}
+	require.NoError(t, types.Cbor.Unmarshal(buf, params))
+	require.Equal(t, legacy.Partitions, params.Partitions)
+	require.Nil(t, params.GasSchedule)
+	require.Nil(t, params.RewardPolicies)
+	require.Zero(t, params.FeeCreditRecordExpiryInterval)
+	require.Nil(t, params.PredicateEngines)
+
+	src := &MoneyPartitionParams{
+		Partitions:  legacy.Partitions,
+		GasSchedule: predicates.DefaultGasSchedule(),
+		RewardPolicies: []*RewardPolicy{{
+			SystemIdentifier: 1,
+			Policy:           RewardPolicyEqualSplit,
+			EpochLength:      100,
+			Validators:       []*RewardRecipient{{NodeIdentifier: "node1", OwnerPredicate: []byte{1}}},
+		}},
+		FeeCreditRecordExpiryInterval: 100,
+		PredicateEngines:              []string{"script"},
+	}
+	buf, err = types.Cbor.Marshal(src)
+	require.NoError(t, err)
+	params = &MoneyPartitionParams{}
+	require.NoError(t, types.Cbor.Unmarshal(buf, params))
+	require.Equal(t, src, params)
+}
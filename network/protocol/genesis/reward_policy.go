@@ -0,0 +1,74 @@
+package genesis
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+)
+
+const (
+	// RewardPolicyEqualSplit splits the fees of the epoch equally between the validators.
+	RewardPolicyEqualSplit uint8 = 1
+	// RewardPolicyBlocksProposed splits the fees of the epoch between the validators
+	// proportionally to the number of the (recorded) blocks proposed by the validator.
+	RewardPolicyBlocksProposed uint8 = 2
+)
+
+type (
+	/*
+		RewardPolicy is the fee reward distribution policy of a partition. The money
+		partition pays the fees earned by the partition out to the reward bills of the
+		validators in the end of every EpochLength-th round of the money partition.
+
+		The policies are part of the money partition params (MoneyPartitionParams) rather
+		than the partition description records: the PDR type is defined by the SDK and its
+		hash is certified by the root chain, extending it would change the hash of every
+		existing PDR. The money partition is the only consumer of the policies and the
+		params are agreed on by its validators in genesis.
+	*/
+	RewardPolicy struct {
+		_                struct{}           `cbor:",toarray"`
+		SystemIdentifier types.SystemID     `json:"systemIdentifier"`
+		Policy           uint8              `json:"policy"`             // RewardPolicyEqualSplit or RewardPolicyBlocksProposed
+		EpochLength      uint64             `json:"epochLength,string"` // length of the reward epoch in money partition rounds
+		Validators       []*RewardRecipient `json:"validators"`
+	}
+
+	// RewardRecipient is the validator of the partition receiving the fee rewards.
+	RewardRecipient struct {
+		_              struct{}             `cbor:",toarray"`
+		NodeIdentifier string               `json:"nodeIdentifier"`
+		OwnerPredicate types.PredicateBytes `json:"ownerPredicate"` // owner predicate of the reward bill created for the validator
+	}
+)
+
+func (p *RewardPolicy) IsValid() error {
+	if p == nil {
+		return errors.New("reward policy is nil")
+	}
+	if p.Policy != RewardPolicyEqualSplit && p.Policy != RewardPolicyBlocksProposed {
+		return fmt.Errorf("unknown reward policy %d", p.Policy)
+	}
+	if p.EpochLength == 0 {
+		return errors.New("epoch length must be greater than zero")
+	}
+	if len(p.Validators) == 0 {
+		return errors.New("validators are missing")
+	}
+	for i, v := range p.Validators {
+		if v == nil || v.NodeIdentifier == "" || len(v.OwnerPredicate) == 0 {
+			return fmt.Errorf("validator %d: node identifier or owner predicate is missing", i)
+		}
+		if p.ValidatorIndex(v.NodeIdentifier) != i {
+			return fmt.Errorf("duplicate validator %s", v.NodeIdentifier)
+		}
+	}
+	return nil
+}
+
+// ValidatorIndex returns index of the validator "nodeID" in the Validators, -1 when not found.
+func (p *RewardPolicy) ValidatorIndex(nodeID string) int {
+	return slices.IndexFunc(p.Validators, func(v *RewardRecipient) bool { return v != nil && v.NodeIdentifier == nodeID })
+}
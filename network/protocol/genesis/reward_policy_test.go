@@ -0,0 +1,52 @@
+package genesis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewardPolicy_IsValid(t *testing.T) {
+	validPolicy := func() *RewardPolicy {
+		return &RewardPolicy{
+			SystemIdentifier: 1,
+			Policy:           RewardPolicyEqualSplit,
+			EpochLength:      10,
+			Validators: []*RewardRecipient{
+				{NodeIdentifier: "1", OwnerPredicate: []byte{1}},
+				{NodeIdentifier: "2", OwnerPredicate: []byte{2}},
+			},
+		}
+	}
+	require.NoError(t, validPolicy().IsValid())
+
+	var p *RewardPolicy
+	require.EqualError(t, p.IsValid(), "reward policy is nil")
+
+	p = validPolicy()
+	p.Policy = 3
+	require.EqualError(t, p.IsValid(), "unknown reward policy 3")
+
+	p = validPolicy()
+	p.EpochLength = 0
+	require.EqualError(t, p.IsValid(), "epoch length must be greater than zero")
+
+	p = validPolicy()
+	p.Validators = nil
+	require.EqualError(t, p.IsValid(), "validators are missing")
+
+	p = validPolicy()
+	p.Validators[1].OwnerPredicate = nil
+	require.EqualError(t, p.IsValid(), "validator 1: node identifier or owner predicate is missing")
+
+	p = validPolicy()
+	p.Validators[1].NodeIdentifier = "1"
+	require.EqualError(t, p.IsValid(), "duplicate validator 1")
+}
+
+func TestRewardPolicy_ValidatorIndex(t *testing.T) {
+	p := &RewardPolicy{Validators: []*RewardRecipient{{NodeIdentifier: "1"}, nil, {NodeIdentifier: "2"}}}
+	require.Equal(t, 0, p.ValidatorIndex("1"))
+	require.Equal(t, 2, p.ValidatorIndex("2"))
+	require.Equal(t, -1, p.ValidatorIndex("3"))
+}
@@ -0,0 +1,93 @@
+package partition
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill-go-base/util"
+	"github.com/alphabill-org/alphabill/keyvaluedb"
+)
+
+// the block store keeps the finalized blocks (keyed by round number) and the pending
+// block proposal in separate namespaces
+var (
+	blockStoreBlocksPrefix   = []byte("block_")
+	blockStoreProposalPrefix = []byte("proposal_")
+)
+
+// number of entries moved to the namespaces of the block store in one batch
+const blockStoreMigrationBatchSize = 1000
+
+// BlockStoreMigrations are the schema migrations of the block store, ordered by version. Version 1 is the
+// schema used before the schema version was recorded.
+var BlockStoreMigrations = []keyvaluedb.Migration{
+	{Version: 1, Description: "initial schema", Migrate: func(keyvaluedb.KeyValueDB) error { return nil }},
+	{Version: 2, Description: "blocks and block proposal in separate namespaces", Migrate: migrateBlockStoreNamespaces},
+}
+
+// ProofIndexMigrations are the schema migrations of the proof index, ordered by version. Version 1 is the
+// schema used before the schema version was recorded.
+var ProofIndexMigrations = []keyvaluedb.Migration{
+	{Version: 1, Description: "initial schema", Migrate: func(keyvaluedb.KeyValueDB) error { return nil }},
+}
+
+// BlockStoreBlocks returns the view of the block store which contains the finalized blocks keyed by
+// round number.
+func BlockStoreBlocks(blockStore keyvaluedb.KeyValueDB) keyvaluedb.KeyValueDB {
+	return blockStore.WithPrefix(blockStoreBlocksPrefix)
+}
+
+/*
+migrateBlockStoreNamespaces moves the blocks (8 byte round number keys) and the block proposal
+(4 byte key) of the version 1 schema into the namespaces of the block store. The entries are
+copied before the originals are deleted so the migration can be re-run when interrupted.
+*/
+func migrateBlockStoreNamespaces(db keyvaluedb.KeyValueDB) error {
+	blocks := BlockStoreBlocks(db).NewBatch()
+	proposal := db.WithPrefix(blockStoreProposalPrefix).NewBatch()
+	root := db.NewBatch()
+	for {
+		if err := collectBlockStoreMoves(db, blocks, proposal, root); err != nil {
+			return err
+		}
+		if root.Len() == 0 {
+			return nil
+		}
+		if err := errors.Join(blocks.Commit(), proposal.Commit()); err != nil {
+			return fmt.Errorf("moving block store entries: %w", err)
+		}
+		if err := root.Commit(); err != nil {
+			return fmt.Errorf("deleting moved block store entries: %w", err)
+		}
+	}
+}
+
+// collectBlockStoreMoves adds up to blockStoreMigrationBatchSize moves of the version 1 entries to the batches.
+func collectBlockStoreMoves(db keyvaluedb.KeyValueDB, blocks, proposal, root keyvaluedb.Batch) (err error) {
+	it := db.First()
+	defer func() { err = errors.Join(err, it.Close()) }()
+	for ; it.Valid() && root.Len() < blockStoreMigrationBatchSize; it.Next() {
+		key := it.Key()
+		var dst keyvaluedb.Batch
+		switch {
+		case len(key) == 8:
+			dst = blocks
+		case len(key) == 4 && util.BytesToUint32(key) == proposalKey:
+			dst = proposal
+		default:
+			continue
+		}
+		var value types.RawCBOR
+		if err := it.Value(&value); err != nil {
+			return fmt.Errorf("reading block store entry %x: %w", key, err)
+		}
+		if err := dst.Write(key, value); err != nil {
+			return fmt.Errorf("moving block store entry %x: %w", key, err)
+		}
+		if err := root.Delete(key); err != nil {
+			return fmt.Errorf("deleting block store entry %x: %w", key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package partition
+
+import (
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill-go-base/util"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill/keyvaluedb"
+	"github.com/alphabill-org/alphabill/keyvaluedb/memorydb"
+)
+
+func TestBlockStoreMigrations(t *testing.T) {
+	// version 1 schema: blocks keyed by round number and the proposal by 4 byte key
+	db, err := memorydb.New()
+	require.NoError(t, err)
+	require.NoError(t, db.Write(keyvaluedb.SchemaVersionKey, uint64(1)))
+	const blockCount = blockStoreMigrationBatchSize + 1
+	for round := uint64(1); round <= blockCount; round++ {
+		require.NoError(t, db.Write(util.Uint64ToBytes(round), &types.Block{Header: &types.Header{PreviousBlockHash: util.Uint64ToBytes(round)}}))
+	}
+	require.NoError(t, db.Write(util.Uint32ToBytes(proposalKey), &types.Block{Header: &types.Header{ProposerID: "proposal"}}))
+
+	plan, err := keyvaluedb.Migrate(db, BlockStoreMigrations)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, plan.CurrentVersion)
+	require.Len(t, plan.Pending, 1)
+	version, found, err := keyvaluedb.ReadSchemaVersion(db)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 2, version)
+
+	blocks := BlockStoreBlocks(db)
+	for round := uint64(1); round <= blockCount; round++ {
+		var b types.Block
+		found, err := blocks.Read(util.Uint64ToBytes(round), &b)
+		require.NoError(t, err)
+		require.True(t, found, "block %d", round)
+		require.EqualValues(t, util.Uint64ToBytes(round), b.Header.PreviousBlockHash)
+
+		found, err = db.Read(util.Uint64ToBytes(round), &b)
+		require.NoError(t, err)
+		require.False(t, found, "block %d", round)
+	}
+	var proposal types.Block
+	found, err = db.WithPrefix(blockStoreProposalPrefix).Read(util.Uint32ToBytes(proposalKey), &proposal)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "proposal", proposal.Header.ProposerID)
+	found, err = db.Read(util.Uint32ToBytes(proposalKey), &proposal)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// the last block is the last item of the blocks namespace
+	it := blocks.Last()
+	require.True(t, it.Valid())
+	require.Equal(t, util.Uint64ToBytes(blockCount), it.Key())
+	require.NoError(t, it.Close())
+
+	// migration can be re-run
+	require.NoError(t, migrateBlockStoreNamespaces(db))
+	found, err = blocks.Read(util.Uint64ToBytes(1), &proposal)
+	require.NoError(t, err)
+	require.True(t, found)
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/alphabill-org/alphabill/observability"
 	"github.com/alphabill-org/alphabill/partition/event"
 	"github.com/alphabill-org/alphabill/txsystem"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
 )
 
 const (
@@ -55,8 +56,7 @@ func (s status) String() string {
 	}
 }
 
-// Key 0 is used for proposal, that way it is still possible to reverse iterate the DB
-// and use 4 byte key, make it incompatible with block number
+// Key of the pending block proposal in the proposal namespace of the block store.
 const proposalKey = uint32(0)
 const ledgerReplicationTimeout = 1500 * time.Millisecond
 const blockSubscriptionTimeout = 3000 * time.Millisecond
@@ -101,6 +101,7 @@ type (
 		unicityCertificateValidator UnicityCertificateValidator
 		blockProposalValidator      BlockProposalValidator
 		blockStore                  keyvaluedb.KeyValueDB
+		proposalStore               keyvaluedb.KeyValueDB
 		proofIndexer                *ProofIndexer
 		ownerIndexer                *OwnerIndexer
 		stopTxProcessor             atomic.Value
@@ -172,7 +173,8 @@ func NewNode(
 		txValidator:                 conf.txValidator,
 		unicityCertificateValidator: conf.unicityCertificateValidator,
 		blockProposalValidator:      conf.blockProposalValidator,
-		blockStore:                  conf.blockStore,
+		blockStore:                  BlockStoreBlocks(conf.blockStore),
+		proposalStore:               conf.blockStore.WithPrefix(blockStoreProposalPrefix),
 		proofIndexer:                NewProofIndexer(conf.hashAlgorithm, conf.proofIndexConfig.store, conf.proofIndexConfig.historyLen, observe.Logger()),
 		ownerIndexer:                conf.ownerIndexer,
 		t1event:                     make(chan struct{}), // do not buffer!
@@ -431,7 +433,7 @@ func (n *Node) restoreBlockProposal(ctx context.Context) {
 	defer span.End()
 
 	pr := &types.Block{}
-	found, err := n.blockStore.Read(util.Uint32ToBytes(proposalKey), pr)
+	found, err := n.proposalStore.Read(util.Uint32ToBytes(proposalKey), pr)
 	if err != nil {
 		n.log.ErrorContext(ctx, "Error fetching block proposal", logger.Error(err))
 		return
@@ -738,7 +740,7 @@ func (n *Node) startNewRound(ctx context.Context) error {
 	n.resetProposal()
 	n.sumOfEarnedFees = 0
 	// not a fatal issue, but log anyway
-	if err := n.blockStore.Delete(util.Uint32ToBytes(proposalKey)); err != nil {
+	if err := n.proposalStore.Delete(util.Uint32ToBytes(proposalKey)); err != nil {
 		n.log.DebugContext(ctx, "DB proposal delete failed", logger.Error(err))
 	}
 
@@ -933,17 +935,22 @@ func (n *Node) finalizeBlock(ctx context.Context, b *types.Block, uc *types.Unic
 
 	if isInitializing {
 		// ProofIndexer not running yet, index synchronously
-		if err := n.proofIndexer.IndexBlock(ctx, b, blockNumber, n.transactionSystem.State()); err != nil {
+		if err := n.proofIndexer.IndexBlock(ctx, b, blockNumber, n.TransactionSystemState()); err != nil {
 			return fmt.Errorf("failed to index block: %w", err)
 		}
 	} else {
-		n.proofIndexer.Handle(ctx, b, n.transactionSystem.State())
+		n.proofIndexer.Handle(ctx, b, n.TransactionSystemState())
 	}
 
 	if n.ownerIndexer != nil {
-		if err := n.ownerIndexer.IndexBlock(b, n.transactionSystem.State()); err != nil {
+		if err := n.ownerIndexer.IndexBlock(b, n.TransactionSystemState()); err != nil {
 			return fmt.Errorf("failed to index block: %w", err)
 		}
+		if ud, ok := n.transactionSystem.(txtypes.UnitsDeleter); ok {
+			if err := n.ownerIndexer.RemoveDeletedUnits(ud.DeletedUnits()); err != nil {
+				return fmt.Errorf("failed to index deleted units: %w", err)
+			}
+		}
 	}
 	return nil
 }
@@ -1279,7 +1286,7 @@ func (n *Node) sendBlockProposal(ctx context.Context) error {
 }
 
 func (n *Node) persistBlockProposal(pr *types.Block) error {
-	if err := n.blockStore.Write(util.Uint32ToBytes(proposalKey), pr); err != nil {
+	if err := n.proposalStore.Write(util.Uint32ToBytes(proposalKey), pr); err != nil {
 		return fmt.Errorf("persist error, %w", err)
 	}
 	return nil
@@ -1485,10 +1492,22 @@ func (n *Node) FilterValidatorNodes(exclude peer.ID) []peer.ID {
 	return result
 }
 
+// TransactionSystemState returns a read-only view of the latest committed transaction system state.
 func (n *Node) TransactionSystemState() txsystem.StateReader {
+	if uc := n.transactionSystem.CommittedUC(); uc != nil {
+		if s, err := n.transactionSystem.StateAt(uc.GetRoundNumber()); err == nil {
+			return s
+		}
+	}
+	// snapshot is not available (disabled or already dropped), fall back to cloning the state
 	return n.transactionSystem.State()
 }
 
+// TransactionSystemStateAt returns a read-only view of the transaction system state committed in the given round.
+func (n *Node) TransactionSystemStateAt(roundNumber uint64) (txsystem.StateReader, error) {
+	return n.transactionSystem.StateAt(roundNumber)
+}
+
 func (n *Node) stopForwardingOrHandlingTransactions() {
 	n.stopTxProcessor.Load().(func())()
 }
@@ -70,10 +70,10 @@ func TestNode_NodeStartWithRecoverStateFromDB(t *testing.T) {
 	newBlock1, uc1 := createNewBlockOutsideNode(t, tp, system, uc0, testtransaction.NewTransactionRecord(t))
 	newBlock2, uc2 := createNewBlockOutsideNode(t, tp, system, uc1, testtransaction.NewTransactionRecord(t))
 	newBlock3, _ := createNewBlockOutsideNode(t, tp, system, uc2, testtransaction.NewTransactionRecord(t))
-	require.NoError(t, db.Write(util.Uint64ToBytes(1), newBlock1))
-	require.NoError(t, db.Write(util.Uint64ToBytes(2), newBlock2))
+	require.NoError(t, BlockStoreBlocks(db).Write(util.Uint64ToBytes(1), newBlock1))
+	require.NoError(t, BlockStoreBlocks(db).Write(util.Uint64ToBytes(2), newBlock2))
 	// add transactions from block 4 as pending block
-	require.NoError(t, db.Write(util.Uint32ToBytes(proposalKey), newBlock3))
+	require.NoError(t, db.WithPrefix(blockStoreProposalPrefix).Write(util.Uint32ToBytes(proposalKey), newBlock3))
 	// start node with db filled
 	ctx, cancel := context.WithCancel(context.Background())
 	done := StartSingleNodePartition(ctx, t, tp)
@@ -12,6 +12,7 @@ import (
 	"github.com/alphabill-org/alphabill/predicates"
 	"github.com/alphabill-org/alphabill/state"
 	"github.com/alphabill-org/alphabill/txsystem"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
 )
 
 type (
@@ -27,6 +28,7 @@ type (
 	IndexWriter interface {
 		LoadState(s txsystem.StateReader) error
 		IndexBlock(b *types.Block, s StateProvider) error
+		RemoveDeletedUnits(units []*txtypes.DeletedUnit) error
 	}
 
 	IndexReader interface {
@@ -88,6 +90,20 @@ func (o *OwnerIndexer) IndexBlock(b *types.Block, s StateProvider) error {
 	return nil
 }
 
+// RemoveDeletedUnits removes the units deleted outside of transactions (ie by the end
+// block functions of the transaction system) from the index.
+func (o *OwnerIndexer) RemoveDeletedUnits(units []*txtypes.DeletedUnit) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, u := range units {
+		if err := o.delOwnerIndex(u.UnitID, u.Data.Owner()); err != nil {
+			return fmt.Errorf("failed to remove owner index of deleted unit [%s]: %w", u.UnitID, err)
+		}
+	}
+	return nil
+}
+
 func (o *OwnerIndexer) indexUnit(unitID types.UnitID, logs []*state.Log) error {
 	// logs - tx logs that changed the unit
 	// if unit was created in this round:
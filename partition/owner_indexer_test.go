@@ -15,6 +15,7 @@ import (
 	testlogger "github.com/alphabill-org/alphabill/internal/testutils/logger"
 	"github.com/alphabill-org/alphabill/state"
 	testtransaction "github.com/alphabill-org/alphabill/txsystem/testutils/transaction"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
 )
 
 func TestOwnerIndexer(t *testing.T) {
@@ -195,6 +196,26 @@ func TestOwnerIndexer(t *testing.T) {
 		require.Len(t, ownerUnitIDs, 1)
 		require.Equal(t, unitID, ownerUnitIDs[0])
 	})
+	t.Run("deleted units are removed from index", func(t *testing.T) {
+		ownerIndexer := NewOwnerIndexer(testlogger.New(t))
+		unitID1 := types.UnitID{1}
+		unitID2 := types.UnitID{2}
+		ownerID := []byte{1}
+		ownerPredicate := templates.NewP2pkh256BytesFromKeyHash(ownerID)
+		ownerIndexer.ownerUnits[string(ownerID)] = []types.UnitID{unitID1, unitID2}
+
+		require.NoError(t, ownerIndexer.RemoveDeletedUnits([]*txtypes.DeletedUnit{
+			{UnitID: unitID1, Data: &mockUnitData{ownerPredicate: ownerPredicate}},
+		}))
+		ownerUnitIDs, err := ownerIndexer.GetOwnerUnits(ownerID)
+		require.NoError(t, err)
+		require.Equal(t, []types.UnitID{unitID2}, ownerUnitIDs)
+
+		require.NoError(t, ownerIndexer.RemoveDeletedUnits([]*txtypes.DeletedUnit{
+			{UnitID: unitID2, Data: &mockUnitData{ownerPredicate: ownerPredicate}},
+		}))
+		require.Empty(t, ownerIndexer.ownerUnits)
+	})
 }
 
 type mockUnitData struct {
@@ -981,7 +981,7 @@ func TestNode_CertificationRequestNotSentWhenProposalStoreFails(t *testing.T) {
 	require.Len(t, tp.mockNet.SentMessages(network.ProtocolBlockCertification), 0)
 	// make no certification request is sent, proposal is not stored
 	var pr types.Block
-	found, err := db.Read(util.Uint32ToBytes(proposalKey), &pr)
+	found, err := db.WithPrefix(blockStoreProposalPrefix).Read(util.Uint32ToBytes(proposalKey), &pr)
 	require.NoError(t, err)
 	require.False(t, found)
 	tp.SubmitUnicityCertificate(uc1)
@@ -110,34 +110,14 @@ func (p *ProofIndexer) loop(ctx context.Context) error {
 	}
 }
 
-// create - creates proof index DB entries
+// create - creates proof index DB entries, all the entries of the block are written atomically
 func (p *ProofIndexer) create(ctx context.Context, block *types.Block, roundNumber uint64, stateReader UnitAndProof) (err error) {
-	dbTx, err := p.storage.StartTx()
-	if err != nil {
-		return fmt.Errorf("start DB transaction failed: %w", err)
-	}
-
-	// commit if no error, rollback if any error
-	defer func() {
-		if err != nil {
-			if e := dbTx.Rollback(); e != nil {
-				err = errors.Join(err, fmt.Errorf("index transaction rollback failed: %w", e))
-			}
-		}
-	}()
-	defer func() {
-		if err == nil {
-			if e := dbTx.Commit(); e != nil {
-				err = errors.Join(err, fmt.Errorf("index transaction commit failed: %w", e))
-			}
-		}
-	}()
-
+	batch := p.storage.NewBatch()
 	var history historyIndex
 	for i, tx := range block.Transactions {
 		// write down tx index for generating block proofs
 		txoHash := tx.TransactionOrder.Hash(p.hashAlgorithm)
-		if err = dbTx.Write(txoHash, &TxIndex{
+		if err = batch.Write(txoHash, &TxIndex{
 			RoundNumber:  roundNumber,
 			TxOrderIndex: i,
 		}); err != nil {
@@ -170,7 +150,7 @@ func (p *ProofIndexer) create(ctx context.Context, block *types.Block, roundNumb
 				}
 				key := bytes.Join([][]byte{unitID, txoHash}, nil)
 				history.UnitProofIndexKeys = append(history.UnitProofIndexKeys, key)
-				if err = dbTx.Write(key, &types.UnitDataAndProof{
+				if err = batch.Write(key, &types.UnitDataAndProof{
 					UnitData: &types.StateUnitData{Data: res},
 					Proof:    usp,
 				}); err != nil {
@@ -180,16 +160,19 @@ func (p *ProofIndexer) create(ctx context.Context, block *types.Block, roundNumb
 		}
 	}
 	// update latest round number
-	if err = dbTx.Write(keyLatestRoundNumber, roundNumber); err != nil {
+	if err = batch.Write(keyLatestRoundNumber, roundNumber); err != nil {
 		return fmt.Errorf("round number update failed: %w", err)
 	}
 	// write delete index
 	// only add if there were any transactions
 	if len(block.Transactions) > 0 {
-		if err = dbTx.Write(util.Uint64ToBytes(roundNumber), history); err != nil {
+		if err = batch.Write(util.Uint64ToBytes(roundNumber), history); err != nil {
 			return fmt.Errorf("history index write failed: %w", err)
 		}
 	}
+	if err = batch.Commit(); err != nil {
+		return fmt.Errorf("index batch commit failed: %w", err)
+	}
 	return nil
 }
 
@@ -203,7 +186,7 @@ func (p *ProofIndexer) latestIndexedBlockNumber() uint64 {
 
 // historyCleanup - removes old indexes from DB
 // todo: NB! it does not currently work correctly if history size is changed
-func (p *ProofIndexer) historyCleanup(ctx context.Context, round uint64) (resErr error) {
+func (p *ProofIndexer) historyCleanup(ctx context.Context, round uint64) error {
 	// if history size is set to 0, then do not run clean-up ||
 	// if round - history is <= 0 then there is nothing to clean
 	if p.historySize == 0 || round < p.historySize || round-p.historySize <= 0 {
@@ -220,41 +203,20 @@ func (p *ProofIndexer) historyCleanup(ctx context.Context, round uint64) (resErr
 		return nil
 	}
 	// delete all info added in round
-	dbTx, err := p.storage.StartTx()
-	if err != nil {
-		return fmt.Errorf("unable to start DB transaction: %w", err)
-	}
-
-	// commit if no error, rollback if any error
-	defer func() {
-		if resErr != nil {
-			if err = dbTx.Rollback(); err != nil {
-				resErr = errors.Join(resErr, fmt.Errorf("history clean rollback failed: %w", err))
-			}
-		}
-	}()
-	defer func() {
-		if resErr == nil {
-			if err = dbTx.Commit(); err != nil {
-				resErr = errors.Join(resErr, fmt.Errorf("history clean commit failed: %w", err))
-			}
-		}
-	}()
-
+	batch := p.storage.NewBatch()
 	for _, key := range history.UnitProofIndexKeys {
-		if err = dbTx.Delete(key); err != nil {
-			resErr = errors.Join(resErr, fmt.Errorf("unable to delete unit poof index: %w", err))
+		if err = batch.Delete(key); err != nil {
+			return fmt.Errorf("unable to delete unit poof index: %w", err)
 		}
 	}
-	// if node was not able to clean the proof index, then do not delete history index too
-	if resErr != nil {
-		return resErr
+	if err = batch.Delete(util.Uint64ToBytes(d)); err != nil {
+		return fmt.Errorf("unable to delete history index: %w", err)
 	}
-	if err = dbTx.Delete(util.Uint64ToBytes(d)); err != nil {
-		resErr = errors.Join(resErr, fmt.Errorf("unable to delete history index: %w", err))
+	if err = batch.Commit(); err != nil {
+		return fmt.Errorf("history clean commit failed: %w", err)
 	}
 	p.log.Log(ctx, logger.LevelTrace, fmt.Sprintf("Removed old unit proofs from round %d, index size %d", d, len(history.UnitProofIndexKeys)))
-	return
+	return nil
 }
 
 func ReadTransactionIndex(db keyvaluedb.KeyValueDB, txOrderHash []byte) (*TxIndex, error) {
@@ -0,0 +1,179 @@
+package predicates
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// GasScheduleVersion is the latest (and currently the only) supported version of the GasSchedule.
+const GasScheduleVersion = 1
+
+/*
+GasSchedule is the set of gas costs charged by the transaction system and the
+predicate engines. It is part of the partition genesis so that all the validators
+charge the same amount of gas for the same work and the costs are not tied to the
+version of the node software.
+The validators may agree on a new schedule taking effect from the first round of
+an epoch, see GasScheduleUpdate.
+*/
+type GasSchedule struct {
+	_       struct{} `cbor:",toarray"`
+	Version uint32   `json:"version"`
+
+	// cost of processing a transaction, charged in addition to the predicates evaluated
+	GeneralTx uint64 `json:"general_tx"`
+	// charged for every token created by the NFT batch mint transaction
+	NFTBatchMintToken uint64 `json:"nft_batch_mint_token"`
+
+	// predicate templates
+	P2PKH       uint64 `json:"p2pkh"`
+	AlwaysTrue  uint64 `json:"always_true"`
+	AlwaysFalse uint64 `json:"always_false"`
+	MultiSig    uint64 `json:"multisig"`  // base cost, P2PKH is charged for every signature checked
+	TimeLock    uint64 `json:"time_lock"` // cost of selecting the key, P2PKH is charged for the signature check
+	HashLock    uint64 `json:"hash_lock"`
+
+	// WASM predicate engine. The instrumenter charges fixed amount of gas units per
+	// instruction, WasmInstruction is the multiplier used to convert these units to gas.
+	WasmInstruction      uint64 `json:"wasm_instruction"`
+	WasmHash             uint64 `json:"wasm_hash"`
+	WasmHashWord         uint64 `json:"wasm_hash_word"` // per 32 bytes of input
+	WasmVerifySignature  uint64 `json:"wasm_verify_signature"`
+	WasmVerifyUC         uint64 `json:"wasm_verify_uc"`
+	WasmStorageRead      uint64 `json:"wasm_storage_read"`
+	WasmStorageReadByte  uint64 `json:"wasm_storage_read_byte"`
+	WasmStorageWrite     uint64 `json:"wasm_storage_write"`
+	WasmStorageWriteByte uint64 `json:"wasm_storage_write_byte"`
+
+	// script predicate engine. Script is charged once per script execution, in
+	// addition every opcode executed is charged ScriptOp (or the opcode specific cost).
+	Script         uint64 `json:"script"`
+	ScriptOp       uint64 `json:"script_op"`
+	ScriptSHA256   uint64 `json:"script_sha256"`
+	ScriptCheckSig uint64 `json:"script_check_sig"`
+}
+
+type (
+	// GasScheduleUpdate is the gas schedule taking effect from the partition round ActivationRound.
+	GasScheduleUpdate struct {
+		_               struct{}     `cbor:",toarray"`
+		ActivationRound uint64       `json:"activation_round,string"`
+		Schedule        *GasSchedule `json:"schedule"`
+	}
+
+	// GasScheduleUpdates is the list of the gas schedule updates of a partition, ordered by the activation round.
+	GasScheduleUpdates []*GasScheduleUpdate
+
+	// GasScheduler is optionally implemented by the TxContext, it returns the gas schedule
+	// in effect in the current round of the transaction system.
+	GasScheduler interface {
+		GasSchedule() *GasSchedule
+	}
+)
+
+/*
+DefaultGasSchedule returns the gas schedule used when the partition genesis doesn't
+define one.
+*/
+func DefaultGasSchedule() *GasSchedule {
+	return &GasSchedule{
+		Version:              GasScheduleVersion,
+		GeneralTx:            400,
+		NFTBatchMintToken:    200,
+		P2PKH:                1000,
+		AlwaysTrue:           100,
+		AlwaysFalse:          100,
+		MultiSig:             100,
+		TimeLock:             100,
+		HashLock:             200,
+		WasmInstruction:      1,
+		WasmHash:             60,
+		WasmHashWord:         12,
+		WasmVerifySignature:  3000,
+		WasmVerifyUC:         25000,
+		WasmStorageRead:      200,
+		WasmStorageReadByte:  1,
+		WasmStorageWrite:     2000,
+		WasmStorageWriteByte: 20,
+		Script:               100,
+		ScriptOp:             10,
+		ScriptSHA256:         100,
+		ScriptCheckSig:       1000,
+	}
+}
+
+func (gs *GasSchedule) IsValid() error {
+	if gs == nil {
+		return errors.New("gas schedule is nil")
+	}
+	if gs.Version != GasScheduleVersion {
+		return fmt.Errorf("unsupported gas schedule version %d, latest supported version is %d", gs.Version, GasScheduleVersion)
+	}
+	if gs.WasmInstruction == 0 {
+		return errors.New("WASM instruction cost must be greater than zero")
+	}
+	if gs.ScriptOp == 0 {
+		return errors.New("script opcode cost must be greater than zero")
+	}
+	return nil
+}
+
+// HashingGas returns the gas cost of hashing n bytes by the WASM host API.
+func (gs *GasSchedule) HashingGas(n int) uint64 {
+	return gs.WasmHash + gs.WasmHashWord*uint64((n+31)/32)
+}
+
+func (u GasScheduleUpdates) IsValid() error {
+	for i, su := range u {
+		if su == nil {
+			return fmt.Errorf("gas schedule update %d is nil", i)
+		}
+		if su.ActivationRound == 0 {
+			return fmt.Errorf("gas schedule update %d: activation round must be greater than zero", i)
+		}
+		if i > 0 && su.ActivationRound <= u[i-1].ActivationRound {
+			return fmt.Errorf("gas schedule update %d: activation round %d must be greater than the previous activation round %d", i, su.ActivationRound, u[i-1].ActivationRound)
+		}
+		if err := su.Schedule.IsValid(); err != nil {
+			return fmt.Errorf("gas schedule update %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+/*
+ScheduleOf returns the gas schedule in effect in the round, "initial" when none of
+the updates is active yet. The updates must be valid (ordered by the activation round).
+*/
+func (u GasScheduleUpdates) ScheduleOf(round uint64, initial *GasSchedule) *GasSchedule {
+	idx, found := slices.BinarySearchFunc(u, round, func(su *GasScheduleUpdate, round uint64) int {
+		switch {
+		case su.ActivationRound < round:
+			return -1
+		case su.ActivationRound > round:
+			return 1
+		}
+		return 0
+	})
+	if found {
+		return u[idx].Schedule
+	}
+	if idx == 0 {
+		return initial
+	}
+	return u[idx-1].Schedule
+}
+
+/*
+ActiveGasSchedule returns the gas schedule provided by the environment (when it
+implements GasScheduler), "gs" otherwise.
+*/
+func ActiveGasSchedule(env GasMeter, gs *GasSchedule) *GasSchedule {
+	if sch, ok := env.(GasScheduler); ok {
+		if active := sch.GasSchedule(); active != nil {
+			return active
+		}
+	}
+	return gs
+}
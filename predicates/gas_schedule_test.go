@@ -0,0 +1,114 @@
+package predicates
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GasSchedule_IsValid(t *testing.T) {
+	var gs *GasSchedule
+	require.EqualError(t, gs.IsValid(), `gas schedule is nil`)
+
+	gs = DefaultGasSchedule()
+	require.NoError(t, gs.IsValid())
+
+	gs.Version = 0
+	require.EqualError(t, gs.IsValid(), `unsupported gas schedule version 0, latest supported version is 1`)
+	gs.Version = 2
+	require.EqualError(t, gs.IsValid(), `unsupported gas schedule version 2, latest supported version is 1`)
+
+	gs = DefaultGasSchedule()
+	gs.WasmInstruction = 0
+	require.EqualError(t, gs.IsValid(), `WASM instruction cost must be greater than zero`)
+
+	gs = DefaultGasSchedule()
+	gs.ScriptOp = 0
+	require.EqualError(t, gs.IsValid(), `script opcode cost must be greater than zero`)
+}
+
+func Test_GasSchedule_HashingGas(t *testing.T) {
+	gs := &GasSchedule{WasmHash: 60, WasmHashWord: 12}
+	require.EqualValues(t, 60, gs.HashingGas(0))
+	require.EqualValues(t, 72, gs.HashingGas(1))
+	require.EqualValues(t, 72, gs.HashingGas(32))
+	require.EqualValues(t, 84, gs.HashingGas(33))
+}
+
+func Test_GasSchedule_serialization(t *testing.T) {
+	gs := DefaultGasSchedule()
+	gs.P2PKH = 1234
+
+	buf, err := types.Cbor.Marshal(gs)
+	require.NoError(t, err)
+	fromCBOR := &GasSchedule{}
+	require.NoError(t, types.Cbor.Unmarshal(buf, fromCBOR))
+	require.Equal(t, gs, fromCBOR)
+
+	buf, err = json.Marshal(gs)
+	require.NoError(t, err)
+	fromJSON := &GasSchedule{}
+	require.NoError(t, json.Unmarshal(buf, fromJSON))
+	require.Equal(t, gs, fromJSON)
+}
+
+func Test_GasScheduleUpdates_IsValid(t *testing.T) {
+	var updates GasScheduleUpdates
+	require.NoError(t, updates.IsValid())
+
+	updates = GasScheduleUpdates{{ActivationRound: 10, Schedule: DefaultGasSchedule()}, {ActivationRound: 20, Schedule: DefaultGasSchedule()}}
+	require.NoError(t, updates.IsValid())
+
+	require.EqualError(t, GasScheduleUpdates{nil}.IsValid(), `gas schedule update 0 is nil`)
+	require.EqualError(t, GasScheduleUpdates{{Schedule: DefaultGasSchedule()}}.IsValid(), `gas schedule update 0: activation round must be greater than zero`)
+	require.EqualError(t, GasScheduleUpdates{{ActivationRound: 20, Schedule: DefaultGasSchedule()}, {ActivationRound: 10, Schedule: DefaultGasSchedule()}}.IsValid(),
+		`gas schedule update 1: activation round 10 must be greater than the previous activation round 20`)
+	require.EqualError(t, GasScheduleUpdates{{ActivationRound: 10}}.IsValid(), `gas schedule update 0: gas schedule is nil`)
+}
+
+func Test_GasScheduleUpdates_ScheduleOf(t *testing.T) {
+	initial, gs10, gs20 := DefaultGasSchedule(), DefaultGasSchedule(), DefaultGasSchedule()
+	require.Same(t, initial, GasScheduleUpdates(nil).ScheduleOf(100, initial))
+
+	updates := GasScheduleUpdates{{ActivationRound: 10, Schedule: gs10}, {ActivationRound: 20, Schedule: gs20}}
+	require.Same(t, initial, updates.ScheduleOf(0, initial))
+	require.Same(t, initial, updates.ScheduleOf(9, initial))
+	require.Same(t, gs10, updates.ScheduleOf(10, initial))
+	require.Same(t, gs10, updates.ScheduleOf(19, initial))
+	require.Same(t, gs20, updates.ScheduleOf(20, initial))
+	require.Same(t, gs20, updates.ScheduleOf(1000, initial))
+}
+
+type mockGasScheduler struct {
+	GasMeter
+	gs *GasSchedule
+}
+
+func (m mockGasScheduler) GasSchedule() *GasSchedule { return m.gs }
+
+func Test_ActiveGasSchedule(t *testing.T) {
+	gs, active := DefaultGasSchedule(), DefaultGasSchedule()
+	require.Same(t, gs, ActiveGasSchedule(nil, gs))
+	require.Same(t, gs, ActiveGasSchedule(mockGasScheduler{}, gs))
+	require.Same(t, active, ActiveGasSchedule(mockGasScheduler{gs: active}, gs))
+}
+
+func Test_GasScheduleUpdates_serialization(t *testing.T) {
+	gs := DefaultGasSchedule()
+	gs.P2PKH = 1234
+	updates := GasScheduleUpdates{{ActivationRound: 10, Schedule: gs}}
+
+	buf, err := types.Cbor.Marshal(updates)
+	require.NoError(t, err)
+	var fromCBOR GasScheduleUpdates
+	require.NoError(t, types.Cbor.Unmarshal(buf, &fromCBOR))
+	require.Equal(t, updates, fromCBOR)
+
+	buf, err = json.Marshal(updates)
+	require.NoError(t, err)
+	var fromJSON GasScheduleUpdates
+	require.NoError(t, json.Unmarshal(buf, &fromJSON))
+	require.Equal(t, updates, fromJSON)
+}
@@ -44,6 +44,16 @@ type (
 		TrustBase(epoch uint64) (types.RootTrustBase, error)
 		TransactionOrder() (*types.TransactionOrder, error)
 	}
+
+	// PredicateStorage is the persistent key-value storage of the predicates. Keys are
+	// namespaced by the hash of the predicate code so predicates can't access each other's
+	// data. Optionally implemented by the TxContext.
+	PredicateStorage interface {
+		// ReadPredicateData returns the value stored under the key, nil when the key is not found.
+		ReadPredicateData(predicateHash, key []byte) ([]byte, error)
+		// WritePredicateData stores the value under the key, empty value deletes the key.
+		WritePredicateData(predicateHash, key, value []byte) error
+	}
 )
 
 func ExtractPredicate(predicateBytes []byte) (*predicates.Predicate, error) {
@@ -0,0 +1,74 @@
+package script
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdkpredicates "github.com/alphabill-org/alphabill-go-base/predicates"
+	"github.com/alphabill-org/alphabill-go-base/types"
+)
+
+/*
+Builder is a helper for assembling scripts. Errors are recorded and returned
+by the Bytes method so the individual calls do not have to be checked.
+*/
+type Builder struct {
+	code []byte
+	err  error
+}
+
+// Op appends opcodes which do not have inline data.
+func (b *Builder) Op(ops ...byte) *Builder {
+	for _, op := range ops {
+		if op == OpPushData || op == OpPushU64 {
+			b.setErr(fmt.Errorf("opcode 0x%02x requires data, use Push* method", op))
+			continue
+		}
+		b.code = append(b.code, op)
+	}
+	return b
+}
+
+func (b *Builder) PushData(data []byte) *Builder {
+	if len(data) > 255 {
+		b.setErr(fmt.Errorf("push data is too large: max 255 bytes, got %d", len(data)))
+		return b
+	}
+	b.code = append(b.code, OpPushData, byte(len(data)))
+	b.code = append(b.code, data...)
+	return b
+}
+
+func (b *Builder) PushU64(v uint64) *Builder {
+	b.code = append(b.code, OpPushU64)
+	b.code = binary.BigEndian.AppendUint64(b.code, v)
+	return b
+}
+
+func (b *Builder) Bytes() ([]byte, error) {
+	if b.err == nil && len(b.code) > MaxScriptSize {
+		return nil, fmt.Errorf("script is too large: max %d bytes, got %d", MaxScriptSize, len(b.code))
+	}
+	return b.code, b.err
+}
+
+func (b *Builder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// NewPredicateBytes returns script as predicate to be dispatched to the ScriptRunner.
+func NewPredicateBytes(code []byte) (types.PredicateBytes, error) {
+	return sdkpredicates.Predicate{Tag: PredicateEngineID, Code: code}.AsBytes()
+}
+
+// NewOwnerProof returns owner proof for the script predicate, items are pushed
+// to the stack in the order given before executing the script.
+func NewOwnerProof(items ...[]byte) []byte {
+	if items == nil {
+		items = [][]byte{}
+	}
+	buf, _ := types.Cbor.Marshal(items)
+	return buf
+}
@@ -0,0 +1,364 @@
+package script
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/alphabill-org/alphabill-go-base/crypto"
+	"github.com/alphabill-org/alphabill-go-base/hash"
+	sdkpredicates "github.com/alphabill-org/alphabill-go-base/predicates"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/predicates"
+)
+
+/*
+PredicateEngineID is the ID of the script predicate engine, ie predicates with
+Tag == PredicateEngineID are dispatched to the ScriptRunner.
+*/
+const PredicateEngineID = 2
+
+const (
+	MaxScriptSize    = 1024
+	MaxStackSize     = 32
+	MaxStackItemSize = 256
+)
+
+// opcodes of the script language
+const (
+	OpFalse    byte = 0x00 // push empty item (false)
+	OpTrue     byte = 0x01 // push 0x01 (true)
+	OpPushData byte = 0x02 // followed by one byte length and data, pushes the data
+	OpPushU64  byte = 0x03 // followed by 8 byte big-endian integer, pushes the integer
+
+	OpDup  byte = 0x10 // a -- a a
+	OpDrop byte = 0x11 // a --
+	OpSwap byte = 0x12 // a b -- b a
+
+	OpEqual  byte = 0x20 // a b -- a==b
+	OpVerify byte = 0x21 // a -- ; script evaluates to "false" when a is false
+	OpNot    byte = 0x22 // a -- !a
+	OpAnd    byte = 0x23 // a b -- a&&b
+	OpOr     byte = 0x24 // a b -- a||b
+	OpLess   byte = 0x25 // a b -- a<b ; a and b are interpreted as integers
+
+	OpSHA256   byte = 0x30 // a -- sha256(a)
+	OpCheckSig byte = 0x40 // sig pubkey pubkeyhash -- bool ; secp256k1 signature of the tx sig bytes
+	OpRound    byte = 0x50 // -- current round number
+
+	// opcodes inspecting the transaction being executed, ie the sponsor predicate of a
+	// sponsored transaction can restrict which transactions the sponsor pays for
+	OpTxType     byte = 0x51 // -- type of the transaction
+	OpTxMaxFee   byte = 0x52 // -- max fee of the transaction
+	OpTxUnitType byte = 0x53 // a -- bool ; true when the unit ID of the transaction has type a
+)
+
+var errVerifyFailed = errors.New("verify failed")
+
+/*
+ScriptRunner executes stack based script predicates.
+
+Predicate.Code is the script: sequence of opcodes executed once from start to end
+(there is no branching nor loops). Owner proof (args) is CBOR array of byte strings
+which are pushed to the stack (in order) before the script is executed. Predicate
+evaluates to "true" when after executing the script there is exactly one item in
+the stack and it is "true" (contains non-zero byte).
+
+Integers are big-endian, at most 8 bytes long, empty item is zero.
+*/
+type (
+	ScriptRunner struct {
+		gas *predicates.GasSchedule
+	}
+
+	Option func(*ScriptRunner)
+)
+
+func New(opts ...Option) ScriptRunner {
+	sr := ScriptRunner{gas: predicates.DefaultGasSchedule()}
+	for _, opt := range opts {
+		opt(&sr)
+	}
+	return sr
+}
+
+// WithGasSchedule sets the gas costs charged by the scripts, by default DefaultGasSchedule is used.
+func WithGasSchedule(gs *predicates.GasSchedule) Option {
+	return func(sr *ScriptRunner) {
+		sr.gas = gs
+	}
+}
+
+func (ScriptRunner) ID() uint64 {
+	return PredicateEngineID
+}
+
+func (sr ScriptRunner) Execute(_ context.Context, p *sdkpredicates.Predicate, args []byte, sigBytesFn func() ([]byte, error), env predicates.TxContext) (bool, error) {
+	if p.Tag != PredicateEngineID {
+		return false, fmt.Errorf("expected predicate script tag %d but got %d", PredicateEngineID, p.Tag)
+	}
+	if len(p.Params) != 0 {
+		return false, errors.New("script predicate must not have parameters")
+	}
+	if len(p.Code) > MaxScriptSize {
+		return false, fmt.Errorf("script is too large: max %d bytes, got %d", MaxScriptSize, len(p.Code))
+	}
+	// charge by the gas schedule of the current round when the tx system provides it
+	gas := predicates.ActiveGasSchedule(env, sr.gas)
+	if err := env.SpendGas(gas.Script); err != nil {
+		return false, err
+	}
+
+	var items [][]byte
+	if len(args) != 0 {
+		if err := types.Cbor.Unmarshal(args, &items); err != nil {
+			return false, fmt.Errorf("decoding owner proof: %w", err)
+		}
+	}
+	vm := &machine{env: env, gas: gas, sigBytesFn: sigBytesFn}
+	for _, item := range items {
+		if err := vm.push(item); err != nil {
+			return false, fmt.Errorf("owner proof: %w", err)
+		}
+	}
+
+	switch err := vm.run(p.Code); {
+	case errors.Is(err, errVerifyFailed):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	if len(vm.stack) != 1 {
+		return false, fmt.Errorf("expected exactly one item in the stack after executing the script, got %d", len(vm.stack))
+	}
+	return isTrue(vm.stack[0]), nil
+}
+
+type machine struct {
+	stack      [][]byte
+	env        predicates.TxContext
+	gas        *predicates.GasSchedule
+	sigBytesFn func() ([]byte, error)
+}
+
+func (vm *machine) run(code []byte) error {
+	for pc := 0; pc < len(code); pc++ {
+		op := code[pc]
+		gas := vm.gas.ScriptOp
+		switch op {
+		case OpSHA256:
+			gas = vm.gas.ScriptSHA256
+		case OpCheckSig:
+			gas = vm.gas.ScriptCheckSig
+		}
+		if err := vm.env.SpendGas(gas); err != nil {
+			return err
+		}
+
+		var err error
+		switch op {
+		case OpFalse:
+			err = vm.push(nil)
+		case OpTrue:
+			err = vm.push([]byte{1})
+		case OpPushData:
+			if pc+1 >= len(code) {
+				return fmt.Errorf("missing data length at %d", pc)
+			}
+			size := int(code[pc+1])
+			if pc+1+size >= len(code) {
+				return fmt.Errorf("not enough data for push at %d: expected %d bytes, got %d", pc, size, len(code)-pc-2)
+			}
+			err = vm.push(bytes.Clone(code[pc+2 : pc+2+size]))
+			pc += 1 + size
+		case OpPushU64:
+			if pc+8 >= len(code) {
+				return fmt.Errorf("not enough data for push at %d: expected 8 bytes, got %d", pc, len(code)-pc-1)
+			}
+			err = vm.push(bytes.Clone(code[pc+1 : pc+9]))
+			pc += 8
+		case OpDup:
+			var a []byte
+			if a, err = vm.pop(); err == nil {
+				if err = vm.push(a); err == nil {
+					err = vm.push(bytes.Clone(a))
+				}
+			}
+		case OpDrop:
+			_, err = vm.pop()
+		case OpSwap:
+			var a, b []byte
+			if a, b, err = vm.pop2(); err == nil {
+				vm.stack = append(vm.stack, b, a)
+			}
+		case OpEqual:
+			var a, b []byte
+			if a, b, err = vm.pop2(); err == nil {
+				err = vm.pushBool(bytes.Equal(a, b))
+			}
+		case OpVerify:
+			var a []byte
+			if a, err = vm.pop(); err == nil && !isTrue(a) {
+				return errVerifyFailed
+			}
+		case OpNot:
+			var a []byte
+			if a, err = vm.pop(); err == nil {
+				err = vm.pushBool(!isTrue(a))
+			}
+		case OpAnd:
+			var a, b []byte
+			if a, b, err = vm.pop2(); err == nil {
+				err = vm.pushBool(isTrue(a) && isTrue(b))
+			}
+		case OpOr:
+			var a, b []byte
+			if a, b, err = vm.pop2(); err == nil {
+				err = vm.pushBool(isTrue(a) || isTrue(b))
+			}
+		case OpLess:
+			var a, b []byte
+			if a, b, err = vm.pop2(); err == nil {
+				var x, y uint64
+				if x, err = toUint64(a); err == nil {
+					if y, err = toUint64(b); err == nil {
+						err = vm.pushBool(x < y)
+					}
+				}
+			}
+		case OpSHA256:
+			var a []byte
+			if a, err = vm.pop(); err == nil {
+				err = vm.push(hash.Sum256(a))
+			}
+		case OpCheckSig:
+			err = vm.checkSig()
+		case OpRound:
+			err = vm.push(binary.BigEndian.AppendUint64(nil, vm.env.CurrentRound()))
+		case OpTxType:
+			var tx *types.TransactionOrder
+			if tx, err = vm.txOrder(); err == nil {
+				err = vm.push(binary.BigEndian.AppendUint64(nil, uint64(tx.Type)))
+			}
+		case OpTxMaxFee:
+			var tx *types.TransactionOrder
+			if tx, err = vm.txOrder(); err == nil {
+				err = vm.push(binary.BigEndian.AppendUint64(nil, tx.MaxFee()))
+			}
+		case OpTxUnitType:
+			var a []byte
+			var tx *types.TransactionOrder
+			if a, err = vm.pop(); err == nil {
+				if tx, err = vm.txOrder(); err == nil {
+					err = vm.pushBool(len(a) != 0 && tx.UnitID.HasType(a))
+				}
+			}
+		default:
+			return fmt.Errorf("unknown opcode 0x%02x at %d", op, pc)
+		}
+		if err != nil {
+			return fmt.Errorf("executing opcode 0x%02x at %d: %w", op, pc, err)
+		}
+	}
+	return nil
+}
+
+func (vm *machine) txOrder() (*types.TransactionOrder, error) {
+	tx, err := vm.env.TransactionOrder()
+	if err != nil {
+		return nil, fmt.Errorf("reading transaction order: %w", err)
+	}
+	if tx == nil {
+		return nil, errors.New("transaction order is not available")
+	}
+	return tx, nil
+}
+
+func (vm *machine) checkSig() error {
+	pkh, err := vm.pop()
+	if err != nil {
+		return err
+	}
+	sig, pubKey, err := vm.pop2()
+	if err != nil {
+		return err
+	}
+	if len(sig) != 65 || len(pubKey) != 33 || !bytes.Equal(pkh, hash.Sum256(pubKey)) {
+		return vm.pushBool(false)
+	}
+	sigBytes, err := vm.sigBytesFn()
+	if err != nil {
+		return fmt.Errorf("reading transaction sig bytes: %w", err)
+	}
+	verifier, err := crypto.NewVerifierSecp256k1(pubKey)
+	if err != nil {
+		return vm.pushBool(false)
+	}
+	if err := verifier.VerifyBytes(sig, sigBytes); err != nil {
+		if errors.Is(err, crypto.ErrVerificationFailed) {
+			return vm.pushBool(false)
+		}
+		return fmt.Errorf("failed to verify signature: %w", err)
+	}
+	return vm.pushBool(true)
+}
+
+func (vm *machine) push(item []byte) error {
+	if len(vm.stack) >= MaxStackSize {
+		return fmt.Errorf("stack overflow: max %d items", MaxStackSize)
+	}
+	if len(item) > MaxStackItemSize {
+		return fmt.Errorf("stack item is too large: max %d bytes, got %d", MaxStackItemSize, len(item))
+	}
+	vm.stack = append(vm.stack, item)
+	return nil
+}
+
+func (vm *machine) pushBool(b bool) error {
+	if b {
+		return vm.push([]byte{1})
+	}
+	return vm.push(nil)
+}
+
+func (vm *machine) pop() ([]byte, error) {
+	if len(vm.stack) == 0 {
+		return nil, errors.New("stack underflow")
+	}
+	item := vm.stack[len(vm.stack)-1]
+	vm.stack = vm.stack[:len(vm.stack)-1]
+	return item, nil
+}
+
+// pop2 pops two items from the stack, "a" is the item which was below "b".
+func (vm *machine) pop2() (a, b []byte, err error) {
+	if len(vm.stack) < 2 {
+		return nil, nil, errors.New("stack underflow")
+	}
+	a, b = vm.stack[len(vm.stack)-2], vm.stack[len(vm.stack)-1]
+	vm.stack = vm.stack[:len(vm.stack)-2]
+	return a, b, nil
+}
+
+func isTrue(item []byte) bool {
+	for _, b := range item {
+		if b != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func toUint64(item []byte) (uint64, error) {
+	if len(item) > 8 {
+		return 0, fmt.Errorf("integer is too large: max 8 bytes, got %d", len(item))
+	}
+	var v uint64
+	for _, b := range item {
+		v = v<<8 | uint64(b)
+	}
+	return v, nil
+}
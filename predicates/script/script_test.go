@@ -0,0 +1,313 @@
+package script
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill-go-base/hash"
+	sdkpredicates "github.com/alphabill-org/alphabill-go-base/predicates"
+	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
+	"github.com/alphabill-org/alphabill/predicates"
+	"github.com/alphabill-org/alphabill/state"
+)
+
+func TestScriptRunner_Execute(t *testing.T) {
+	t.Parallel()
+
+	// distinct costs so that the test verifies which one is charged
+	gs := predicates.DefaultGasSchedule()
+	gs.Script = 101
+	gs.ScriptOp = 11
+	gs.ScriptSHA256 = 102
+	gs.ScriptCheckSig = 1001
+
+	sigBytes := []byte("transaction sig bytes")
+	sigBytesFn := func() ([]byte, error) { return sigBytes, nil }
+
+	signer, verifier := testsig.CreateSignerAndVerifier(t)
+	pubKey, err := verifier.MarshalPublicKey()
+	require.NoError(t, err)
+	sig, err := signer.SignBytes(sigBytes)
+	require.NoError(t, err)
+
+	execute := func(t *testing.T, code, ownerProof []byte, round uint64) (bool, uint64, error) {
+		t.Helper()
+		var gasUsed uint64
+		env := &mockTxContext{
+			currentRound: round,
+			spendGas:     func(gas uint64) error { gasUsed += gas; return nil },
+		}
+		res, err := New(WithGasSchedule(gs)).Execute(context.Background(), &sdkpredicates.Predicate{Tag: PredicateEngineID, Code: code}, ownerProof, sigBytesFn, env)
+		return res, gasUsed, err
+	}
+
+	t.Run("signature", func(t *testing.T) {
+		code, err := (&Builder{}).PushData(hash.Sum256(pubKey)).Op(OpCheckSig).Bytes()
+		require.NoError(t, err)
+
+		res, gas, err := execute(t, code, NewOwnerProof(sig, pubKey), 0)
+		require.NoError(t, err)
+		require.True(t, res)
+		require.EqualValues(t, gs.Script+gs.ScriptOp+gs.ScriptCheckSig, gas)
+
+		// signature of the other data
+		otherSig, err := signer.SignBytes([]byte("other"))
+		require.NoError(t, err)
+		res, _, err = execute(t, code, NewOwnerProof(otherSig, pubKey), 0)
+		require.NoError(t, err)
+		require.False(t, res)
+
+		// key doesn't match the hash
+		res, _, err = execute(t, code, NewOwnerProof(sig, make([]byte, 33)), 0)
+		require.NoError(t, err)
+		require.False(t, res)
+	})
+
+	t.Run("round", func(t *testing.T) {
+		// true before round 10
+		code, err := (&Builder{}).Op(OpRound).PushU64(10).Op(OpLess).Bytes()
+		require.NoError(t, err)
+		for round, exp := range map[uint64]bool{0: true, 9: true, 10: false, 11: false} {
+			res, gas, err := execute(t, code, nil, round)
+			require.NoError(t, err)
+			require.Equal(t, exp, res, "round %d", round)
+			require.EqualValues(t, gs.Script+3*gs.ScriptOp, gas)
+		}
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		// pays only for tx of type 5 with max fee less than 10 targeting unit of type 0x21
+		code, err := (&Builder{}).
+			Op(OpTxType).PushU64(5).Op(OpEqual, OpTxMaxFee).
+			PushU64(10).Op(OpLess, OpAnd).
+			PushData([]byte{0x21}).Op(OpTxUnitType, OpAnd).Bytes()
+		require.NoError(t, err)
+
+		for _, tc := range []struct {
+			txType uint16
+			maxFee uint64
+			unitID types.UnitID
+			result bool
+		}{
+			{txType: 5, maxFee: 9, unitID: types.UnitID{1, 2, 0x21}, result: true},
+			{txType: 6, maxFee: 9, unitID: types.UnitID{1, 2, 0x21}, result: false},
+			{txType: 5, maxFee: 10, unitID: types.UnitID{1, 2, 0x21}, result: false},
+			{txType: 5, maxFee: 9, unitID: types.UnitID{1, 2, 0x22}, result: false},
+		} {
+			env := &mockTxContext{
+				spendGas: func(gas uint64) error { return nil },
+				tx: &types.TransactionOrder{Payload: types.Payload{
+					Type:           tc.txType,
+					UnitID:         tc.unitID,
+					ClientMetadata: &types.ClientMetadata{MaxTransactionFee: tc.maxFee},
+				}},
+			}
+			res, err := New(WithGasSchedule(gs)).Execute(context.Background(), &sdkpredicates.Predicate{Tag: PredicateEngineID, Code: code}, nil, sigBytesFn, env)
+			require.NoError(t, err)
+			require.Equal(t, tc.result, res, "%+v", tc)
+		}
+
+		// transaction is not available
+		_, _, err = execute(t, []byte{OpTxType}, nil, 0)
+		require.EqualError(t, err, "executing opcode 0x51 at 0: transaction order is not available")
+	})
+
+	t.Run("hash lock or signature", func(t *testing.T) {
+		// spendable by revealing the preimage before round 10 or by the owner of the key
+		preimage := []byte("secret")
+		code, err := (&Builder{}).
+			PushData(hash.Sum256(pubKey)).Op(OpCheckSig, OpSwap, OpSHA256).
+			PushData(hash.Sum256(preimage)).Op(OpEqual, OpRound).
+			PushU64(10).Op(OpLess, OpAnd, OpOr).Bytes()
+		require.NoError(t, err)
+
+		for _, tc := range []struct {
+			preimage []byte
+			sig      []byte
+			round    uint64
+			result   bool
+		}{
+			{preimage: preimage, round: 5, result: true},
+			{preimage: preimage, round: 10, result: false},
+			{preimage: []byte("guess"), round: 5, result: false},
+			{preimage: nil, sig: sig, round: 10, result: true},
+			{preimage: []byte("guess"), sig: sig, round: 5, result: true},
+		} {
+			res, _, err := execute(t, code, NewOwnerProof(tc.preimage, tc.sig, pubKey), tc.round)
+			require.NoError(t, err)
+			require.Equal(t, tc.result, res, "preimage %q round %d", tc.preimage, tc.round)
+		}
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		code := []byte{OpFalse, OpVerify, OpTrue}
+		res, _, err := execute(t, code, nil, 0)
+		require.NoError(t, err)
+		require.False(t, res)
+
+		code = []byte{OpTrue, OpVerify, OpTrue}
+		res, _, err = execute(t, code, nil, 0)
+		require.NoError(t, err)
+		require.True(t, res)
+	})
+
+	t.Run("stack manipulation", func(t *testing.T) {
+		for _, tc := range []struct {
+			code   []byte
+			result bool
+		}{
+			{code: []byte{OpTrue, OpDup, OpAnd}, result: true},
+			{code: []byte{OpTrue, OpFalse, OpDrop}, result: true},
+			{code: []byte{OpTrue, OpFalse, OpSwap, OpDrop}, result: false},
+			{code: []byte{OpFalse, OpNot}, result: true},
+			{code: []byte{OpFalse, OpTrue, OpOr}, result: true},
+			{code: []byte{OpFalse, OpTrue, OpAnd}, result: false},
+			{code: []byte{OpFalse, OpFalse, OpEqual}, result: true},
+		} {
+			res, _, err := execute(t, tc.code, nil, 0)
+			require.NoError(t, err)
+			require.Equal(t, tc.result, res, "code %x", tc.code)
+		}
+	})
+
+	t.Run("invalid script", func(t *testing.T) {
+		for _, tc := range []struct {
+			code   []byte
+			errMsg string
+		}{
+			{code: []byte{0xff}, errMsg: "unknown opcode 0xff at 0"},
+			{code: []byte{OpTrue, OpAnd}, errMsg: "executing opcode 0x23 at 1: stack underflow"},
+			{code: []byte{OpDrop}, errMsg: "executing opcode 0x11 at 0: stack underflow"},
+			{code: []byte{OpPushData}, errMsg: "missing data length at 0"},
+			{code: []byte{OpPushData, 2, 1}, errMsg: "not enough data for push at 0: expected 2 bytes, got 1"},
+			{code: []byte{OpPushU64, 1, 2}, errMsg: "not enough data for push at 0: expected 8 bytes, got 2"},
+			{code: []byte{OpPushData, 9, 1, 2, 3, 4, 5, 6, 7, 8, 9, OpPushU64, 0, 0, 0, 0, 0, 0, 0, 1, OpLess}, errMsg: "executing opcode 0x25 at 20: integer is too large: max 8 bytes, got 9"},
+			{code: []byte{OpTrue, OpTrue}, errMsg: "expected exactly one item in the stack after executing the script, got 2"},
+			{code: nil, errMsg: "expected exactly one item in the stack after executing the script, got 0"},
+			{code: make([]byte, MaxScriptSize+1), errMsg: "script is too large: max 1024 bytes, got 1025"},
+		} {
+			res, _, err := execute(t, tc.code, nil, 0)
+			require.EqualError(t, err, tc.errMsg)
+			require.False(t, res)
+		}
+
+		overflow := make([]byte, MaxStackSize+1)
+		res, _, err := execute(t, overflow, nil, 0)
+		require.EqualError(t, err, "executing opcode 0x00 at 32: stack overflow: max 32 items")
+		require.False(t, res)
+	})
+
+	t.Run("invalid owner proof", func(t *testing.T) {
+		res, _, err := execute(t, []byte{OpTrue}, []byte{0x01}, 0)
+		require.ErrorContains(t, err, "decoding owner proof: ")
+		require.False(t, res)
+
+		res, _, err = execute(t, []byte{OpTrue}, NewOwnerProof(make([]byte, MaxStackItemSize+1)), 0)
+		require.EqualError(t, err, "owner proof: stack item is too large: max 256 bytes, got 257")
+		require.False(t, res)
+	})
+
+	t.Run("invalid predicate", func(t *testing.T) {
+		env := &mockTxContext{spendGas: func(gas uint64) error { return nil }}
+		res, err := New(WithGasSchedule(gs)).Execute(context.Background(), &sdkpredicates.Predicate{Tag: 1, Code: []byte{OpTrue}}, nil, sigBytesFn, env)
+		require.EqualError(t, err, "expected predicate script tag 2 but got 1")
+		require.False(t, res)
+
+		res, err = New(WithGasSchedule(gs)).Execute(context.Background(), &sdkpredicates.Predicate{Tag: PredicateEngineID, Code: []byte{OpTrue}, Params: []byte{1}}, nil, sigBytesFn, env)
+		require.EqualError(t, err, "script predicate must not have parameters")
+		require.False(t, res)
+	})
+
+	t.Run("sig bytes error", func(t *testing.T) {
+		env := &mockTxContext{spendGas: func(gas uint64) error { return nil }}
+		sigBytesErr := func() ([]byte, error) { return nil, fmt.Errorf("no tx") }
+		code, err := (&Builder{}).PushData(hash.Sum256(pubKey)).Op(OpCheckSig).Bytes()
+		require.NoError(t, err)
+		res, err := New(WithGasSchedule(gs)).Execute(context.Background(), &sdkpredicates.Predicate{Tag: PredicateEngineID, Code: code}, NewOwnerProof(sig, pubKey), sigBytesErr, env)
+		require.EqualError(t, err, "executing opcode 0x40 at 34: reading transaction sig bytes: no tx")
+		require.False(t, res)
+	})
+
+	t.Run("out of gas", func(t *testing.T) {
+		env := &mockTxContext{
+			spendGas: func(gas uint64) error {
+				if gas == gs.ScriptSHA256 {
+					return fmt.Errorf("out of gas")
+				}
+				return nil
+			},
+		}
+		res, err := New(WithGasSchedule(gs)).Execute(context.Background(), &sdkpredicates.Predicate{Tag: PredicateEngineID, Code: []byte{OpTrue, OpSHA256}}, nil, sigBytesFn, env)
+		require.EqualError(t, err, "out of gas")
+		require.False(t, res)
+	})
+}
+
+func TestScriptRunner_Dispatcher(t *testing.T) {
+	engines, err := predicates.Dispatcher(New())
+	require.NoError(t, err)
+
+	pb, err := NewPredicateBytes([]byte{OpTrue})
+	require.NoError(t, err)
+	env := &mockTxContext{spendGas: func(gas uint64) error { return nil }}
+	res, err := engines.Execute(context.Background(), pb, nil, nil, env)
+	require.NoError(t, err)
+	require.True(t, res)
+
+	// template predicates are not handled by the script engine
+	res, err = engines.Execute(context.Background(), templates.AlwaysTrueBytes(), nil, nil, env)
+	require.EqualError(t, err, "unknown predicate engine with id 0")
+	require.False(t, res)
+}
+
+func TestBuilder(t *testing.T) {
+	code, err := (&Builder{}).PushData([]byte{1, 2}).PushU64(0x0102).Op(OpEqual, OpNot).Bytes()
+	require.NoError(t, err)
+	require.Equal(t, []byte{OpPushData, 2, 1, 2, OpPushU64, 0, 0, 0, 0, 0, 0, 1, 2, OpEqual, OpNot}, code)
+
+	_, err = (&Builder{}).Op(OpPushData).Bytes()
+	require.EqualError(t, err, "opcode 0x02 requires data, use Push* method")
+
+	_, err = (&Builder{}).PushData(make([]byte, 256)).Bytes()
+	require.EqualError(t, err, "push data is too large: max 255 bytes, got 256")
+
+	b := &Builder{}
+	for range MaxScriptSize / 9 {
+		b.PushU64(1).Op(OpDrop)
+	}
+	_, err = b.Bytes()
+	require.EqualError(t, err, "script is too large: max 1024 bytes, got 1130")
+
+	require.Equal(t, []byte{0x80}, NewOwnerProof())
+	// nil item is encoded as CBOR null which is decoded as empty item
+	require.Equal(t, []byte{0x82, 0x41, 0x01, 0xf6}, NewOwnerProof([]byte{1}, nil))
+}
+
+type mockTxContext struct {
+	currentRound uint64
+	spendGas     func(gas uint64) error
+	tx           *types.TransactionOrder
+}
+
+func (env *mockTxContext) GasAvailable() uint64 { return 0 }
+
+func (env *mockTxContext) SpendGas(gas uint64) error { return env.spendGas(gas) }
+
+func (env *mockTxContext) GetUnit(id types.UnitID, committed bool) (*state.Unit, error) {
+	return nil, fmt.Errorf("mockTxContext.GetUnit is not implemented")
+}
+
+func (env *mockTxContext) CurrentRound() uint64 { return env.currentRound }
+
+func (env *mockTxContext) TrustBase(epoch uint64) (types.RootTrustBase, error) {
+	return nil, fmt.Errorf("mockTxContext.TrustBase is not implemented")
+}
+
+func (env *mockTxContext) CalculateCost() uint64 { return 0 }
+
+func (env *mockTxContext) TransactionOrder() (*types.TransactionOrder, error) { return env.tx, nil }
@@ -0,0 +1,164 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/alphabill-org/alphabill-go-base/hash"
+	sdkpredicates "github.com/alphabill-org/alphabill-go-base/predicates"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/predicates"
+)
+
+const (
+	// TimeLock256ID is the ID of the "spendable by A after round R, otherwise by B" predicate template.
+	TimeLock256ID = MultiSig256ID + 1
+	// HashLock256ID is the ID of the "spendable by A revealing the preimage of H before round R, otherwise by B" predicate template.
+	HashLock256ID = MultiSig256ID + 2
+
+	// MaxHashLockPreimageSize is the maximum size of the preimage accepted by the hash-lock predicate.
+	MaxHashLockPreimageSize = 256
+)
+
+type (
+	/*
+		TimeLock256Params are the parameters of the time-lock predicate: after the round
+		Round the unit can be spent by the owner of the AfterPubKeyHash, until then by
+		the owner of the BeforePubKeyHash. Owner proof is P2pkh256Signature.
+	*/
+	TimeLock256Params struct {
+		_                struct{} `cbor:",toarray"`
+		Round            uint64
+		AfterPubKeyHash  []byte
+		BeforePubKeyHash []byte
+	}
+
+	/*
+		HashLock256Params are the parameters of the hash-lock predicate: until the round
+		Round the unit can be spent by the owner of the RecipientPubKeyHash revealing the
+		preimage of the SHA256 hash Hash, starting from the round Round the unit can be
+		refunded by the owner of the RefundPubKeyHash. Owner proof is HashLock256Proof.
+		The signature binds the revealed preimage to the transaction of the recipient so
+		the preimage seen in a pending transaction can't be used by anybody else.
+	*/
+	HashLock256Params struct {
+		_                   struct{} `cbor:",toarray"`
+		Hash                []byte
+		Round               uint64
+		RecipientPubKeyHash []byte
+		RefundPubKeyHash    []byte
+	}
+
+	/*
+		HashLock256Proof is the owner proof of the hash-lock predicate. Preimage is
+		required only before the round of the lock, OwnerProof is P2pkh256Signature of
+		the recipient (before the round) or of the refund key (starting from the round).
+	*/
+	HashLock256Proof struct {
+		_          struct{} `cbor:",toarray"`
+		Preimage   []byte
+		OwnerProof []byte
+	}
+)
+
+func NewTimeLock256(round uint64, afterPubKeyHash, beforePubKeyHash []byte) (sdkpredicates.Predicate, error) {
+	return newTemplate(TimeLock256ID, TimeLock256Params{Round: round, AfterPubKeyHash: afterPubKeyHash, BeforePubKeyHash: beforePubKeyHash})
+}
+
+func NewTimeLock256Bytes(round uint64, afterPubKeyHash, beforePubKeyHash []byte) (types.PredicateBytes, error) {
+	predicate, err := NewTimeLock256(round, afterPubKeyHash, beforePubKeyHash)
+	if err != nil {
+		return nil, err
+	}
+	return predicate.AsBytes()
+}
+
+func NewHashLock256(preimageHash []byte, round uint64, recipientPubKeyHash, refundPubKeyHash []byte) (sdkpredicates.Predicate, error) {
+	return newTemplate(HashLock256ID, HashLock256Params{Hash: preimageHash, Round: round, RecipientPubKeyHash: recipientPubKeyHash, RefundPubKeyHash: refundPubKeyHash})
+}
+
+func NewHashLock256Bytes(preimageHash []byte, round uint64, recipientPubKeyHash, refundPubKeyHash []byte) (types.PredicateBytes, error) {
+	predicate, err := NewHashLock256(preimageHash, round, recipientPubKeyHash, refundPubKeyHash)
+	if err != nil {
+		return nil, err
+	}
+	return predicate.AsBytes()
+}
+
+/*
+NewHashLock256ProofBytes returns owner proof for the hash-lock predicate, "ownerProof"
+is the P2pkh256Signature of the recipient or of the refund key. The preimage is not
+needed (may be nil) for the refund.
+*/
+func NewHashLock256ProofBytes(preimage, ownerProof []byte) []byte {
+	pb, _ := types.Cbor.Marshal(HashLock256Proof{Preimage: preimage, OwnerProof: ownerProof})
+	return pb
+}
+
+func (p TimeLock256Params) IsValid() error {
+	if len(p.AfterPubKeyHash) != 32 {
+		return fmt.Errorf("invalid after pubkey hash size: expected 32, got %d", len(p.AfterPubKeyHash))
+	}
+	if len(p.BeforePubKeyHash) != 32 {
+		return fmt.Errorf("invalid before pubkey hash size: expected 32, got %d", len(p.BeforePubKeyHash))
+	}
+	return nil
+}
+
+func (p HashLock256Params) IsValid() error {
+	if len(p.Hash) != 32 {
+		return fmt.Errorf("invalid hash size: expected 32, got %d", len(p.Hash))
+	}
+	if len(p.RecipientPubKeyHash) != 32 {
+		return fmt.Errorf("invalid recipient pubkey hash size: expected 32, got %d", len(p.RecipientPubKeyHash))
+	}
+	if len(p.RefundPubKeyHash) != 32 {
+		return fmt.Errorf("invalid refund pubkey hash size: expected 32, got %d", len(p.RefundPubKeyHash))
+	}
+	return nil
+}
+
+func (tr TemplateRunner) executeTimeLock256TxAuth(params, args []byte, sigBytesFn func() ([]byte, error), env predicates.TxContext) (bool, error) {
+	if err := env.SpendGas(tr.gas.TimeLock); err != nil {
+		return false, err
+	}
+	par := TimeLock256Params{}
+	if err := types.Cbor.Unmarshal(params, &par); err != nil {
+		return false, fmt.Errorf("failed to decode time-lock parameters: %w", err)
+	}
+	if err := par.IsValid(); err != nil {
+		return false, fmt.Errorf("invalid time-lock parameters: %w", err)
+	}
+	pubKeyHash := par.BeforePubKeyHash
+	if env.CurrentRound() > par.Round {
+		pubKeyHash = par.AfterPubKeyHash
+	}
+	return tr.executeP2PKH256TxAuth(pubKeyHash, args, sigBytesFn, env)
+}
+
+func (tr TemplateRunner) executeHashLock256TxAuth(params, args []byte, sigBytesFn func() ([]byte, error), env predicates.TxContext) (bool, error) {
+	if err := env.SpendGas(tr.gas.HashLock); err != nil {
+		return false, err
+	}
+	par := HashLock256Params{}
+	if err := types.Cbor.Unmarshal(params, &par); err != nil {
+		return false, fmt.Errorf("failed to decode hash-lock parameters: %w", err)
+	}
+	if err := par.IsValid(); err != nil {
+		return false, fmt.Errorf("invalid hash-lock parameters: %w", err)
+	}
+	proof := HashLock256Proof{}
+	if err := types.Cbor.Unmarshal(args, &proof); err != nil {
+		return false, fmt.Errorf("failed to decode hash-lock proof: %w", err)
+	}
+	if env.CurrentRound() >= par.Round {
+		return tr.executeP2PKH256TxAuth(par.RefundPubKeyHash, proof.OwnerProof, sigBytesFn, env)
+	}
+	if len(proof.Preimage) > MaxHashLockPreimageSize {
+		return false, fmt.Errorf("hash-lock preimage is too large: max %d bytes, got %d", MaxHashLockPreimageSize, len(proof.Preimage))
+	}
+	if !bytes.Equal(par.Hash, hash.Sum256(proof.Preimage)) {
+		return false, nil
+	}
+	return tr.executeP2PKH256TxAuth(par.RecipientPubKeyHash, proof.OwnerProof, sigBytesFn, env)
+}
@@ -0,0 +1,208 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill-go-base/hash"
+	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
+	"github.com/alphabill-org/alphabill/predicates"
+)
+
+func TestTimeLock256_Execute(t *testing.T) {
+	t.Parallel()
+
+	sigBytes := []byte("transaction sig bytes")
+	sigBytesFn := func() ([]byte, error) { return sigBytes, nil }
+
+	signerA, verifierA := testsig.CreateSignerAndVerifier(t)
+	pubKeyA, err := verifierA.MarshalPublicKey()
+	require.NoError(t, err)
+	signerB, verifierB := testsig.CreateSignerAndVerifier(t)
+	pubKeyB, err := verifierB.MarshalPublicKey()
+	require.NoError(t, err)
+	proofA := testsig.NewP2pkhSignature(t, signerA, sigBytes)
+	proofB := testsig.NewP2pkhSignature(t, signerB, sigBytes)
+
+	// spendable by A after round 10, until then by B
+	pb, err := NewTimeLock256Bytes(10, hash.Sum256(pubKeyA), hash.Sum256(pubKeyB))
+	require.NoError(t, err)
+	predicate, err := predicates.ExtractPredicate(pb)
+	require.NoError(t, err)
+	require.Equal(t, []byte{TimeLock256ID}, predicate.Code)
+
+	execute := func(round uint64, ownerProof []byte) (bool, uint64, error) {
+		var gasUsed uint64
+		env := &mockTxContext{
+			currentRound: round,
+			spendGas:     func(gas uint64) error { gasUsed += gas; return nil },
+		}
+		res, err := New().Execute(context.Background(), predicate, ownerProof, sigBytesFn, env)
+		return res, gasUsed, err
+	}
+
+	for _, tc := range []struct {
+		round      uint64
+		ownerProof []byte
+		result     bool
+	}{
+		{round: 1, ownerProof: proofB, result: true},
+		{round: 1, ownerProof: proofA, result: false},
+		{round: 10, ownerProof: proofB, result: true},
+		{round: 10, ownerProof: proofA, result: false},
+		{round: 11, ownerProof: proofA, result: true},
+		{round: 11, ownerProof: proofB, result: false},
+	} {
+		res, gas, err := execute(tc.round, tc.ownerProof)
+		require.NoError(t, err)
+		require.Equal(t, tc.result, res, "round %d", tc.round)
+		require.EqualValues(t, defaultGas.TimeLock+defaultGas.P2PKH, gas)
+	}
+
+	t.Run("invalid owner proof", func(t *testing.T) {
+		res, _, err := execute(1, []byte{0x01})
+		require.ErrorContains(t, err, "failed to decode P2PKH256 signature: ")
+		require.False(t, res)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		_, err := NewTimeLock256(1, []byte{1}, hash.Sum256(pubKeyB))
+		require.EqualError(t, err, "invalid after pubkey hash size: expected 32, got 1")
+		_, err = NewTimeLock256(1, hash.Sum256(pubKeyA), nil)
+		require.EqualError(t, err, "invalid before pubkey hash size: expected 32, got 0")
+
+		env := &mockTxContext{spendGas: func(gas uint64) error { return nil }}
+		res, err := New().executeTimeLock256TxAuth([]byte{0x01}, proofA, sigBytesFn, env)
+		require.ErrorContains(t, err, "failed to decode time-lock parameters: ")
+		require.False(t, res)
+
+		params, err := types.Cbor.Marshal(TimeLock256Params{Round: 1, AfterPubKeyHash: []byte{1}})
+		require.NoError(t, err)
+		res, err = New().executeTimeLock256TxAuth(params, proofA, sigBytesFn, env)
+		require.EqualError(t, err, "invalid time-lock parameters: invalid after pubkey hash size: expected 32, got 1")
+		require.False(t, res)
+	})
+
+	t.Run("out of gas", func(t *testing.T) {
+		env := &mockTxContext{spendGas: func(gas uint64) error { return fmt.Errorf("out of gas") }}
+		res, err := New().executeTimeLock256TxAuth(predicate.Params, proofA, sigBytesFn, env)
+		require.EqualError(t, err, "out of gas")
+		require.False(t, res)
+	})
+}
+
+func TestHashLock256_Execute(t *testing.T) {
+	t.Parallel()
+
+	sigBytes := []byte("transaction sig bytes")
+	sigBytesFn := func() ([]byte, error) { return sigBytes, nil }
+
+	signerA, verifierA := testsig.CreateSignerAndVerifier(t)
+	pubKeyA, err := verifierA.MarshalPublicKey()
+	require.NoError(t, err)
+	signerB, verifierB := testsig.CreateSignerAndVerifier(t)
+	pubKeyB, err := verifierB.MarshalPublicKey()
+	require.NoError(t, err)
+	sigA := testsig.NewP2pkhSignature(t, signerA, sigBytes)
+	sigB := testsig.NewP2pkhSignature(t, signerB, sigBytes)
+
+	preimage := []byte("secret")
+	// spendable by A revealing the preimage before round 10, starting from round 10 refundable by B
+	pb, err := NewHashLock256Bytes(hash.Sum256(preimage), 10, hash.Sum256(pubKeyA), hash.Sum256(pubKeyB))
+	require.NoError(t, err)
+	predicate, err := predicates.ExtractPredicate(pb)
+	require.NoError(t, err)
+	require.Equal(t, []byte{HashLock256ID}, predicate.Code)
+
+	execute := func(round uint64, ownerProof []byte) (bool, uint64, error) {
+		var gasUsed uint64
+		env := &mockTxContext{
+			currentRound: round,
+			spendGas:     func(gas uint64) error { gasUsed += gas; return nil },
+		}
+		res, err := New().Execute(context.Background(), predicate, ownerProof, sigBytesFn, env)
+		return res, gasUsed, err
+	}
+
+	// signature is checked (and charged) only when the preimage matches or for the refund
+	hashLockGas := defaultGas.HashLock
+	sigGas := defaultGas.HashLock + defaultGas.P2PKH
+	for _, tc := range []struct {
+		name     string
+		round    uint64
+		preimage []byte
+		sig      []byte
+		result   bool
+		gas      uint64
+	}{
+		{name: "recipient reveals preimage", round: 1, preimage: preimage, sig: sigA, result: true, gas: sigGas},
+		{name: "recipient reveals preimage in last round", round: 9, preimage: preimage, sig: sigA, result: true, gas: sigGas},
+		{name: "preimage revealed by other", round: 1, preimage: preimage, sig: sigB, result: false, gas: sigGas},
+		{name: "wrong preimage", round: 1, preimage: []byte("guess"), sig: sigA, result: false, gas: hashLockGas},
+		{name: "missing preimage", round: 1, preimage: nil, sig: sigA, result: false, gas: hashLockGas},
+		{name: "refund before the round", round: 9, preimage: nil, sig: sigB, result: false, gas: hashLockGas},
+		{name: "lock expired", round: 10, preimage: preimage, sig: sigA, result: false, gas: sigGas},
+		{name: "refund", round: 10, preimage: nil, sig: sigB, result: true, gas: sigGas},
+	} {
+		res, gas, err := execute(tc.round, NewHashLock256ProofBytes(tc.preimage, tc.sig))
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.result, res, tc.name)
+		require.Equal(t, tc.gas, gas, tc.name)
+	}
+
+	t.Run("invalid owner proof", func(t *testing.T) {
+		res, _, err := execute(1, []byte{0x01})
+		require.ErrorContains(t, err, "failed to decode hash-lock proof: ")
+		require.False(t, res)
+
+		res, _, err = execute(1, NewHashLock256ProofBytes(make([]byte, MaxHashLockPreimageSize+1), sigA))
+		require.EqualError(t, err, "hash-lock preimage is too large: max 256 bytes, got 257")
+		require.False(t, res)
+
+		res, _, err = execute(1, NewHashLock256ProofBytes(preimage, []byte{0x01}))
+		require.ErrorContains(t, err, "failed to decode P2PKH256 signature: ")
+		require.False(t, res)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		_, err := NewHashLock256([]byte{1, 2, 3}, 10, hash.Sum256(pubKeyA), hash.Sum256(pubKeyB))
+		require.EqualError(t, err, "invalid hash size: expected 32, got 3")
+		_, err = NewHashLock256(hash.Sum256(preimage), 10, nil, hash.Sum256(pubKeyB))
+		require.EqualError(t, err, "invalid recipient pubkey hash size: expected 32, got 0")
+		_, err = NewHashLock256(hash.Sum256(preimage), 10, hash.Sum256(pubKeyA), []byte{1})
+		require.EqualError(t, err, "invalid refund pubkey hash size: expected 32, got 1")
+
+		env := &mockTxContext{spendGas: func(gas uint64) error { return nil }}
+		res, err := New().executeHashLock256TxAuth([]byte{0x01}, NewHashLock256ProofBytes(preimage, sigA), sigBytesFn, env)
+		require.ErrorContains(t, err, "failed to decode hash-lock parameters: ")
+		require.False(t, res)
+
+		params, err := types.Cbor.Marshal(HashLock256Params{Hash: []byte{1}, Round: 10})
+		require.NoError(t, err)
+		res, err = New().executeHashLock256TxAuth(params, NewHashLock256ProofBytes(preimage, sigA), sigBytesFn, env)
+		require.EqualError(t, err, "invalid hash-lock parameters: invalid hash size: expected 32, got 1")
+		require.False(t, res)
+	})
+
+	t.Run("out of gas", func(t *testing.T) {
+		env := &mockTxContext{spendGas: func(gas uint64) error { return fmt.Errorf("out of gas") }}
+		res, err := New().executeHashLock256TxAuth(predicate.Params, NewHashLock256ProofBytes(preimage, sigA), sigBytesFn, env)
+		require.EqualError(t, err, "out of gas")
+		require.False(t, res)
+	})
+}
+
+func TestNewTemplate(t *testing.T) {
+	pkh := hash.Sum256([]byte{1})
+	p, err := NewTimeLock256(5, pkh, pkh)
+	require.NoError(t, err)
+	require.EqualValues(t, templates.TemplateStartByte, p.Tag)
+	par := TimeLock256Params{}
+	require.NoError(t, types.Cbor.Unmarshal(p.Params, &par))
+	require.Equal(t, TimeLock256Params{Round: 5, AfterPubKeyHash: pkh, BeforePubKeyHash: pkh}, par)
+}
@@ -0,0 +1,139 @@
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/alphabill-org/alphabill-go-base/crypto"
+	"github.com/alphabill-org/alphabill-go-base/hash"
+	sdkpredicates "github.com/alphabill-org/alphabill-go-base/predicates"
+	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/predicates"
+)
+
+const (
+	// MultiSig256ID is the ID of the m-of-n multisig predicate template.
+	MultiSig256ID = templates.P2pkh256ID + 1
+)
+
+type (
+	/*
+		MultiSig256Params are the parameters (Predicate.Params) of the multisig predicate:
+		the predicate is satisfied when the owner proof contains valid signatures of at
+		least Threshold keys in the PubKeyHashes list.
+	*/
+	MultiSig256Params struct {
+		_            struct{} `cbor:",toarray"`
+		Threshold    uint64
+		PubKeyHashes [][]byte // SHA256 hashes of the public keys
+	}
+)
+
+func NewMultiSig256(threshold uint64, pubKeyHashes ...[]byte) (sdkpredicates.Predicate, error) {
+	return newTemplate(MultiSig256ID, MultiSig256Params{Threshold: threshold, PubKeyHashes: pubKeyHashes})
+}
+
+func NewMultiSig256Bytes(threshold uint64, pubKeyHashes ...[]byte) (types.PredicateBytes, error) {
+	predicate, err := NewMultiSig256(threshold, pubKeyHashes...)
+	if err != nil {
+		return nil, err
+	}
+	return predicate.AsBytes()
+}
+
+/*
+NewMultiSig256SignatureBytes returns owner proof for the multisig predicate. The
+signatures must be in the same order as the keys in the predicate.
+*/
+func NewMultiSig256SignatureBytes(signatures ...templates.P2pkh256Signature) []byte {
+	sb, _ := types.Cbor.Marshal(signatures)
+	return sb
+}
+
+func (p MultiSig256Params) IsValid() error {
+	if p.Threshold == 0 {
+		return errors.New("threshold must be greater than zero")
+	}
+	if p.Threshold > uint64(len(p.PubKeyHashes)) {
+		return fmt.Errorf("threshold %d is greater than the number of keys %d", p.Threshold, len(p.PubKeyHashes))
+	}
+	// duplicate keys would allow the same signer to satisfy the threshold more than once
+	keys := make(map[string]struct{}, len(p.PubKeyHashes))
+	for i, pkh := range p.PubKeyHashes {
+		if len(pkh) != 32 {
+			return fmt.Errorf("invalid pubkey hash size at index %d: expected 32, got %d", i, len(pkh))
+		}
+		if _, ok := keys[string(pkh)]; ok {
+			return fmt.Errorf("duplicate pubkey hash at index %d", i)
+		}
+		keys[string(pkh)] = struct{}{}
+	}
+	return nil
+}
+
+func (tr TemplateRunner) executeMultiSig256TxAuth(params, args []byte, sigBytesFn func() ([]byte, error), env predicates.TxContext) (bool, error) {
+	if err := env.SpendGas(tr.gas.MultiSig); err != nil {
+		return false, err
+	}
+	par := MultiSig256Params{}
+	if err := types.Cbor.Unmarshal(params, &par); err != nil {
+		return false, fmt.Errorf("failed to decode multisig parameters: %w", err)
+	}
+	if err := par.IsValid(); err != nil {
+		return false, fmt.Errorf("invalid multisig parameters: %w", err)
+	}
+	var signatures []templates.P2pkh256Signature
+	if err := types.Cbor.Unmarshal(args, &signatures); err != nil {
+		return false, fmt.Errorf("failed to decode multisig signatures: %w", err)
+	}
+	if len(signatures) > len(par.PubKeyHashes) {
+		return false, fmt.Errorf("expected at most %d signatures, got %d", len(par.PubKeyHashes), len(signatures))
+	}
+	if uint64(len(signatures)) < par.Threshold {
+		return false, nil
+	}
+	sigBytes, err := sigBytesFn()
+	if err != nil {
+		return false, fmt.Errorf("reading transaction sig bytes: %w", err)
+	}
+
+	// signatures must be in the same order as the keys so that every key can be used only once
+	keyIdx := 0
+	for i, sig := range signatures {
+		if err := env.SpendGas(tr.gas.P2PKH); err != nil {
+			return false, err
+		}
+		if len(sig.Sig) != 65 {
+			return false, fmt.Errorf("invalid signature size at index %d: expected 65, got %d", i, len(sig.Sig))
+		}
+		if len(sig.PubKey) != 33 {
+			return false, fmt.Errorf("invalid pubkey size at index %d: expected 33, got %d", i, len(sig.PubKey))
+		}
+		pkh := hash.Sum256(sig.PubKey)
+		for keyIdx < len(par.PubKeyHashes) && !bytes.Equal(par.PubKeyHashes[keyIdx], pkh) {
+			keyIdx++
+		}
+		if keyIdx == len(par.PubKeyHashes) {
+			// key is not in the list or signatures are not in the order of the keys
+			return false, nil
+		}
+		keyIdx++
+
+		verifier, err := crypto.NewVerifierSecp256k1(sig.PubKey)
+		if err != nil {
+			return false, fmt.Errorf("failed to create verifier: %w", err)
+		}
+		if err := verifier.VerifyBytes(sig.Sig, sigBytes); err != nil {
+			if errors.Is(err, crypto.ErrVerificationFailed) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to verify signature: %w", err)
+		}
+		if uint64(i+1) == par.Threshold {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,186 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill-go-base/crypto"
+	"github.com/alphabill-org/alphabill-go-base/hash"
+	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/predicates"
+)
+
+func TestMultiSig256Params_IsValid(t *testing.T) {
+	pkh := make([]byte, 32)
+	require.EqualError(t, MultiSig256Params{PubKeyHashes: [][]byte{pkh}}.IsValid(), "threshold must be greater than zero")
+	require.EqualError(t, MultiSig256Params{Threshold: 2, PubKeyHashes: [][]byte{pkh}}.IsValid(), "threshold 2 is greater than the number of keys 1")
+	require.EqualError(t, MultiSig256Params{Threshold: 1, PubKeyHashes: [][]byte{pkh, pkh[:31]}}.IsValid(), "invalid pubkey hash size at index 1: expected 32, got 31")
+	require.EqualError(t, MultiSig256Params{Threshold: 2, PubKeyHashes: [][]byte{pkh, pkh}}.IsValid(), "duplicate pubkey hash at index 1")
+	require.NoError(t, MultiSig256Params{Threshold: 2, PubKeyHashes: [][]byte{pkh, hash.Sum256([]byte{2})}}.IsValid())
+}
+
+func TestNewMultiSig256Bytes(t *testing.T) {
+	pkh := hash.Sum256([]byte{1})
+	pb, err := NewMultiSig256Bytes(1, pkh)
+	require.NoError(t, err)
+	p, err := predicates.ExtractPredicate(pb)
+	require.NoError(t, err)
+	require.EqualValues(t, templates.TemplateStartByte, p.Tag)
+	require.Equal(t, []byte{MultiSig256ID}, p.Code)
+	par := MultiSig256Params{}
+	require.NoError(t, types.Cbor.Unmarshal(p.Params, &par))
+	require.Equal(t, MultiSig256Params{Threshold: 1, PubKeyHashes: [][]byte{pkh}}, par)
+
+	_, err = NewMultiSig256Bytes(0, pkh)
+	require.EqualError(t, err, "threshold must be greater than zero")
+}
+
+func TestMultiSig256_Execute(t *testing.T) {
+	t.Parallel()
+
+	sigBytes := []byte("transaction sig bytes")
+	sigBytesFn := func() ([]byte, error) { return sigBytes, nil }
+
+	const keyCnt = 3
+	signatures := make([]templates.P2pkh256Signature, keyCnt)
+	pubKeyHashes := make([][]byte, keyCnt)
+	for i := range keyCnt {
+		signer, err := crypto.NewInMemorySecp256K1Signer()
+		require.NoError(t, err)
+		verifier, err := signer.Verifier()
+		require.NoError(t, err)
+		pubKey, err := verifier.MarshalPublicKey()
+		require.NoError(t, err)
+		sig, err := signer.SignBytes(sigBytes)
+		require.NoError(t, err)
+		signatures[i] = templates.P2pkh256Signature{Sig: sig, PubKey: pubKey}
+		pubKeyHashes[i] = hash.Sum256(pubKey)
+	}
+	// 2-of-3 multisig
+	predicate, err := NewMultiSig256(2, pubKeyHashes...)
+	require.NoError(t, err)
+
+	execute := func(t *testing.T, ownerProof []byte) (bool, uint64, error) {
+		t.Helper()
+		var gasUsed uint64
+		env := &mockTxContext{
+			spendGas: func(gas uint64) error { gasUsed += gas; return nil },
+		}
+		res, err := New().Execute(context.Background(), &predicate, ownerProof, sigBytesFn, env)
+		return res, gasUsed, err
+	}
+
+	t.Run("success", func(t *testing.T) {
+		for _, sigs := range [][]templates.P2pkh256Signature{
+			{signatures[0], signatures[1]},
+			{signatures[0], signatures[2]},
+			{signatures[1], signatures[2]},
+		} {
+			res, gas, err := execute(t, NewMultiSig256SignatureBytes(sigs...))
+			require.NoError(t, err)
+			require.True(t, res)
+			require.EqualValues(t, defaultGas.MultiSig+2*defaultGas.P2PKH, gas)
+		}
+
+		// signatures after the threshold is reached are not checked
+		res, gas, err := execute(t, NewMultiSig256SignatureBytes(signatures[0], signatures[1], templates.P2pkh256Signature{}))
+		require.NoError(t, err)
+		require.True(t, res)
+		require.EqualValues(t, defaultGas.MultiSig+2*defaultGas.P2PKH, gas)
+	})
+
+	t.Run("not enough signatures", func(t *testing.T) {
+		res, gas, err := execute(t, NewMultiSig256SignatureBytes(signatures[1]))
+		require.NoError(t, err)
+		require.False(t, res)
+		require.EqualValues(t, defaultGas.MultiSig, gas)
+
+		// the same key used twice
+		res, gas, err = execute(t, NewMultiSig256SignatureBytes(signatures[1], signatures[1]))
+		require.NoError(t, err)
+		require.False(t, res)
+		require.EqualValues(t, defaultGas.MultiSig+2*defaultGas.P2PKH, gas)
+	})
+
+	t.Run("signatures not in the order of keys", func(t *testing.T) {
+		res, _, err := execute(t, NewMultiSig256SignatureBytes(signatures[2], signatures[0]))
+		require.NoError(t, err)
+		require.False(t, res)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		sig := templates.P2pkh256Signature{Sig: signatures[0].Sig, PubKey: make([]byte, 33)}
+		res, _, err := execute(t, NewMultiSig256SignatureBytes(sig, signatures[1]))
+		require.NoError(t, err)
+		require.False(t, res)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		sig := templates.P2pkh256Signature{Sig: signatures[1].Sig, PubKey: signatures[0].PubKey}
+		res, gas, err := execute(t, NewMultiSig256SignatureBytes(sig, signatures[1]))
+		require.NoError(t, err)
+		require.False(t, res)
+		require.EqualValues(t, defaultGas.MultiSig+defaultGas.P2PKH, gas)
+	})
+
+	t.Run("invalid owner proof", func(t *testing.T) {
+		res, _, err := execute(t, []byte{0x01})
+		require.ErrorContains(t, err, "failed to decode multisig signatures: ")
+		require.False(t, res)
+
+		res, _, err = execute(t, NewMultiSig256SignatureBytes(signatures[0], signatures[1], signatures[2], signatures[0]))
+		require.EqualError(t, err, "expected at most 3 signatures, got 4")
+		require.False(t, res)
+
+		sig := templates.P2pkh256Signature{Sig: []byte{1, 2, 3}, PubKey: signatures[0].PubKey}
+		res, _, err = execute(t, NewMultiSig256SignatureBytes(sig, signatures[1]))
+		require.EqualError(t, err, "invalid signature size at index 0: expected 65, got 3")
+		require.False(t, res)
+
+		sig = templates.P2pkh256Signature{Sig: signatures[0].Sig, PubKey: []byte{1, 2, 3}}
+		res, _, err = execute(t, NewMultiSig256SignatureBytes(signatures[0], sig))
+		require.EqualError(t, err, "invalid pubkey size at index 1: expected 33, got 3")
+		require.False(t, res)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		env := &mockTxContext{spendGas: func(gas uint64) error { return nil }}
+		ownerProof := NewMultiSig256SignatureBytes(signatures[0])
+
+		res, err := New().executeMultiSig256TxAuth([]byte{0x01}, ownerProof, sigBytesFn, env)
+		require.ErrorContains(t, err, "failed to decode multisig parameters: ")
+		require.False(t, res)
+
+		params, err := types.Cbor.Marshal(MultiSig256Params{Threshold: 2, PubKeyHashes: pubKeyHashes[:1]})
+		require.NoError(t, err)
+		res, err = New().executeMultiSig256TxAuth(params, ownerProof, sigBytesFn, env)
+		require.EqualError(t, err, "invalid multisig parameters: threshold 2 is greater than the number of keys 1")
+		require.False(t, res)
+	})
+
+	t.Run("sig bytes error", func(t *testing.T) {
+		env := &mockTxContext{spendGas: func(gas uint64) error { return nil }}
+		sigBytesErr := func() ([]byte, error) { return nil, fmt.Errorf("no tx") }
+		res, err := New().executeMultiSig256TxAuth(predicate.Params, NewMultiSig256SignatureBytes(signatures[0], signatures[1]), sigBytesErr, env)
+		require.EqualError(t, err, "reading transaction sig bytes: no tx")
+		require.False(t, res)
+	})
+
+	t.Run("out of gas", func(t *testing.T) {
+		env := &mockTxContext{
+			spendGas: func(gas uint64) error {
+				if gas == defaultGas.P2PKH {
+					return fmt.Errorf("out of gas")
+				}
+				return nil
+			},
+		}
+		res, err := New().executeMultiSig256TxAuth(predicate.Params, NewMultiSig256SignatureBytes(signatures[0], signatures[1]), sigBytesFn, env)
+		require.EqualError(t, err, "out of gas")
+		require.False(t, res)
+	})
+}
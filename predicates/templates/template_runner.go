@@ -14,46 +14,77 @@ import (
 	"github.com/alphabill-org/alphabill/predicates"
 )
 
-const (
-	P2PKHGasCost       = 1000
-	AlwaysTrueGasCost  = 100
-	AlwaysFalseGasCost = 100
-)
-
 var cborNull = []byte{0xf6}
 
-type TemplateRunner struct{}
+type (
+	TemplateRunner struct {
+		gas *predicates.GasSchedule
+	}
+
+	Option func(*TemplateRunner)
+)
+
+func New(opts ...Option) TemplateRunner {
+	tr := TemplateRunner{gas: predicates.DefaultGasSchedule()}
+	for _, opt := range opts {
+		opt(&tr)
+	}
+	return tr
+}
 
-func New() TemplateRunner {
-	return TemplateRunner{}
+// WithGasSchedule sets the gas costs charged by the templates, by default DefaultGasSchedule is used.
+func WithGasSchedule(gs *predicates.GasSchedule) Option {
+	return func(tr *TemplateRunner) {
+		tr.gas = gs
+	}
 }
 
 func (TemplateRunner) ID() uint64 {
 	return templates.TemplateStartByte
 }
 
-func (TemplateRunner) Execute(_ context.Context, p *sdkpredicates.Predicate, args []byte, sigBytesFn func() ([]byte, error), env predicates.TxContext) (bool, error) {
+func (tr TemplateRunner) Execute(_ context.Context, p *sdkpredicates.Predicate, args []byte, sigBytesFn func() ([]byte, error), env predicates.TxContext) (bool, error) {
 	if p.Tag != templates.TemplateStartByte {
 		return false, fmt.Errorf("expected predicate template tag %d but got %d", templates.TemplateStartByte, p.Tag)
 	}
 	if len(p.Code) != 1 {
 		return false, fmt.Errorf("expected predicate template code length to be 1, got %d", len(p.Code))
 	}
+	// charge by the gas schedule of the current round when the tx system provides it
+	tr.gas = predicates.ActiveGasSchedule(env, tr.gas)
 
 	switch p.Code[0] {
 	case templates.P2pkh256ID:
-		return executeP2PKH256TxAuth(p.Params, args, sigBytesFn, env)
+		return tr.executeP2PKH256TxAuth(p.Params, args, sigBytesFn, env)
 	case templates.AlwaysTrueID:
-		return executeAlwaysTrue(p.Params, args, env)
+		return tr.executeAlwaysTrue(p.Params, args, env)
 	case templates.AlwaysFalseID:
-		return executeAlwaysFalse(p.Params, args, env)
+		return tr.executeAlwaysFalse(p.Params, args, env)
+	case MultiSig256ID:
+		return tr.executeMultiSig256TxAuth(p.Params, args, sigBytesFn, env)
+	case TimeLock256ID:
+		return tr.executeTimeLock256TxAuth(p.Params, args, sigBytesFn, env)
+	case HashLock256ID:
+		return tr.executeHashLock256TxAuth(p.Params, args, sigBytesFn, env)
 	default:
 		return false, fmt.Errorf("unknown predicate template with id %d", p.Code[0])
 	}
 }
 
-func executeAlwaysTrue(params, args []byte, env predicates.TxContext) (bool, error) {
-	if err := env.SpendGas(AlwaysTrueGasCost); err != nil {
+// newTemplate returns predicate template with given ID and CBOR encoded parameters.
+func newTemplate(id byte, params interface{ IsValid() error }) (sdkpredicates.Predicate, error) {
+	if err := params.IsValid(); err != nil {
+		return sdkpredicates.Predicate{}, err
+	}
+	buf, err := types.Cbor.Marshal(params)
+	if err != nil {
+		return sdkpredicates.Predicate{}, fmt.Errorf("encoding predicate parameters: %w", err)
+	}
+	return sdkpredicates.Predicate{Tag: templates.TemplateStartByte, Code: []byte{id}, Params: buf}, nil
+}
+
+func (tr TemplateRunner) executeAlwaysTrue(params, args []byte, env predicates.TxContext) (bool, error) {
+	if err := env.SpendGas(tr.gas.AlwaysTrue); err != nil {
 		return false, err
 	}
 	// do not allow to piggyback any additional data on "always true" predicate
@@ -64,8 +95,8 @@ func executeAlwaysTrue(params, args []byte, env predicates.TxContext) (bool, err
 	return false, fmt.Errorf(`"always true" predicate arguments must be empty`)
 }
 
-func executeAlwaysFalse(params, args []byte, env predicates.TxContext) (bool, error) {
-	if err := env.SpendGas(AlwaysFalseGasCost); err != nil {
+func (tr TemplateRunner) executeAlwaysFalse(params, args []byte, env predicates.TxContext) (bool, error) {
+	if err := env.SpendGas(tr.gas.AlwaysFalse); err != nil {
 		return false, err
 	}
 	// do not allow to piggyback any additional data on "always false" predicate
@@ -76,16 +107,16 @@ func executeAlwaysFalse(params, args []byte, env predicates.TxContext) (bool, er
 	return false, fmt.Errorf(`"always false" predicate arguments must be empty`)
 }
 
-func executeP2PKH256TxAuth(pubKeyHash, args []byte, sigBytesFn func() ([]byte, error), env predicates.TxContext) (bool, error) {
+func (tr TemplateRunner) executeP2PKH256TxAuth(pubKeyHash, args []byte, sigBytesFn func() ([]byte, error), env predicates.TxContext) (bool, error) {
 	sigBytes, err := sigBytesFn()
 	if err != nil {
 		return false, fmt.Errorf("reading transaction sig bytes: %w", err)
 	}
-	return executeP2PKH256(pubKeyHash, args, sigBytes, env)
+	return tr.executeP2PKH256(pubKeyHash, args, sigBytes, env)
 }
 
-func executeP2PKH256(pubKeyHash, args []byte, sigBytes []byte, env predicates.TxContext) (bool, error) {
-	if err := env.SpendGas(P2PKHGasCost); err != nil {
+func (tr TemplateRunner) executeP2PKH256(pubKeyHash, args []byte, sigBytes []byte, env predicates.TxContext) (bool, error) {
+	if err := env.SpendGas(tr.gas.P2PKH); err != nil {
 		return false, err
 	}
 	p2pkh256Signature := templates.P2pkh256Signature{}
@@ -16,6 +16,8 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var defaultGas = predicates.DefaultGasSchedule()
+
 func TestTemplateRunner(t *testing.T) {
 	t.Parallel()
 
@@ -83,6 +85,26 @@ func TestTemplateRunner(t *testing.T) {
 		require.NoError(t, err)
 		require.True(t, res)
 	})
+
+	t.Run("gas schedule", func(t *testing.T) {
+		gas := predicates.DefaultGasSchedule()
+		gas.AlwaysTrue = 42
+		var spent uint64
+		execEnv := &mockTxContext{
+			spendGas: func(gas uint64) error { spent += gas; return nil },
+		}
+		at := &sdkpredicates.Predicate{Tag: templates.TemplateStartByte, Code: []byte{templates.AlwaysTrueID}}
+		res, err := New(WithGasSchedule(gas)).Execute(context.Background(), at, nil, nil, execEnv)
+		require.NoError(t, err)
+		require.True(t, res)
+		require.EqualValues(t, 42, spent)
+
+		spent = 0
+		res, err = runner.Execute(context.Background(), at, nil, nil, execEnv)
+		require.NoError(t, err)
+		require.True(t, res)
+		require.EqualValues(t, defaultGas.AlwaysTrue, spent)
+	})
 }
 
 func TestAlwaysTrue(t *testing.T) {
@@ -109,7 +131,7 @@ func TestAlwaysTrue(t *testing.T) {
 			spendGas: func(gas uint64) error { return nil },
 		}
 		for _, tc := range args {
-			res, err := executeAlwaysTrue(tc.params, tc.args, execEnv)
+			res, err := New().executeAlwaysTrue(tc.params, tc.args, execEnv)
 			if err != nil {
 				t.Errorf("unexpected error with arguments (%#v , %#v): %v", tc.params, tc.args, err)
 			}
@@ -123,7 +145,7 @@ func TestAlwaysTrue(t *testing.T) {
 		execEnv := &mockTxContext{
 			spendGas: func(gas uint64) error { return fmt.Errorf("out of gas") },
 		}
-		res, err := executeAlwaysTrue(nil, nil, execEnv)
+		res, err := New().executeAlwaysTrue(nil, nil, execEnv)
 		require.EqualError(t, err, "out of gas")
 		require.False(t, res)
 	})
@@ -142,7 +164,7 @@ func TestAlwaysTrue(t *testing.T) {
 			spendGas: func(gas uint64) error { return nil },
 		}
 		for _, tc := range args {
-			res, err := executeAlwaysTrue(tc.params, tc.args, execEnv)
+			res, err := New().executeAlwaysTrue(tc.params, tc.args, execEnv)
 			if err == nil {
 				t.Errorf("expected error with arguments (%#v , %#v)", tc.params, tc.args)
 			} else if err.Error() != `"always true" predicate arguments must be empty` {
@@ -179,7 +201,7 @@ func TestAlwaysFalse(t *testing.T) {
 			spendGas: func(gas uint64) error { return nil },
 		}
 		for _, tc := range args {
-			res, err := executeAlwaysFalse(tc.params, tc.args, execEnv)
+			res, err := New().executeAlwaysFalse(tc.params, tc.args, execEnv)
 			if err != nil {
 				t.Errorf("unexpected error with arguments (%#v , %#v): %v", tc.params, tc.args, err)
 			}
@@ -193,7 +215,7 @@ func TestAlwaysFalse(t *testing.T) {
 		execEnv := &mockTxContext{
 			spendGas: func(gas uint64) error { return fmt.Errorf("out of gas") },
 		}
-		res, err := executeAlwaysFalse(nil, nil, execEnv)
+		res, err := New().executeAlwaysFalse(nil, nil, execEnv)
 		require.EqualError(t, err, "out of gas")
 		require.False(t, res)
 	})
@@ -212,7 +234,7 @@ func TestAlwaysFalse(t *testing.T) {
 			spendGas: func(gas uint64) error { return nil },
 		}
 		for _, tc := range args {
-			res, err := executeAlwaysFalse(tc.params, tc.args, execEnv)
+			res, err := New().executeAlwaysFalse(tc.params, tc.args, execEnv)
 			if err == nil {
 				t.Errorf("expected error with arguments (%#v , %#v)", tc.params, tc.args)
 			} else if err.Error() != `"always false" predicate arguments must be empty` {
@@ -252,21 +274,21 @@ func TestP2pkh256_Execute(t *testing.T) {
 	}
 
 	t.Run("txAuth success", func(t *testing.T) {
-		res, err := executeP2PKH256TxAuth(pubKeyHash, ownerProof, validTxOrder.AuthProofSigBytes, execEnv)
+		res, err := New().executeP2PKH256TxAuth(pubKeyHash, ownerProof, validTxOrder.AuthProofSigBytes, execEnv)
 		require.NoError(t, err)
 		require.True(t, res)
 	})
 
 	t.Run("invalid CBOR encoded OwnerProof", func(t *testing.T) {
-		res, err := executeP2PKH256TxAuth(pubKeyHash, nil, validTxOrder.AuthProofSigBytes, execEnv)
+		res, err := New().executeP2PKH256TxAuth(pubKeyHash, nil, validTxOrder.AuthProofSigBytes, execEnv)
 		require.EqualError(t, err, `failed to decode P2PKH256 signature: EOF`)
 		require.False(t, res)
 
-		res, err = executeP2PKH256TxAuth(pubKeyHash, []byte{}, validTxOrder.AuthProofSigBytes, execEnv)
+		res, err = New().executeP2PKH256TxAuth(pubKeyHash, []byte{}, validTxOrder.AuthProofSigBytes, execEnv)
 		require.EqualError(t, err, `failed to decode P2PKH256 signature: EOF`)
 		require.False(t, res)
 
-		res, err = executeP2PKH256TxAuth(pubKeyHash, []byte{0, 1, 2}, validTxOrder.AuthProofSigBytes, execEnv)
+		res, err = New().executeP2PKH256TxAuth(pubKeyHash, []byte{0, 1, 2}, validTxOrder.AuthProofSigBytes, execEnv)
 		require.EqualError(t, err, `failed to decode P2PKH256 signature: cbor: 2 bytes of extraneous data starting at index 1`)
 		require.False(t, res)
 	})
@@ -277,7 +299,7 @@ func TestP2pkh256_Execute(t *testing.T) {
 		signature := templates.P2pkh256Signature{Sig: []byte{1, 2, 3}, PubKey: pubKey}
 		ownerProof, err := types.Cbor.Marshal(signature)
 		require.NoError(t, err)
-		res, err := executeP2PKH256TxAuth(pubKeyHash, ownerProof, validTxOrder.AuthProofSigBytes, execEnv)
+		res, err := New().executeP2PKH256TxAuth(pubKeyHash, ownerProof, validTxOrder.AuthProofSigBytes, execEnv)
 		require.EqualError(t, err, `invalid signature size: expected 65, got 3 (010203)`)
 		require.False(t, res)
 
@@ -285,7 +307,7 @@ func TestP2pkh256_Execute(t *testing.T) {
 		signature = templates.P2pkh256Signature{Sig: make([]byte, 65), PubKey: []byte{4, 5, 6}}
 		ownerProof, err = types.Cbor.Marshal(signature)
 		require.NoError(t, err)
-		res, err = executeP2PKH256TxAuth(pubKeyHash, ownerProof, validTxOrder.AuthProofSigBytes, execEnv)
+		res, err = New().executeP2PKH256TxAuth(pubKeyHash, ownerProof, validTxOrder.AuthProofSigBytes, execEnv)
 		require.EqualError(t, err, `invalid pubkey size: expected 33, got 3 (040506)`)
 		require.False(t, res)
 
@@ -293,7 +315,7 @@ func TestP2pkh256_Execute(t *testing.T) {
 		signature = templates.P2pkh256Signature{Sig: make([]byte, 65), PubKey: make([]byte, 33)}
 		ownerProof, err = types.Cbor.Marshal(signature)
 		require.NoError(t, err)
-		res, err = executeP2PKH256TxAuth(pubKeyHash, ownerProof, validTxOrder.AuthProofSigBytes, execEnv)
+		res, err = New().executeP2PKH256TxAuth(pubKeyHash, ownerProof, validTxOrder.AuthProofSigBytes, execEnv)
 		require.NoError(t, err, `testing against different public key is not error`)
 		require.False(t, res)
 
@@ -301,7 +323,7 @@ func TestP2pkh256_Execute(t *testing.T) {
 		signature = templates.P2pkh256Signature{Sig: make([]byte, 65), PubKey: make([]byte, 33)}
 		ownerProof, err = types.Cbor.Marshal(signature)
 		require.NoError(t, err)
-		res, err = executeP2PKH256TxAuth(hash.Sum256(signature.PubKey), ownerProof, validTxOrder.AuthProofSigBytes, execEnv)
+		res, err = New().executeP2PKH256TxAuth(hash.Sum256(signature.PubKey), ownerProof, validTxOrder.AuthProofSigBytes, execEnv)
 		require.EqualError(t, err, `failed to create verifier: public key decompress failed`)
 		require.False(t, res)
 	})
@@ -312,13 +334,13 @@ func TestP2pkh256_Execute(t *testing.T) {
 		signature := templates.P2pkh256Signature{Sig: make([]byte, 65), PubKey: pubKey}
 		ownerProof, err := types.Cbor.Marshal(signature)
 		require.NoError(t, err)
-		res, err := executeP2PKH256TxAuth(pubKeyHash, ownerProof, validTxOrder.AuthProofSigBytes, execEnv)
+		res, err := New().executeP2PKH256TxAuth(pubKeyHash, ownerProof, validTxOrder.AuthProofSigBytes, execEnv)
 		require.NoError(t, err)
 		require.False(t, res)
 	})
 
 	t.Run("invalid pubkey hash size", func(t *testing.T) {
-		res, err := executeP2PKH256TxAuth(pubKeyHash[:len(pubKeyHash)-1], ownerProof, validTxOrder.AuthProofSigBytes, execEnv)
+		res, err := New().executeP2PKH256TxAuth(pubKeyHash[:len(pubKeyHash)-1], ownerProof, validTxOrder.AuthProofSigBytes, execEnv)
 		require.ErrorContains(t, err, `invalid pubkey hash size: expected 32, got 31`)
 		require.False(t, res)
 	})
@@ -327,13 +349,15 @@ func TestP2pkh256_Execute(t *testing.T) {
 		execEnv := &mockTxContext{
 			spendGas: func(gas uint64) error { return fmt.Errorf("out of gas") },
 		}
-		res, err := executeP2PKH256TxAuth(pubKeyHash, ownerProof, validTxOrder.AuthProofSigBytes, execEnv)
+		res, err := New().executeP2PKH256TxAuth(pubKeyHash, ownerProof, validTxOrder.AuthProofSigBytes, execEnv)
 		require.EqualError(t, err, "out of gas")
 		require.False(t, res)
 	})
 }
 
 func Benchmark_templateExecute(b *testing.B) {
+	runner := New()
+
 	b.Run("p2pkh", func(b *testing.B) {
 		// random 42 bytes
 		payload := []byte{0x16, 0x95, 0xf8, 0xf7, 0xa9, 0xd1, 0x9a, 0xe1, 0xce, 0xf5, 0x45, 0x6, 0xd1, 0x81, 0x2a, 0x1, 0xaa, 0x6d, 0x3e, 0xe1, 0x76, 0x42, 0x2e, 0xfb, 0x3e, 0xae, 0xe2, 0x36, 0xdf, 0x5f, 0xe1, 0x8f, 0x17, 0xa1, 0xf4, 0xad, 0xfa, 0xfa, 0x7c, 0x1e, 0x53, 0x5e}
@@ -351,7 +375,7 @@ func Benchmark_templateExecute(b *testing.B) {
 
 		// valid data, the P2pkh256.Execute should not return any error
 		for i := 0; i < b.N; i++ {
-			res, err := executeP2PKH256(pubKeyHash, ownerProof, payload, execEnv)
+			res, err := runner.executeP2PKH256(pubKeyHash, ownerProof, payload, execEnv)
 			if err != nil {
 				b.Error(err.Error())
 			}
@@ -366,7 +390,7 @@ func Benchmark_templateExecute(b *testing.B) {
 			spendGas: func(gas uint64) error { return nil },
 		}
 		for i := 0; i < b.N; i++ {
-			res, err := executeAlwaysTrue(nil, nil, execEnv)
+			res, err := runner.executeAlwaysTrue(nil, nil, execEnv)
 			if err != nil {
 				b.Error(err.Error())
 			}
@@ -381,7 +405,7 @@ func Benchmark_templateExecute(b *testing.B) {
 			spendGas: func(gas uint64) error { return nil },
 		}
 		for i := 0; i < b.N; i++ {
-			res, err := executeAlwaysFalse(nil, nil, execEnv)
+			res, err := runner.executeAlwaysFalse(nil, nil, execEnv)
 			if err != nil {
 				b.Error(err.Error())
 			}
@@ -394,6 +418,7 @@ func Benchmark_templateExecute(b *testing.B) {
 
 type mockTxContext struct {
 	gasRemaining uint64
+	currentRound uint64
 	getUnit      func(id types.UnitID, committed bool) (*state.Unit, error)
 	spendGas     func(gas uint64) error
 }
@@ -410,7 +435,7 @@ func (env *mockTxContext) GetUnit(id types.UnitID, committed bool) (*state.Unit,
 	return env.getUnit(id, committed)
 }
 
-func (env *mockTxContext) CurrentRound() uint64 { return 0 }
+func (env *mockTxContext) CurrentRound() uint64 { return env.currentRound }
 
 func (env *mockTxContext) TrustBase(epoch uint64) (types.RootTrustBase, error) {
 	return nil, fmt.Errorf("mockTxContext.TrustBase is not implemented")
@@ -17,8 +17,8 @@ type WasmRunner struct {
 	log *slog.Logger
 }
 
-func New(enc wvm.Encoder, engines exec.PredicateExecutor, obs wvm.Observability) WasmRunner {
-	vm, err := wvm.New(context.Background(), enc, engines, obs)
+func New(enc wvm.Encoder, engines exec.PredicateExecutor, obs wvm.Observability, opts ...wvm.Option) WasmRunner {
+	vm, err := wvm.New(context.Background(), enc, engines, obs, opts...)
 	if err != nil {
 		panic(fmt.Errorf("creating WASM engine: %w", err))
 	}
@@ -56,3 +56,8 @@ func (wr WasmRunner) Execute(ctx context.Context, p *predicates.Predicate, args
 		return false, fmt.Errorf("unexpected evaluation result %v (%x)", er, code)
 	}
 }
+
+// Close releases the resources of the WASM VM.
+func (wr WasmRunner) Close(ctx context.Context) error {
+	return wr.vm.Close(ctx)
+}
@@ -200,7 +200,7 @@ func Test_conference_tickets_v2(t *testing.T) {
 				}
 				return state.NewUnit(&tokens.NonFungibleTokenData{Data: []byte("early-bird"), OwnerPredicate: []byte{1}}), nil
 			},
-			trustBase:    func() (types.RootTrustBase, error) { return trustbase, nil },
+			trustBase:    func(uint64) (types.RootTrustBase, error) { return trustbase, nil },
 			GasRemaining: 50000,
 		}
 
@@ -284,7 +284,7 @@ func Test_conference_tickets_v2(t *testing.T) {
 				}
 				return state.NewUnit(&tokens.NonFungibleTokenData{Data: []byte("early-bird"), OwnerPredicate: []byte{1}}), nil
 			},
-			trustBase: func() (types.RootTrustBase, error) { return trustbase, nil },
+			trustBase: func(uint64) (types.RootTrustBase, error) { return trustbase, nil },
 			curRound:  func() uint64 { return regularDate },
 			//payloadBytes: payloadBytes,
 			GasRemaining: 30000,
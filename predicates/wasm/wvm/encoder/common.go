@@ -6,6 +6,7 @@ import (
 
 	"github.com/alphabill-org/alphabill-go-base/types"
 	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/txsystem/fc/unit"
 )
 
 // returns tx order attributes encoded to WASM representation
@@ -13,6 +14,15 @@ type TxAttributesEncoder func(txo *types.TransactionOrder, ver uint32) ([]byte,
 
 type UnitDataEncoder func(data types.UnitData, ver uint32) ([]byte, error)
 
+/*
+AnyTxSystem can be used as the AttrEncID.TxSys value to register encoder for
+transaction type which is not specific to a particular transaction system (ie
+fee credit transactions). The encoder of a concrete tx system is looked up first
+and the one registered for AnyTxSystem is used as a fallback. The same tx type can't
+be registered both for a concrete tx system and for AnyTxSystem.
+*/
+const AnyTxSystem types.SystemID = 0
+
 // tx attribute encoder ID
 type AttrEncID struct {
 	TxSys types.SystemID
@@ -78,18 +88,39 @@ func (TXSystemEncoder) txRecord(txo *types.TransactionRecord, _ uint32, getHandl
 	return buf.Bytes()
 }
 
-func (TXSystemEncoder) txOrder(txo *types.TransactionOrder, _ uint32) ([]byte, error) {
+/*
+txOrder encodes the generic fields of the tx order:
+  - ver 1: network, partition, unit ID, tx type and reference number;
+  - ver 2: ver 1 fields plus timeout and max transaction fee;
+  - ver 3: ver 2 fields plus ID of the fee credit record which pays the fees of the
+    transaction (the sponsor's record when the transaction is sponsored);
+*/
+func (TXSystemEncoder) txOrder(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
 	var buf TVEnc
 	buf.EncodeTagged(1, uint16(txo.NetworkID))
 	buf.EncodeTagged(2, uint32(txo.SystemID))
 	buf.EncodeTagged(3, txo.UnitID)
 	buf.EncodeTagged(4, txo.Type)
 	buf.EncodeTagged(5, txo.ReferenceNumber())
+	if ver >= 2 {
+		buf.EncodeTagged(6, txo.Timeout())
+		buf.EncodeTagged(7, txo.MaxFee())
+	}
+	if ver >= 3 {
+		fcrID, _, err := unit.FeePayer(txo)
+		if err != nil {
+			return nil, fmt.Errorf("reading fee payer: %w", err)
+		}
+		buf.EncodeTagged(8, fcrID)
+	}
 	return buf.Bytes()
 }
 
 func (enc TXSystemEncoder) TxAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
 	encoder, ok := enc.attrEnc[AttrEncID{TxSys: txo.SystemID, Attr: txo.Type}]
+	if !ok {
+		encoder, ok = enc.attrEnc[AttrEncID{TxSys: AnyTxSystem, Attr: txo.Type}]
+	}
 	if !ok {
 		return nil, fmt.Errorf("serializing to bytes is not implemented for transaction system %d %q attributes", txo.SystemID, txo.Type)
 	}
@@ -105,6 +136,18 @@ func (enc TXSystemEncoder) UnitData(unit *state.Unit, ver uint32) ([]byte, error
 	return encoder(data, ver)
 }
 
+/*
+CheckVersion returns error when encoder doesn't support the encoding version "ver"
+requested by the predicate. Version zero is treated as version 1, "latest" is the
+latest version the encoder supports.
+*/
+func CheckVersion(ver, latest uint32) error {
+	if ver > latest {
+		return fmt.Errorf("unsupported encoding version %d, latest supported version is %d", ver, latest)
+	}
+	return nil
+}
+
 func (enc *TXSystemEncoder) RegisterUnitDataEncoder(ud any, encoder UnitDataEncoder) error {
 	if enc.udEnc == nil {
 		enc.udEnc = make(map[reflect.Type]UnitDataEncoder)
@@ -118,7 +161,9 @@ func (enc *TXSystemEncoder) RegisterUnitDataEncoder(ud any, encoder UnitDataEnco
 }
 
 /*
-RegisterAttrEncoder registers tx attribute encoder.
+RegisterAttrEncoder registers tx attribute encoder. It is an error to register
+encoder for a tx type which already has an encoder registered for AnyTxSystem or,
+when registering for AnyTxSystem, for any concrete tx system.
 */
 func (enc *TXSystemEncoder) RegisterAttrEncoder(id AttrEncID, encoder TxAttributesEncoder) error {
 	if enc.attrEnc == nil {
@@ -127,6 +172,11 @@ func (enc *TXSystemEncoder) RegisterAttrEncoder(id AttrEncID, encoder TxAttribut
 	if _, ok := enc.attrEnc[id]; ok {
 		return fmt.Errorf("tx attribute encoder for %v is already registered", id)
 	}
+	for k := range enc.attrEnc {
+		if k.Attr == id.Attr && (k.TxSys == AnyTxSystem || id.TxSys == AnyTxSystem) {
+			return fmt.Errorf("tx attribute encoder for %v collides with the encoder registered for %v", id, k)
+		}
+	}
 	enc.attrEnc[id] = encoder
 	return nil
 }
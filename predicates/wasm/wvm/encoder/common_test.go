@@ -0,0 +1,105 @@
+package encoder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+)
+
+func Test_CheckVersion(t *testing.T) {
+	require.NoError(t, CheckVersion(0, 1))
+	require.NoError(t, CheckVersion(1, 1))
+	require.NoError(t, CheckVersion(1, 2))
+	require.EqualError(t, CheckVersion(2, 1), `unsupported encoding version 2, latest supported version is 1`)
+}
+
+func Test_TXSystemEncoder_TxAttributes(t *testing.T) {
+	encFn := func(b byte) TxAttributesEncoder {
+		return func(txo *types.TransactionOrder, ver uint32) ([]byte, error) { return []byte{b}, nil }
+	}
+	enc, err := New(func(reg func(id AttrEncID, enc TxAttributesEncoder) error) error {
+		require.NoError(t, reg(AttrEncID{TxSys: 1, Attr: 1}, encFn(1)))
+		require.NoError(t, reg(AttrEncID{TxSys: 2, Attr: 1}, encFn(2)))
+		require.NoError(t, reg(AttrEncID{TxSys: AnyTxSystem, Attr: 2}, encFn(3)))
+		return nil
+	})
+	require.NoError(t, err)
+
+	txo := &types.TransactionOrder{Payload: types.Payload{SystemID: 1, Type: 1}}
+	buf, err := enc.TxAttributes(txo, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, buf)
+
+	txo.SystemID = 2
+	buf, err = enc.TxAttributes(txo, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{2}, buf)
+
+	// no encoder for tx system 3, type 1 and no fallback either
+	txo.SystemID = 3
+	buf, err = enc.TxAttributes(txo, 1)
+	require.EqualError(t, err, `serializing to bytes is not implemented for transaction system 3 '\x01' attributes`)
+	require.Nil(t, buf)
+
+	// fallback encoder is used for any tx system
+	txo.Type = 2
+	buf, err = enc.TxAttributes(txo, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{3}, buf)
+
+	txo.Type = 3
+	buf, err = enc.TxAttributes(txo, 1)
+	require.EqualError(t, err, `serializing to bytes is not implemented for transaction system 3 '\x03' attributes`)
+	require.Nil(t, buf)
+}
+
+func Test_TXSystemEncoder_RegisterAttrEncoder(t *testing.T) {
+	encFn := func(txo *types.TransactionOrder, ver uint32) ([]byte, error) { return nil, nil }
+
+	t.Run("specific after fallback", func(t *testing.T) {
+		enc := TXSystemEncoder{}
+		require.NoError(t, enc.RegisterAttrEncoder(AttrEncID{TxSys: AnyTxSystem, Attr: 1}, encFn))
+		require.EqualError(t, enc.RegisterAttrEncoder(AttrEncID{TxSys: 5, Attr: 1}, encFn),
+			`tx attribute encoder for {00000005 1} collides with the encoder registered for {00000000 1}`)
+		// other tx types are not affected
+		require.NoError(t, enc.RegisterAttrEncoder(AttrEncID{TxSys: 5, Attr: 2}, encFn))
+	})
+
+	t.Run("fallback after specific", func(t *testing.T) {
+		enc := TXSystemEncoder{}
+		require.NoError(t, enc.RegisterAttrEncoder(AttrEncID{TxSys: 5, Attr: 1}, encFn))
+		require.EqualError(t, enc.RegisterAttrEncoder(AttrEncID{TxSys: AnyTxSystem, Attr: 1}, encFn),
+			`tx attribute encoder for {00000000 1} collides with the encoder registered for {00000005 1}`)
+		require.NoError(t, enc.RegisterAttrEncoder(AttrEncID{TxSys: AnyTxSystem, Attr: 2}, encFn))
+	})
+
+	t.Run("same id twice", func(t *testing.T) {
+		enc := TXSystemEncoder{}
+		require.NoError(t, enc.RegisterAttrEncoder(AttrEncID{TxSys: AnyTxSystem, Attr: 1}, encFn))
+		require.EqualError(t, enc.RegisterAttrEncoder(AttrEncID{TxSys: AnyTxSystem, Attr: 1}, encFn),
+			`tx attribute encoder for {00000000 1} is already registered`)
+	})
+}
+
+func Test_NewFromRegistry(t *testing.T) {
+	registered := registry.f
+	t.Cleanup(func() { registry.f = registered })
+
+	id := AttrEncID{TxSys: 0xFFFF, Attr: 1}
+	Register(func(reg func(id AttrEncID, enc TxAttributesEncoder) error) error {
+		return reg(id, func(txo *types.TransactionOrder, ver uint32) ([]byte, error) { return []byte{1}, nil })
+	})
+
+	enc, err := NewFromRegistry()
+	require.NoError(t, err)
+	require.Contains(t, enc.attrEnc, id)
+
+	// registering the same encoder second time is an error
+	Register(func(reg func(id AttrEncID, enc TxAttributesEncoder) error) error {
+		return reg(id, func(txo *types.TransactionOrder, ver uint32) ([]byte, error) { return nil, nil })
+	})
+	_, err = NewFromRegistry()
+	require.EqualError(t, err, `registering attribute encoder [1]: tx attribute encoder for {0000FFFF 1} is already registered`)
+}
@@ -118,3 +118,17 @@ func (enc *TVEnc) EncodeTagged(tag Tag, item any) {
 	enc.buf = append(enc.buf, tag)
 	enc.Encode(item)
 }
+
+/*
+CBORBytes returns item serialized as CBOR. It is meant for complex data structures
+(ie proofs) which are passed to the predicate "as is" and which predicate can parse
+using the CBOR host API. Encoding error is recorded and returned by Bytes.
+*/
+func (enc *TVEnc) CBORBytes(item any) []byte {
+	b, err := types.Cbor.Marshal(item)
+	if err != nil {
+		_ = enc.setErr(fmt.Errorf("encoding %T as CBOR: %w", item, err))
+		return nil
+	}
+	return b
+}
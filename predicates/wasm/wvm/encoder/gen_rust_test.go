@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/txsystem/fc/unit"
 )
 
 func Test_TXSystemEncoder_trigger(t *testing.T) {
@@ -52,6 +53,64 @@ func Test_TXSystemEncoder_trigger(t *testing.T) {
 		require.Equal(t, []byte{0x1, 0x4, 0x0, 0x0, 0x2, 0x3, 0x7, 0x0, 0x0, 0x0, 0x3, 0x1, 0xa, 0x0, 0x0, 0x0, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0xa, 0x4, 0x4, 0x16, 0x0, 0x5, 0x1, 0x6, 0x0, 0x0, 0x0, 0x72, 0x65, 0x66, 0x2d, 0x6e, 0x6f}, buf)
 	})
 
+	t.Run("txOrder ver 2", func(t *testing.T) {
+		getHandle := func(obj any) uint64 { t.Errorf("unexpected call of getHandle(%T)", obj); return 0 }
+		// ver 2 adds timeout and max fee to the ver 1 fields
+		txo := &types.TransactionOrder{
+			Payload: types.Payload{
+				SystemID: 7,
+				Type:     22,
+				UnitID:   []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+				ClientMetadata: &types.ClientMetadata{
+					ReferenceNumber:   []byte("ref-no"),
+					Timeout:           0x0102,
+					MaxTransactionFee: 5,
+				},
+			},
+		}
+		buf, err := enc.Encode(txo, 2, getHandle)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x1, 0x4, 0x0, 0x0, 0x2, 0x3, 0x7, 0x0, 0x0, 0x0, 0x3, 0x1, 0xa, 0x0, 0x0, 0x0, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0xa, 0x4, 0x4, 0x16, 0x0, 0x5, 0x1, 0x6, 0x0, 0x0, 0x0, 0x72, 0x65, 0x66, 0x2d, 0x6e, 0x6f,
+			0x6, 0x2, 0x2, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+			0x7, 0x2, 0x5, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, buf)
+	})
+
+	t.Run("txOrder ver 3", func(t *testing.T) {
+		getHandle := func(obj any) uint64 { t.Errorf("unexpected call of getHandle(%T)", obj); return 0 }
+		// ver 3 adds the fee payer to the ver 2 fields
+		txo := &types.TransactionOrder{
+			Payload: types.Payload{
+				SystemID: 7,
+				Type:     22,
+				UnitID:   []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+				ClientMetadata: &types.ClientMetadata{
+					ReferenceNumber:   []byte("ref-no"),
+					Timeout:           0x0102,
+					MaxTransactionFee: 5,
+					FeeCreditRecordID: []byte{0xf, 0xc},
+				},
+			},
+		}
+		ver2, err := enc.Encode(txo, 2, getHandle)
+		require.NoError(t, err)
+
+		buf, err := enc.Encode(txo, 3, getHandle)
+		require.NoError(t, err)
+		require.Equal(t, append(ver2, 0x8, 0x1, 0x2, 0x0, 0x0, 0x0, 0xf, 0xc), buf)
+
+		// sponsored transaction, the sponsor's fee credit record is the fee payer
+		txo.FeeProof, err = unit.NewSponsorFeeProof([]byte{0x5, 0x9}, nil)
+		require.NoError(t, err)
+		buf, err = enc.Encode(txo, 3, getHandle)
+		require.NoError(t, err)
+		require.Equal(t, append(ver2, 0x8, 0x1, 0x2, 0x0, 0x0, 0x0, 0x5, 0x9), buf)
+
+		txo.FeeProof = []byte{0xd9, 0x41, 0x42, 0x01}
+		buf, err = enc.Encode(txo, 3, getHandle)
+		require.ErrorContains(t, err, "reading fee payer: decoding sponsor fee proof")
+		require.Nil(t, buf)
+	})
+
 	t.Run("byte slice", func(t *testing.T) {
 		// byte slice is returned exactly as-is
 		buf, err := enc.Encode([]byte{0, 1, 127, 128, 255}, 1, nil)
@@ -0,0 +1,31 @@
+package encoder
+
+import "sync"
+
+var registry = struct {
+	mu sync.Mutex
+	f  []any
+}{}
+
+/*
+Register adds encoder registration functions to the global registry. Tx system
+encoder packages call it from their init function so that importing the package
+is enough to make its encoders available via NewFromRegistry.
+
+Arguments must be of the same type as accepted by New.
+*/
+func Register(f ...any) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.f = append(registry.f, f...)
+}
+
+/*
+NewFromRegistry returns encoder with all the encoders added to the global registry
+(see Register) registered.
+*/
+func NewFromRegistry() (TXSystemEncoder, error) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	return New(registry.f...)
+}
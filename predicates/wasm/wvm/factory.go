@@ -22,6 +22,8 @@ func (ABTypesFactory) createObj(typID uint32, data []byte) (any, error) {
 		obj = &types.TransactionRecord{}
 	case type_id_tx_proof:
 		obj = &types.TxProof{Version: 1}
+	case type_id_unicity_certificate:
+		obj = &types.UnicityCertificate{}
 	default:
 		return nil, fmt.Errorf("unknown type ID %d", typID)
 	}
@@ -36,4 +38,6 @@ const (
 	type_id_tx_order  = 1
 	type_id_tx_record = 8
 	type_id_tx_proof  = 9
+
+	type_id_unicity_certificate = 10
 )
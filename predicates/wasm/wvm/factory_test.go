@@ -82,4 +82,18 @@ func Test_ABTypesFactory_createObj(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, &txp, obj)
 	})
+
+	t.Run("UnicityCertificate ok", func(t *testing.T) {
+		uc := types.UnicityCertificate{Version: 1,
+			InputRecord: &types.InputRecord{Version: 1, RoundNumber: 3, Hash: []byte{1, 2}},
+			UnicitySeal: &types.UnicitySeal{Version: 1, RootChainRoundNumber: 5, Signatures: map[string][]byte{"1": {1}}},
+		}
+		buf, err := types.Cbor.Marshal(uc)
+		require.NoError(t, err)
+
+		f := ABTypesFactory{}
+		obj, err := f.createObj(type_id_unicity_certificate, buf)
+		require.NoError(t, err)
+		require.Equal(t, &uc, obj)
+	})
 }
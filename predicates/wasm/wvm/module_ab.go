@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"crypto"
+	"crypto/sha512"
 	"errors"
 	"fmt"
 
 	"github.com/tetratelabs/wazero"
 	"github.com/tetratelabs/wazero/api"
+	"golang.org/x/crypto/sha3"
 
+	abcrypto "github.com/alphabill-org/alphabill-go-base/crypto"
 	"github.com/alphabill-org/alphabill-go-base/hash"
 	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
 	"github.com/alphabill-org/alphabill-go-base/txsystem/money"
@@ -27,6 +30,10 @@ func addAlphabillModule(ctx context.Context, rt wazero.Runtime, _ Observability)
 		NewFunctionBuilder().WithGoModuleFunction(hostAPI(verifyTxProof), []api.ValueType{api.ValueTypeI64, api.ValueTypeI64}, []api.ValueType{api.ValueTypeI32}).Export("verify_tx_proof").
 		NewFunctionBuilder().WithGoModuleFunction(hostAPI(amountTransferred), []api.ValueType{api.ValueTypeI64, api.ValueTypeI64, api.ValueTypeI64}, []api.ValueType{api.ValueTypeI64}).Export("amount_transferred").
 		NewFunctionBuilder().WithGoModuleFunction(api.GoModuleFunc(txSignedByPKH), []api.ValueType{api.ValueTypeI64, api.ValueTypeI64}, []api.ValueType{api.ValueTypeI32}).Export("tx_signed_by_pkh").
+		NewFunctionBuilder().WithGoModuleFunction(hostAPI(digestSHA512), []api.ValueType{api.ValueTypeI64}, []api.ValueType{api.ValueTypeI64}).Export("digest_sha512").
+		NewFunctionBuilder().WithGoModuleFunction(hostAPI(digestKeccak256), []api.ValueType{api.ValueTypeI64}, []api.ValueType{api.ValueTypeI64}).Export("digest_keccak256").
+		NewFunctionBuilder().WithGoModuleFunction(hostAPI(verifySignatureSecp256k1), []api.ValueType{api.ValueTypeI64, api.ValueTypeI64, api.ValueTypeI64}, []api.ValueType{api.ValueTypeI32}).Export("verify_sig_secp256k1").
+		NewFunctionBuilder().WithGoModuleFunction(hostAPI(verifyUnicityCertificate), []api.ValueType{api.ValueTypeI64, api.ValueTypeI32, api.ValueTypeI64}, []api.ValueType{api.ValueTypeI32}).Export("verify_uc").
 		Instantiate(ctx)
 	return err
 }
@@ -127,6 +134,109 @@ func digestSHA256(vec *vmContext, mod api.Module, stack []uint64) error {
 	return nil
 }
 
+func digestSHA512(vec *vmContext, mod api.Module, stack []uint64) error {
+	data := read(mod, stack[0])
+	if err := vec.spendGas(mod, vec.gas.HashingGas(len(data))); err != nil {
+		return err
+	}
+	digest := sha512.Sum512(data)
+	addr, err := vec.writeToMemory(mod, digest[:])
+	if err != nil {
+		return fmt.Errorf("allocating memory for digest result: %w", err)
+	}
+	stack[0] = addr
+	return nil
+}
+
+func digestKeccak256(vec *vmContext, mod api.Module, stack []uint64) error {
+	data := read(mod, stack[0])
+	if err := vec.spendGas(mod, vec.gas.HashingGas(len(data))); err != nil {
+		return err
+	}
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	addr, err := vec.writeToMemory(mod, h.Sum(nil))
+	if err != nil {
+		return fmt.Errorf("allocating memory for digest result: %w", err)
+	}
+	stack[0] = addr
+	return nil
+}
+
+/*
+verifySignatureSecp256k1 verifies the secp256k1 signature of the message (message
+is hashed using SHA256 before verification).
+Arguments in "stack":
+  - [0] address of the compressed public key;
+  - [1] address of the signature;
+  - [2] address of the message;
+
+Returns:
+  - 0: signature is valid;
+  - 1: signature is not valid;
+  - 2: public key is not valid;
+*/
+func verifySignatureSecp256k1(vec *vmContext, mod api.Module, stack []uint64) error {
+	pubKey := read(mod, stack[0])
+	sig := read(mod, stack[1])
+	msg := read(mod, stack[2])
+	if err := vec.spendGas(mod, vec.gas.WasmVerifySignature+vec.gas.HashingGas(len(msg))); err != nil {
+		return err
+	}
+	verifier, err := abcrypto.NewVerifierSecp256k1(pubKey)
+	if err != nil {
+		vec.log.Debug(fmt.Sprintf("%s.verifySignatureSecp256k1: invalid public key: %v", mod.Name(), err))
+		stack[0] = 2
+		return nil
+	}
+	if err := verifier.VerifyBytes(sig, msg); err != nil {
+		vec.log.Debug(fmt.Sprintf("%s.verifySignatureSecp256k1: %v", mod.Name(), err))
+		stack[0] = 1
+	} else {
+		stack[0] = 0
+	}
+	return nil
+}
+
+/*
+verifyUnicityCertificate verifies the unicity certificate against the trust base
+of the given epoch and the partition description record of the partition (the
+certificates of the partitions which descriptions are not known are rejected).
+Arguments in "stack":
+  - [0] handle of the unicity certificate;
+  - [1] ID of the partition the certificate must be issued to;
+  - [2] epoch of the trust base;
+
+Returns 0 when the certificate is valid and 1 otherwise.
+*/
+func verifyUnicityCertificate(vec *vmContext, mod api.Module, stack []uint64) error {
+	uc, err := getVar[*types.UnicityCertificate](vec.curPrg.vars, stack[0])
+	if err != nil {
+		return fmt.Errorf("unicity certificate: %w", err)
+	}
+	if err := vec.spendGas(mod, vec.gas.WasmVerifyUC); err != nil {
+		return err
+	}
+	tb, err := vec.curPrg.env.TrustBase(stack[2])
+	if err != nil {
+		return fmt.Errorf("acquiring trust base: %w", err)
+	}
+	systemID := types.SystemID(api.DecodeU32(stack[1]))
+	pdrHash, ok := vec.pdrHashes[systemID]
+	if !ok {
+		vec.log.Debug(fmt.Sprintf("%s.verifyUnicityCertificate: unknown partition %s", mod.Name(), systemID))
+		stack[0] = 1
+		return nil
+	}
+	if err := uc.Verify(tb, crypto.SHA256, systemID, pdrHash); err != nil {
+		vec.log.Debug(fmt.Sprintf("%s.verifyUnicityCertificate: %v", mod.Name(), err))
+		stack[0] = 1
+	} else {
+		stack[0] = 0
+	}
+	return nil
+}
+
 /*
 Given raw BLOB of transaction proofs return amount on "money" transferred to
 given receiver, optionally matching reference number too.
@@ -2,9 +2,13 @@ package wvm
 
 import (
 	"context"
+	"crypto"
+	"encoding/hex"
 	"errors"
+	"math"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tetratelabs/wazero/api"
@@ -15,7 +19,10 @@ import (
 	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
 	"github.com/alphabill-org/alphabill-go-base/types"
 	testblock "github.com/alphabill-org/alphabill/internal/testutils/block"
+	testcertificates "github.com/alphabill-org/alphabill/internal/testutils/certificates"
 	"github.com/alphabill-org/alphabill/internal/testutils/observability"
+	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
+	testtb "github.com/alphabill-org/alphabill/internal/testutils/trustbase"
 	"github.com/alphabill-org/alphabill/predicates"
 	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/bumpallocator"
 )
@@ -441,3 +448,143 @@ func Test_transferredSum(t *testing.T) {
 		require.Zero(t, sum)
 	})
 }
+
+func Test_digests(t *testing.T) {
+	t.Run("SHA512", func(t *testing.T) {
+		vm, mod, gas := newTestModule(t, &mockTxContext{}, 1000)
+		stack := []uint64{writeTestArg(t, vm, mod, []byte("abc"))}
+		require.NoError(t, digestSHA512(vm, mod, stack))
+		exp, err := hex.DecodeString("ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f")
+		require.NoError(t, err)
+		require.Equal(t, exp, read(mod, stack[0]))
+		require.EqualValues(t, 1000-defaultGas.WasmHash-defaultGas.WasmHashWord, gas.value)
+	})
+
+	t.Run("Keccak256", func(t *testing.T) {
+		vm, mod, gas := newTestModule(t, &mockTxContext{}, 1000)
+		stack := []uint64{writeTestArg(t, vm, mod, nil)}
+		require.NoError(t, digestKeccak256(vm, mod, stack))
+		exp, err := hex.DecodeString("c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470")
+		require.NoError(t, err)
+		require.Equal(t, exp, read(mod, stack[0]))
+		require.EqualValues(t, 1000-defaultGas.WasmHash, gas.value)
+	})
+
+	t.Run("out of gas", func(t *testing.T) {
+		vm, mod, gas := newTestModule(t, &mockTxContext{}, defaultGas.WasmHash)
+		stack := []uint64{writeTestArg(t, vm, mod, make([]byte, 33))}
+		require.ErrorContains(t, digestKeccak256(vm, mod, stack), "out of gas")
+		require.EqualValues(t, uint64(math.MaxUint64), gas.value)
+	})
+}
+
+func Test_verifySignatureSecp256k1(t *testing.T) {
+	signer, err := abcrypto.NewInMemorySecp256K1Signer()
+	require.NoError(t, err)
+	verifier, err := signer.Verifier()
+	require.NoError(t, err)
+	pubKey, err := verifier.MarshalPublicKey()
+	require.NoError(t, err)
+	msg := []byte("oracle says yes")
+	sig, err := signer.SignBytes(msg)
+	require.NoError(t, err)
+
+	verify := func(t *testing.T, pubKey, sig, msg []byte) uint64 {
+		vm, mod, gas := newTestModule(t, &mockTxContext{}, 10000)
+		stack := []uint64{writeTestArg(t, vm, mod, pubKey), writeTestArg(t, vm, mod, sig), writeTestArg(t, vm, mod, msg)}
+		require.NoError(t, verifySignatureSecp256k1(vm, mod, stack))
+		require.EqualValues(t, 10000-defaultGas.WasmVerifySignature-defaultGas.HashingGas(len(msg)), gas.value)
+		return stack[0]
+	}
+
+	require.EqualValues(t, 0, verify(t, pubKey, sig, msg))
+	require.EqualValues(t, 1, verify(t, pubKey, sig, []byte("oracle says no")))
+	require.EqualValues(t, 2, verify(t, []byte{1, 2, 3}, sig, msg))
+}
+
+func Test_verifyUnicityCertificate(t *testing.T) {
+	signer, verifier := testsig.CreateSignerAndVerifier(t)
+	tb := testtb.NewTrustBase(t, verifier)
+	pdr := &types.PartitionDescriptionRecord{
+		NetworkIdentifier: 5,
+		SystemIdentifier:  money.DefaultSystemID,
+		TypeIdLen:         8,
+		UnitIdLen:         256,
+		T2Timeout:         2500 * time.Millisecond,
+	}
+	ir := &types.InputRecord{Version: 1,
+		PreviousHash: make([]byte, 32),
+		Hash:         make([]byte, 32),
+		BlockHash:    make([]byte, 32),
+		SummaryValue: make([]byte, 32),
+		RoundNumber:  1,
+	}
+	uc := testcertificates.CreateUnicityCertificate(t, signer, ir, pdr, 1, make([]byte, 32))
+
+	var epoch uint64
+	env := &mockTxContext{
+		trustBase: func(e uint64) (types.RootTrustBase, error) {
+			epoch = e
+			return tb, nil
+		},
+	}
+
+	newTestModule := func(t *testing.T, env EvalEnvironment, gas uint64) (*vmContext, *mockApiMod, *mockGlobal) {
+		vm, mod, counter := newTestModule(t, env, gas)
+		vm.pdrHashes = map[types.SystemID][]byte{pdr.SystemIdentifier: pdr.Hash(crypto.SHA256)}
+		return vm, mod, counter
+	}
+
+	t.Run("invalid handle", func(t *testing.T) {
+		vm, mod, _ := newTestModule(t, env, 100000)
+		stack := []uint64{handle_max_reserved + 1, uint64(pdr.SystemIdentifier), 0}
+		require.EqualError(t, verifyUnicityCertificate(vm, mod, stack), `unicity certificate: invalid handle 11 (not found)`)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		vm, mod, gas := newTestModule(t, env, 100000)
+		stack := []uint64{vm.curPrg.addVar(uc), uint64(pdr.SystemIdentifier), 7}
+		require.NoError(t, verifyUnicityCertificate(vm, mod, stack))
+		require.EqualValues(t, 0, stack[0])
+		require.EqualValues(t, 7, epoch)
+		require.EqualValues(t, 100000-defaultGas.WasmVerifyUC, gas.value)
+	})
+
+	t.Run("wrong partition", func(t *testing.T) {
+		vm, mod, _ := newTestModule(t, env, 100000)
+		vm.pdrHashes[pdr.SystemIdentifier+1] = pdr.Hash(crypto.SHA256)
+		stack := []uint64{vm.curPrg.addVar(uc), uint64(pdr.SystemIdentifier + 1), 0}
+		require.NoError(t, verifyUnicityCertificate(vm, mod, stack))
+		require.EqualValues(t, 1, stack[0])
+	})
+
+	t.Run("unknown partition", func(t *testing.T) {
+		vm, mod, _ := newTestModule(t, env, 100000)
+		vm.pdrHashes = nil
+		stack := []uint64{vm.curPrg.addVar(uc), uint64(pdr.SystemIdentifier), 0}
+		require.NoError(t, verifyUnicityCertificate(vm, mod, stack))
+		require.EqualValues(t, 1, stack[0])
+	})
+
+	t.Run("partition description does not match", func(t *testing.T) {
+		// the certificate is issued for the PDR with different T2 timeout
+		otherPDR := *pdr
+		otherPDR.T2Timeout = 1000 * time.Millisecond
+		vm, mod, _ := newTestModule(t, env, 100000)
+		vm.pdrHashes[pdr.SystemIdentifier] = otherPDR.Hash(crypto.SHA256)
+		stack := []uint64{vm.curPrg.addVar(uc), uint64(pdr.SystemIdentifier), 0}
+		require.NoError(t, verifyUnicityCertificate(vm, mod, stack))
+		require.EqualValues(t, 1, stack[0])
+	})
+
+	t.Run("not signed by trust base", func(t *testing.T) {
+		_, otherVerifier := testsig.CreateSignerAndVerifier(t)
+		otherEnv := &mockTxContext{
+			trustBase: func(uint64) (types.RootTrustBase, error) { return testtb.NewTrustBase(t, otherVerifier), nil },
+		}
+		vm, mod, _ := newTestModule(t, otherEnv, 100000)
+		stack := []uint64{vm.curPrg.addVar(uc), uint64(pdr.SystemIdentifier), 0}
+		require.NoError(t, verifyUnicityCertificate(vm, mod, stack))
+		require.EqualValues(t, 1, stack[0])
+	})
+}
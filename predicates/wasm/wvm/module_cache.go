@@ -0,0 +1,92 @@
+package wvm
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+
+	"github.com/tetratelabs/wazero"
+)
+
+type (
+	// moduleCache is a LRU cache of the instrumented and compiled predicate modules, keyed by the hash of
+	// the (not instrumented) predicate code.
+	moduleCache struct {
+		size    int
+		mu      sync.Mutex
+		lru     *list.List // front is the most recently used
+		modules map[[sha256.Size]byte]*list.Element
+	}
+
+	cachedModule struct {
+		key      [sha256.Size]byte
+		compiled wazero.CompiledModule
+	}
+)
+
+func newModuleCache(size int) *moduleCache {
+	return &moduleCache{
+		size:    size,
+		lru:     list.New(),
+		modules: make(map[[sha256.Size]byte]*list.Element),
+	}
+}
+
+func moduleCacheKey(predicate []byte) [sha256.Size]byte {
+	return sha256.Sum256(predicate)
+}
+
+// get returns the compiled module for the key and marks it as the most recently used.
+func (c *moduleCache) get(key [sha256.Size]byte) (wazero.CompiledModule, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.modules[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	return e.Value.(*cachedModule).compiled, true
+}
+
+// add adds the compiled module to the cache, the least recently used modules are closed and removed when
+// the cache is full. When the module with the same key is already cached the new module is closed and the
+// cached one is returned.
+func (c *moduleCache) add(ctx context.Context, key [sha256.Size]byte, compiled wazero.CompiledModule) (wazero.CompiledModule, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.modules[key]; ok {
+		c.lru.MoveToFront(e)
+		return e.Value.(*cachedModule).compiled, compiled.Close(ctx)
+	}
+	c.modules[key] = c.lru.PushFront(&cachedModule{key: key, compiled: compiled})
+	var err error
+	for c.lru.Len() > c.size {
+		err = errors.Join(err, c.remove(ctx, c.lru.Back()))
+	}
+	return compiled, err
+}
+
+func (c *moduleCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// close closes and removes all the cached modules.
+func (c *moduleCache) close(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var err error
+	for c.lru.Len() > 0 {
+		err = errors.Join(err, c.remove(ctx, c.lru.Back()))
+	}
+	return err
+}
+
+func (c *moduleCache) remove(ctx context.Context, e *list.Element) error {
+	m := c.lru.Remove(e).(*cachedModule)
+	delete(c.modules, m.key)
+	return m.compiled.Close(ctx)
+}
@@ -0,0 +1,58 @@
+package wvm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero"
+)
+
+func TestModuleCache(t *testing.T) {
+	ctx := context.Background()
+	rt := wazero.NewRuntime(ctx)
+	defer rt.Close(ctx)
+
+	compile := func(t *testing.T) wazero.CompiledModule {
+		compiled, err := rt.CompileModule(ctx, loopWasm)
+		require.NoError(t, err)
+		return compiled
+	}
+
+	cache := newModuleCache(2)
+	k1, k2, k3 := moduleCacheKey([]byte{1}), moduleCacheKey([]byte{2}), moduleCacheKey([]byte{3})
+	_, ok := cache.get(k1)
+	require.False(t, ok)
+
+	m1 := compile(t)
+	cm, err := cache.add(ctx, k1, m1)
+	require.NoError(t, err)
+	require.Equal(t, m1, cm)
+	m2 := compile(t)
+	_, err = cache.add(ctx, k2, m2)
+	require.NoError(t, err)
+	require.Equal(t, 2, cache.len())
+
+	// k1 becomes the most recently used, adding k3 evicts k2
+	cm, ok = cache.get(k1)
+	require.True(t, ok)
+	require.Equal(t, m1, cm)
+	_, err = cache.add(ctx, k3, compile(t))
+	require.NoError(t, err)
+	require.Equal(t, 2, cache.len())
+	_, ok = cache.get(k2)
+	require.False(t, ok)
+	_, ok = cache.get(k1)
+	require.True(t, ok)
+
+	// adding already cached key returns the cached module
+	cm, err = cache.add(ctx, k1, compile(t))
+	require.NoError(t, err)
+	require.Equal(t, m1, cm)
+	require.Equal(t, 2, cache.len())
+
+	require.NoError(t, cache.close(ctx))
+	require.Zero(t, cache.len())
+	_, ok = cache.get(k1)
+	require.False(t, ok)
+}
@@ -121,12 +121,14 @@ func Test_cbor_parse_array_raw(t *testing.T) {
 }
 
 type mockApiMod struct {
-	memory func() api.Memory
+	memory         func() api.Memory
+	exportedGlobal func(name string) api.Global
 	// to "implement" everything we haven't mocked
 	api.Module
 }
 
-func (m *mockApiMod) Memory() api.Memory { return m.memory() }
+func (m *mockApiMod) Memory() api.Memory                    { return m.memory() }
+func (m *mockApiMod) ExportedGlobal(name string) api.Global { return m.exportedGlobal(name) }
 
 type mockMemory struct {
 	size  func() uint32
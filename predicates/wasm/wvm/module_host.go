@@ -2,27 +2,34 @@ package wvm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 
 	"github.com/tetratelabs/wazero"
 	"github.com/tetratelabs/wazero/api"
 
-	"github.com/alphabill-org/alphabill-go-base/util"
-	"github.com/alphabill-org/alphabill/logger"
+	"github.com/alphabill-org/alphabill/predicates"
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/instrument"
+)
+
+const (
+	maxStorageKeySize   = 128
+	maxStorageValueSize = 4096
 )
 
 /*
 addHostModule adds "host" module to the "rt".
-The host module provides "utility APIs" for the runtime, ie memory manager and logging.
+The host module provides "utility APIs" for the runtime, ie memory manager, logging and
+the persistent storage of the predicate.
 */
 func addHostModule(ctx context.Context, rt wazero.Runtime, observe Observability) error {
 	_, err := rt.NewHostModuleBuilder("host").
 		NewFunctionBuilder().WithFunc(logMsg).Export("log_msg").
 		NewFunctionBuilder().WithGoModuleFunction(extMalloc(observe), []api.ValueType{api.ValueTypeI32}, []api.ValueType{api.ValueTypeI32}).Export("ext_malloc").
 		NewFunctionBuilder().WithGoModuleFunction(extFree(observe), []api.ValueType{api.ValueTypeI32}, []api.ValueType{}).Export("ext_free").
-		// do not expose storage API, need to decide do we want to support it, fees etc...
-		//NewFunctionBuilder().WithFunc(storageReadV1).Export("storage_read").
-		//NewFunctionBuilder().WithFunc(storageWriteV1).Export("storage_write").
+		NewFunctionBuilder().WithGoModuleFunction(hostAPI(storageRead), []api.ValueType{api.ValueTypeI64}, []api.ValueType{api.ValueTypeI64}).Export("storage_read").
+		NewFunctionBuilder().WithGoModuleFunction(hostAPI(storageWrite), []api.ValueType{api.ValueTypeI64, api.ValueTypeI64}, []api.ValueType{}).Export("storage_write").
 		Instantiate(ctx)
 	return err
 }
@@ -49,44 +56,108 @@ func read(m api.Module, pointerSize uint64) (data []byte) {
 	return data
 }
 
-func storageReadV1(ctx context.Context, m api.Module, fileID uint32) uint64 {
-	rtCtx := extractVMContext(ctx)
-	rtCtx.log.DebugContext(ctx, fmt.Sprintf("program state file request, %v", fileID))
-	var Value []byte
-	found, err := rtCtx.storage.Read(util.Uint32ToBytes(fileID), &Value)
-	if !found {
-		return 0
+/*
+storageRead returns the value stored under the key in the storage of the current predicate.
+Parameters (stack):
+  - 0: address of the key (uint64)
+
+Returns address of the value, zero when the key is not found.
+*/
+func storageRead(vec *vmContext, mod api.Module, stack []uint64) error {
+	storage, err := predicateStorage(vec)
+	if err != nil {
+		return err
+	}
+	key := read(mod, stack[0])
+	if err := checkStorageKey(key); err != nil {
+		return err
 	}
+	if err := vec.spendGas(mod, vec.gas.WasmStorageRead+uint64(len(key))*vec.gas.WasmStorageReadByte); err != nil {
+		return err
+	}
+	value, err := storage.ReadPredicateData(vec.curPrg.hash, key)
 	if err != nil {
-		rtCtx.log.WarnContext(ctx, "get state from storage failed, %v", logger.Error(err))
-		return 0
+		return fmt.Errorf("reading predicate storage: %w", err)
+	}
+	if value == nil {
+		stack[0] = 0
+		return nil
+	}
+	if err := vec.spendGas(mod, uint64(len(value))*vec.gas.WasmStorageReadByte); err != nil {
+		return err
+	}
+	if stack[0], err = vec.writeToMemory(mod, value); err != nil {
+		return fmt.Errorf("writing value into shared memory: %w", err)
 	}
-	dataLen := uint32(len(Value))
-	offset, err := rtCtx.memMngr.Alloc(m.Memory(), dataLen)
+	return nil
+}
+
+/*
+storageWrite stores the value under the key in the storage of the current predicate,
+empty value deletes the key.
+Parameters (stack):
+  - 0: address of the key (uint64)
+  - 1: address of the value (uint64)
+*/
+func storageWrite(vec *vmContext, mod api.Module, stack []uint64) error {
+	storage, err := predicateStorage(vec)
 	if err != nil {
-		rtCtx.log.WarnContext(ctx, "program state file memory allocation failed failed", logger.Error(err))
+		return err
+	}
+	key := read(mod, stack[0])
+	if err := checkStorageKey(key); err != nil {
+		return err
+	}
+	value := read(mod, stack[1])
+	if len(value) > maxStorageValueSize {
+		return fmt.Errorf("storage value is %d bytes, allowed maximum is %d bytes", len(value), maxStorageValueSize)
+	}
+	if err := vec.spendGas(mod, vec.gas.WasmStorageWrite+uint64(len(key)+len(value))*vec.gas.WasmStorageWriteByte); err != nil {
+		return err
 	}
-	if ok := m.Memory().Write(offset, Value); !ok {
-		rtCtx.log.WarnContext(ctx, "program state file write failed")
-		return 0
+	if err := storage.WritePredicateData(vec.curPrg.hash, key, value); err != nil {
+		return fmt.Errorf("writing predicate storage: %w", err)
 	}
-	return newPointerSize(offset, dataLen)
+	return nil
 }
 
-func storageWriteV1(ctx context.Context, m api.Module, fileID uint32, value uint64) int32 {
-	rtCtx := extractVMContext(ctx)
-	prt, size := splitPointerSize(value)
-	data, ok := m.Memory().Read(prt, size)
+func predicateStorage(vec *vmContext) (predicates.PredicateStorage, error) {
+	storage, ok := vec.curPrg.env.(predicates.PredicateStorage)
 	if !ok {
-		rtCtx.log.WarnContext(ctx, "failed to read state from program memory")
-		return -1
+		return nil, errors.New("predicate storage is not supported by the evaluation environment")
+	}
+	return storage, nil
+}
+
+func checkStorageKey(key []byte) error {
+	if n := len(key); n == 0 || n > maxStorageKeySize {
+		return fmt.Errorf("storage key must be 1 to %d bytes, got %d bytes", maxStorageKeySize, n)
+	}
+	return nil
+}
+
+/*
+spendGas charges the gas used by the host API from the gas counter of the module.
+The counter is in units of the instrumenter so the gas is converted using the
+instruction cost of the gas schedule (rounding up).
+When the module runs out of gas the counter is set to max value which signals Exec
+to spend the whole budget.
+*/
+func (vec *vmContext) spendGas(mod api.Module, gas uint64) error {
+	counter, ok := mod.ExportedGlobal(instrument.GasCounterName).(api.MutableGlobal)
+	if !ok {
+		return errors.New("gas counter not found")
+	}
+	if ic := vec.gas.WasmInstruction; ic > 1 {
+		gas = gas/ic + min(gas%ic, 1)
 	}
-	rtCtx.log.WarnContext(ctx, fmt.Sprintf("set state, %v id, new state: %v", fileID, data))
-	if err := rtCtx.storage.Write(util.Uint32ToBytes(fileID), data); err != nil {
-		rtCtx.log.WarnContext(ctx, "failed to persist program state")
-		return -1
+	remaining := counter.Get()
+	if remaining < gas {
+		counter.Set(math.MaxUint64)
+		return fmt.Errorf("out of gas: %d required, %d remaining", gas, remaining)
 	}
-	return 0
+	counter.Set(remaining - gas)
+	return nil
 }
 
 func logMsg(ctx context.Context, m api.Module, level uint32, msgData uint64) {
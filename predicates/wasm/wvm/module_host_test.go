@@ -1,7 +1,16 @@
 package wvm
 
 import (
+	"math"
 	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero/api"
+
+	"github.com/alphabill-org/alphabill/internal/testutils/observability"
+	"github.com/alphabill-org/alphabill/predicates"
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/bumpallocator"
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/instrument"
 )
 
 func Test_addr_encoding(t *testing.T) {
@@ -28,3 +37,165 @@ func Test_addr_encoding(t *testing.T) {
 		}
 	}
 }
+
+func Test_storage(t *testing.T) {
+	t.Run("storage not supported", func(t *testing.T) {
+		vm, mod, _ := newTestModule(t, &mockTxContext{}, 10000)
+		stack := []uint64{writeTestArg(t, vm, mod, []byte("key"))}
+		require.EqualError(t, storageRead(vm, mod, stack), `predicate storage is not supported by the evaluation environment`)
+	})
+
+	t.Run("write and read", func(t *testing.T) {
+		env := &mockStorageEnv{data: map[string][]byte{}}
+		vm, mod, gas := newTestModule(t, env, 100000)
+		key := []byte("counter")
+		value := []byte{0, 0, 0, 42}
+
+		// key not found
+		stack := []uint64{writeTestArg(t, vm, mod, key)}
+		require.NoError(t, storageRead(vm, mod, stack))
+		require.Zero(t, stack[0])
+		require.EqualValues(t, 100000-defaultGas.WasmStorageRead-uint64(len(key))*defaultGas.WasmStorageReadByte, gas.value)
+
+		gas.value = 100000
+		stack = []uint64{writeTestArg(t, vm, mod, key), writeTestArg(t, vm, mod, value)}
+		require.NoError(t, storageWrite(vm, mod, stack))
+		require.EqualValues(t, 100000-defaultGas.WasmStorageWrite-uint64(len(key)+len(value))*defaultGas.WasmStorageWriteByte, gas.value)
+		require.Equal(t, value, env.data[string(vm.curPrg.hash)+string(key)], "key must be namespaced by predicate hash")
+
+		stack = []uint64{writeTestArg(t, vm, mod, key)}
+		require.NoError(t, storageRead(vm, mod, stack))
+		require.Equal(t, value, read(mod, stack[0]))
+
+		// empty value deletes the key
+		stack = []uint64{writeTestArg(t, vm, mod, key), writeTestArg(t, vm, mod, nil)}
+		require.NoError(t, storageWrite(vm, mod, stack))
+		require.NotContains(t, env.data, string(vm.curPrg.hash)+string(key))
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		vm, mod, _ := newTestModule(t, &mockStorageEnv{data: map[string][]byte{}}, 100000)
+		stack := []uint64{writeTestArg(t, vm, mod, nil)}
+		require.EqualError(t, storageRead(vm, mod, stack), `storage key must be 1 to 128 bytes, got 0 bytes`)
+
+		stack = []uint64{writeTestArg(t, vm, mod, make([]byte, maxStorageKeySize+1)), writeTestArg(t, vm, mod, []byte{1})}
+		require.EqualError(t, storageWrite(vm, mod, stack), `storage key must be 1 to 128 bytes, got 129 bytes`)
+	})
+
+	t.Run("value too large", func(t *testing.T) {
+		vm, mod, _ := newTestModule(t, &mockStorageEnv{data: map[string][]byte{}}, 1000000)
+		stack := []uint64{writeTestArg(t, vm, mod, []byte("key")), writeTestArg(t, vm, mod, make([]byte, maxStorageValueSize+1))}
+		require.EqualError(t, storageWrite(vm, mod, stack), `storage value is 4097 bytes, allowed maximum is 4096 bytes`)
+	})
+
+	t.Run("out of gas", func(t *testing.T) {
+		env := &mockStorageEnv{data: map[string][]byte{}}
+		vm, mod, gas := newTestModule(t, env, defaultGas.WasmStorageWrite)
+		stack := []uint64{writeTestArg(t, vm, mod, []byte("key")), writeTestArg(t, vm, mod, []byte{1})}
+		require.ErrorContains(t, storageWrite(vm, mod, stack), `out of gas`)
+		require.EqualValues(t, uint64(math.MaxUint64), gas.value)
+		require.Empty(t, env.data)
+	})
+}
+
+type mockStorageEnv struct {
+	mockTxContext
+	data map[string][]byte
+}
+
+func (env *mockStorageEnv) ReadPredicateData(predicateHash, key []byte) ([]byte, error) {
+	return env.data[string(predicateHash)+string(key)], nil
+}
+
+func (env *mockStorageEnv) WritePredicateData(predicateHash, key, value []byte) error {
+	if len(value) == 0 {
+		delete(env.data, string(predicateHash)+string(key))
+		return nil
+	}
+	env.data[string(predicateHash)+string(key)] = value
+	return nil
+}
+
+type mockGlobal struct {
+	value uint64
+	// to "implement" everything we haven't mocked
+	api.MutableGlobal
+}
+
+func (g *mockGlobal) Get() uint64  { return g.value }
+func (g *mockGlobal) Set(v uint64) { g.value = v }
+
+/*
+newTestModule returns VM context and mocked module with 10000 bytes of memory and
+gas counter set to "gas".
+*/
+func Test_spendGas(t *testing.T) {
+	t.Run("default instruction cost", func(t *testing.T) {
+		vm, mod, gas := newTestModule(t, nil, 1000)
+		require.NoError(t, vm.spendGas(mod, 300))
+		require.EqualValues(t, 700, gas.value)
+		require.NoError(t, vm.spendGas(mod, 700))
+		require.Zero(t, gas.value)
+	})
+
+	t.Run("gas is converted into instrumenter units", func(t *testing.T) {
+		vm, mod, gas := newTestModule(t, nil, 1000)
+		vm.gas = predicates.DefaultGasSchedule()
+		vm.gas.WasmInstruction = 10
+		require.NoError(t, vm.spendGas(mod, 300))
+		require.EqualValues(t, 970, gas.value)
+		// partial unit is rounded up
+		require.NoError(t, vm.spendGas(mod, 1))
+		require.EqualValues(t, 969, gas.value)
+		require.NoError(t, vm.spendGas(mod, 11))
+		require.EqualValues(t, 967, gas.value)
+	})
+
+	t.Run("out of gas", func(t *testing.T) {
+		vm, mod, gas := newTestModule(t, nil, 100)
+		require.EqualError(t, vm.spendGas(mod, 101), `out of gas: 101 required, 100 remaining`)
+		require.EqualValues(t, uint64(math.MaxUint64), gas.value)
+	})
+}
+
+var defaultGas = predicates.DefaultGasSchedule()
+
+func newTestModule(t *testing.T, env EvalEnvironment, gas uint64) (*vmContext, *mockApiMod, *mockGlobal) {
+	vm := &vmContext{
+		gas: defaultGas,
+		curPrg: &evalContext{
+			hash: []byte{1, 2, 3},
+			env:  env,
+			vars: map[uint64]any{},
+		},
+		memMngr: bumpallocator.New(0, maxMem(10000)),
+		log:     observability.Default(t).Logger(),
+	}
+	buf := make([]byte, 10000)
+	mem := &mockMemory{
+		size: func() uint32 { return uint32(len(buf)) },
+		read: func(offset, byteCount uint32) ([]byte, bool) {
+			return buf[offset : offset+byteCount], true
+		},
+		write: func(offset uint32, v []byte) bool {
+			copy(buf[offset:], v)
+			return true
+		},
+	}
+	counter := &mockGlobal{value: gas}
+	mod := &mockApiMod{
+		memory: func() api.Memory { return mem },
+		exportedGlobal: func(name string) api.Global {
+			require.Equal(t, instrument.GasCounterName, name)
+			return counter
+		},
+	}
+	return vm, mod, counter
+}
+
+// writeTestArg writes data into module memory and returns it's address
+func writeTestArg(t *testing.T, vm *vmContext, mod api.Module, data []byte) uint64 {
+	addr, err := vm.writeToMemory(mod, data)
+	require.NoError(t, err)
+	return addr
+}
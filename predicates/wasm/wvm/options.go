@@ -1,25 +1,37 @@
 package wvm
 
 import (
-	"github.com/alphabill-org/alphabill/keyvaluedb"
-	"github.com/alphabill-org/alphabill/keyvaluedb/memorydb"
+	"crypto"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
 	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/experimental"
+
+	"github.com/alphabill-org/alphabill/predicates"
 )
 
+// DefaultModuleCacheSize is the default number of compiled predicate modules kept in memory.
+const DefaultModuleCacheSize = 128
+
 type (
 	Options struct {
-		cfg     wazero.RuntimeConfig
-		storage keyvaluedb.KeyValueDB
+		cfg                 wazero.RuntimeConfig
+		moduleCacheSize     int
+		compilationCacheDir string
+		listener            experimental.FunctionListenerFactory
+		gas                 *predicates.GasSchedule
+		// hashes of the known partition description records
+		pdrHashes map[types.SystemID][]byte
 	}
 
 	Option func(*Options)
 )
 
 func defaultOptions() *Options {
-	memDB, _ := memorydb.New()
 	return &Options{
-		cfg:     wazero.NewRuntimeConfig().WithCloseOnContextDone(true),
-		storage: memDB,
+		cfg:             wazero.NewRuntimeConfig().WithCloseOnContextDone(true),
+		moduleCacheSize: DefaultModuleCacheSize,
+		gas:             predicates.DefaultGasSchedule(),
 	}
 }
 
@@ -29,8 +41,59 @@ func WithRuntimeConfig(cfg wazero.RuntimeConfig) Option {
 	}
 }
 
-func WithStorage(db keyvaluedb.KeyValueDB) Option {
+/*
+WithModuleCacheSize sets the number of instrumented and compiled predicate modules
+kept in memory, zero disables the cache (predicate is compiled on every evaluation).
+*/
+func WithModuleCacheSize(size int) Option {
+	return func(c *Options) {
+		c.moduleCacheSize = size
+	}
+}
+
+/*
+WithCompilationCacheDir enables the on-disk cache of the compiled modules so that
+the predicates do not need to be recompiled after restart.
+*/
+func WithCompilationCacheDir(dir string) Option {
+	return func(c *Options) {
+		c.compilationCacheDir = dir
+	}
+}
+
+/*
+WithFunctionListener registers listener factory which is notified about the calls
+of both the predicate and the host API functions (ie GasTracer). Meant for debugging,
+the listeners slow down the execution of the predicates.
+*/
+func WithFunctionListener(factory experimental.FunctionListenerFactory) Option {
+	return func(c *Options) {
+		c.listener = factory
+	}
+}
+
+/*
+WithGasSchedule sets the gas costs of the host API functions and the WASM instructions,
+by default DefaultGasSchedule is used.
+*/
+func WithGasSchedule(gs *predicates.GasSchedule) Option {
+	return func(c *Options) {
+		c.gas = gs
+	}
+}
+
+/*
+WithPartitionDescriptions sets the partition description records of the partitions
+whose unicity certificates the predicates can verify. The certificates of the other
+partitions are rejected by the verifyUnicityCertificate host function.
+*/
+func WithPartitionDescriptions(pdrs ...*types.PartitionDescriptionRecord) Option {
 	return func(c *Options) {
-		c.storage = db
+		if c.pdrHashes == nil {
+			c.pdrHashes = make(map[types.SystemID][]byte, len(pdrs))
+		}
+		for _, pdr := range pdrs {
+			c.pdrHashes[pdr.SystemIdentifier] = pdr.Hash(crypto.SHA256)
+		}
 	}
 }
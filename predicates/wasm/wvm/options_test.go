@@ -5,11 +5,23 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/tetratelabs/wazero"
+
+	"github.com/alphabill-org/alphabill/predicates"
 )
 
 func TestDefault(t *testing.T) {
 	options := defaultOptions()
-	require.NotNil(t, options.storage)
+	require.NotNil(t, options.cfg)
+	require.Equal(t, DefaultModuleCacheSize, options.moduleCacheSize)
+	require.Equal(t, predicates.DefaultGasSchedule(), options.gas)
+}
+
+func TestWithGasSchedule(t *testing.T) {
+	gas := predicates.DefaultGasSchedule()
+	gas.WasmInstruction = 5
+	options := defaultOptions()
+	WithGasSchedule(gas)(options)
+	require.Same(t, gas, options.gas)
 }
 
 func TestOverrideWazeroCfg(t *testing.T) {
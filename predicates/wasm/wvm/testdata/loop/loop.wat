@@ -0,0 +1,19 @@
+(module
+  (type (;0;) (func (result i64)))
+  (func (;0;) (type 0) (result i64)
+    (local i32)
+    loop  ;; label = @1
+      local.get 0
+      i32.const 1
+      i32.add
+      local.tee 0
+      i32.const 10
+      i32.lt_u
+      br_if 0 (;@1;)
+    end
+    i64.const 0)
+  (memory (;0;) 1)
+  (global (;0;) i32 (i32.const 1024))
+  (export "memory" (memory 0))
+  (export "__heap_base" (global 0))
+  (export "ab_main" (func 0)))
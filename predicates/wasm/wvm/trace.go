@@ -0,0 +1,185 @@
+package wvm
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/experimental"
+
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/instrument"
+)
+
+type (
+	/*
+		GasTracer collects the gas usage of the functions and the list of host API calls
+		of the evaluated predicates. It is meant to be used for debugging predicates (the
+		listeners slow down the execution considerably) and is not safe for concurrent use.
+		The tracer must be registered using WithFunctionListener option.
+	*/
+	GasTracer struct {
+		stack     []traceFrame
+		functions map[string]*FunctionStats
+		hostCalls []HostCall
+		result    []uint64 // return values of the last top level call
+	}
+
+	// FunctionStats is the gas usage of the function collected by the GasTracer.
+	FunctionStats struct {
+		Name  string // "module.function"
+		Calls uint64
+		// gas used by the function, including the gas used by the functions it called
+		Gas uint64
+	}
+
+	// HostCall is the call of the host API function made by the predicate.
+	HostCall struct {
+		Name    string // "module.function"
+		Params  []uint64
+		Results []uint64
+		Gas     uint64 // gas charged by the host function
+		Err     error  // reason why the call was aborted
+	}
+
+	traceFrame struct {
+		name     string
+		gas      uint64 // gas counter value when the function was called
+		hostCall int    // index in the hostCalls, -1 when not a host function
+	}
+)
+
+func NewGasTracer() *GasTracer {
+	return &GasTracer{functions: make(map[string]*FunctionStats)}
+}
+
+// NewFunctionListener implements experimental.FunctionListenerFactory.
+func (gt *GasTracer) NewFunctionListener(def api.FunctionDefinition) experimental.FunctionListener {
+	return gt
+}
+
+func (gt *GasTracer) Before(ctx context.Context, mod api.Module, def api.FunctionDefinition, params []uint64, _ experimental.StackIterator) {
+	frame := traceFrame{name: functionName(def), gas: gasCounter(mod), hostCall: -1}
+	if def.GoFunction() != nil {
+		frame.hostCall = len(gt.hostCalls)
+		gt.hostCalls = append(gt.hostCalls, HostCall{Name: frame.name, Params: slices.Clone(params)})
+	}
+	gt.stack = append(gt.stack, frame)
+}
+
+func (gt *GasTracer) After(ctx context.Context, mod api.Module, def api.FunctionDefinition, results []uint64) {
+	if hc := gt.pop(mod); hc != nil {
+		hc.Results = slices.Clone(results)
+	}
+	if len(gt.stack) == 0 {
+		gt.result = slices.Clone(results)
+	}
+}
+
+func (gt *GasTracer) Abort(ctx context.Context, mod api.Module, def api.FunctionDefinition, err error) {
+	if hc := gt.pop(mod); hc != nil {
+		hc.Err = err
+	}
+	if len(gt.stack) == 0 {
+		gt.result = nil
+	}
+}
+
+/*
+pop removes the topmost frame from the call stack and updates the stats of the function.
+Returns pointer to the host call record when the frame was a host function call.
+*/
+func (gt *GasTracer) pop(mod api.Module) *HostCall {
+	if len(gt.stack) == 0 {
+		return nil
+	}
+	frame := gt.stack[len(gt.stack)-1]
+	gt.stack = gt.stack[:len(gt.stack)-1]
+
+	// when predicate runs out of gas the counter is set to MaxUint64,
+	// in that case the whole remaining budget was used
+	gasUsed := frame.gas
+	if after := gasCounter(mod); after <= frame.gas {
+		gasUsed = frame.gas - after
+	}
+
+	fs, ok := gt.functions[frame.name]
+	if !ok {
+		fs = &FunctionStats{Name: frame.name}
+		gt.functions[frame.name] = fs
+	}
+	fs.Calls++
+	fs.Gas += gasUsed
+
+	if frame.hostCall < 0 {
+		return nil
+	}
+	hc := &gt.hostCalls[frame.hostCall]
+	hc.Gas = gasUsed
+	return hc
+}
+
+/*
+Functions returns the gas usage stats of the called functions, sorted by
+the gas used (descending).
+*/
+func (gt *GasTracer) Functions() []FunctionStats {
+	fs := make([]FunctionStats, 0, len(gt.functions))
+	for _, v := range gt.functions {
+		fs = append(fs, *v)
+	}
+	slices.SortFunc(fs, func(a, b FunctionStats) int {
+		if c := cmp.Compare(b.Gas, a.Gas); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return fs
+}
+
+// HostCalls returns the host API calls in the order they were made.
+func (gt *GasTracer) HostCalls() []HostCall {
+	return slices.Clone(gt.hostCalls)
+}
+
+/*
+Result returns the return values of the last top level function call (ie the
+entrypoint of the predicate), nil when the call was aborted.
+*/
+func (gt *GasTracer) Result() []uint64 {
+	return gt.result
+}
+
+// Reset clears the collected data.
+func (gt *GasTracer) Reset() {
+	gt.stack = gt.stack[:0]
+	gt.hostCalls = nil
+	gt.result = nil
+	clear(gt.functions)
+}
+
+func functionName(def api.FunctionDefinition) string {
+	name := def.Name()
+	if names := def.ExportNames(); len(names) > 0 {
+		name = names[0]
+	}
+	if name == "" {
+		return def.DebugName()
+	}
+	if def.ModuleName() == "" {
+		return name
+	}
+	return def.ModuleName() + "." + name
+}
+
+// gasCounter returns the current value of the gas counter of the module, zero
+// when the module is not instrumented.
+func gasCounter(mod api.Module) uint64 {
+	if mod == nil {
+		return 0
+	}
+	if g := mod.ExportedGlobal(instrument.GasCounterName); g != nil {
+		return g.Get()
+	}
+	return 0
+}
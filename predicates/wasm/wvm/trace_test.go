@@ -0,0 +1,104 @@
+package wvm
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero/api"
+
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/instrument"
+)
+
+func Test_GasTracer(t *testing.T) {
+	ctx := context.Background()
+	newModule := func() (*mockApiMod, *mockGlobal) {
+		counter := &mockGlobal{value: 1000}
+		return &mockApiMod{
+			exportedGlobal: func(name string) api.Global {
+				require.Equal(t, instrument.GasCounterName, name)
+				return counter
+			},
+		}, counter
+	}
+	entrypoint := &mockFuncDef{name: "check"}
+	helper := &mockFuncDef{name: "helper"}
+	hostFn := &mockFuncDef{module: "ab", name: "verify_tx_proof", goFunc: true}
+
+	t.Run("nested calls", func(t *testing.T) {
+		mod, gas := newModule()
+		gt := NewGasTracer()
+		gt.Before(ctx, mod, entrypoint, nil, nil)
+		gas.value -= 10
+		gt.Before(ctx, mod, hostFn, []uint64{1, 2}, nil)
+		gas.value -= 100
+		gt.After(ctx, mod, hostFn, []uint64{0})
+		for range 2 {
+			gt.Before(ctx, mod, helper, nil, nil)
+			gas.value -= 5
+			gt.After(ctx, mod, helper, nil)
+		}
+		gt.After(ctx, mod, entrypoint, []uint64{0x0101})
+
+		require.Equal(t, []FunctionStats{
+			{Name: "check", Calls: 1, Gas: 120},
+			{Name: "ab.verify_tx_proof", Calls: 1, Gas: 100},
+			{Name: "helper", Calls: 2, Gas: 10},
+		}, gt.Functions())
+		require.Equal(t, []HostCall{
+			{Name: "ab.verify_tx_proof", Params: []uint64{1, 2}, Results: []uint64{0}, Gas: 100},
+		}, gt.HostCalls())
+		require.Equal(t, []uint64{0x0101}, gt.Result())
+
+		gt.Reset()
+		require.Empty(t, gt.Functions())
+		require.Empty(t, gt.HostCalls())
+		require.Nil(t, gt.Result())
+	})
+
+	t.Run("out of gas", func(t *testing.T) {
+		mod, gas := newModule()
+		gt := NewGasTracer()
+		expErr := errors.New("out of gas")
+		gt.Before(ctx, mod, entrypoint, nil, nil)
+		gt.Before(ctx, mod, hostFn, nil, nil)
+		gas.value = math.MaxUint64
+		gt.Abort(ctx, mod, hostFn, expErr)
+		gt.Abort(ctx, mod, entrypoint, expErr)
+
+		require.Equal(t, []FunctionStats{
+			{Name: "ab.verify_tx_proof", Calls: 1, Gas: 1000},
+			{Name: "check", Calls: 1, Gas: 1000},
+		}, gt.Functions())
+		require.Equal(t, []HostCall{{Name: "ab.verify_tx_proof", Gas: 1000, Err: expErr}}, gt.HostCalls())
+		require.Nil(t, gt.Result())
+	})
+
+	t.Run("unbalanced After", func(t *testing.T) {
+		mod, _ := newModule()
+		gt := NewGasTracer()
+		gt.After(ctx, mod, entrypoint, nil)
+		require.Empty(t, gt.Functions())
+	})
+}
+
+type mockFuncDef struct {
+	module string
+	name   string
+	goFunc bool
+	// to "implement" everything we haven't mocked
+	api.FunctionDefinition
+}
+
+func (fd *mockFuncDef) ModuleName() string    { return fd.module }
+func (fd *mockFuncDef) Name() string          { return fd.name }
+func (fd *mockFuncDef) ExportNames() []string { return nil }
+func (fd *mockFuncDef) DebugName() string     { return fd.module + "." + fd.name }
+func (fd *mockFuncDef) GoFunction() any {
+	if fd.goFunc {
+		return api.GoModuleFunc(nil)
+	}
+	return nil
+}
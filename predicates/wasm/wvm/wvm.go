@@ -2,6 +2,7 @@ package wvm
 
 import (
 	"context"
+	"crypto/sha256"
 	_ "embed"
 	"errors"
 	"fmt"
@@ -10,10 +11,11 @@ import (
 
 	"github.com/tetratelabs/wazero"
 	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/experimental"
+	"go.opentelemetry.io/otel/metric"
 
 	"github.com/alphabill-org/alphabill-go-base/predicates/wasm"
 	"github.com/alphabill-org/alphabill-go-base/types"
-	"github.com/alphabill-org/alphabill/keyvaluedb"
 	"github.com/alphabill-org/alphabill/logger"
 	"github.com/alphabill-org/alphabill/predicates"
 	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/bumpallocator"
@@ -47,22 +49,36 @@ type (
 
 	vmContext struct {
 		memMngr allocator
-		storage keyvaluedb.KeyValueDB
 		curPrg  *evalContext
 		encoder Encoder
 		factory ABTypesFactory
 		engines predicates.PredicateExecutor
+		gas     *predicates.GasSchedule
 		log     *slog.Logger
+		// hashes of the known partition description records
+		pdrHashes map[types.SystemID][]byte
 	}
 
 	WasmVM struct {
 		runtime wazero.Runtime
 		ctx     *vmContext
+		// compiled predicate modules, nil when the cache is disabled
+		modules *moduleCache
+		// on-disk compilation cache, nil when not enabled
+		compilationCache wazero.CompilationCache
+		// function listener, nil when not enabled
+		listener experimental.FunctionListenerFactory
+		// gas schedule used when the environment doesn't provide the schedule of the current round
+		gas *predicates.GasSchedule
+
+		cacheHits   metric.Int64Counter
+		cacheMisses metric.Int64Counter
 	}
 
 	// "evaluation context" of current program
 	evalContext struct {
 		mod    api.Module // created from the WASM of the predicate
+		hash   []byte     // hash of the predicate code, namespace of the predicate storage
 		vars   map[uint64]any
 		varIdx uint64          // "handle generator" for vars
 		env    EvalEnvironment // callback to the tx system
@@ -87,7 +103,7 @@ type (
 
 	Observability interface {
 		//Tracer(name string, options ...trace.TracerOption) trace.Tracer
-		//Meter(name string, opts ...metric.MeterOption) metric.Meter
+		Meter(name string, opts ...metric.MeterOption) metric.Meter
 		Logger() *slog.Logger
 	}
 )
@@ -101,6 +117,25 @@ func (ec *evalContext) addVar(obj any) uint64 {
 	return ec.varIdx
 }
 
+/*
+bindCurrentTx binds the transaction order which triggered the predicate to the
+handle_current_tx_order handle. The handle stays unassigned when the environment
+doesn't have the tx order (ie predicate is evaluated outside of tx processing).
+*/
+func (ec *evalContext) bindCurrentTx() error {
+	txo, err := ec.env.TransactionOrder()
+	if err != nil {
+		if errors.Is(err, types.ErrTransactionOrderIsNil) {
+			return nil
+		}
+		return fmt.Errorf("reading current transaction order: %w", err)
+	}
+	if txo != nil {
+		ec.vars[handle_current_tx_order] = txo
+	}
+	return nil
+}
+
 func getVar[T any](vars map[uint64]any, handle uint64) (T, error) {
 	var e T
 	v, ok := vars[handle]
@@ -140,6 +175,7 @@ the context.
 */
 func (vmc *vmContext) reset() {
 	vmc.curPrg.mod = nil
+	vmc.curPrg.hash = nil
 	vmc.curPrg.env = nil
 	clear(vmc.curPrg.vars)
 }
@@ -172,7 +208,23 @@ func New(ctx context.Context, enc Encoder, engines predicates.PredicateExecutor,
 	for _, opt := range opts {
 		opt(options)
 	}
+	if err := options.gas.IsValid(); err != nil {
+		return nil, fmt.Errorf("invalid gas schedule: %w", err)
+	}
+
+	var compilationCache wazero.CompilationCache
+	if options.compilationCacheDir != "" {
+		var err error
+		if compilationCache, err = wazero.NewCompilationCacheWithDir(options.compilationCacheDir); err != nil {
+			return nil, fmt.Errorf("creating compilation cache: %w", err)
+		}
+		options.cfg = options.cfg.WithCompilationCache(compilationCache)
+	}
 
+	if options.listener != nil {
+		// listeners are attached to the functions when module is compiled
+		ctx = experimental.WithFunctionListenerFactory(ctx, options.listener)
+	}
 	rt := wazero.NewRuntimeWithConfig(ctx, options.cfg)
 	// WASM shared memory env
 	if _, err := rt.Instantiate(ctx, envWasm); err != nil {
@@ -193,19 +245,84 @@ func New(ctx context.Context, enc Encoder, engines predicates.PredicateExecutor,
 		return nil, fmt.Errorf("adding alphabill API module: %w", err)
 	}
 
-	return &WasmVM{
-		runtime: rt,
+	vm := &WasmVM{
+		runtime:          rt,
+		compilationCache: compilationCache,
+		listener:         options.listener,
+		gas:              options.gas,
 		ctx: &vmContext{
 			curPrg: &evalContext{
 				vars: map[uint64]any{},
 			},
-			encoder: enc,
-			engines: engines,
-			factory: ABTypesFactory{},
-			storage: options.storage,
-			log:     observe.Logger(),
+			encoder:   enc,
+			engines:   engines,
+			gas:       options.gas,
+			factory:   ABTypesFactory{},
+			log:       observe.Logger(),
+			pdrHashes: options.pdrHashes,
 		},
-	}, nil
+	}
+	if options.moduleCacheSize > 0 {
+		vm.modules = newModuleCache(options.moduleCacheSize)
+	}
+	if err := vm.initMetrics(observe.Meter("wvm")); err != nil {
+		return nil, errors.Join(fmt.Errorf("initializing metrics: %w", err), vm.Close(ctx))
+	}
+	return vm, nil
+}
+
+func (vm *WasmVM) initMetrics(m metric.Meter) (err error) {
+	vm.cacheHits, err = m.Int64Counter("module.cache.hit", metric.WithDescription("Number of predicate evaluations which used cached compiled module"))
+	if err != nil {
+		return fmt.Errorf("creating counter for module cache hits: %w", err)
+	}
+	vm.cacheMisses, err = m.Int64Counter("module.cache.miss", metric.WithDescription("Number of predicate evaluations which had to compile the predicate module"))
+	if err != nil {
+		return fmt.Errorf("creating counter for module cache misses: %w", err)
+	}
+	return nil
+}
+
+/*
+compile returns instrumented and compiled module of the predicate. Returned release
+func must be called when the module is not needed anymore.
+
+Instrumentation is deterministic (depends only on the predicate code) and gas is
+set per module instance so gas accounting is the same whether the module was found
+in the cache or not.
+*/
+func (vm *WasmVM) compile(ctx context.Context, predicate []byte, key [sha256.Size]byte) (_ wazero.CompiledModule, release func(), err error) {
+	if vm.modules != nil {
+		if compiled, ok := vm.modules.get(key); ok {
+			vm.cacheHits.Add(ctx, 1)
+			return compiled, func() {}, nil
+		}
+	}
+	vm.cacheMisses.Add(ctx, 1)
+
+	// Generally we expect long running / buggy predicates to be terminated because
+	// of running out of gas; we do set the stack height limit as last resort
+	// safety measure / to keep things deterministic...
+	// Hardcoded to 64K for now, judged to be enough until requirements are refined.
+	instrPredicate, err := instrument.MeterGasAndStack(predicate, 1<<16)
+	if err != nil {
+		return nil, nil, fmt.Errorf("instrumenting predicate error: %w", err)
+	}
+	compileCtx := ctx
+	if vm.listener != nil {
+		compileCtx = experimental.WithFunctionListenerFactory(ctx, vm.listener)
+	}
+	compiled, err := vm.runtime.CompileModule(compileCtx, instrPredicate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to compile predicate code: %w", err)
+	}
+	if vm.modules == nil {
+		return compiled, func() { _ = compiled.Close(ctx) }, nil
+	}
+	if compiled, err = vm.modules.add(ctx, key, compiled); err != nil {
+		vm.ctx.log.WarnContext(ctx, "closing evicted predicate modules", logger.Error(err))
+	}
+	return compiled, func() {}, nil
 }
 
 /*
@@ -217,15 +334,13 @@ func (vm *WasmVM) Exec(ctx context.Context, predicate, args []byte, conf wasm.Pr
 	if len(predicate) < 1 {
 		return 0, fmt.Errorf("predicate is nil")
 	}
-	// Generally we expect long running / buggy predicates to be terminated because
-	// of running out of gas; we do set the stack height limit as last resort
-	// safety measure / to keep things deterministic...
-	// Hardcoded to 64K for now, judged to be enough until requirements are refined.
-	instrPredicate, err := instrument.MeterGasAndStack(predicate, 1<<16)
+	predicateHash := moduleCacheKey(predicate)
+	compiled, release, err := vm.compile(ctx, predicate, predicateHash)
 	if err != nil {
-		return 0, fmt.Errorf("instrumenting predicate error: %w", err)
+		return 0, err
 	}
-	m, err := vm.runtime.Instantiate(ctx, instrPredicate)
+	defer release()
+	m, err := vm.runtime.InstantiateModule(ctx, compiled, wazero.NewModuleConfig())
 	if err != nil {
 		return 0, fmt.Errorf("failed to instantiate predicate code: %w", err)
 	}
@@ -239,17 +354,24 @@ func (vm *WasmVM) Exec(ctx context.Context, predicate, args []byte, conf wasm.Pr
 	if !ok {
 		return 0, fmt.Errorf("instrumentation failed, gas counter not found")
 	}
-	initialGas := env.GasAvailable()
+	// charge by the gas schedule of the current round when the tx system provides it
+	vm.ctx.gas = predicates.ActiveGasSchedule(env, vm.gas)
+	// the gas counter of the module is in instrumenter's units, the host API
+	// functions convert their cost into these units too
+	initialGas := env.GasAvailable() / vm.ctx.gas.WasmInstruction
 	gas.Set(initialGas)
 
 	defer vm.ctx.reset()
 	vm.ctx.memMngr = bumpallocator.New(api.DecodeU32(heapBase.Get()), m.Memory().Definition())
 	vm.ctx.curPrg.mod = m
+	vm.ctx.curPrg.hash = predicateHash[:]
 	vm.ctx.curPrg.env = env
 	vm.ctx.curPrg.varIdx = handle_max_reserved
-	//vm.ctx.curPrg.vars[handle_current_tx_order] = txo // TODO AB-1724
 	vm.ctx.curPrg.vars[handle_current_args] = args
 	vm.ctx.curPrg.vars[handle_predicate_conf] = conf.Args
+	if err := vm.ctx.curPrg.bindCurrentTx(); err != nil {
+		return 0, err
+	}
 
 	fn := m.ExportedFunction(conf.Entrypoint)
 	if fn == nil {
@@ -266,7 +388,7 @@ func (vm *WasmVM) Exec(ctx context.Context, predicate, args []byte, conf wasm.Pr
 	}
 	// spend gas according to how much was used. the execution of the predicate might have
 	// failed but we take the fee (gas) anyway!
-	if err := env.SpendGas(initialGas - gasRemaining); err != nil {
+	if err := env.SpendGas((initialGas - gasRemaining) * vm.ctx.gas.WasmInstruction); err != nil {
 		return 0, errors.Join(evalErr, fmt.Errorf("calculating gas usage: %w", err))
 	}
 	if evalErr != nil {
@@ -281,7 +403,15 @@ func (vm *WasmVM) Exec(ctx context.Context, predicate, args []byte, conf wasm.Pr
 }
 
 func (vm *WasmVM) Close(ctx context.Context) error {
-	return vm.runtime.Close(ctx)
+	var err error
+	if vm.modules != nil {
+		err = vm.modules.close(ctx)
+	}
+	err = errors.Join(err, vm.runtime.Close(ctx))
+	if vm.compilationCache != nil {
+		err = errors.Join(err, vm.compilationCache.Close(ctx))
+	}
+	return err
 }
 
 func extractVMContext(ctx context.Context) *vmContext {
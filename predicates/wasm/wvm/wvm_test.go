@@ -3,8 +3,10 @@ package wvm
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"math"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -13,13 +15,14 @@ import (
 	"github.com/tetratelabs/wazero/api"
 
 	"github.com/alphabill-org/alphabill-go-base/predicates/wasm"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
 	"github.com/alphabill-org/alphabill-go-base/types"
 	"github.com/alphabill-org/alphabill/internal/testutils/observability"
-	"github.com/alphabill-org/alphabill/keyvaluedb/memorydb"
 	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/bumpallocator"
 	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/encoder"
 	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/instrument"
 	"github.com/alphabill-org/alphabill/state"
+	tokenenc "github.com/alphabill-org/alphabill/txsystem/tokens/encoder"
 )
 
 //go:embed testdata/add_one/target/wasm32-unknown-unknown/release/add_one.wasm
@@ -32,11 +35,7 @@ func TestNew(t *testing.T) {
 	ctx := context.Background()
 	obs := observability.Default(t)
 
-	memDB, err := memorydb.New()
-	require.NoError(t, err)
-
-	require.NoError(t, err)
-	wvm, err := New(ctx, encoder.TXSystemEncoder{}, nil, obs, WithStorage(memDB))
+	wvm, err := New(ctx, encoder.TXSystemEncoder{}, nil, obs)
 	require.NoError(t, err)
 	require.NotNil(t, wvm)
 
@@ -63,6 +62,68 @@ func TestReadHeapBase(t *testing.T) {
 //go:embed testdata/stack_height.wasm
 var stackHeightWasm []byte
 
+//go:embed testdata/loop/loop.wasm
+var loopWasm []byte
+
+func TestExec_ModuleCache(t *testing.T) {
+	conf := wasm.PredicateParams{Entrypoint: "ab_main"}
+	exec := func(t *testing.T, vm *WasmVM) uint64 {
+		env := &mockTxContext{GasRemaining: 10000}
+		res, err := vm.Exec(context.Background(), loopWasm, nil, conf, nil, env)
+		require.NoError(t, err)
+		require.EqualValues(t, 0, res)
+		return 10000 - env.GasRemaining
+	}
+
+	t.Run("cache enabled", func(t *testing.T) {
+		vm, err := New(context.Background(), encoder.TXSystemEncoder{}, nil, observability.Default(t), WithModuleCacheSize(1))
+		require.NoError(t, err)
+		defer vm.Close(context.Background())
+
+		// gas spent must not depend on whether the module was cached
+		gasMiss := exec(t, vm)
+		require.NotZero(t, gasMiss)
+		require.Equal(t, 1, vm.modules.len())
+		require.Equal(t, gasMiss, exec(t, vm))
+		require.Equal(t, 1, vm.modules.len())
+
+		// other predicate evicts the cached module
+		env := &mockTxContext{GasRemaining: 10000}
+		_, err = vm.Exec(context.Background(), ticketsWasm, nil, wasm.PredicateParams{Entrypoint: "bearer_invariant"}, nil, env)
+		require.Error(t, err)
+		require.Equal(t, 1, vm.modules.len())
+		_, ok := vm.modules.get(moduleCacheKey(loopWasm))
+		require.False(t, ok)
+		require.Equal(t, gasMiss, exec(t, vm))
+	})
+
+	t.Run("cache disabled", func(t *testing.T) {
+		vm, err := New(context.Background(), encoder.TXSystemEncoder{}, nil, observability.Default(t), WithModuleCacheSize(0))
+		require.NoError(t, err)
+		defer vm.Close(context.Background())
+		require.Nil(t, vm.modules)
+		gas := exec(t, vm)
+		require.Equal(t, gas, exec(t, vm))
+	})
+
+	t.Run("compilation cache dir", func(t *testing.T) {
+		dir := t.TempDir()
+		vm, err := New(context.Background(), encoder.TXSystemEncoder{}, nil, observability.Default(t), WithCompilationCacheDir(dir))
+		require.NoError(t, err)
+		gas := exec(t, vm)
+		require.NoError(t, vm.Close(context.Background()))
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.NotEmpty(t, entries)
+
+		// new VM (ie after restart) uses the same on-disk cache
+		vm, err = New(context.Background(), encoder.TXSystemEncoder{}, nil, observability.Default(t), WithCompilationCacheDir(dir))
+		require.NoError(t, err)
+		defer vm.Close(context.Background())
+		require.Equal(t, gas, exec(t, vm))
+	})
+}
+
 func Test_instrumentation_trigger(t *testing.T) {
 	// if this test fails it means that either the way how instrumentation lib
 	// calculates stack height or gas cost has changed or the way Wazero
@@ -175,13 +236,70 @@ func Benchmark_wazero_call_wasm_fn(b *testing.B) {
 	}
 }
 
+func Test_bindCurrentTx(t *testing.T) {
+	txo := &types.TransactionOrder{
+		Payload: types.Payload{
+			SystemID: tokens.DefaultSystemID,
+			Type:     tokens.TransactionTypeTransferNFT,
+			UnitID:   tokens.NewNonFungibleTokenID(nil, []byte{1, 2, 3}),
+			ClientMetadata: &types.ClientMetadata{
+				Timeout:           20,
+				MaxTransactionFee: 5,
+			},
+		},
+	}
+	require.NoError(t, txo.SetAttributes(tokens.TransferNonFungibleTokenAttributes{NewOwnerPredicate: []byte{5, 5, 5}, Counter: 2}))
+
+	t.Run("tx order is bound", func(t *testing.T) {
+		vm, mod, _ := newTestModule(t, &mockTxContext{txo: txo}, 10000)
+		require.NoError(t, vm.curPrg.bindCurrentTx())
+		v, err := getVar[*types.TransactionOrder](vm.curPrg.vars, handle_current_tx_order)
+		require.NoError(t, err)
+		require.Same(t, txo, v)
+
+		// tx order can be serialized
+		vm.encoder = encoder.TXSystemEncoder{}
+		stack := []uint64{handle_current_tx_order, 2}
+		require.NoError(t, expSerialize(vm, mod, stack))
+		expected, err := vm.encoder.Encode(txo, 2, nil)
+		require.NoError(t, err)
+		require.Equal(t, expected, read(mod, stack[0]))
+
+		// and it's attributes read
+		enc := encoder.TXSystemEncoder{}
+		require.NoError(t, tokenenc.RegisterTxAttributeEncoders(enc.RegisterAttrEncoder))
+		vm.encoder = enc
+		stack = []uint64{handle_current_tx_order, 1}
+		require.NoError(t, expTxAttributes(vm, mod, stack))
+		expected, err = enc.TxAttributes(txo, 1)
+		require.NoError(t, err)
+		require.Equal(t, expected, read(mod, stack[0]))
+	})
+
+	t.Run("tx order not available", func(t *testing.T) {
+		vm, _, _ := newTestModule(t, &mockTxContext{txoErr: types.ErrTransactionOrderIsNil}, 10000)
+		require.NoError(t, vm.curPrg.bindCurrentTx())
+		require.NotContains(t, vm.curPrg.vars, uint64(handle_current_tx_order))
+
+		vm, _, _ = newTestModule(t, &mockTxContext{}, 10000)
+		require.NoError(t, vm.curPrg.bindCurrentTx())
+		require.NotContains(t, vm.curPrg.vars, uint64(handle_current_tx_order))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		vm, _, _ := newTestModule(t, &mockTxContext{txoErr: errors.New("boom")}, 10000)
+		require.EqualError(t, vm.curPrg.bindCurrentTx(), `reading current transaction order: boom`)
+	})
+}
+
 type mockTxContext struct {
 	getUnit      func(id types.UnitID, committed bool) (*state.Unit, error)
 	curRound     func() uint64
-	trustBase    func() (types.RootTrustBase, error)
+	trustBase    func(epoch uint64) (types.RootTrustBase, error)
 	GasRemaining uint64
 	calcCost     func() uint64
 	txo          *types.TransactionOrder
+	txoErr       error
 }
 
 func (env *mockTxContext) GetUnit(id types.UnitID, committed bool) (*state.Unit, error) {
@@ -190,7 +308,7 @@ func (env *mockTxContext) GetUnit(id types.UnitID, committed bool) (*state.Unit,
 
 func (env *mockTxContext) CurrentRound() uint64 { return env.curRound() }
 func (env *mockTxContext) TrustBase(epoch uint64) (types.RootTrustBase, error) {
-	return env.trustBase()
+	return env.trustBase(epoch)
 }
 
 func (env *mockTxContext) GasAvailable() uint64 {
@@ -208,7 +326,9 @@ func (env *mockTxContext) SpendGas(gas uint64) error {
 
 func (env *mockTxContext) CalculateCost() uint64 { return env.calcCost() }
 
-func (env *mockTxContext) TransactionOrder() (*types.TransactionOrder, error) { return env.txo, nil }
+func (env *mockTxContext) TransactionOrder() (*types.TransactionOrder, error) {
+	return env.txo, env.txoErr
+}
 
 type mockRootTrustBase struct {
 	verifyQuorumSignatures func(data []byte, signatures map[string][]byte) (error, []error)
@@ -0,0 +1,11 @@
+package storage
+
+import (
+	"github.com/alphabill-org/alphabill/keyvaluedb"
+)
+
+// Migrations are the schema migrations of the root chain consensus DB, ordered by version. Version 1 is the
+// schema used before the schema version was recorded.
+var Migrations = []keyvaluedb.Migration{
+	{Version: 1, Description: "initial schema", Migrate: func(keyvaluedb.KeyValueDB) error { return nil }},
+}
@@ -1,15 +1,24 @@
 package rpc
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/alphabill-org/alphabill-go-base/types"
 	"github.com/alphabill-org/alphabill/logger"
+	"github.com/alphabill-org/alphabill/state"
 	"github.com/gorilla/mux"
 )
 
+// snapshotV2Serializer is implemented by the committed state snapshots.
+type snapshotV2Serializer interface {
+	SerializeV2(writer io.Writer, opts ...state.SerializeOption) error
+}
+
 func NodeEndpoints(node partitionNode, obs Observability) RegistrarFunc {
 	return func(r *mux.Router) {
 		log := obs.Logger()
@@ -19,20 +28,81 @@ func NodeEndpoints(node partitionNode, obs Observability) RegistrarFunc {
 	}
 }
 
+// getState writes the committed state of the node. By default the latest committed state is written, query parameter
+// "round=N" selects the state committed in round N (from the snapshots retained by the node, 410 Gone is returned when
+// the snapshot is no longer available). By default the state is written in v1 format, query parameter "version=2"
+// selects the chunked v2 format, optionally with "compression=gzip" and "firstChunk=N" (to resume an interrupted
+// download, must be combined with the "round" of the interrupted download).
 func getState(node partitionNode, log *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, request *http.Request) {
-		if err := node.TransactionSystemState().Serialize(w, true); err != nil {
-			w.Header().Set("Content-Type", "application/cbor")
-			w.WriteHeader(http.StatusInternalServerError)
-			if err := types.Cbor.Encode(w, struct {
-				_   struct{} `cbor:",toarray"`
-				Err string
-			}{
-				Err: fmt.Sprintf("%v", err),
-			}); err != nil {
-				log.Warn("failed to write CBOR error response", logger.Error(err))
+		query := request.URL.Query()
+		stateReader := node.TransactionSystemState()
+		if round := query.Get("round"); round != "" {
+			roundNumber, err := strconv.ParseUint(round, 10, 64)
+			if err != nil {
+				writeStateError(w, http.StatusBadRequest, fmt.Errorf("invalid round: %w", err), log)
+				return
+			}
+			if stateReader, err = node.TransactionSystemStateAt(roundNumber); err != nil {
+				status := http.StatusInternalServerError
+				if errors.Is(err, state.ErrSnapshotNotFound) {
+					status = http.StatusGone
+				}
+				writeStateError(w, status, err, log)
+				return
+			}
+		}
+		if query.Get("version") != "2" {
+			if err := stateReader.Serialize(w, true); err != nil {
+				writeStateError(w, http.StatusInternalServerError, err, log)
 			}
 			return
 		}
+
+		opts, err := parseSerializeOptions(query.Get("compression"), query.Get("firstChunk"))
+		if err != nil {
+			writeStateError(w, http.StatusBadRequest, err, log)
+			return
+		}
+		snapshot, ok := stateReader.(snapshotV2Serializer)
+		if !ok {
+			writeStateError(w, http.StatusBadRequest, errors.New("state snapshot v2 is not supported"), log)
+			return
+		}
+		if err := snapshot.SerializeV2(w, opts...); err != nil {
+			writeStateError(w, http.StatusInternalServerError, err, log)
+		}
+	}
+}
+
+func parseSerializeOptions(compression, firstChunk string) ([]state.SerializeOption, error) {
+	var opts []state.SerializeOption
+	switch compression {
+	case "", state.CompressionNone.String():
+	case state.CompressionGzip.String():
+		opts = append(opts, state.WithCompression(state.CompressionGzip))
+	default:
+		return nil, fmt.Errorf("unsupported compression: %q", compression)
+	}
+	if firstChunk != "" {
+		index, err := strconv.ParseUint(firstChunk, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid first chunk: %w", err)
+		}
+		opts = append(opts, state.WithFirstChunk(index))
+	}
+	return opts, nil
+}
+
+func writeStateError(w http.ResponseWriter, status int, err error, log *slog.Logger) {
+	w.Header().Set("Content-Type", "application/cbor")
+	w.WriteHeader(status)
+	if err := types.Cbor.Encode(w, struct {
+		_   struct{} `cbor:",toarray"`
+		Err string
+	}{
+		Err: fmt.Sprintf("%v", err),
+	}); err != nil {
+		log.Warn("failed to write CBOR error response", logger.Error(err))
 	}
 }
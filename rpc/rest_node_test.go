@@ -9,6 +9,7 @@ import (
 
 	"github.com/alphabill-org/alphabill/internal/testutils/observability"
 	testtxsystem "github.com/alphabill-org/alphabill/internal/testutils/txsystem"
+	"github.com/alphabill-org/alphabill/state"
 	"github.com/stretchr/testify/require"
 )
 
@@ -32,3 +33,82 @@ func TestRESTServer_GetState_Error(t *testing.T) {
 	require.Equal(t, http.StatusInternalServerError, recorder.Result().StatusCode)
 	require.Contains(t, recorder.Body.String(), "state error")
 }
+
+func TestRESTServer_GetStateV2(t *testing.T) {
+	s := prepareState(t)
+	snapshot := s.LatestSnapshot()
+	require.NotNil(t, snapshot)
+	node := &MockNode{txs: &testtxsystem.CounterTxSystem{FixedState: snapshot}}
+	obs := observability.Default(t)
+	handler := NewRESTServer("", 10, obs, NodeEndpoints(node, obs)).Handler
+
+	t.Run("ok", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/state?version=2&compression=gzip", bytes.NewReader([]byte{}))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+		expected := &bytes.Buffer{}
+		require.NoError(t, snapshot.SerializeV2(expected, state.WithCompression(state.CompressionGzip)))
+		require.Equal(t, expected.Bytes(), recorder.Body.Bytes())
+	})
+	t.Run("first chunk", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/state?version=2&firstChunk=1", bytes.NewReader([]byte{}))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+		expected := &bytes.Buffer{}
+		require.NoError(t, snapshot.SerializeV2(expected, state.WithFirstChunk(1)))
+		require.Equal(t, expected.Bytes(), recorder.Body.Bytes())
+	})
+	t.Run("invalid compression", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/state?version=2&compression=zip", bytes.NewReader([]byte{}))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+		require.Contains(t, recorder.Body.String(), "unsupported compression")
+	})
+	t.Run("v2 not supported", func(t *testing.T) {
+		node := &MockNode{txs: &testtxsystem.CounterTxSystem{}}
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/state?version=2", bytes.NewReader([]byte{}))
+		recorder := httptest.NewRecorder()
+		NewRESTServer("", 10, obs, NodeEndpoints(node, obs)).Handler.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+		require.Contains(t, recorder.Body.String(), "state snapshot v2 is not supported")
+	})
+}
+
+func TestRESTServer_GetState_Round(t *testing.T) {
+	s := prepareState(t)
+	snapshot, err := s.Snapshot(1)
+	require.NoError(t, err)
+	node := &MockNode{txs: &testtxsystem.CounterTxSystem{FixedState: s}}
+	obs := observability.Default(t)
+	handler := NewRESTServer("", 10, obs, NodeEndpoints(node, obs)).Handler
+
+	t.Run("resume download of the round", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/state?version=2&round=1&firstChunk=1", bytes.NewReader([]byte{}))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+		expected := &bytes.Buffer{}
+		require.NoError(t, snapshot.SerializeV2(expected, state.WithFirstChunk(1)))
+		require.Equal(t, expected.Bytes(), recorder.Body.Bytes())
+	})
+	t.Run("snapshot is not available", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/state?version=2&round=2&firstChunk=1", bytes.NewReader([]byte{}))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusGone, recorder.Result().StatusCode)
+		require.Contains(t, recorder.Body.String(), "round 2: state snapshot not found")
+	})
+	t.Run("invalid round", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/state?round=last", bytes.NewReader([]byte{}))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+		require.Contains(t, recorder.Body.String(), "invalid round")
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/alphabill-org/alphabill-go-base/types"
 	"github.com/alphabill-org/alphabill/partition"
+	"github.com/alphabill-org/alphabill/state"
 	"github.com/alphabill-org/alphabill/tree/avl"
 	"github.com/alphabill-org/alphabill/txsystem"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -27,6 +28,7 @@ type (
 		GetTransactionRecordProof(ctx context.Context, hash []byte) (*types.TxRecordProof, error)
 		GetLatestRoundNumber(ctx context.Context) (uint64, error)
 		TransactionSystemState() txsystem.StateReader
+		TransactionSystemStateAt(roundNumber uint64) (txsystem.StateReader, error)
 		ValidatorNodes() peer.IDSlice
 		GetTrustBase(epochNumber uint64) (types.RootTrustBase, error)
 		IsPermissionedMode() bool
@@ -41,6 +43,16 @@ type (
 		StateProof *types.UnitStateProof `json:"stateProof,omitempty"`
 	}
 
+	StateDiff struct {
+		NetworkID types.NetworkID `json:"networkId"`
+		SystemID  types.SystemID  `json:"systemId"`
+		FromRound types.Uint64    `json:"fromRound"`
+		ToRound   types.Uint64    `json:"toRound"`
+		Added     []*Unit[any]    `json:"added"`
+		Updated   []*Unit[any]    `json:"updated"`
+		Deleted   []types.UnitID  `json:"deleted"`
+	}
+
 	TransactionRecordAndProof struct {
 		TxRecordProof types.Bytes `json:"txRecordProof"` // hex encoded CBOR of types.TxRecordProof
 	}
@@ -61,32 +73,49 @@ func (s *StateAPI) GetRoundNumber(ctx context.Context) (types.Uint64, error) {
 
 // GetUnit returns unit data and optionally the state proof for the given unitID.
 func (s *StateAPI) GetUnit(unitID types.UnitID, includeStateProof bool) (*Unit[any], error) {
-	state := s.node.TransactionSystemState()
+	return s.getUnit(s.node.TransactionSystemState(), unitID, includeStateProof)
+}
 
-	unit, err := state.GetUnit(unitID, true)
+// GetUnitAtRound returns unit data and optionally the state proof for the given unitID from the state committed in
+// the given round. Only a limited number of recent rounds are available.
+func (s *StateAPI) GetUnitAtRound(unitID types.UnitID, roundNumber types.Uint64, includeStateProof bool) (*Unit[any], error) {
+	state, err := s.node.TransactionSystemStateAt(uint64(roundNumber))
 	if err != nil {
-		if errors.Is(err, avl.ErrNotFound) {
-			return nil, nil
-		}
-		return nil, err
+		return nil, fmt.Errorf("failed to load state: %w", err)
 	}
+	return s.getUnit(state, unitID, includeStateProof)
+}
 
-	resp := &Unit[any]{
-		NetworkID:  s.node.NetworkID(),
-		SystemID:   s.node.SystemID(),
-		UnitID:     unitID,
-		Data:       unit.Data(),
-		StateProof: nil,
+// GetStateDiff returns the units added, updated and deleted between the states committed in the given rounds. Only a
+// limited number of recent rounds are available.
+func (s *StateAPI) GetStateDiff(fromRound, toRound types.Uint64) (*StateDiff, error) {
+	from, err := s.stateSnapshot(uint64(fromRound))
+	if err != nil {
+		return nil, err
 	}
-
-	if includeStateProof {
-		stateProof, err := state.CreateUnitStateProof(unitID, unit.LastLogIndex())
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate unit state proof: %w", err)
-		}
-		resp.StateProof = stateProof
+	to, err := s.stateSnapshot(uint64(toRound))
+	if err != nil {
+		return nil, err
+	}
+	diff, err := state.Diff(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate state diff: %w", err)
+	}
+	resp := &StateDiff{
+		NetworkID: s.node.NetworkID(),
+		SystemID:  s.node.SystemID(),
+		FromRound: fromRound,
+		ToRound:   toRound,
+		Added:     make([]*Unit[any], len(diff.Added)),
+		Updated:   make([]*Unit[any], len(diff.Updated)),
+		Deleted:   diff.Deleted,
+	}
+	for i, u := range diff.Added {
+		resp.Added[i] = s.newUnit(u.UnitID, u.Data)
+	}
+	for i, u := range diff.Updated {
+		resp.Updated[i] = s.newUnit(u.UnitID, u.Data)
 	}
-
 	return resp, nil
 }
 
@@ -157,3 +186,46 @@ func (s *StateAPI) GetTrustBase(epochNumber types.Uint64) (types.RootTrustBase,
 	}
 	return trustBase, nil
 }
+
+func (s *StateAPI) getUnit(state txsystem.StateReader, unitID types.UnitID, includeStateProof bool) (*Unit[any], error) {
+	unit, err := state.GetUnit(unitID, true)
+	if err != nil {
+		if errors.Is(err, avl.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	resp := s.newUnit(unitID, unit.Data())
+
+	if includeStateProof {
+		stateProof, err := state.CreateUnitStateProof(unitID, unit.LastLogIndex())
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate unit state proof: %w", err)
+		}
+		resp.StateProof = stateProof
+	}
+
+	return resp, nil
+}
+
+func (s *StateAPI) newUnit(unitID types.UnitID, data types.UnitData) *Unit[any] {
+	return &Unit[any]{
+		NetworkID: s.node.NetworkID(),
+		SystemID:  s.node.SystemID(),
+		UnitID:    unitID,
+		Data:      data,
+	}
+}
+
+func (s *StateAPI) stateSnapshot(roundNumber uint64) (*state.Snapshot, error) {
+	sr, err := s.node.TransactionSystemStateAt(roundNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state: %w", err)
+	}
+	snapshot, ok := sr.(*state.Snapshot)
+	if !ok {
+		return nil, fmt.Errorf("state of round %d does not support diff", roundNumber)
+	}
+	return snapshot, nil
+}
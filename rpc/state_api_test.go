@@ -89,6 +89,73 @@ func TestGetUnit(t *testing.T) {
 	})
 }
 
+func TestGetUnitAtRound(t *testing.T) {
+	node := &MockNode{
+		txs: &testtxsystem.CounterTxSystem{
+			FixedState: prepareState(t),
+		},
+	}
+	api := NewStateAPI(node, nil)
+
+	t.Run("get unit (proof=true)", func(t *testing.T) {
+		unit, err := api.GetUnitAtRound(unitID, 1, true)
+		require.NoError(t, err)
+		require.NotNil(t, unit)
+		require.NotNil(t, unit.Data)
+		require.NotNil(t, unit.StateProof)
+		require.EqualValues(t, unitID, unit.StateProof.UnitID)
+	})
+	t.Run("unit not found", func(t *testing.T) {
+		unit, err := api.GetUnitAtRound([]byte{1, 2, 3}, 1, false)
+		require.NoError(t, err)
+		require.Nil(t, unit)
+	})
+	t.Run("round not found", func(t *testing.T) {
+		unit, err := api.GetUnitAtRound(unitID, 2, false)
+		require.ErrorIs(t, err, state.ErrSnapshotNotFound)
+		require.Nil(t, unit)
+	})
+}
+
+func TestGetStateDiff(t *testing.T) {
+	s := prepareState(t)
+	newUnitID := types.NewUnitID(33, nil, []byte{6}, []byte{0xFF})
+	require.NoError(t, s.Prune())
+	require.NoError(t, s.Apply(state.AddUnit(newUnitID, &unitData{I: 5, O: templates.AlwaysTrueBytes()})))
+	require.NoError(t, s.AddUnitLog(newUnitID, test.RandomBytes(32)))
+	summaryValue, summaryHash, err := s.CalculateRoot()
+	require.NoError(t, err)
+	require.NoError(t, s.Commit(&types.UnicityCertificate{Version: 1, InputRecord: &types.InputRecord{Version: 1,
+		RoundNumber:  2,
+		Hash:         summaryHash,
+		SummaryValue: util.Uint64ToBytes(summaryValue),
+	}}))
+
+	node := &MockNode{
+		txs: &testtxsystem.CounterTxSystem{
+			FixedState: s,
+		},
+	}
+	api := NewStateAPI(node, nil)
+
+	t.Run("ok", func(t *testing.T) {
+		diff, err := api.GetStateDiff(1, 2)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, diff.FromRound)
+		require.EqualValues(t, 2, diff.ToRound)
+		require.Len(t, diff.Added, 1)
+		require.Equal(t, newUnitID, diff.Added[0].UnitID)
+		require.EqualValues(t, 5, diff.Added[0].Data.(*unitData).I)
+		require.Empty(t, diff.Updated)
+		require.Empty(t, diff.Deleted)
+	})
+	t.Run("round not found", func(t *testing.T) {
+		diff, err := api.GetStateDiff(1, 3)
+		require.ErrorIs(t, err, state.ErrSnapshotNotFound)
+		require.Nil(t, diff)
+	})
+}
+
 func TestGetUnitsByOwnerID(t *testing.T) {
 	node := &MockNode{}
 	ownerIndex := &MockOwnerIndex{ownerUnits: map[string][]types.UnitID{}}
@@ -284,6 +351,10 @@ func (mn *MockNode) TransactionSystemState() txsystem.StateReader {
 	return mn.txs.State()
 }
 
+func (mn *MockNode) TransactionSystemStateAt(roundNumber uint64) (txsystem.StateReader, error) {
+	return mn.txs.StateAt(roundNumber)
+}
+
 func (mn *MockNode) GetTransactionRecordProof(_ context.Context, hash []byte) (*types.TxRecordProof, error) {
 	if mn.err != nil {
 		return nil, mn.err
@@ -4,9 +4,13 @@ import (
 	"crypto"
 )
 
+// DefaultSnapshotCount is the default number of committed state snapshots kept by the State.
+const DefaultSnapshotCount = 10
+
 type (
 	Options struct {
 		hashAlgorithm crypto.Hash
+		snapshotCount int
 	}
 
 	Option func(o *Options)
@@ -18,9 +22,18 @@ func WithHashAlgorithm(hashAlgorithm crypto.Hash) Option {
 	}
 }
 
+// WithSnapshotCount sets the number of last committed state snapshots kept by the State (see State.Snapshot).
+// Zero disables snapshots.
+func WithSnapshotCount(count int) Option {
+	return func(o *Options) {
+		o.snapshotCount = count
+	}
+}
+
 func loadOptions(opts ...Option) *Options {
 	options := &Options{
 		hashAlgorithm: crypto.SHA256,
+		snapshotCount: DefaultSnapshotCount,
 	}
 	for _, opt := range opts {
 		opt(options)
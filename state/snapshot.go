@@ -0,0 +1,74 @@
+package state
+
+import (
+	"crypto"
+	"errors"
+	"io"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/tree/avl"
+)
+
+var ErrSnapshotNotFound = errors.New("state snapshot not found")
+
+// Snapshot is an immutable view of the state committed in a given round.
+//
+// Snapshot shares all the unchanged AVL tree nodes with the state it was taken from (tree nodes are copied on write),
+// so keeping it around is cheap. Reading from a snapshot does not lock the state, i.e. long-running reads never block
+// the state from being changed or committed.
+type Snapshot struct {
+	hashAlgorithm crypto.Hash
+	tree          *tree
+	uc            *types.UnicityCertificate
+}
+
+// RoundNumber returns the round number of the unicity certificate that certified the snapshot.
+func (s *Snapshot) RoundNumber() uint64 {
+	return s.uc.GetRoundNumber()
+}
+
+// CommittedUC returns the unicity certificate that certified the snapshot.
+func (s *Snapshot) CommittedUC() *types.UnicityCertificate {
+	return s.uc
+}
+
+func (s *Snapshot) HashAlgorithm() crypto.Hash {
+	return s.hashAlgorithm
+}
+
+// GetUnit returns the unit with the given id. Snapshot contains only committed units, committed flag is ignored and
+// exists only to satisfy the same interface as State.
+func (s *Snapshot) GetUnit(id types.UnitID, _ bool) (*Unit, error) {
+	return s.tree.Get(id)
+}
+
+func (s *Snapshot) CreateUnitStateProof(id types.UnitID, logIndex int) (*types.UnitStateProof, error) {
+	return createUnitStateProof(s.tree, s.uc, s.hashAlgorithm, id, logIndex)
+}
+
+func (s *Snapshot) CreateIndex(ke KeyExtractor[string]) (Index[string], error) {
+	return CreateIndex(s, ke)
+}
+
+// Serialize writes the snapshot to the given writer. Snapshot contains only committed units, committed flag is
+// ignored and exists only to satisfy the same interface as State.
+func (s *Snapshot) Serialize(writer io.Writer, _ bool) error {
+	return serialize(writer, s.tree, s.uc, s.hashAlgorithm)
+}
+
+func (s *Snapshot) Traverse(traverser avl.Traverser[types.UnitID, *Unit]) {
+	s.tree.Traverse(traverser)
+}
+
+// addSnapshot adds a new snapshot and drops the oldest ones so that at most snapshotCount snapshots are kept.
+func (s *State) addSnapshot(snapshot *Snapshot) {
+	if s.snapshotCount <= 0 {
+		return
+	}
+	s.snapshots = append(s.snapshots, snapshot)
+	if n := len(s.snapshots) - s.snapshotCount; n > 0 {
+		// do not keep references to the dropped snapshots in the backing array
+		clear(s.snapshots[:n])
+		s.snapshots = s.snapshots[n:]
+	}
+}
@@ -0,0 +1,150 @@
+package state
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_Snapshot_NotCommitted(t *testing.T) {
+	s := NewEmptyState()
+	require.Nil(t, s.LatestSnapshot())
+	snapshot, err := s.Snapshot(1)
+	require.ErrorIs(t, err, ErrSnapshotNotFound)
+	require.Nil(t, snapshot)
+}
+
+func TestState_Snapshot_Disabled(t *testing.T) {
+	s := NewEmptyState(WithSnapshotCount(0))
+	require.NoError(t, s.Apply(AddUnit([]byte{0, 0, 0, 1}, &pruneUnitData{I: 10})))
+	require.NoError(t, s.AddUnitLog([]byte{0, 0, 0, 1}, []byte{1}))
+	summaryValue, summaryHash, err := s.CalculateRoot()
+	require.NoError(t, err)
+	require.NoError(t, s.Commit(createUC(s, summaryValue, summaryHash)))
+
+	require.Nil(t, s.LatestSnapshot())
+	_, err = s.Snapshot(1)
+	require.ErrorIs(t, err, ErrSnapshotNotFound)
+}
+
+func TestState_Snapshot_KeepsLastCommittedRounds(t *testing.T) {
+	s := NewEmptyState(WithSnapshotCount(2))
+	id := types.UnitID{0, 0, 0, 1}
+	require.NoError(t, s.Apply(AddUnit(id, &pruneUnitData{I: 1})))
+	for i := 1; i <= 3; i++ {
+		if i > 1 {
+			require.NoError(t, s.Prune())
+			require.NoError(t, s.Apply(UpdateUnitData(id, multiply(10))))
+		}
+		require.NoError(t, s.AddUnitLog(id, []byte{byte(i)}))
+		summaryValue, summaryHash, err := s.CalculateRoot()
+		require.NoError(t, err)
+		require.NoError(t, s.Commit(createUC(s, summaryValue, summaryHash)))
+	}
+
+	// round 1 has been dropped
+	_, err := s.Snapshot(1)
+	require.ErrorIs(t, err, ErrSnapshotNotFound)
+
+	snapshot2, err := s.Snapshot(2)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, snapshot2.RoundNumber())
+	u, err := snapshot2.GetUnit(id, true)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, u.Data().SummaryValueInput())
+
+	snapshot3 := s.LatestSnapshot()
+	require.EqualValues(t, 3, snapshot3.RoundNumber())
+	require.Equal(t, s.CommittedUC(), snapshot3.CommittedUC())
+	u, err = snapshot3.GetUnit(id, true)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, u.Data().SummaryValueInput())
+}
+
+func TestSnapshot_IsNotAffectedByStateChanges(t *testing.T) {
+	s, rootHash, summaryValue := prepareState(t)
+	snapshot := s.LatestSnapshot()
+	require.NotNil(t, snapshot)
+
+	// change and commit the state
+	require.NoError(t, s.Prune())
+	updateUnits(t, s)
+	require.NoError(t, s.Apply(DeleteUnit([]byte{0, 0, 0, 6})))
+	require.NotEqual(t, snapshot.RoundNumber(), s.CommittedUC().GetRoundNumber())
+
+	// snapshot still has the original content
+	u, err := snapshot.GetUnit([]byte{0, 0, 0, 6}, true)
+	require.NoError(t, err)
+	require.EqualValues(t, 60, u.Data().SummaryValueInput())
+	root := snapshot.tree.Root()
+	require.Equal(t, rootHash, root.Value().subTreeSummaryHash)
+	require.Equal(t, summaryValue, root.Value().subTreeSummaryValue)
+
+	for _, id := range unitIdentifiers {
+		proof, err := snapshot.CreateUnitStateProof(id, 0)
+		require.NoError(t, err)
+		require.NotEmpty(t, proof.UnicityCertificate)
+	}
+}
+
+func TestSnapshot_Serialize(t *testing.T) {
+	s, rootHash, _ := prepareState(t)
+	snapshot := s.LatestSnapshot()
+	require.NoError(t, s.Prune())
+	updateUnits(t, s)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, snapshot.Serialize(buf, true))
+	recovered, err := NewRecoveredState(buf, unitDataConstructor)
+	require.NoError(t, err)
+	require.True(t, recovered.IsCommitted())
+	require.Equal(t, snapshot.CommittedUC(), recovered.CommittedUC())
+	_, recoveredRootHash, err := recovered.CalculateRoot()
+	require.NoError(t, err)
+	require.Equal(t, rootHash, recoveredRootHash)
+}
+
+func TestSnapshot_CreateIndex(t *testing.T) {
+	s, _, _ := prepareState(t)
+	index, err := s.LatestSnapshot().CreateIndex(func(unit *Unit) (string, error) {
+		if unit.Data().SummaryValueInput() >= 50 {
+			return "large", nil
+		}
+		return "small", nil
+	})
+	require.NoError(t, err)
+	require.Len(t, index, 2)
+	require.Len(t, index["large"], 6)
+	require.Len(t, index["small"], 5)
+}
+
+func TestSnapshot_ConcurrentReadsAndCommits(t *testing.T) {
+	s, _, _ := prepareState(t)
+	snapshot := s.LatestSnapshot()
+
+	var wg sync.WaitGroup
+	var readErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10 && readErr == nil; i++ {
+			for _, id := range unitIdentifiers {
+				if _, readErr = snapshot.CreateUnitStateProof(id, 0); readErr != nil {
+					return
+				}
+			}
+			ss := &stateSize{}
+			snapshot.Traverse(ss)
+			readErr = ss.err
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		require.NoError(t, s.Prune())
+		updateUnits(t, s)
+	}
+	wg.Wait()
+	require.NoError(t, readErr)
+}
@@ -33,6 +33,10 @@ type (
 		// savepoint is a special marker that allows all actions that are executed after tree was established to
 		// be rolled back, restoring the state to what it was at the time of the tree.
 		savepoints []*tree
+
+		// snapshots contains immutable views of the last committed states, ordered by round number (oldest first).
+		snapshots     []*Snapshot
+		snapshotCount int
 	}
 
 	tree = avl.Tree[types.UnitID, *Unit]
@@ -52,9 +56,11 @@ func NewEmptyState(opts ...Option) *State {
 		hashAlgorithm: options.hashAlgorithm,
 		committedTree: t,
 		savepoints:    []*tree{t.Clone()},
+		snapshotCount: options.snapshotCount,
 	}
 }
 
+// NewRecoveredState restores the state from the given snapshot, both v1 and v2 snapshot formats are supported.
 func NewRecoveredState(stateData io.Reader, udc UnitDataConstructor, opts ...Option) (*State, error) {
 	options := loadOptions(opts...)
 	if stateData == nil {
@@ -67,9 +73,27 @@ func NewRecoveredState(stateData io.Reader, udc UnitDataConstructor, opts ...Opt
 	crc32Reader := NewCRC32Reader(stateData, CBORChecksumLength)
 	decoder := types.Cbor.GetDecoder(crc32Reader)
 
-	var header header
-	err := decoder.Decode(&header)
+	var rawHeader cbor.RawMessage
+	if err := decoder.Decode(&rawHeader); err != nil {
+		return nil, fmt.Errorf("unable to decode header: %w", err)
+	}
+	version, err := snapshotVersion(rawHeader)
 	if err != nil {
+		return nil, err
+	}
+	if version == SnapshotVersion2 {
+		r, err := NewSnapshotRecoverer(udc, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.readFrom(decoder, rawHeader); err != nil {
+			return nil, err
+		}
+		return r.State()
+	}
+
+	var header header
+	if err := types.Cbor.Unmarshal(rawHeader, &header); err != nil {
 		return nil, fmt.Errorf("unable to decode header: %w", err)
 	}
 
@@ -86,17 +110,23 @@ func NewRecoveredState(stateData io.Reader, udc UnitDataConstructor, opts ...Opt
 		return nil, fmt.Errorf("checksum mismatch")
 	}
 
+	return newRecoveredState(root, header.UnicityCertificate, opts...)
+}
+
+func newRecoveredState(root *node, uc *types.UnicityCertificate, opts ...Option) (*State, error) {
+	options := loadOptions(opts...)
 	hasher := newStateHasher(options.hashAlgorithm)
 	t := avl.NewWithTraverserAndRoot[types.UnitID, *Unit](hasher, root)
 	state := &State{
 		hashAlgorithm: options.hashAlgorithm,
 		savepoints:    []*tree{t},
+		snapshotCount: options.snapshotCount,
 	}
 	if _, _, err := state.CalculateRoot(); err != nil {
 		return nil, err
 	}
-	if header.UnicityCertificate != nil {
-		if err := state.Commit(header.UnicityCertificate); err != nil {
+	if uc != nil {
+		if err := state.Commit(uc); err != nil {
 			return nil, fmt.Errorf("unable to commit recovered state: %w", err)
 		}
 	}
@@ -117,44 +147,11 @@ func readNodeRecords(decoder *cbor.Decoder, unitDataConstructor UnitDataConstruc
 			return nil, fmt.Errorf("unable to decode node record: %w", err)
 		}
 
-		unitData, err := unitDataConstructor(nodeRecord.UnitID)
+		n, err := newNodeFromRecord(&nodeRecord, unitDataConstructor, hashAlgorithm)
 		if err != nil {
-			return nil, fmt.Errorf("unable to construct unit data: %w", err)
-		}
-
-		err = types.Cbor.Unmarshal(nodeRecord.UnitData, &unitData)
-		if err != nil {
-			return nil, fmt.Errorf("unable to decode unit data: %w", err)
-		}
-
-		latestLog := &Log{
-			UnitLedgerHeadHash: nodeRecord.UnitLedgerHeadHash,
-			NewUnitData:        unitData,
-		}
-		logsHash := mt.EvalMerklePath(nodeRecord.UnitTreePath, latestLog, hashAlgorithm)
-
-		unit := &Unit{logsHash: logsHash}
-		if len(nodeRecord.UnitTreePath) > 0 {
-			// A non-zero UnitTreePath length means that the unit had multiple logs at serialization.
-			// Those logs must be pruned at the beginning of the next round and the summary hash must
-			// be recalculated for such units after pruning. Let's add an extra empty log for the unit,
-			// so that the pruner can find it and the summary hash is recalculated. This does not
-			// interfere with proof indexer as proofs are not calculated for the recovered round.
-			// Everything else uses just the latest log.
-			unit.logs = []*Log{{}, latestLog}
-		} else {
-			unit.logs = []*Log{latestLog}
+			return nil, err
 		}
-
-		var right, left *node
-		if nodeRecord.HasRight {
-			right = nodeStack.Pop()
-		}
-		if nodeRecord.HasLeft {
-			left = nodeStack.Pop()
-		}
-
-		nodeStack.Push(avl.NewBalancedNode(nodeRecord.UnitID, unit, left, right))
+		pushNode(&nodeStack, n, &nodeRecord)
 	}
 
 	root := nodeStack.Pop()
@@ -164,6 +161,52 @@ func readNodeRecords(decoder *cbor.Decoder, unitDataConstructor UnitDataConstruc
 	return root, nil
 }
 
+// newNodeFromRecord creates a new tree node (without children) from the given node record.
+func newNodeFromRecord(nodeRecord *nodeRecord, unitDataConstructor UnitDataConstructor, hashAlgorithm crypto.Hash) (*node, error) {
+	unitData, err := unitDataConstructor(nodeRecord.UnitID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to construct unit data: %w", err)
+	}
+
+	err = types.Cbor.Unmarshal(nodeRecord.UnitData, &unitData)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode unit data: %w", err)
+	}
+
+	latestLog := &Log{
+		UnitLedgerHeadHash: nodeRecord.UnitLedgerHeadHash,
+		NewUnitData:        unitData,
+	}
+	logsHash := mt.EvalMerklePath(nodeRecord.UnitTreePath, latestLog, hashAlgorithm)
+
+	unit := &Unit{logsHash: logsHash}
+	if len(nodeRecord.UnitTreePath) > 0 {
+		// A non-zero UnitTreePath length means that the unit had multiple logs at serialization.
+		// Those logs must be pruned at the beginning of the next round and the summary hash must
+		// be recalculated for such units after pruning. Let's add an extra empty log for the unit,
+		// so that the pruner can find it and the summary hash is recalculated. This does not
+		// interfere with proof indexer as proofs are not calculated for the recovered round.
+		// Everything else uses just the latest log.
+		unit.logs = []*Log{{}, latestLog}
+	} else {
+		unit.logs = []*Log{latestLog}
+	}
+	return avl.NewBalancedNode[types.UnitID, *Unit](nodeRecord.UnitID, unit, nil, nil), nil
+}
+
+// pushNode pops the children of the node from the stack (node records are in post-order) and pushes the node
+// with its children to the stack.
+func pushNode(nodeStack *util.Stack[*node], n *node, nodeRecord *nodeRecord) {
+	var right, left *node
+	if nodeRecord.HasRight {
+		right = nodeStack.Pop()
+	}
+	if nodeRecord.HasLeft {
+		left = nodeStack.Pop()
+	}
+	nodeStack.Push(avl.NewBalancedNode(n.Key(), n.Value(), left, right))
+}
+
 // Clone returns a clone of the state. The original state and the cloned state can be used by different goroutines but
 // can never be merged. The cloned state is usually used by read only operations (e.g. unit proof generation).
 func (s *State) Clone() *State {
@@ -174,6 +217,7 @@ func (s *State) Clone() *State {
 		committedTree:   s.committedTree.Clone(),
 		committedTreeUC: s.committedTreeUC,
 		savepoints:      []*tree{s.latestSavepoint().Clone()},
+		snapshotCount:   s.snapshotCount,
 	}
 }
 
@@ -264,9 +308,37 @@ func (s *State) Commit(uc *types.UnicityCertificate) error {
 	s.committedTree = sp.Clone()
 	s.committedTreeUC = uc
 	s.savepoints = []*tree{sp}
+	s.addSnapshot(&Snapshot{
+		hashAlgorithm: s.hashAlgorithm,
+		tree:          s.committedTree.Clone(),
+		uc:            uc,
+	})
 	return nil
 }
 
+// Snapshot returns an immutable view of the state committed in the given round. Only the last N committed states are
+// kept (see WithSnapshotCount), ErrSnapshotNotFound is returned for the rounds that are not available.
+func (s *State) Snapshot(roundNumber uint64) (*Snapshot, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for i := len(s.snapshots) - 1; i >= 0; i-- {
+		if s.snapshots[i].RoundNumber() == roundNumber {
+			return s.snapshots[i], nil
+		}
+	}
+	return nil, fmt.Errorf("round %d: %w", roundNumber, ErrSnapshotNotFound)
+}
+
+// LatestSnapshot returns an immutable view of the latest committed state or nil if the state has never been committed.
+func (s *State) LatestSnapshot() *Snapshot {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if len(s.snapshots) == 0 {
+		return nil
+	}
+	return s.snapshots[len(s.snapshots)-1]
+}
+
 // CommittedUC returns the Unicity Certificate of the committed state.
 func (s *State) CommittedUC() *types.UnicityCertificate {
 	s.mutex.RLock()
@@ -348,21 +420,20 @@ func (s *State) Size() (uint64, error) {
 // Serialize writes the current committed state to the given writer.
 // Not concurrency safe. Should clone the state before calling this.
 func (s *State) Serialize(writer io.Writer, committed bool) error {
+	if committed {
+		return serialize(writer, s.committedTree, s.committedTreeUC, s.hashAlgorithm)
+	}
+	return serialize(writer, s.latestSavepoint(), nil, s.hashAlgorithm)
+}
+
+func serialize(writer io.Writer, tree *tree, uc *types.UnicityCertificate, hashAlgorithm crypto.Hash) error {
 	crc32Writer := NewCRC32Writer(writer)
 	encoder, err := types.Cbor.GetEncoder(crc32Writer)
 	if err != nil {
 		return fmt.Errorf("unable to get encoder: %w", err)
 	}
 
-	header := &header{}
-
-	var tree *tree
-	if committed {
-		tree = s.committedTree
-		header.UnicityCertificate = s.committedTreeUC
-	} else {
-		tree = s.latestSavepoint()
-	}
+	header := &header{UnicityCertificate: uc}
 
 	// Add node record count to header
 	snc := NewStateNodeCounter()
@@ -375,7 +446,7 @@ func (s *State) Serialize(writer io.Writer, committed bool) error {
 	}
 
 	// Write node records
-	ss := newStateSerializer(encoder.Encode, s.hashAlgorithm)
+	ss := newStateSerializer(encoder.Encode, hashAlgorithm)
 	if tree.Traverse(ss); ss.err != nil {
 		return fmt.Errorf("unable to write node records: %w", ss.err)
 	}
@@ -391,7 +462,11 @@ func (s *State) Serialize(writer io.Writer, committed bool) error {
 func (s *State) CreateUnitStateProof(id types.UnitID, logIndex int) (*types.UnitStateProof, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
-	unit, err := s.committedTree.Get(id)
+	return createUnitStateProof(s.committedTree, s.committedTreeUC, s.hashAlgorithm, id, logIndex)
+}
+
+func createUnitStateProof(t *tree, uc *types.UnicityCertificate, hashAlgorithm crypto.Hash, id types.UnitID, logIndex int) (*types.UnitStateProof, error) {
+	unit, err := t.Get(id)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get unit %v: %w", id, err)
 	}
@@ -408,11 +483,11 @@ func (s *State) CreateUnitStateProof(id types.UnitID, logIndex int) (*types.Unit
 		// initial state was copied from previous round
 		unitLedgerHeadHash = unit.logs[0].UnitLedgerHeadHash
 	}
-	unitTreeCert, err := s.createUnitTreeCert(unit, logIndex)
+	unitTreeCert, err := createUnitTreeCert(unit, logIndex, hashAlgorithm)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract unit tree cert for unit %v: %w", id, err)
 	}
-	stateTreeCert, err := s.createStateTreeCert(id)
+	stateTreeCert, err := createStateTreeCert(t, id)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract unit state tree cert for unit %v: %w", id, err)
 	}
@@ -422,7 +497,7 @@ func (s *State) CreateUnitStateProof(id types.UnitID, logIndex int) (*types.Unit
 	}
 
 	// TODO verify proof before returning
-	ucBytes, err := s.committedTreeUC.MarshalCBOR()
+	ucBytes, err := uc.MarshalCBOR()
 	if err != nil {
 		return nil, fmt.Errorf("unable to marshal unicity certificate: %w", err)
 	}
@@ -436,24 +511,14 @@ func (s *State) CreateUnitStateProof(id types.UnitID, logIndex int) (*types.Unit
 	}, nil
 }
 
-func (s *State) HashAlgorithm() crypto.Hash {
-	return s.hashAlgorithm
-}
-
-func (s *State) Traverse(traverser avl.Traverser[types.UnitID, *Unit]) {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-	s.committedTree.Traverse(traverser)
-}
-
-func (s *State) createUnitTreeCert(unit *Unit, logIndex int) (*types.UnitTreeCert, error) {
-	merkle := mt.New(s.hashAlgorithm, unit.logs)
+func createUnitTreeCert(unit *Unit, logIndex int, hashAlgorithm crypto.Hash) (*types.UnitTreeCert, error) {
+	merkle := mt.New(hashAlgorithm, unit.logs)
 	path, err := merkle.GetMerklePath(logIndex)
 	if err != nil {
 		return nil, err
 	}
 	l := unit.logs[logIndex]
-	dataHasher := s.hashAlgorithm.New()
+	dataHasher := hashAlgorithm.New()
 	if err = l.NewUnitData.Write(dataHasher); err != nil {
 		return nil, fmt.Errorf("add to hasher error: %w", err)
 	}
@@ -464,9 +529,9 @@ func (s *State) createUnitTreeCert(unit *Unit, logIndex int) (*types.UnitTreeCer
 	}, nil
 }
 
-func (s *State) createStateTreeCert(id types.UnitID) (*types.StateTreeCert, error) {
+func createStateTreeCert(t *tree, id types.UnitID) (*types.StateTreeCert, error) {
 	var path []*types.StateTreePathItem
-	node := s.committedTree.Root()
+	node := t.Root()
 	for node != nil && !id.Eq(node.Key()) {
 		nodeKey := node.Key()
 		v := getSummaryValueInput(node)
@@ -508,6 +573,24 @@ func (s *State) createStateTreeCert(id types.UnitID) (*types.StateTreeCert, erro
 	return nil, fmt.Errorf("unable to extract unit state tree cert for unit %v", id)
 }
 
+func (s *State) HashAlgorithm() crypto.Hash {
+	return s.hashAlgorithm
+}
+
+func (s *State) Traverse(traverser avl.Traverser[types.UnitID, *Unit]) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	s.committedTree.Traverse(traverser)
+}
+
+// TraverseUncommitted traverses the latest savepoint of the state, ie unlike Traverse
+// it includes the changes made in the current round.
+func (s *State) TraverseUncommitted(traverser avl.Traverser[types.UnitID, *Unit]) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	s.latestSavepoint().Traverse(traverser)
+}
+
 func (s *State) createSavepoint() int {
 	clonedSavepoint := s.latestSavepoint().Clone()
 	// mark AVL Tree nodes as clean
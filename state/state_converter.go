@@ -0,0 +1,236 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill-go-base/util"
+	"github.com/fxamacker/cbor/v2"
+)
+
+type (
+	// SnapshotInfo contains information about a serialized state snapshot.
+	SnapshotInfo struct {
+		Version            uint32
+		UnicityCertificate *types.UnicityCertificate
+		NodeRecordCount    uint64
+		// v2 snapshot only
+		Compression Compression
+		ChunkSize   uint64
+		ChunkCount  uint64
+		// UnitTypes contains statistics of the units grouped by the type part of the unit identifier.
+		UnitTypes map[string]*UnitTypeStats
+	}
+
+	UnitTypeStats struct {
+		UnitCount    uint64
+		UnitDataSize uint64
+	}
+)
+
+// ConvertSnapshotV1ToV2 reads a v1 snapshot from the reader and writes it to the writer in v2 format. Unit data
+// is copied as is, i.e. the conversion does not depend on the transaction system. If an error is returned the
+// output must be discarded.
+func ConvertSnapshotV1ToV2(reader io.Reader, writer io.Writer, opts ...SerializeOption) error {
+	options, err := loadSerializeOptions(opts...)
+	if err != nil {
+		return err
+	}
+	crc32Reader := NewCRC32Reader(reader, CBORChecksumLength)
+	decoder := types.Cbor.GetDecoder(crc32Reader)
+	var h header
+	if err := decoder.Decode(&h); err != nil {
+		return fmt.Errorf("unable to decode header: %w", err)
+	}
+
+	encoder, err := types.Cbor.GetEncoder(writer)
+	if err != nil {
+		return fmt.Errorf("unable to get encoder: %w", err)
+	}
+	if err := encoder.Encode(newHeaderV2(h.UnicityCertificate, h.NodeRecordCount, options)); err != nil {
+		return fmt.Errorf("unable to write header: %w", err)
+	}
+	cw := newChunkWriter(encoder.Encode, options)
+	for i := uint64(0); i < h.NodeRecordCount; i++ {
+		var nr nodeRecord
+		if err := decoder.Decode(&nr); err != nil {
+			return fmt.Errorf("unable to decode node record: %w", err)
+		}
+		if err := cw.add(&nr); err != nil {
+			return fmt.Errorf("unable to write node records: %w", err)
+		}
+	}
+	if err := cw.flush(); err != nil {
+		return fmt.Errorf("unable to write node records: %w", err)
+	}
+
+	var checksum []byte
+	if err := decoder.Decode(&checksum); err != nil {
+		return fmt.Errorf("unable to decode checksum: %w", err)
+	}
+	if util.BytesToUint32(checksum) != crc32Reader.Sum() {
+		return fmt.Errorf("checksum mismatch")
+	}
+	return nil
+}
+
+// ConvertSnapshotV2ToV1 reads a v2 snapshot from the reader and writes it to the writer in v1 format. Unit data
+// is copied as is, i.e. the conversion does not depend on the transaction system. If an error is returned the
+// output must be discarded.
+func ConvertSnapshotV2ToV1(reader io.Reader, writer io.Writer) error {
+	decoder := types.Cbor.GetDecoder(reader)
+	h, err := decodeHeaderV2(decoder)
+	if err != nil {
+		return err
+	}
+
+	crc32Writer := NewCRC32Writer(writer)
+	encoder, err := types.Cbor.GetEncoder(crc32Writer)
+	if err != nil {
+		return fmt.Errorf("unable to get encoder: %w", err)
+	}
+	if err := encoder.Encode(&header{UnicityCertificate: h.UnicityCertificate, NodeRecordCount: h.NodeRecordCount}); err != nil {
+		return fmt.Errorf("unable to write header: %w", err)
+	}
+	err = readChunks(decoder, h, func(nr *nodeRecord) error {
+		return encoder.Encode(nr)
+	})
+	if err != nil {
+		return err
+	}
+	if err := encoder.Encode(util.Uint32ToBytes(crc32Writer.Sum())); err != nil {
+		return fmt.Errorf("unable to write checksum: %w", err)
+	}
+	return nil
+}
+
+// InspectSnapshot reads a v1 or v2 snapshot and returns information about it. Units are grouped by the last
+// typePartLength bytes of the unit identifier.
+func InspectSnapshot(reader io.Reader, typePartLength int) (*SnapshotInfo, error) {
+	crc32Reader := NewCRC32Reader(reader, CBORChecksumLength)
+	decoder := types.Cbor.GetDecoder(crc32Reader)
+	var rawHeader cbor.RawMessage
+	if err := decoder.Decode(&rawHeader); err != nil {
+		return nil, fmt.Errorf("unable to decode header: %w", err)
+	}
+	version, err := snapshotVersion(rawHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &SnapshotInfo{Version: version, UnitTypes: map[string]*UnitTypeStats{}}
+	addStats := func(nr *nodeRecord) error {
+		typePart := nr.UnitID
+		if len(typePart) > typePartLength {
+			typePart = typePart[len(typePart)-typePartLength:]
+		}
+		key := fmt.Sprintf("%X", []byte(typePart))
+		stats, ok := info.UnitTypes[key]
+		if !ok {
+			stats = &UnitTypeStats{}
+			info.UnitTypes[key] = stats
+		}
+		stats.UnitCount++
+		stats.UnitDataSize += uint64(len(nr.UnitData))
+		return nil
+	}
+
+	switch version {
+	case SnapshotVersion1:
+		var h header
+		if err := types.Cbor.Unmarshal(rawHeader, &h); err != nil {
+			return nil, fmt.Errorf("unable to decode header: %w", err)
+		}
+		info.UnicityCertificate = h.UnicityCertificate
+		info.NodeRecordCount = h.NodeRecordCount
+		for i := uint64(0); i < h.NodeRecordCount; i++ {
+			var nr nodeRecord
+			if err := decoder.Decode(&nr); err != nil {
+				return nil, fmt.Errorf("unable to decode node record: %w", err)
+			}
+			_ = addStats(&nr)
+		}
+		var checksum []byte
+		if err := decoder.Decode(&checksum); err != nil {
+			return nil, fmt.Errorf("unable to decode checksum: %w", err)
+		}
+		if util.BytesToUint32(checksum) != crc32Reader.Sum() {
+			return nil, fmt.Errorf("checksum mismatch")
+		}
+	case SnapshotVersion2:
+		h := &headerV2{}
+		if err := types.Cbor.Unmarshal(rawHeader, h); err != nil {
+			return nil, fmt.Errorf("unable to decode header: %w", err)
+		}
+		info.UnicityCertificate = h.UnicityCertificate
+		info.NodeRecordCount = h.NodeRecordCount
+		info.Compression = h.Compression
+		info.ChunkSize = h.ChunkSize
+		info.ChunkCount = h.ChunkCount
+		if err := readChunks(decoder, h, addStats); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported snapshot version: %d", version)
+	}
+	return info, nil
+}
+
+func decodeHeaderV2(decoder *cbor.Decoder) (*headerV2, error) {
+	var rawHeader cbor.RawMessage
+	if err := decoder.Decode(&rawHeader); err != nil {
+		return nil, fmt.Errorf("unable to decode header: %w", err)
+	}
+	version, err := snapshotVersion(rawHeader)
+	if err != nil {
+		return nil, err
+	}
+	if version != SnapshotVersion2 {
+		return nil, fmt.Errorf("unsupported snapshot version: %d", version)
+	}
+	h := &headerV2{}
+	if err := types.Cbor.Unmarshal(rawHeader, h); err != nil {
+		return nil, fmt.Errorf("unable to decode header: %w", err)
+	}
+	return h, nil
+}
+
+// readChunks reads and verifies all the chunks of the v2 snapshot and calls f for each node record.
+func readChunks(decoder *cbor.Decoder, h *headerV2, f func(nr *nodeRecord) error) error {
+	var recordCount uint64
+	for i := uint64(0); i < h.ChunkCount; i++ {
+		var c chunk
+		if err := decoder.Decode(&c); err != nil {
+			return fmt.Errorf("unable to decode chunk %d: %w", i, err)
+		}
+		if c.Index != i {
+			return fmt.Errorf("expected chunk %d, got chunk %d", i, c.Index)
+		}
+		data, err := c.records(h.Compression)
+		if err != nil {
+			return err
+		}
+		recordDecoder := types.Cbor.GetDecoder(bytes.NewReader(data))
+		for j := uint64(0); j < c.RecordCount; j++ {
+			var nr nodeRecord
+			if err := recordDecoder.Decode(&nr); err != nil {
+				return fmt.Errorf("chunk %d: unable to decode node record: %w", c.Index, err)
+			}
+			if err := f(&nr); err != nil {
+				return err
+			}
+		}
+		recordCount += c.RecordCount
+	}
+	if recordCount != h.NodeRecordCount {
+		return fmt.Errorf("expected %d node records, got %d", h.NodeRecordCount, recordCount)
+	}
+	var extra cbor.RawMessage
+	if err := decoder.Decode(&extra); !errors.Is(err, io.EOF) {
+		return errors.New("unexpected data after the last chunk")
+	}
+	return nil
+}
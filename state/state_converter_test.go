@@ -0,0 +1,104 @@
+package state
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertSnapshot_V1ToV2ToV1(t *testing.T) {
+	s, rootHash, _ := prepareState(t)
+	v1 := &bytes.Buffer{}
+	require.NoError(t, s.Serialize(v1, true))
+
+	v2 := &bytes.Buffer{}
+	require.NoError(t, ConvertSnapshotV1ToV2(bytes.NewReader(v1.Bytes()), v2, WithChunkSize(4), WithCompression(CompressionGzip)))
+
+	// converted snapshot is equal to the snapshot serialized in v2 format
+	expectedV2 := &bytes.Buffer{}
+	require.NoError(t, s.SerializeV2(expectedV2, true, WithChunkSize(4), WithCompression(CompressionGzip)))
+	require.Equal(t, expectedV2.Bytes(), v2.Bytes())
+
+	recovered, err := NewRecoveredState(bytes.NewReader(v2.Bytes()), unitDataConstructor)
+	require.NoError(t, err)
+	_, recoveredRootHash, err := recovered.CalculateRoot()
+	require.NoError(t, err)
+	require.Equal(t, rootHash, recoveredRootHash)
+
+	convertedV1 := &bytes.Buffer{}
+	require.NoError(t, ConvertSnapshotV2ToV1(v2, convertedV1))
+	require.Equal(t, v1.Bytes(), convertedV1.Bytes())
+}
+
+func TestConvertSnapshot_Errors(t *testing.T) {
+	s, _, _ := prepareState(t)
+	v1 := &bytes.Buffer{}
+	require.NoError(t, s.Serialize(v1, true))
+	v2 := &bytes.Buffer{}
+	require.NoError(t, s.SerializeV2(v2, true))
+
+	require.ErrorContains(t, ConvertSnapshotV2ToV1(bytes.NewReader(v1.Bytes()), &bytes.Buffer{}), "unsupported snapshot version: 1")
+	require.ErrorContains(t, ConvertSnapshotV1ToV2(bytes.NewReader(v2.Bytes()), &bytes.Buffer{}), "unable to decode header")
+
+	// invalid v1 checksum
+	data := bytes.Clone(v1.Bytes())
+	data[len(data)-1] ^= 0xFF
+	require.ErrorContains(t, ConvertSnapshotV1ToV2(bytes.NewReader(data), &bytes.Buffer{}), "checksum mismatch")
+
+	// data after the last chunk
+	data = append(bytes.Clone(v2.Bytes()), 0x01)
+	require.ErrorContains(t, ConvertSnapshotV2ToV1(bytes.NewReader(data), &bytes.Buffer{}), "unexpected data after the last chunk")
+}
+
+func TestInspectSnapshot(t *testing.T) {
+	s, _, _ := prepareState(t)
+	v1 := &bytes.Buffer{}
+	require.NoError(t, s.Serialize(v1, true))
+	v2 := &bytes.Buffer{}
+	require.NoError(t, s.SerializeV2(v2, true, WithChunkSize(5), WithCompression(CompressionGzip)))
+
+	info, err := InspectSnapshot(v1, 1)
+	require.NoError(t, err)
+	require.EqualValues(t, SnapshotVersion1, info.Version)
+	require.EqualValues(t, 11, info.NodeRecordCount)
+	require.Equal(t, s.CommittedUC(), info.UnicityCertificate)
+	require.Len(t, info.UnitTypes, 10)
+	require.EqualValues(t, 2, info.UnitTypes["00"].UnitCount)
+	require.EqualValues(t, 1, info.UnitTypes["05"].UnitCount)
+
+	info, err = InspectSnapshot(v2, 1)
+	require.NoError(t, err)
+	require.EqualValues(t, SnapshotVersion2, info.Version)
+	require.EqualValues(t, 11, info.NodeRecordCount)
+	require.Equal(t, CompressionGzip, info.Compression)
+	require.EqualValues(t, 5, info.ChunkSize)
+	require.EqualValues(t, 3, info.ChunkCount)
+	require.Equal(t, s.CommittedUC(), info.UnicityCertificate)
+	require.Len(t, info.UnitTypes, 10)
+	require.EqualValues(t, 2, info.UnitTypes["00"].UnitCount)
+	require.NotZero(t, info.UnitTypes["00"].UnitDataSize)
+}
+
+func TestInspectSnapshot_Uncommitted(t *testing.T) {
+	s := NewEmptyState()
+	require.NoError(t, s.Apply(AddUnit([]byte{1, 1}, &TestData{Value: 1})))
+	require.NoError(t, s.AddUnitLog([]byte{1, 1}, []byte{1}))
+	_, _, err := s.CalculateRoot()
+	require.NoError(t, err)
+	v1 := &bytes.Buffer{}
+	require.NoError(t, s.Serialize(v1, false))
+
+	// the v1 header of an uncommitted state starts with null unicity certificate
+	info, err := InspectSnapshot(bytes.NewReader(v1.Bytes()), 1)
+	require.NoError(t, err)
+	require.EqualValues(t, SnapshotVersion1, info.Version)
+	require.Nil(t, info.UnicityCertificate)
+	require.EqualValues(t, 1, info.UnitTypes["01"].UnitCount)
+
+	v2 := &bytes.Buffer{}
+	require.NoError(t, ConvertSnapshotV1ToV2(bytes.NewReader(v1.Bytes()), v2))
+	convertedV1 := &bytes.Buffer{}
+	require.NoError(t, ConvertSnapshotV2ToV1(v2, convertedV1))
+	require.Equal(t, v1.Bytes(), convertedV1.Bytes())
+}
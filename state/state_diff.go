@@ -0,0 +1,207 @@
+package state
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"fmt"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+)
+
+type (
+	// StateDiff describes the changes between two committed versions of the state. Units are ordered by the unit
+	// identifier.
+	StateDiff struct {
+		FromRound uint64
+		ToRound   uint64
+		Added     []*UnitChange
+		Updated   []*UnitChange
+		Deleted   []types.UnitID
+	}
+
+	// UnitChange is a unit that was added or updated, Data is the new data of the unit.
+	UnitChange struct {
+		UnitID types.UnitID
+		Data   types.UnitData
+	}
+
+	// diffIterator is an in-order iterator of the state tree nodes. Unlike the usual in-order iterator it does not
+	// expand the subtrees eagerly, that allows to skip the subtrees that are equal in both trees.
+	diffIterator struct {
+		stack []diffItem
+	}
+
+	diffItem struct {
+		node *node
+		// expanded is true if the item represents only the unit of the node (i.e. children of the node have already
+		// been pushed to the stack), false if the item represents the whole subtree rooted at the node
+		expanded bool
+	}
+)
+
+// Diff returns the units that were added, updated and deleted between the given snapshots.
+//
+// Subtrees with equal summary hashes contain exactly the same units and are skipped without comparing them unit by
+// unit, so the cost of the diff is proportional to the number of changed units rather than the size of the state.
+func Diff(from, to *Snapshot) (*StateDiff, error) {
+	if from == nil || to == nil {
+		return nil, errors.New("snapshot is nil")
+	}
+	if from.hashAlgorithm != to.hashAlgorithm {
+		return nil, fmt.Errorf("snapshots use different hash algorithms: %v and %v", from.hashAlgorithm, to.hashAlgorithm)
+	}
+	diff := &StateDiff{FromRound: from.RoundNumber(), ToRound: to.RoundNumber()}
+	a := newDiffIterator(from.tree.Root())
+	b := newDiffIterator(to.tree.Root())
+	for !a.isEmpty() && !b.isEmpty() {
+		ta, tb := a.peek(), b.peek()
+		if !ta.expanded && !tb.expanded {
+			if bytes.Equal(getSubTreeSummaryHash(ta.node), getSubTreeSummaryHash(tb.node)) {
+				// equal subtrees, skip both
+				a.pop()
+				b.pop()
+				continue
+			}
+			// expand the deeper subtree first, it gives a better chance to find equal subtrees
+			if ta.node.Depth() >= tb.node.Depth() {
+				a.expand()
+			} else {
+				b.expand()
+			}
+			continue
+		}
+		if !ta.expanded {
+			a.expand()
+			continue
+		}
+		if !tb.expanded {
+			b.expand()
+			continue
+		}
+
+		switch c := ta.node.Key().Compare(tb.node.Key()); {
+		case c < 0:
+			diff.Deleted = append(diff.Deleted, ta.node.Key())
+			a.pop()
+		case c > 0:
+			diff.Added = append(diff.Added, newUnitChange(tb.node))
+			b.pop()
+		default:
+			changed, err := isUnitChanged(ta.node.Value(), tb.node.Value(), from.hashAlgorithm)
+			if err != nil {
+				return nil, fmt.Errorf("unable to compare unit %v: %w", ta.node.Key(), err)
+			}
+			if changed {
+				diff.Updated = append(diff.Updated, newUnitChange(tb.node))
+			}
+			a.pop()
+			b.pop()
+		}
+	}
+	for n := a.next(); n != nil; n = a.next() {
+		diff.Deleted = append(diff.Deleted, n.Key())
+	}
+	for n := b.next(); n != nil; n = b.next() {
+		diff.Added = append(diff.Added, newUnitChange(n))
+	}
+	return diff, nil
+}
+
+// Diff returns the units that were added, updated and deleted between the states committed in the given rounds.
+// Both rounds must be available as snapshots (see WithSnapshotCount).
+func (s *State) Diff(fromRound, toRound uint64) (*StateDiff, error) {
+	from, err := s.Snapshot(fromRound)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.Snapshot(toRound)
+	if err != nil {
+		return nil, err
+	}
+	return Diff(from, to)
+}
+
+func newUnitChange(n *node) *UnitChange {
+	return &UnitChange{UnitID: n.Key(), Data: n.Value().Data()}
+}
+
+// isUnitChanged returns true if the unit ledger head or the data of the unit differ. The logs hash is not compared,
+// as it changes also when the logs of an unchanged unit are pruned.
+func isUnitChanged(a, b *Unit, hashAlgorithm crypto.Hash) (bool, error) {
+	if !bytes.Equal(latestUnitLedgerHeadHash(a), latestUnitLedgerHeadHash(b)) {
+		return true, nil
+	}
+	ha, err := unitDataHash(a.data, hashAlgorithm)
+	if err != nil {
+		return false, err
+	}
+	hb, err := unitDataHash(b.data, hashAlgorithm)
+	if err != nil {
+		return false, err
+	}
+	return !bytes.Equal(ha, hb), nil
+}
+
+func latestUnitLedgerHeadHash(u *Unit) []byte {
+	if len(u.logs) == 0 {
+		return nil
+	}
+	return u.logs[len(u.logs)-1].UnitLedgerHeadHash
+}
+
+func unitDataHash(data types.UnitData, hashAlgorithm crypto.Hash) ([]byte, error) {
+	if data == nil {
+		return nil, nil
+	}
+	hasher := hashAlgorithm.New()
+	if err := data.Write(hasher); err != nil {
+		return nil, err
+	}
+	return hasher.Sum(nil), nil
+}
+
+func newDiffIterator(root *node) *diffIterator {
+	it := &diffIterator{}
+	it.push(root, false)
+	return it
+}
+
+func (it *diffIterator) isEmpty() bool {
+	return len(it.stack) == 0
+}
+
+func (it *diffIterator) peek() diffItem {
+	return it.stack[len(it.stack)-1]
+}
+
+func (it *diffIterator) pop() diffItem {
+	item := it.peek()
+	it.stack = it.stack[:len(it.stack)-1]
+	return item
+}
+
+func (it *diffIterator) push(n *node, expanded bool) {
+	if n != nil {
+		it.stack = append(it.stack, diffItem{node: n, expanded: expanded})
+	}
+}
+
+// expand replaces the subtree on top of the stack with its right subtree, the unit of the node and its left subtree.
+func (it *diffIterator) expand() {
+	item := it.pop()
+	it.push(item.node.Right(), false)
+	it.push(item.node, true)
+	it.push(item.node.Left(), false)
+}
+
+// next returns the next node in order or nil if there are no more nodes.
+func (it *diffIterator) next() *node {
+	for !it.isEmpty() {
+		if it.peek().expanded {
+			return it.pop().node
+		}
+		it.expand()
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package state
+
+import (
+	"crypto"
+	"math/rand"
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff_SameSnapshot(t *testing.T) {
+	s, _, _ := prepareState(t)
+	snapshot := s.LatestSnapshot()
+	diff, err := Diff(snapshot, snapshot)
+	require.NoError(t, err)
+	require.Empty(t, diff.Added)
+	require.Empty(t, diff.Updated)
+	require.Empty(t, diff.Deleted)
+}
+
+func TestDiff_InvalidSnapshots(t *testing.T) {
+	s, _, _ := prepareState(t)
+	_, err := Diff(nil, s.LatestSnapshot())
+	require.ErrorContains(t, err, "snapshot is nil")
+
+	s512 := NewEmptyState(WithHashAlgorithm(crypto.SHA512))
+	summaryValue, summaryHash, err := s512.CalculateRoot()
+	require.NoError(t, err)
+	if summaryHash == nil {
+		summaryHash = make([]byte, crypto.SHA512.Size())
+	}
+	require.NoError(t, s512.Commit(createUC(s512, summaryValue, summaryHash)))
+	_, err = Diff(s.LatestSnapshot(), s512.LatestSnapshot())
+	require.ErrorContains(t, err, "snapshots use different hash algorithms")
+
+	_, err = s.Diff(1, 5)
+	require.ErrorIs(t, err, ErrSnapshotNotFound)
+}
+
+func TestDiff_AddUpdateDelete(t *testing.T) {
+	s, _, _ := prepareState(t)
+	fromRound := s.CommittedUC().GetRoundNumber()
+
+	// round without changes
+	require.NoError(t, s.Prune())
+	commitState(t, s)
+
+	// changes
+	require.NoError(t, s.Prune())
+	require.NoError(t, s.Apply(
+		AddUnit([]byte{0, 0, 2, 0}, &pruneUnitData{I: 200}),
+		UpdateUnitData([]byte{0, 0, 0, 4}, multiply(2)),
+		DeleteUnit([]byte{0, 0, 0, 6}),
+		DeleteUnit([]byte{0, 0, 0, 0}),
+	))
+	require.NoError(t, s.AddUnitLog([]byte{0, 0, 2, 0}, []byte{1}))
+	require.NoError(t, s.AddUnitLog([]byte{0, 0, 0, 4}, []byte{1}))
+	commitState(t, s)
+
+	// round without changes (logs of the changed units are pruned)
+	require.NoError(t, s.Prune())
+	commitState(t, s)
+	toRound := s.CommittedUC().GetRoundNumber()
+
+	diff, err := s.Diff(fromRound, toRound)
+	require.NoError(t, err)
+	require.Equal(t, fromRound, diff.FromRound)
+	require.Equal(t, toRound, diff.ToRound)
+	require.Equal(t, []*UnitChange{{UnitID: []byte{0, 0, 2, 0}, Data: &pruneUnitData{I: 200}}}, diff.Added)
+	require.Equal(t, []*UnitChange{{UnitID: []byte{0, 0, 0, 4}, Data: &pruneUnitData{I: 80}}}, diff.Updated)
+	require.Equal(t, []types.UnitID{{0, 0, 0, 0}, {0, 0, 0, 6}}, diff.Deleted)
+
+	// reverse diff
+	diff, err = s.Diff(toRound, fromRound)
+	require.NoError(t, err)
+	require.Equal(t, []*UnitChange{{UnitID: []byte{0, 0, 0, 0}, Data: &pruneUnitData{I: 1}}, {UnitID: []byte{0, 0, 0, 6}, Data: &pruneUnitData{I: 60}}}, diff.Added)
+	require.Equal(t, []*UnitChange{{UnitID: []byte{0, 0, 0, 4}, Data: &pruneUnitData{I: 40}}}, diff.Updated)
+	require.Equal(t, []types.UnitID{{0, 0, 2, 0}}, diff.Deleted)
+}
+
+func TestDiff_Random(t *testing.T) {
+	s := NewEmptyState()
+	from := s.LatestSnapshot()
+	units := map[byte]uint64{}
+	for round := 0; round < 10; round++ {
+		if round > 0 {
+			require.NoError(t, s.Prune())
+		}
+		for i := 0; i < 20; i++ {
+			id := byte(rand.Intn(100))
+			if _, ok := units[id]; ok {
+				if rand.Intn(2) == 0 {
+					require.NoError(t, s.Apply(DeleteUnit([]byte{id})))
+					delete(units, id)
+					continue
+				}
+				require.NoError(t, s.Apply(UpdateUnitData([]byte{id}, multiply(2))))
+				units[id] *= 2
+			} else {
+				require.NoError(t, s.Apply(AddUnit([]byte{id}, &pruneUnitData{I: uint64(id) + 1})))
+				units[id] = uint64(id) + 1
+			}
+			require.NoError(t, s.AddUnitLog([]byte{id}, []byte{byte(round), byte(i)}))
+		}
+		commitState(t, s)
+		if from == nil {
+			from = s.LatestSnapshot()
+		}
+	}
+
+	diff, err := Diff(from, s.LatestSnapshot())
+	require.NoError(t, err)
+	// applying the diff to the first snapshot must result in the latest state
+	result := map[byte]uint64{}
+	from.Traverse(&unitCollector{units: result})
+	for _, id := range diff.Deleted {
+		_, ok := result[id[0]]
+		require.True(t, ok)
+		delete(result, id[0])
+	}
+	for _, u := range diff.Updated {
+		_, ok := result[u.UnitID[0]]
+		require.True(t, ok)
+		result[u.UnitID[0]] = u.Data.SummaryValueInput()
+	}
+	for _, u := range diff.Added {
+		_, ok := result[u.UnitID[0]]
+		require.False(t, ok)
+		result[u.UnitID[0]] = u.Data.SummaryValueInput()
+	}
+	require.Equal(t, units, result)
+}
+
+type unitCollector struct {
+	units map[byte]uint64
+}
+
+func (c *unitCollector) Traverse(n *node) {
+	if n == nil {
+		return
+	}
+	c.Traverse(n.Left())
+	c.Traverse(n.Right())
+	c.units[n.Key()[0]] = n.Value().Data().SummaryValueInput()
+}
+
+func commitState(t *testing.T, s *State) {
+	summaryValue, summaryHash, err := s.CalculateRoot()
+	require.NoError(t, err)
+	if summaryHash == nil {
+		summaryHash = make([]byte, s.hashAlgorithm.Size())
+	}
+	require.NoError(t, s.Commit(createUC(s, summaryValue, summaryHash)))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/tree/avl"
 )
 
 // stateIndexer traverses the state tree and constructs an index using the keyExtractor
@@ -16,6 +17,10 @@ type (
 
 	Index[T comparable]        map[T][]types.UnitID
 	KeyExtractor[T comparable] func(unit *Unit) (T, error)
+
+	traversable interface {
+		Traverse(traverser avl.Traverser[types.UnitID, *Unit])
+	}
 )
 
 func (s *stateIndexer[T]) Traverse(n *node) {
@@ -37,7 +42,7 @@ func (s *stateIndexer[T]) Traverse(n *node) {
 	}
 }
 
-func CreateIndex[T comparable](s *State, ke KeyExtractor[T]) (Index[T], error) {
+func CreateIndex[T comparable](s traversable, ke KeyExtractor[T]) (Index[T], error) {
 	indexer := &stateIndexer[T]{
 		index:        Index[T]{},
 		keyExtractor: ke,
@@ -0,0 +1,467 @@
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill-go-base/util"
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	SnapshotVersion1 = 1
+	SnapshotVersion2 = 2
+
+	// DefaultChunkSize is the default maximum number of node records in a v2 snapshot chunk.
+	DefaultChunkSize = 10000
+)
+
+const (
+	CompressionNone Compression = iota
+	CompressionGzip
+)
+
+type (
+	// Compression is the compression algorithm of the v2 snapshot chunks.
+	Compression uint8
+
+	// headerV2 is the header of the v2 snapshot. The header is followed by ChunkCount chunks, each chunk
+	// containing (at most) ChunkSize node records. Node records are in the same (post-order) order as in
+	// the v1 snapshot.
+	headerV2 struct {
+		_                  struct{} `cbor:",toarray"`
+		Version            uint32
+		Compression        Compression
+		UnicityCertificate *types.UnicityCertificate
+		NodeRecordCount    uint64
+		ChunkSize          uint64
+		ChunkCount         uint64
+	}
+
+	// chunk is an independently verifiable part of the v2 snapshot. Hash is the SHA256 hash of the
+	// uncompressed node records, Data contains the node records compressed according to the header.
+	chunk struct {
+		_           struct{} `cbor:",toarray"`
+		Index       uint64
+		RecordCount uint64
+		Hash        []byte
+		Data        []byte
+	}
+
+	SerializeOptions struct {
+		chunkSize   uint64
+		compression Compression
+		firstChunk  uint64
+	}
+
+	SerializeOption func(o *SerializeOptions)
+
+	// chunkWriter collects the encoded node records into chunks and writes the chunks using the encode function.
+	chunkWriter struct {
+		encode      func(any) error
+		options     *SerializeOptions
+		index       uint64
+		recordCount uint64
+		buf         bytes.Buffer
+	}
+)
+
+// WithChunkSize sets the maximum number of node records in a v2 snapshot chunk.
+func WithChunkSize(chunkSize uint64) SerializeOption {
+	return func(o *SerializeOptions) {
+		o.chunkSize = chunkSize
+	}
+}
+
+// WithCompression sets the compression algorithm of the v2 snapshot chunks.
+func WithCompression(compression Compression) SerializeOption {
+	return func(o *SerializeOptions) {
+		o.compression = compression
+	}
+}
+
+// WithFirstChunk makes the serializer skip all the chunks before the given chunk index. Used to resume
+// an interrupted snapshot download.
+func WithFirstChunk(index uint64) SerializeOption {
+	return func(o *SerializeOptions) {
+		o.firstChunk = index
+	}
+}
+
+func loadSerializeOptions(opts ...SerializeOption) (*SerializeOptions, error) {
+	options := &SerializeOptions{
+		chunkSize:   DefaultChunkSize,
+		compression: CompressionNone,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.chunkSize == 0 {
+		return nil, errors.New("chunk size must be greater than zero")
+	}
+	if options.compression > CompressionGzip {
+		return nil, fmt.Errorf("unsupported compression: %d", options.compression)
+	}
+	return options, nil
+}
+
+// SerializeV2 writes the committed state to the given writer using the v2 (chunked) snapshot format.
+// Not concurrency safe. Should use a Snapshot or clone the state before calling this.
+func (s *State) SerializeV2(writer io.Writer, committed bool, opts ...SerializeOption) error {
+	if committed {
+		return serializeV2(writer, s.committedTree, s.committedTreeUC, s.hashAlgorithm, opts...)
+	}
+	return serializeV2(writer, s.latestSavepoint(), nil, s.hashAlgorithm, opts...)
+}
+
+// SerializeV2 writes the snapshot to the given writer using the v2 (chunked) snapshot format.
+func (s *Snapshot) SerializeV2(writer io.Writer, opts ...SerializeOption) error {
+	return serializeV2(writer, s.tree, s.uc, s.hashAlgorithm, opts...)
+}
+
+func serializeV2(writer io.Writer, tree *tree, uc *types.UnicityCertificate, hashAlgorithm crypto.Hash, opts ...SerializeOption) error {
+	options, err := loadSerializeOptions(opts...)
+	if err != nil {
+		return err
+	}
+	encoder, err := types.Cbor.GetEncoder(writer)
+	if err != nil {
+		return fmt.Errorf("unable to get encoder: %w", err)
+	}
+
+	snc := NewStateNodeCounter()
+	tree.Traverse(snc)
+	header := newHeaderV2(uc, snc.NodeCount(), options)
+	if err := encoder.Encode(header); err != nil {
+		return fmt.Errorf("unable to write header: %w", err)
+	}
+
+	cw := newChunkWriter(encoder.Encode, options)
+	ss := newStateSerializer(cw.add, hashAlgorithm)
+	if tree.Traverse(ss); ss.err != nil {
+		return fmt.Errorf("unable to write node records: %w", ss.err)
+	}
+	if err := cw.flush(); err != nil {
+		return fmt.Errorf("unable to write node records: %w", err)
+	}
+	return nil
+}
+
+func newHeaderV2(uc *types.UnicityCertificate, nodeRecordCount uint64, options *SerializeOptions) *headerV2 {
+	return &headerV2{
+		Version:            SnapshotVersion2,
+		Compression:        options.compression,
+		UnicityCertificate: uc,
+		NodeRecordCount:    nodeRecordCount,
+		ChunkSize:          options.chunkSize,
+		ChunkCount:         (nodeRecordCount + options.chunkSize - 1) / options.chunkSize,
+	}
+}
+
+func newChunkWriter(encode func(any) error, options *SerializeOptions) *chunkWriter {
+	return &chunkWriter{encode: encode, options: options}
+}
+
+func (c *chunkWriter) add(nodeRecord any) error {
+	// records of the skipped chunks are only counted
+	if c.index >= c.options.firstChunk {
+		data, err := types.Cbor.Marshal(nodeRecord)
+		if err != nil {
+			return fmt.Errorf("unable to encode node record: %w", err)
+		}
+		c.buf.Write(data)
+	}
+	c.recordCount++
+	if c.recordCount == c.options.chunkSize {
+		return c.flush()
+	}
+	return nil
+}
+
+func (c *chunkWriter) flush() error {
+	if c.recordCount == 0 {
+		return nil
+	}
+	if c.index >= c.options.firstChunk {
+		hash := sha256.Sum256(c.buf.Bytes())
+		data, err := compress(c.buf.Bytes(), c.options.compression)
+		if err != nil {
+			return fmt.Errorf("unable to compress chunk %d: %w", c.index, err)
+		}
+		if err := c.encode(&chunk{Index: c.index, RecordCount: c.recordCount, Hash: hash[:], Data: data}); err != nil {
+			return fmt.Errorf("unable to write chunk %d: %w", c.index, err)
+		}
+	}
+	c.index++
+	c.recordCount = 0
+	c.buf.Reset()
+	return nil
+}
+
+// records verifies the chunk and returns the uncompressed node records of the chunk.
+func (c *chunk) records(compression Compression) ([]byte, error) {
+	data, err := decompress(c.Data, compression)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress chunk %d: %w", c.Index, err)
+	}
+	hash := sha256.Sum256(data)
+	if !bytes.Equal(hash[:], c.Hash) {
+		return nil, fmt.Errorf("chunk %d hash mismatch", c.Index)
+	}
+	return data, nil
+}
+
+func compress(data []byte, compression Compression) ([]byte, error) {
+	switch compression {
+	case CompressionNone:
+		return bytes.Clone(data), nil
+	case CompressionGzip:
+		buf := &bytes.Buffer{}
+		w := gzip.NewWriter(buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression: %d", compression)
+	}
+}
+
+func decompress(data []byte, compression Compression) ([]byte, error) {
+	switch compression {
+	case CompressionNone:
+		return data, nil
+	case CompressionGzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	default:
+		return nil, fmt.Errorf("unsupported compression: %d", compression)
+	}
+}
+
+func (c Compression) String() string {
+	switch c {
+	case CompressionNone:
+		return "none"
+	case CompressionGzip:
+		return "gzip"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(c))
+	}
+}
+
+// snapshotVersion returns the version of the snapshot with the given (CBOR encoded) header. The v1 header does
+// not have a version field, the first field of the v1 header is the unicity certificate (a CBOR array, or null
+// if the state has not been committed yet).
+func snapshotVersion(rawHeader cbor.RawMessage) (uint32, error) {
+	var fields []cbor.RawMessage
+	if err := types.Cbor.Unmarshal(rawHeader, &fields); err != nil {
+		return 0, fmt.Errorf("unable to decode header: %w", err)
+	}
+	if len(fields) == 0 || len(fields[0]) == 0 {
+		return 0, errors.New("header is empty")
+	}
+	// major type 0 is an unsigned integer
+	if fields[0][0]>>5 != 0 {
+		return SnapshotVersion1, nil
+	}
+	var version uint32
+	if err := types.Cbor.Unmarshal(fields[0], &version); err != nil {
+		return SnapshotVersion1, nil
+	}
+	return version, nil
+}
+
+// SnapshotRecoverer restores the state from a v2 snapshot. Chunks are verified and applied one by one, if
+// reading the snapshot is interrupted it can be resumed by reading a new snapshot stream that starts from
+// the chunk NextChunk (see WithFirstChunk).
+type SnapshotRecoverer struct {
+	udc       UnitDataConstructor
+	options   []Option
+	hashAlgo  crypto.Hash
+	header    *headerV2
+	rawHeader cbor.RawMessage
+	nodeStack util.Stack[*node]
+	nextChunk uint64
+}
+
+func NewSnapshotRecoverer(udc UnitDataConstructor, opts ...Option) (*SnapshotRecoverer, error) {
+	if udc == nil {
+		return nil, fmt.Errorf("unit data constructor is nil")
+	}
+	return &SnapshotRecoverer{
+		udc:      udc,
+		options:  opts,
+		hashAlgo: loadOptions(opts...).hashAlgorithm,
+	}, nil
+}
+
+// ReadSnapshot reads the v2 snapshot stream from the given reader. The stream must start with the header and
+// the header must be the same in all the streams read by the recoverer. Chunks that have already been
+// applied are skipped. Returns nil if the stream ends at a chunk boundary, use Done to check if all chunks
+// have been read.
+func (r *SnapshotRecoverer) ReadSnapshot(reader io.Reader) error {
+	if reader == nil {
+		return fmt.Errorf("reader is nil")
+	}
+	decoder := types.Cbor.GetDecoder(reader)
+	var rawHeader cbor.RawMessage
+	if err := decoder.Decode(&rawHeader); err != nil {
+		return fmt.Errorf("unable to decode header: %w", err)
+	}
+	return r.readFrom(decoder, rawHeader)
+}
+
+func (r *SnapshotRecoverer) readFrom(decoder *cbor.Decoder, rawHeader cbor.RawMessage) error {
+	if r.header == nil {
+		version, err := snapshotVersion(rawHeader)
+		if err != nil {
+			return err
+		}
+		if version != SnapshotVersion2 {
+			return fmt.Errorf("unsupported snapshot version: %d", version)
+		}
+		header := &headerV2{}
+		if err := types.Cbor.Unmarshal(rawHeader, header); err != nil {
+			return fmt.Errorf("unable to decode header: %w", err)
+		}
+		if header.ChunkSize == 0 {
+			return errors.New("invalid header: chunk size is zero")
+		}
+		r.header = header
+		r.rawHeader = rawHeader
+	} else if !bytes.Equal(r.rawHeader, rawHeader) {
+		return errors.New("header does not match the header of the previously read snapshot")
+	}
+
+	for !r.Done() {
+		var c chunk
+		if err := decoder.Decode(&c); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("unable to decode chunk %d: %w", r.nextChunk, err)
+		}
+		if c.Index < r.nextChunk {
+			// already applied
+			continue
+		}
+		if c.Index > r.nextChunk {
+			return fmt.Errorf("expected chunk %d, got chunk %d", r.nextChunk, c.Index)
+		}
+		if err := r.applyChunk(&c); err != nil {
+			return err
+		}
+		r.nextChunk++
+	}
+	return nil
+}
+
+func (r *SnapshotRecoverer) applyChunk(c *chunk) error {
+	expectedCount := r.header.ChunkSize
+	if c.Index == r.header.ChunkCount-1 {
+		expectedCount = r.header.NodeRecordCount - c.Index*r.header.ChunkSize
+	}
+	if c.RecordCount != expectedCount {
+		return fmt.Errorf("chunk %d: expected %d node records, got %d", c.Index, expectedCount, c.RecordCount)
+	}
+	data, err := c.records(r.header.Compression)
+	if err != nil {
+		return err
+	}
+	// decode the whole chunk before changing the node stack, so that a failed chunk can be retried
+	decoder := types.Cbor.GetDecoder(bytes.NewReader(data))
+	records := make([]*nodeRecord, c.RecordCount)
+	for i := range records {
+		if err := decoder.Decode(&records[i]); err != nil {
+			return fmt.Errorf("chunk %d: unable to decode node record: %w", c.Index, err)
+		}
+	}
+	if decoder.NumBytesRead() != len(data) {
+		return fmt.Errorf("chunk %d: unexpected data after node records", c.Index)
+	}
+	nodes := make([]*node, 0, len(records))
+	for _, nr := range records {
+		n, err := newNodeFromRecord(nr, r.udc, r.hashAlgo)
+		if err != nil {
+			return fmt.Errorf("chunk %d: %w", c.Index, err)
+		}
+		nodes = append(nodes, n)
+	}
+	if err := r.checkStack(records); err != nil {
+		return fmt.Errorf("chunk %d: %w", c.Index, err)
+	}
+	for i, n := range nodes {
+		pushNode(&r.nodeStack, n, records[i])
+	}
+	return nil
+}
+
+// checkStack verifies that the node stack has enough nodes for the children of the given records.
+func (r *SnapshotRecoverer) checkStack(records []*nodeRecord) error {
+	size := len(r.nodeStack)
+	for _, nr := range records {
+		if nr.HasLeft {
+			size--
+		}
+		if nr.HasRight {
+			size--
+		}
+		if size < 0 {
+			return fmt.Errorf("node record %v: missing child node", nr.UnitID)
+		}
+		size++
+	}
+	return nil
+}
+
+// NextChunk returns the index of the next chunk to be read.
+func (r *SnapshotRecoverer) NextChunk() uint64 {
+	return r.nextChunk
+}
+
+// RoundNumber returns the round number of the snapshot being recovered (zero when the header has not been read yet).
+// The interrupted download must be resumed from the snapshot of the same round (see the "round" parameter of the
+// state endpoint of the node).
+func (r *SnapshotRecoverer) RoundNumber() uint64 {
+	if r.header == nil {
+		return 0
+	}
+	return r.header.UnicityCertificate.GetRoundNumber()
+}
+
+// Done returns true if all the chunks of the snapshot have been read.
+func (r *SnapshotRecoverer) Done() bool {
+	return r.header != nil && r.nextChunk == r.header.ChunkCount
+}
+
+// State returns the recovered state. All the chunks of the snapshot must be read before calling this method.
+func (r *SnapshotRecoverer) State() (*State, error) {
+	if !r.Done() {
+		if r.header == nil {
+			return nil, errors.New("snapshot header has not been read")
+		}
+		return nil, fmt.Errorf("snapshot is incomplete: %d of %d chunks read", r.nextChunk, r.header.ChunkCount)
+	}
+	if len(r.nodeStack) > 1 {
+		return nil, fmt.Errorf("%d unexpected node record(s)", len(r.nodeStack)-1)
+	}
+	var root *node
+	if len(r.nodeStack) == 1 {
+		root = r.nodeStack[0]
+	}
+	return newRecoveredState(root, r.header.UnicityCertificate, r.options...)
+}
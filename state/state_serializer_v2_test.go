@@ -0,0 +1,184 @@
+package state
+
+import (
+	"bytes"
+	"crypto"
+	"fmt"
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerializeV2_OK(t *testing.T) {
+	for _, compression := range []Compression{CompressionNone, CompressionGzip} {
+		for _, chunkSize := range []uint64{1, 3, 11, 100} {
+			t.Run(fmt.Sprintf("%s/%d", compression, chunkSize), func(t *testing.T) {
+				s, rootHash, summaryValue := prepareState(t)
+
+				buf := &bytes.Buffer{}
+				require.NoError(t, s.SerializeV2(buf, true, WithChunkSize(chunkSize), WithCompression(compression)))
+
+				recovered, err := NewRecoveredState(buf, unitDataConstructor)
+				require.NoError(t, err)
+				require.True(t, recovered.IsCommitted())
+				require.Equal(t, s.CommittedUC(), recovered.CommittedUC())
+				recoveredSummaryValue, recoveredRootHash, err := recovered.CalculateRoot()
+				require.NoError(t, err)
+				require.Equal(t, rootHash, recoveredRootHash)
+				require.Equal(t, summaryValue, recoveredSummaryValue)
+			})
+		}
+	}
+}
+
+func TestSerializeV2_EmptyState(t *testing.T) {
+	s := NewEmptyState()
+	buf := &bytes.Buffer{}
+	require.NoError(t, s.SerializeV2(buf, false))
+
+	recovered, err := NewRecoveredState(buf, unitDataConstructor)
+	require.NoError(t, err)
+	require.False(t, recovered.IsCommitted())
+	require.Nil(t, recovered.latestSavepoint().Root())
+}
+
+func TestSerializeV2_InvalidOptions(t *testing.T) {
+	s := NewEmptyState()
+	require.ErrorContains(t, s.SerializeV2(&bytes.Buffer{}, true, WithChunkSize(0)), "chunk size must be greater than zero")
+	require.ErrorContains(t, s.SerializeV2(&bytes.Buffer{}, true, WithCompression(5)), "unsupported compression: 5")
+}
+
+func TestSnapshotRecoverer_Resume(t *testing.T) {
+	s, rootHash, _ := prepareState(t)
+	snapshot := s.LatestSnapshot()
+
+	full := &bytes.Buffer{}
+	require.NoError(t, snapshot.SerializeV2(full, WithChunkSize(2), WithCompression(CompressionGzip)))
+
+	r, err := NewSnapshotRecoverer(unitDataConstructor)
+	require.NoError(t, err)
+	_, err = r.State()
+	require.ErrorContains(t, err, "snapshot header has not been read")
+	require.Zero(t, r.RoundNumber())
+
+	// download is interrupted in the middle of the fourth chunk
+	interrupted := full.Bytes()[:full.Len()*2/3]
+	require.Error(t, r.ReadSnapshot(bytes.NewReader(interrupted)))
+	require.False(t, r.Done())
+	next := r.NextChunk()
+	require.Greater(t, next, uint64(0))
+	require.Less(t, next, uint64(6))
+	require.Equal(t, snapshot.CommittedUC().GetRoundNumber(), r.RoundNumber())
+	_, err = r.State()
+	require.ErrorContains(t, err, "snapshot is incomplete")
+
+	// resume from the next chunk
+	rest := &bytes.Buffer{}
+	require.NoError(t, snapshot.SerializeV2(rest, WithChunkSize(2), WithCompression(CompressionGzip), WithFirstChunk(next)))
+	require.Less(t, rest.Len(), full.Len())
+	require.NoError(t, r.ReadSnapshot(rest))
+	require.True(t, r.Done())
+
+	recovered, err := r.State()
+	require.NoError(t, err)
+	_, recoveredRootHash, err := recovered.CalculateRoot()
+	require.NoError(t, err)
+	require.Equal(t, rootHash, recoveredRootHash)
+	require.Equal(t, snapshot.CommittedUC(), recovered.CommittedUC())
+}
+
+func TestSnapshotRecoverer_AlreadyAppliedChunksAreSkipped(t *testing.T) {
+	s, rootHash, _ := prepareState(t)
+	full := &bytes.Buffer{}
+	require.NoError(t, s.SerializeV2(full, true, WithChunkSize(4)))
+
+	r, err := NewSnapshotRecoverer(unitDataConstructor)
+	require.NoError(t, err)
+	require.NoError(t, r.ReadSnapshot(bytes.NewReader(full.Bytes())))
+	require.True(t, r.Done())
+	require.EqualValues(t, 3, r.NextChunk())
+	// reading the same snapshot again does not change anything
+	require.NoError(t, r.ReadSnapshot(bytes.NewReader(full.Bytes())))
+
+	recovered, err := r.State()
+	require.NoError(t, err)
+	_, recoveredRootHash, err := recovered.CalculateRoot()
+	require.NoError(t, err)
+	require.Equal(t, rootHash, recoveredRootHash)
+}
+
+func TestSnapshotRecoverer_Errors(t *testing.T) {
+	s, _, _ := prepareState(t)
+
+	t.Run("unit data constructor is nil", func(t *testing.T) {
+		_, err := NewSnapshotRecoverer(nil)
+		require.ErrorContains(t, err, "unit data constructor is nil")
+	})
+	t.Run("v1 snapshot", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, s.Serialize(buf, true))
+		r, err := NewSnapshotRecoverer(unitDataConstructor)
+		require.NoError(t, err)
+		require.ErrorContains(t, r.ReadSnapshot(buf), "unsupported snapshot version: 1")
+	})
+	t.Run("header mismatch", func(t *testing.T) {
+		r, err := NewSnapshotRecoverer(unitDataConstructor)
+		require.NoError(t, err)
+		buf := &bytes.Buffer{}
+		require.NoError(t, s.SerializeV2(buf, true, WithChunkSize(2)))
+		require.Error(t, r.ReadSnapshot(bytes.NewReader(buf.Bytes()[:buf.Len()/2])))
+		require.Greater(t, r.NextChunk(), uint64(0))
+		buf.Reset()
+		require.NoError(t, s.SerializeV2(buf, true, WithChunkSize(3)))
+		require.ErrorContains(t, r.ReadSnapshot(buf), "header does not match the header of the previously read snapshot")
+	})
+	t.Run("missing chunk", func(t *testing.T) {
+		r, err := NewSnapshotRecoverer(unitDataConstructor)
+		require.NoError(t, err)
+		buf := &bytes.Buffer{}
+		require.NoError(t, s.SerializeV2(buf, true, WithChunkSize(2), WithFirstChunk(1)))
+		require.ErrorContains(t, r.ReadSnapshot(buf), "expected chunk 0, got chunk 1")
+	})
+	t.Run("invalid chunk hash", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, s.SerializeV2(buf, true, WithChunkSize(20)))
+		data := buf.Bytes()
+		// change the value of the last unit data
+		data[len(data)-80] ^= 0xFF
+		_, err := NewRecoveredState(bytes.NewReader(data), unitDataConstructor)
+		require.ErrorContains(t, err, "chunk 0 hash mismatch")
+	})
+	t.Run("invalid record count", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, s.SerializeV2(buf, true, WithChunkSize(2)))
+		decoder := types.Cbor.GetDecoder(buf)
+		h, err := decodeHeaderV2(decoder)
+		require.NoError(t, err)
+		h.NodeRecordCount = 12
+		h.ChunkCount = 6
+		invalid := &bytes.Buffer{}
+		require.NoError(t, types.Cbor.Encode(invalid, h))
+		_, err = invalid.ReadFrom(decoder.Buffered())
+		require.NoError(t, err)
+		_, err = invalid.ReadFrom(buf)
+		require.NoError(t, err)
+		_, err = NewRecoveredState(invalid, unitDataConstructor)
+		require.ErrorContains(t, err, "chunk 5: expected 2 node records, got 1")
+	})
+	t.Run("unit data constructor error", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, s.SerializeV2(buf, true))
+		_, err := NewRecoveredState(buf, func(types.UnitID) (types.UnitData, error) {
+			return nil, fmt.Errorf("no unit data")
+		})
+		require.ErrorContains(t, err, "unable to construct unit data: no unit data")
+	})
+	t.Run("invalid UC", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		s512 := NewEmptyState(WithHashAlgorithm(crypto.SHA512))
+		require.NoError(t, serializeV2(buf, s.committedTree, s.committedTreeUC, s512.hashAlgorithm))
+		_, err := NewRecoveredState(buf, unitDataConstructor, WithHashAlgorithm(crypto.SHA512))
+		require.ErrorContains(t, err, "unable to commit recovered state")
+	})
+}
@@ -379,6 +379,21 @@ func TestState_RevertAVLTreeRotations(t *testing.T) {
 	require.Equal(t, root, root2)
 }
 
+func TestState_TraverseUncommitted(t *testing.T) {
+	s := NewEmptyState()
+	require.NoError(t, s.Apply(AddUnit([]byte{1}, &TestData{Value: 10})))
+	commitState(t, s)
+	require.NoError(t, s.Apply(AddUnit([]byte{2}, &TestData{Value: 20})))
+
+	committed := map[byte]uint64{}
+	s.Traverse(&unitCollector{units: committed})
+	require.Equal(t, map[byte]uint64{1: 10}, committed)
+
+	latest := map[byte]uint64{}
+	s.TraverseUncommitted(&unitCollector{units: latest})
+	require.Equal(t, map[byte]uint64{1: 10, 2: 20}, latest)
+}
+
 func TestState_GetUnit(t *testing.T) {
 	unitID := []byte{0, 0, 0, 1}
 	unitData := &TestData{Value: 10}
@@ -0,0 +1,55 @@
+package evmenc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/evm"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/encoder"
+)
+
+func init() {
+	encoder.Register(RegisterTxAttributeEncoders, RegisterUnitDataEncoders)
+}
+
+func RegisterTxAttributeEncoders(reg func(id encoder.AttrEncID, enc encoder.TxAttributesEncoder) error) error {
+	key := func(attrID uint16) encoder.AttrEncID {
+		return encoder.AttrEncID{
+			TxSys: evm.DefaultSystemID,
+			Attr:  attrID,
+		}
+	}
+	return errors.Join(
+		reg(key(evm.TransactionTypeEVMCall), txaEVMCallAttributes),
+	)
+}
+
+/*
+txaEVMCallAttributes encodes EVM call attributes:
+  - ver 1: from, to (omitted for contract creation), data, value (big-endian
+    bytes), gas and nonce;
+*/
+func txaEVMCallAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &evm.TxAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.From)
+	if len(attr.To) != 0 {
+		buf.EncodeTagged(2, attr.To)
+	}
+	buf.EncodeTagged(3, attr.Data)
+	var value []byte
+	if attr.Value != nil {
+		value = attr.Value.Bytes()
+	}
+	buf.EncodeTagged(4, value)
+	buf.EncodeTagged(5, attr.Gas)
+	buf.EncodeTagged(6, attr.Nonce)
+	return buf.Bytes()
+}
@@ -0,0 +1,41 @@
+package evmenc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/evm"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/txsystem/evm/statedb"
+)
+
+func Test_txaEVMCallAttributes(t *testing.T) {
+	txo := &types.TransactionOrder{Payload: types.Payload{}}
+	require.NoError(t, txo.SetAttributes(evm.TxAttributes{From: []byte{1}, Data: []byte{2}, Value: big.NewInt(256), Gas: 7, Nonce: 8}))
+	b, err := txaEVMCallAttributes(txo, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x1, 0x1, 0x1, 0x0, 0x0, 0x0, 0x1, 0x3, 0x1, 0x1, 0x0, 0x0, 0x0, 0x2, 0x4, 0x1, 0x2, 0x0, 0x0, 0x0, 0x1, 0x0, 0x5, 0x2, 0x7, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x6, 0x2, 0x8, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, b)
+
+	b, err = txaEVMCallAttributes(txo, 2)
+	require.EqualError(t, err, `unsupported encoding version 2, latest supported version is 1`)
+	require.Nil(t, b)
+}
+
+func Test_udeStateObject(t *testing.T) {
+	b, err := udeStateObject(&statedb.StateObject{
+		Address:   common.Address{1},
+		Account:   &statedb.Account{Balance: uint256.NewInt(256), CodeHash: []byte{2}, Nonce: 3},
+		AlphaBill: &statedb.AlphaBillLink{Counter: 4, Timeout: 5},
+	}, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x1, 0x1, 0x14, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x2, 0x1, 0x2, 0x0, 0x0, 0x0, 0x1, 0x0, 0x3, 0x1, 0x1, 0x0, 0x0, 0x0, 0x2, 0x4, 0x2, 0x3, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x5, 0x2, 0x4, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x6, 0x2, 0x5, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, b)
+
+	// account not linked to fee credit record
+	b, err = udeStateObject(&statedb.StateObject{Address: common.Address{1}}, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x1, 0x1, 0x14, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, b)
+}
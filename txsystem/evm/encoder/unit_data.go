@@ -0,0 +1,43 @@
+package evmenc
+
+import (
+	"errors"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/encoder"
+	"github.com/alphabill-org/alphabill/txsystem/evm/statedb"
+)
+
+func RegisterUnitDataEncoders(reg func(ud any, enc encoder.UnitDataEncoder) error) error {
+	return errors.Join(
+		reg(&statedb.StateObject{}, udeStateObject),
+	)
+}
+
+/*
+udeStateObject encodes EVM account:
+  - ver 1: address, balance (big-endian bytes), code hash, nonce and, when the
+    account is linked to fee credit record, counter and timeout of the link;
+*/
+func udeStateObject(data types.UnitData, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	value := data.(*statedb.StateObject)
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, value.Address.Bytes())
+	if acc := value.Account; acc != nil {
+		var balance []byte
+		if acc.Balance != nil {
+			balance = acc.Balance.Bytes()
+		}
+		buf.EncodeTagged(2, balance)
+		buf.EncodeTagged(3, acc.CodeHash)
+		buf.EncodeTagged(4, acc.Nonce)
+	}
+	if ab := value.AlphaBill; ab != nil {
+		buf.EncodeTagged(5, ab.Counter)
+		buf.EncodeTagged(6, ab.Timeout)
+	}
+	return buf.Bytes()
+}
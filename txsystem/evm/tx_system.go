@@ -92,6 +92,14 @@ func (m *TxSystem) State() txsystem.StateReader {
 	return m.state.Clone()
 }
 
+func (m *TxSystem) StateAt(roundNumber uint64) (txsystem.StateReader, error) {
+	snapshot, err := m.state.Snapshot(roundNumber)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
 func (m *TxSystem) StateSize() (uint64, error) {
 	if !m.state.IsCommitted() {
 		return 0, txsystem.ErrStateContainsUncommittedChanges
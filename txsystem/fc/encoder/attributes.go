@@ -0,0 +1,168 @@
+package fcenc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/fc"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/fc/permissioned"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/encoder"
+)
+
+func init() {
+	encoder.Register(RegisterTxAttributeEncoders, RegisterUnitDataEncoders)
+}
+
+/*
+RegisterTxAttributeEncoders registers fee credit transaction attribute encoders.
+Fee credit transactions are processed by all the partitions so encoders are
+registered for encoder.AnyTxSystem.
+*/
+func RegisterTxAttributeEncoders(reg func(id encoder.AttrEncID, enc encoder.TxAttributesEncoder) error) error {
+	key := func(attrID uint16) encoder.AttrEncID {
+		return encoder.AttrEncID{
+			TxSys: encoder.AnyTxSystem,
+			Attr:  attrID,
+		}
+	}
+	return errors.Join(
+		reg(key(fc.TransactionTypeTransferFeeCredit), txaTransferFeeCreditAttributes),
+		reg(key(fc.TransactionTypeReclaimFeeCredit), txaReclaimFeeCreditAttributes),
+		reg(key(fc.TransactionTypeAddFeeCredit), txaAddFeeCreditAttributes),
+		reg(key(fc.TransactionTypeCloseFeeCredit), txaCloseFeeCreditAttributes),
+		reg(key(fc.TransactionTypeLockFeeCredit), txaLockFeeCreditAttributes),
+		reg(key(fc.TransactionTypeUnlockFeeCredit), txaUnlockFeeCreditAttributes),
+		reg(key(permissioned.TransactionTypeSetFeeCredit), txaSetFeeCreditAttributes),
+		reg(key(permissioned.TransactionTypeDeleteFeeCredit), txaDeleteFeeCreditAttributes),
+	)
+}
+
+func txaTransferFeeCreditAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &fc.TransferFeeCreditAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.Amount)
+	buf.EncodeTagged(2, uint32(attr.TargetSystemIdentifier))
+	buf.EncodeTagged(3, attr.TargetRecordID)
+	buf.EncodeTagged(4, attr.LatestAdditionTime)
+	if attr.TargetUnitCounter != nil {
+		buf.EncodeTagged(5, *attr.TargetUnitCounter)
+	}
+	buf.EncodeTagged(6, attr.Counter)
+	return buf.Bytes()
+}
+
+/*
+txaReclaimFeeCreditAttributes encodes reclaim attributes:
+  - ver 1: CBOR encoded "close fee credit" transaction record proof;
+*/
+func txaReclaimFeeCreditAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &fc.ReclaimFeeCreditAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, buf.CBORBytes(attr.CloseFeeCreditProof))
+	return buf.Bytes()
+}
+
+/*
+txaAddFeeCreditAttributes encodes add fee credit attributes:
+  - ver 1: fee credit owner predicate and CBOR encoded "transfer fee credit"
+    transaction record proof;
+*/
+func txaAddFeeCreditAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &fc.AddFeeCreditAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.FeeCreditOwnerPredicate)
+	buf.EncodeTagged(2, buf.CBORBytes(attr.FeeCreditTransferProof))
+	return buf.Bytes()
+}
+
+func txaCloseFeeCreditAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &fc.CloseFeeCreditAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.Amount)
+	buf.EncodeTagged(2, attr.TargetUnitID)
+	buf.EncodeTagged(3, attr.TargetUnitCounter)
+	buf.EncodeTagged(4, attr.Counter)
+	return buf.Bytes()
+}
+
+func txaLockFeeCreditAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &fc.LockFeeCreditAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.Counter)
+	buf.EncodeTagged(2, attr.LockStatus)
+	return buf.Bytes()
+}
+
+func txaUnlockFeeCreditAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &fc.UnlockFeeCreditAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.Counter)
+	return buf.Bytes()
+}
+
+func txaSetFeeCreditAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &permissioned.SetFeeCreditAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.OwnerPredicate)
+	buf.EncodeTagged(2, attr.Amount)
+	if attr.Counter != nil {
+		buf.EncodeTagged(3, *attr.Counter)
+	}
+	return buf.Bytes()
+}
+
+func txaDeleteFeeCreditAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &permissioned.DeleteFeeCreditAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.Counter)
+	return buf.Bytes()
+}
@@ -0,0 +1,114 @@
+package fcenc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/fc"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/fc/permissioned"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/encoder"
+)
+
+func Test_fcAttributesEncoding_trigger(t *testing.T) {
+	/*
+		If test here fails it's probably because some data structure (or rather
+		how it's serialized for Rust SDK) has been changed without versioning?
+	*/
+	counter := uint64(3)
+	tests := []struct {
+		name string
+		enc  encoder.TxAttributesEncoder
+		attr any
+		exp  []byte
+	}{
+		{
+			name: "TransferFeeCredit",
+			enc:  txaTransferFeeCreditAttributes,
+			attr: fc.TransferFeeCreditAttributes{Amount: 10, TargetSystemIdentifier: 2, TargetRecordID: []byte{1}, LatestAdditionTime: 5, TargetUnitCounter: &counter, Counter: 4},
+			exp:  []byte{0x1, 0x2, 0xa, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x2, 0x3, 0x2, 0x0, 0x0, 0x0, 0x3, 0x1, 0x1, 0x0, 0x0, 0x0, 0x1, 0x4, 0x2, 0x5, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x5, 0x2, 0x3, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x6, 0x2, 0x4, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0},
+		},
+		{
+			name: "TransferFeeCredit without target counter",
+			enc:  txaTransferFeeCreditAttributes,
+			attr: fc.TransferFeeCreditAttributes{Amount: 10, TargetSystemIdentifier: 2, TargetRecordID: []byte{1}, LatestAdditionTime: 5, Counter: 4},
+			exp:  []byte{0x1, 0x2, 0xa, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x2, 0x3, 0x2, 0x0, 0x0, 0x0, 0x3, 0x1, 0x1, 0x0, 0x0, 0x0, 0x1, 0x4, 0x2, 0x5, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x6, 0x2, 0x4, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0},
+		},
+		{
+			name: "ReclaimFeeCredit",
+			enc:  txaReclaimFeeCreditAttributes,
+			attr: fc.ReclaimFeeCreditAttributes{CloseFeeCreditProof: &types.TxRecordProof{}},
+			exp:  []byte{0x1, 0x1, 0x3, 0x0, 0x0, 0x0, 0x82, 0xf6, 0xf6},
+		},
+		{
+			name: "AddFeeCredit",
+			enc:  txaAddFeeCreditAttributes,
+			attr: fc.AddFeeCreditAttributes{FeeCreditOwnerPredicate: []byte{1}, FeeCreditTransferProof: &types.TxRecordProof{}},
+			exp:  []byte{0x1, 0x1, 0x1, 0x0, 0x0, 0x0, 0x1, 0x2, 0x1, 0x3, 0x0, 0x0, 0x0, 0x82, 0xf6, 0xf6},
+		},
+		{
+			name: "CloseFeeCredit",
+			enc:  txaCloseFeeCreditAttributes,
+			attr: fc.CloseFeeCreditAttributes{Amount: 10, TargetUnitID: []byte{1}, TargetUnitCounter: 2, Counter: 3},
+			exp:  []byte{0x1, 0x2, 0xa, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x2, 0x1, 0x1, 0x0, 0x0, 0x0, 0x1, 0x3, 0x2, 0x2, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x4, 0x2, 0x3, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0},
+		},
+		{
+			name: "LockFeeCredit",
+			enc:  txaLockFeeCreditAttributes,
+			attr: fc.LockFeeCreditAttributes{LockStatus: 1, Counter: 2},
+			exp:  []byte{0x1, 0x2, 0x2, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x2, 0x2, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0},
+		},
+		{
+			name: "UnlockFeeCredit",
+			enc:  txaUnlockFeeCreditAttributes,
+			attr: fc.UnlockFeeCreditAttributes{Counter: 2},
+			exp:  []byte{0x1, 0x2, 0x2, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0},
+		},
+		{
+			name: "SetFeeCredit",
+			enc:  txaSetFeeCreditAttributes,
+			attr: permissioned.SetFeeCreditAttributes{OwnerPredicate: []byte{1}, Amount: 10},
+			exp:  []byte{0x1, 0x1, 0x1, 0x0, 0x0, 0x0, 0x1, 0x2, 0x2, 0xa, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0},
+		},
+		{
+			name: "DeleteFeeCredit",
+			enc:  txaDeleteFeeCreditAttributes,
+			attr: permissioned.DeleteFeeCreditAttributes{Counter: 2},
+			exp:  []byte{0x1, 0x2, 0x2, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			txo := &types.TransactionOrder{Payload: types.Payload{}}
+			require.NoError(t, txo.SetAttributes(tc.attr))
+			b, err := tc.enc(txo, 1)
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, b)
+
+			b, err = tc.enc(txo, 2)
+			require.EqualError(t, err, `unsupported encoding version 2, latest supported version is 1`)
+			require.Nil(t, b)
+		})
+	}
+}
+
+func Test_udeFeeCreditRecord(t *testing.T) {
+	b, err := udeFeeCreditRecord(&fc.FeeCreditRecord{Balance: 10, OwnerPredicate: []byte{1}, Counter: 2, Locked: 1, Timeout: 4}, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x1, 0x2, 0xa, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x2, 0x2, 0x2, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x3, 0x2, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x4, 0x2, 0x4, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, b)
+}
+
+func Test_RegisterEncoders(t *testing.T) {
+	enc, err := encoder.New(RegisterTxAttributeEncoders, RegisterUnitDataEncoders)
+	require.NoError(t, err)
+
+	// fee credit attribute encoders are used for any tx system
+	for _, sysID := range []types.SystemID{1, 2, 3} {
+		txo := &types.TransactionOrder{Payload: types.Payload{SystemID: sysID, Type: fc.TransactionTypeUnlockFeeCredit}}
+		require.NoError(t, txo.SetAttributes(fc.UnlockFeeCreditAttributes{Counter: 2}))
+		b, err := enc.TxAttributes(txo, 1)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x1, 0x2, 0x2, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, b)
+	}
+}
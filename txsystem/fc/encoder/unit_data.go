@@ -0,0 +1,28 @@
+package fcenc
+
+import (
+	"errors"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/fc"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/encoder"
+)
+
+func RegisterUnitDataEncoders(reg func(ud any, enc encoder.UnitDataEncoder) error) error {
+	return errors.Join(
+		reg(&fc.FeeCreditRecord{}, udeFeeCreditRecord),
+	)
+}
+
+func udeFeeCreditRecord(data types.UnitData, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	value := data.(*fc.FeeCreditRecord)
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, value.Balance)
+	buf.EncodeTagged(2, value.Counter)
+	buf.EncodeTagged(3, value.Locked)
+	buf.EncodeTagged(4, value.Timeout)
+	return buf.Bytes()
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/alphabill-org/alphabill-go-base/types"
 	"github.com/alphabill-org/alphabill/predicates"
 	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/txsystem/fc/unit"
 	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
 )
 
@@ -36,7 +37,11 @@ IsCredible implements the fee credit verification for ordinary transactions (eve
 */
 func (f *FeeBalanceValidator) IsCredible(exeCtx txtypes.ExecutionContext, tx *types.TransactionOrder) error {
 	// 1. ExtrType(ιf) = fcr ∧ N[ιf] != ⊥ – the fee payer has credit in this system
-	fcrID := tx.FeeCreditRecordID()
+	// (the fee payer is the sponsor when the transaction is sponsored)
+	fcrID, feeProof, err := unit.FeePayer(tx)
+	if err != nil {
+		return fmt.Errorf("reading fee payer: %w", err)
+	}
 	if len(fcrID) == 0 {
 		return errors.New("fee credit record missing")
 	}
@@ -56,7 +61,7 @@ func (f *FeeBalanceValidator) IsCredible(exeCtx txtypes.ExecutionContext, tx *ty
 		return fmt.Errorf("the max fee cannot exceed fee credit balance. FC balance %d vs max fee %d", fcr.Balance, tx.MaxFee())
 	}
 	// VerifyFeeAuth(N[ιf].φ, T, T.sf) - fee authorization proof satisfies the owner predicate of the fee credit record
-	if err := f.execPredicate(fcr.OwnerPredicate, tx.FeeProof, tx.FeeProofSigBytes, exeCtx); err != nil {
+	if err := f.execPredicate(fcr.OwnerPredicate, feeProof, tx.FeeProofSigBytes, exeCtx); err != nil {
 		return fmt.Errorf("evaluating fee proof: %w", err)
 	}
 	return nil
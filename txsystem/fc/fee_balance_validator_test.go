@@ -3,11 +3,14 @@ package fc
 import (
 	"testing"
 
+	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
 	"github.com/alphabill-org/alphabill-go-base/txsystem/fc"
 	"github.com/alphabill-org/alphabill-go-base/types"
 	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
 	testtb "github.com/alphabill-org/alphabill/internal/testutils/trustbase"
 	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/txsystem/fc/unit"
+	testctx "github.com/alphabill-org/alphabill/txsystem/testutils/exec_context"
 	testtransaction "github.com/alphabill-org/alphabill/txsystem/testutils/transaction"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -63,3 +66,37 @@ func TestCheckFeeCreditBalance(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckFeeCreditBalance_Sponsored(t *testing.T) {
+	sponsorID := types.UnitID{1}
+	restrictedSponsorID := types.UnitID{2}
+	s := state.NewEmptyState()
+	require.NoError(t, s.Apply(
+		state.AddUnit(recordID, &fc.FeeCreditRecord{Balance: 100, OwnerPredicate: templates.AlwaysFalseBytes()}),
+		state.AddUnit(sponsorID, &fc.FeeCreditRecord{Balance: 10, OwnerPredicate: templates.AlwaysTrueBytes()}),
+		state.AddUnit(restrictedSponsorID, &fc.FeeCreditRecord{Balance: 10, OwnerPredicate: templates.AlwaysFalseBytes()}),
+	))
+	_, verifier := testsig.CreateSignerAndVerifier(t)
+	fcModule, err := NewFeeCreditModule(5, moneySystemID, moneySystemID, s, testtb.NewTrustBase(t, verifier))
+	require.NoError(t, err)
+
+	sponsoredTx := func(t *testing.T, sponsor types.UnitID, maxFee uint64) *types.TransactionOrder {
+		feeProof, err := unit.NewSponsorFeeProof(sponsor, nil)
+		require.NoError(t, err)
+		return testtransaction.NewTransactionOrder(t,
+			testtransaction.WithTransactionType(22),
+			// the owner predicate of the client's FCR is false, the sponsor pays
+			testtransaction.WithClientMetadata(&types.ClientMetadata{FeeCreditRecordID: recordID, MaxTransactionFee: maxFee}),
+			testtransaction.WithFeeProof(feeProof),
+		)
+	}
+	exeCtx := testctx.NewMockExecutionContext()
+
+	require.NoError(t, fcModule.IsCredible(exeCtx, sponsoredTx(t, sponsorID, 10)))
+	require.EqualError(t, fcModule.IsCredible(exeCtx, sponsoredTx(t, sponsorID, 11)),
+		"the max fee cannot exceed fee credit balance. FC balance 10 vs max fee 11")
+	require.EqualError(t, fcModule.IsCredible(exeCtx, sponsoredTx(t, restrictedSponsorID, 1)),
+		`evaluating fee proof: predicate evaluated to "false"`)
+	require.EqualError(t, fcModule.IsCredible(exeCtx, sponsoredTx(t, types.UnitID{3}, 1)),
+		"fee credit record unit is nil")
+}
@@ -14,8 +14,7 @@ import (
 )
 
 const (
-	GeneralTxCostGasUnits = 400
-	GasUnitsPerTema       = 1000
+	GasUnitsPerTema = 1000
 )
 
 var _ txtypes.FeeCreditModule = (*FeeCreditModule)(nil)
@@ -26,6 +25,7 @@ var (
 	ErrMoneySystemIdentifierMissing = errors.New("money transaction system identifier is missing")
 	ErrStateIsNil                   = errors.New("state is nil")
 	ErrTrustBaseIsNil               = errors.New("trust base is nil")
+	ErrExpiryScheduleIDMissing      = errors.New("fee credit record expiry schedule unit ID constructor is missing")
 )
 
 type (
@@ -40,6 +40,11 @@ type (
 		execPredicate           predicates.PredicateRunner
 		feeBalanceValidator     *FeeBalanceValidator
 		feeCreditRecordUnitType []byte
+		// fee credit record expiry, disabled when the check interval is zero
+		expiryScheduleID    func(roundNumber uint64) types.UnitID
+		expiryCheckInterval uint64
+		// fee credit records deleted by the latest deleteExpiredRecords call
+		deleted []*txtypes.DeletedUnit
 	}
 )
 
@@ -113,6 +118,9 @@ func (f *FeeCreditModule) IsValid() error {
 	if f.trustBase == nil {
 		return ErrTrustBaseIsNil
 	}
+	if f.expiryCheckInterval != 0 && f.expiryScheduleID == nil {
+		return ErrExpiryScheduleIDMissing
+	}
 	return nil
 }
 
@@ -2,6 +2,10 @@ package fc
 
 import (
 	"crypto"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+
+	"github.com/alphabill-org/alphabill/predicates"
 )
 
 type Option func(f *FeeCreditModule)
@@ -17,3 +21,31 @@ func WithFeeCreditRecordUnitType(feeCreditRecordUnitType []byte) Option {
 		f.feeCreditRecordUnitType = feeCreditRecordUnitType
 	}
 }
+
+/*
+WithPredicateExecutor sets the predicate executor used to evaluate the owner predicates
+of the fee credit records (including the predicates of the sponsors) and the fee proofs.
+The default executor supports only the "builtin predicate templates", the partition
+should pass its own executor so that the same predicate engines are available.
+*/
+func WithPredicateExecutor(exec predicates.PredicateExecutor) Option {
+	return func(f *FeeCreditModule) {
+		if exec != nil {
+			f.execPredicate = predicates.NewPredicateRunner(exec)
+		}
+	}
+}
+
+/*
+WithFeeCreditRecordExpiry enables deleting the expired fee credit records in the end of
+the block. The records are checked in the end of the first round after their timeout and
+then after every "checkInterval" rounds until the balance is zero. The check schedule
+is kept in the units returned by "scheduleID" for the round. Zero interval disables the
+expiry.
+*/
+func WithFeeCreditRecordExpiry(scheduleID func(roundNumber uint64) types.UnitID, checkInterval uint64) Option {
+	return func(f *FeeCreditModule) {
+		f.expiryScheduleID = scheduleID
+		f.expiryCheckInterval = checkInterval
+	}
+}
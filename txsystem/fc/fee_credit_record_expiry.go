@@ -0,0 +1,122 @@
+package fc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/fc"
+	"github.com/alphabill-org/alphabill-go-base/types"
+
+	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
+)
+
+var _ txtypes.UnitsDeleter = (*FeeCreditModule)(nil)
+
+/*
+scheduleExpiryCheck returns state action which schedules the fee credit record to be
+checked for expiry in the end of the first round after the timeout of the record and
+the ID of the schedule unit. Nil action is returned when the expiry is not enabled or
+the timeout of the existing record is not extended (ie the record is already scheduled).
+*/
+func (f *FeeCreditModule) scheduleExpiryCheck(id types.UnitID, timeout uint64) (state.Action, types.UnitID, error) {
+	if f.expiryCheckInterval == 0 {
+		return nil, nil, nil
+	}
+	u, err := f.state.GetUnit(id, false)
+	if err != nil && !errors.Is(err, avl.ErrNotFound) {
+		return nil, nil, fmt.Errorf("reading fee credit record: %w", err)
+	}
+	if err == nil {
+		if fcr, ok := u.Data().(*fc.FeeCreditRecord); ok && fcr.Timeout >= timeout {
+			return nil, nil, nil
+		}
+	}
+	return f.addToExpirySchedule(timeout+1, id)
+}
+
+func (f *FeeCreditModule) addToExpirySchedule(roundNumber uint64, ids ...types.UnitID) (state.Action, types.UnitID, error) {
+	scheduleID := f.expiryScheduleID(roundNumber)
+	action, err := txtypes.AddToRoundSchedule(f.state, scheduleID, ids...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("expiry schedule: %w", err)
+	}
+	return action, scheduleID, nil
+}
+
+/*
+EndBlockFuncs returns the end of block functions of the fee credit module, these are
+registered only when the fee credit record expiry is enabled.
+*/
+func (f *FeeCreditModule) EndBlockFuncs() []func(blockNumber uint64) error {
+	if f.expiryCheckInterval == 0 {
+		return nil
+	}
+	return []func(blockNumber uint64) error{f.deleteExpiredRecords}
+}
+
+/*
+deleteExpiredRecords checks the fee credit records scheduled for the round. The expired
+records (timeout is in the past) with zero balance are deleted, the expired records which
+still hold value are checked again after the check interval (the value can be reclaimed
+only by the owner with the closeFC and reclaimFC transactions).
+Records whose timeout was extended after scheduling are skipped as the addFC which
+extended the timeout scheduled the record again.
+*/
+func (f *FeeCreditModule) deleteExpiredRecords(currentRoundNumber uint64) error {
+	f.deleted = nil
+	records, err := txtypes.TakeRoundSchedule(f.state, f.expiryScheduleID(currentRoundNumber))
+	if err != nil {
+		return fmt.Errorf("expiry schedule: %w", err)
+	}
+
+	var deleted []*txtypes.DeletedUnit
+	var recheck []types.UnitID
+	// the record may be scheduled more than once when its timeout was extended
+	checked := make(map[string]struct{}, len(records))
+	for _, id := range records {
+		if _, ok := checked[string(id)]; ok {
+			continue
+		}
+		checked[string(id)] = struct{}{}
+		u, err := f.state.GetUnit(id, false)
+		if err != nil {
+			if errors.Is(err, avl.ErrNotFound) {
+				continue
+			}
+			return fmt.Errorf("reading fee credit record: %w", err)
+		}
+		fcr, ok := u.Data().(*fc.FeeCreditRecord)
+		if !ok || fcr.Timeout >= currentRoundNumber {
+			continue
+		}
+		if fcr.Balance > 0 {
+			recheck = append(recheck, id)
+			continue
+		}
+		if err := f.state.Apply(state.DeleteUnit(id)); err != nil {
+			return fmt.Errorf("deleting expired fee credit record: %w", err)
+		}
+		deleted = append(deleted, &txtypes.DeletedUnit{UnitID: id, Data: fcr})
+	}
+	if len(recheck) > 0 {
+		action, recheckID, err := f.addToExpirySchedule(currentRoundNumber+f.expiryCheckInterval, recheck...)
+		if err != nil {
+			return err
+		}
+		if err := f.state.Apply(action); err != nil {
+			return fmt.Errorf("rescheduling fee credit record expiry check: %w", err)
+		}
+		if err := f.state.AddUnitLog(recheckID, make([]byte, f.state.HashAlgorithm().Size())); err != nil {
+			return fmt.Errorf("failed to update expiry schedule state log: %w", err)
+		}
+	}
+	f.deleted = deleted
+	return nil
+}
+
+// DeletedUnits returns the fee credit records deleted by the latest deleteExpiredRecords call.
+func (f *FeeCreditModule) DeletedUnits() []*txtypes.DeletedUnit {
+	return f.deleted
+}
@@ -0,0 +1,142 @@
+package fc
+
+import (
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/fc"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/money"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/stretchr/testify/require"
+
+	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
+	testtb "github.com/alphabill-org/alphabill/internal/testutils/trustbase"
+	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
+	testfc "github.com/alphabill-org/alphabill/txsystem/fc/testutils"
+	testctx "github.com/alphabill-org/alphabill/txsystem/testutils/exec_context"
+	testtransaction "github.com/alphabill-org/alphabill/txsystem/testutils/transaction"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
+)
+
+func expiryScheduleID(roundNumber uint64) types.UnitID {
+	return txtypes.NewRoundScheduleID(money.UnitIDLength, []byte{34}, roundNumber)
+}
+
+func withExpiry(checkInterval uint64) feeTestOption {
+	return func(m *FeeCreditModule) error {
+		WithFeeCreditRecordExpiry(expiryScheduleID, checkInterval)(m)
+		return nil
+	}
+}
+
+func TestFeeCreditRecordExpiry_config(t *testing.T) {
+	_, verifier := testsig.CreateSignerAndVerifier(t)
+	trustBase := testtb.NewTrustBase(t, verifier)
+
+	m, err := NewFeeCreditModule(5, moneySystemID, moneySystemID, state.NewEmptyState(), trustBase)
+	require.NoError(t, err)
+	require.Empty(t, m.EndBlockFuncs())
+
+	m, err = NewFeeCreditModule(5, moneySystemID, moneySystemID, state.NewEmptyState(), trustBase, WithFeeCreditRecordExpiry(expiryScheduleID, 10))
+	require.NoError(t, err)
+	require.Len(t, m.EndBlockFuncs(), 1)
+
+	_, err = NewFeeCreditModule(5, moneySystemID, moneySystemID, state.NewEmptyState(), trustBase, WithFeeCreditRecordExpiry(nil, 10))
+	require.ErrorIs(t, err, ErrExpiryScheduleIDMissing)
+}
+
+func TestAddFC_ExpirySchedule(t *testing.T) {
+	signer, verifier := testsig.CreateSignerAndVerifier(t)
+	trustBase := testtb.NewTrustBase(t, verifier)
+	attr := testfc.NewAddFCAttr(t, signer)
+	authProof := &fc.AddFeeCreditAuthProof{OwnerProof: templates.EmptyArgument()}
+	tx := testfc.NewAddFC(t, signer, attr, testtransaction.WithAuthProof(authProof))
+	scheduleID := expiryScheduleID(11) // transferFC.latestAdditionTime + 1
+	executeAddFC := func(t *testing.T, m *FeeCreditModule) *types.ServerMetadata {
+		exeCtx := testctx.NewMockExecutionContext(testctx.WithCurrentRound(10))
+		_, err := m.checkTransferFC(tx, attr, exeCtx)
+		require.NoError(t, err)
+		sm, err := m.executeAddFC(tx, attr, authProof, exeCtx)
+		require.NoError(t, err)
+		return sm
+	}
+
+	t.Run("new record is scheduled", func(t *testing.T) {
+		m := newTestFeeModule(t, trustBase, withExpiry(100))
+		sm := executeAddFC(t, m)
+		require.Equal(t, []types.UnitID{tx.UnitID, scheduleID}, sm.TargetUnits)
+		u, err := m.state.GetUnit(scheduleID, false)
+		require.NoError(t, err)
+		require.Equal(t, &txtypes.RoundSchedule{Units: []types.UnitID{tx.UnitID}}, u.Data())
+	})
+
+	t.Run("record with the same timeout is not scheduled again", func(t *testing.T) {
+		existingFCR := &fc.FeeCreditRecord{Balance: 10, Timeout: 10, OwnerPredicate: attr.FeeCreditOwnerPredicate}
+		m := newTestFeeModule(t, trustBase, withExpiry(100), withStateUnit(tx.UnitID, existingFCR))
+		sm := executeAddFC(t, m)
+		require.Equal(t, []types.UnitID{tx.UnitID}, sm.TargetUnits)
+		_, err := m.state.GetUnit(scheduleID, false)
+		require.ErrorIs(t, err, avl.ErrNotFound)
+	})
+
+	t.Run("record with extended timeout is scheduled", func(t *testing.T) {
+		existingFCR := &fc.FeeCreditRecord{Balance: 10, Timeout: 5, OwnerPredicate: attr.FeeCreditOwnerPredicate}
+		m := newTestFeeModule(t, trustBase, withExpiry(100), withStateUnit(tx.UnitID, existingFCR))
+		sm := executeAddFC(t, m)
+		require.Equal(t, []types.UnitID{tx.UnitID, scheduleID}, sm.TargetUnits)
+	})
+
+	t.Run("expiry disabled", func(t *testing.T) {
+		m := newTestFeeModule(t, trustBase)
+		sm := executeAddFC(t, m)
+		require.Equal(t, []types.UnitID{tx.UnitID}, sm.TargetUnits)
+	})
+}
+
+func TestFeeCreditRecordExpiry_deleteExpiredRecords(t *testing.T) {
+	_, verifier := testsig.CreateSignerAndVerifier(t)
+	trustBase := testtb.NewTrustBase(t, verifier)
+	emptyID := money.NewFeeCreditRecordID(nil, []byte{1})
+	dustID := money.NewFeeCreditRecordID(nil, []byte{2})
+	valueID := money.NewFeeCreditRecordID(nil, []byte{3})
+	extendedID := money.NewFeeCreditRecordID(nil, []byte{4})
+	deletedID := money.NewFeeCreditRecordID(nil, []byte{5})
+
+	m := newTestFeeModule(t, trustBase, withExpiry(100),
+		withStateUnit(emptyID, &fc.FeeCreditRecord{Balance: 0, Timeout: 10}),
+		withStateUnit(dustID, &fc.FeeCreditRecord{Balance: 5, Timeout: 10}),
+		withStateUnit(valueID, &fc.FeeCreditRecord{Balance: 6, Timeout: 10}),
+		withStateUnit(extendedID, &fc.FeeCreditRecord{Balance: 0, Timeout: 20}),
+	)
+	action, scheduleID, err := m.addToExpirySchedule(11, emptyID, dustID, valueID, extendedID, deletedID, emptyID)
+	require.NoError(t, err)
+	require.NoError(t, m.state.Apply(action))
+
+	// not yet the round of the schedule
+	require.NoError(t, m.deleteExpiredRecords(10))
+	require.Empty(t, m.DeletedUnits())
+
+	require.NoError(t, m.deleteExpiredRecords(11))
+	deleted := m.DeletedUnits()
+	require.Len(t, deleted, 1)
+	require.Equal(t, emptyID, deleted[0].UnitID)
+	for _, id := range []types.UnitID{emptyID, scheduleID} {
+		_, err := m.state.GetUnit(id, false)
+		require.ErrorIs(t, err, avl.ErrNotFound)
+	}
+	for _, id := range []types.UnitID{dustID, valueID, extendedID} {
+		_, err := m.state.GetUnit(id, false)
+		require.NoError(t, err)
+	}
+
+	// the records holding value (even dust) are checked again after the check interval
+	u, err := m.state.GetUnit(expiryScheduleID(111), false)
+	require.NoError(t, err)
+	require.Equal(t, &txtypes.RoundSchedule{Units: []types.UnitID{dustID, valueID}}, u.Data())
+	require.Len(t, u.Logs(), 1)
+
+	// deleted units are reset by the next call
+	require.NoError(t, m.deleteExpiredRecords(12))
+	require.Empty(t, m.DeletedUnits())
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/alphabill-org/alphabill-go-base/txsystem/fc"
 	"github.com/alphabill-org/alphabill-go-base/types"
 	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/txsystem/fc/unit"
 )
 
 var (
@@ -20,6 +21,9 @@ func ValidateGenericFeeCreditTx(tx *types.TransactionOrder) error {
 	if tx.FeeCreditRecordID() != nil {
 		return errors.New("fee transaction cannot contain fee credit reference")
 	}
+	if err := unit.VerifyNotSponsored(tx); err != nil {
+		return fmt.Errorf("fee transaction: %w", err)
+	}
 	if tx.FeeProof != nil {
 		return errors.New("fee transaction cannot contain fee authorization proof")
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/alphabill-org/alphabill-go-base/txsystem/fc"
 	"github.com/alphabill-org/alphabill-go-base/types"
 	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/txsystem/fc/unit"
 	testtransaction "github.com/alphabill-org/alphabill/txsystem/testutils/transaction"
 	"github.com/stretchr/testify/require"
 )
@@ -45,6 +46,15 @@ func TestValidateGenericFeeCreditTx(t *testing.T) {
 		tx.FeeProof = []byte{1, 2, 3}
 		require.EqualError(t, ValidateGenericFeeCreditTx(tx), "fee transaction cannot contain fee authorization proof")
 	})
+	t.Run("Fee credit transactions must not be sponsored", func(t *testing.T) {
+		tx := testtransaction.NewTransactionOrder(t,
+			testtransaction.WithAttributes(&fc.AddFeeCreditAttributes{}),
+		)
+		var err error
+		tx.FeeProof, err = unit.NewSponsorFeeProof(recordID, feeProof)
+		require.NoError(t, err)
+		require.ErrorIs(t, ValidateGenericFeeCreditTx(tx), unit.ErrSponsoredTx)
+	})
 	t.Run("Fee credit transactions must not contain FeeCreditRecordID", func(t *testing.T) {
 		tx := testtransaction.NewTransactionOrder(t,
 			testtransaction.WithAttributes(&fc.AddFeeCreditAttributes{}),
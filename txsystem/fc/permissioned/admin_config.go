@@ -0,0 +1,140 @@
+package permissioned
+
+import (
+	"bytes"
+	"fmt"
+	"hash"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/state"
+	feeModule "github.com/alphabill-org/alphabill/txsystem/fc"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
+)
+
+// TransactionTypeRotateAdmin is the type of the transaction which replaces the admin
+// predicate of the partition, types 20 and 21 are used by the SetFC and DeleteFC transactions.
+const TransactionTypeRotateAdmin uint16 = 23
+
+var _ types.UnitData = (*AdminConfigData)(nil)
+
+/*
+AdminConfigData is the unit data of the admin configuration unit. The unit holds the
+current admin predicate of the partition, it is created in the genesis state of the
+partition (with the admin predicate of the genesis) and updated by the rotate-admin
+transactions.
+*/
+type AdminConfigData struct {
+	_              struct{} `cbor:",toarray"`
+	AdminPredicate []byte   `json:"adminPredicate"` // the predicate which controls the SetFC, DeleteFC and rotate-admin transactions
+	Counter        uint64   `json:"counter,string"` // the counter of the rotate-admin transactions
+}
+
+type (
+	// RotateAdminAttributes is transaction of type "rotateAdmin".
+	// The transaction is used to replace the admin predicate of the partition.
+	// The transaction must be signed by the current admin key.
+	RotateAdminAttributes struct {
+		_ struct{} `cbor:",toarray"`
+
+		AdminPredicate []byte // the new admin predicate
+		Counter        uint64 // the counter of the admin configuration unit
+	}
+
+	RotateAdminAuthProof struct {
+		_ struct{} `cbor:",toarray"`
+
+		OwnerProof []byte // the owner proof signed by the current admin key
+	}
+)
+
+func (a *AdminConfigData) Write(hasher hash.Hash) error {
+	res, err := types.Cbor.Marshal(a)
+	if err != nil {
+		return fmt.Errorf("admin configuration data encode error: %w", err)
+	}
+	_, err = hasher.Write(res)
+	return err
+}
+
+func (a *AdminConfigData) SummaryValueInput() uint64 {
+	return 0
+}
+
+func (a *AdminConfigData) Copy() types.UnitData {
+	return &AdminConfigData{AdminPredicate: bytes.Clone(a.AdminPredicate), Counter: a.Counter}
+}
+
+func (a *AdminConfigData) Owner() []byte {
+	return nil
+}
+
+/*
+adminConfig returns the admin configuration of the partition: the data of the admin
+configuration unit or the admin predicate given to the constructor when the admin
+rotation is not enabled.
+*/
+func (f *FeeCreditModule) adminConfig() (*AdminConfigData, error) {
+	if !f.adminRotation {
+		return &AdminConfigData{AdminPredicate: f.adminOwnerPredicate}, nil
+	}
+	u, err := f.state.GetUnit(f.adminConfigUnitID, false)
+	if err != nil {
+		return nil, fmt.Errorf("reading admin configuration unit: %w", err)
+	}
+	data, ok := u.Data().(*AdminConfigData)
+	if !ok {
+		return nil, fmt.Errorf("admin configuration unit data type is not of *AdminConfigData type")
+	}
+	return data, nil
+}
+
+// verifyAdminProof verifies that the transaction is signed by the current admin key.
+func (f *FeeCreditModule) verifyAdminProof(tx *types.TransactionOrder, ownerProof []byte, exeCtx txtypes.ExecutionContext) error {
+	cfg, err := f.adminConfig()
+	if err != nil {
+		return err
+	}
+	if err := f.execPredicate(cfg.AdminPredicate, ownerProof, tx.AuthProofSigBytes, exeCtx); err != nil {
+		return fmt.Errorf("invalid owner proof: %w", err)
+	}
+	return nil
+}
+
+func (f *FeeCreditModule) validateRotateAdmin(tx *types.TransactionOrder, attr *RotateAdminAttributes, authProof *RotateAdminAuthProof, exeCtx txtypes.ExecutionContext) error {
+	// verify there's no fee credit reference or separate fee authorization proof
+	if err := feeModule.ValidateGenericFeeCreditTx(tx); err != nil {
+		return err
+	}
+	if !tx.UnitID.Eq(f.adminConfigUnitID) {
+		return fmt.Errorf("invalid unit ID: expected admin configuration unit %s, got %s", f.adminConfigUnitID, tx.UnitID)
+	}
+	if len(attr.AdminPredicate) == 0 {
+		return ErrMissingAdminOwnerPredicate
+	}
+	cfg, err := f.adminConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.Counter != attr.Counter {
+		return fmt.Errorf("invalid counter: tx.Counter=%d admin.Counter=%d", attr.Counter, cfg.Counter)
+	}
+	// verify tx is signed by the current admin key
+	if err := f.execPredicate(cfg.AdminPredicate, authProof.OwnerProof, tx.AuthProofSigBytes, exeCtx); err != nil {
+		return fmt.Errorf("invalid owner proof: %w", err)
+	}
+	return nil
+}
+
+func (f *FeeCreditModule) executeRotateAdmin(tx *types.TransactionOrder, attr *RotateAdminAttributes, _ *RotateAdminAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
+	newCfg := &AdminConfigData{AdminPredicate: attr.AdminPredicate, Counter: attr.Counter + 1}
+	action := state.UpdateUnitData(tx.UnitID, func(types.UnitData) (types.UnitData, error) {
+		return newCfg, nil
+	})
+	if err := f.state.Apply(action); err != nil {
+		return nil, fmt.Errorf("failed to rotate admin predicate: %w", err)
+	}
+	return &types.ServerMetadata{
+		TargetUnits:      []types.UnitID{tx.UnitID},
+		SuccessIndicator: types.TxStatusSuccessful,
+	}, nil
+}
@@ -0,0 +1,140 @@
+package permissioned
+
+import (
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/crypto"
+	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/fc"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
+	"github.com/alphabill-org/alphabill/state"
+	testctx "github.com/alphabill-org/alphabill/txsystem/testutils/exec_context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateAdmin(t *testing.T) {
+	adminSigner, adminVerifier := testsig.CreateSignerAndVerifier(t)
+	adminPubKey, err := adminVerifier.MarshalPublicKey()
+	require.NoError(t, err)
+	newAdminSigner, newAdminVerifier := testsig.CreateSignerAndVerifier(t)
+	newAdminPubKey, err := newAdminVerifier.MarshalPublicKey()
+	require.NoError(t, err)
+	adminPredicate := templates.NewP2pkh256BytesFromKey(adminPubKey)
+	newAdminPredicate := templates.NewP2pkh256BytesFromKey(newAdminPubKey)
+
+	systemID := types.SystemID(5)
+	fcrUnitType := []byte{1}
+	adminConfigID := types.NewUnitID(33, nil, nil, []byte{2})
+	newModule := func(t *testing.T) *FeeCreditModule {
+		s := state.NewEmptyState()
+		require.NoError(t, s.Apply(state.AddUnit(adminConfigID, &AdminConfigData{AdminPredicate: adminPredicate})))
+		m, err := NewFeeCreditModule(5, systemID, s, fcrUnitType, adminPredicate, WithAdminConfigUnitID(adminConfigID))
+		require.NoError(t, err)
+		return m
+	}
+
+	t.Run("admin configuration unit ID is missing", func(t *testing.T) {
+		m, err := NewFeeCreditModule(5, systemID, state.NewEmptyState(), fcrUnitType, adminPredicate, WithAdminConfigUnitID(nil))
+		require.ErrorIs(t, err, ErrMissingAdminConfigUnitID)
+		require.Nil(t, m)
+	})
+
+	t.Run("admin configuration unit is missing", func(t *testing.T) {
+		m, err := NewFeeCreditModule(5, systemID, state.NewEmptyState(), fcrUnitType, adminPredicate, WithAdminConfigUnitID(adminConfigID))
+		require.NoError(t, err)
+		_, err = m.adminConfig()
+		require.ErrorContains(t, err, "reading admin configuration unit")
+
+		tx, attr, authProof := newRotateAdminTx(t, adminSigner, systemID, adminConfigID, newAdminPredicate, 0)
+		require.ErrorContains(t, m.validateRotateAdmin(tx, attr, authProof, testctx.NewMockExecutionContext()), "reading admin configuration unit")
+		fcrOwnerPredicate := templates.AlwaysTrueBytes()
+		setTx, setAttr, setAuthProof, err := newSetFeeCreditTx(adminSigner, systemID, newFeeCreditRecordID(fcrOwnerPredicate, fcrUnitType, 10), fcrOwnerPredicate, nil, 10, nil, nil)
+		require.NoError(t, err)
+		require.ErrorContains(t, m.validateSetFC(setTx, setAttr, setAuthProof, testctx.NewMockExecutionContext()), "reading admin configuration unit")
+	})
+
+	t.Run("handler is registered only with admin configuration unit", func(t *testing.T) {
+		m := newModule(t)
+		require.Contains(t, m.TxHandlers(), TransactionTypeRotateAdmin)
+		require.True(t, m.IsFeeCreditTx(&types.TransactionOrder{Payload: types.Payload{Type: TransactionTypeRotateAdmin}}))
+
+		m, err := NewFeeCreditModule(5, systemID, state.NewEmptyState(), fcrUnitType, adminPredicate)
+		require.NoError(t, err)
+		require.NotContains(t, m.TxHandlers(), TransactionTypeRotateAdmin)
+		require.False(t, m.IsFeeCreditTx(&types.TransactionOrder{Payload: types.Payload{Type: TransactionTypeRotateAdmin}}))
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		m := newModule(t)
+		exeCtx := testctx.NewMockExecutionContext()
+		tx, attr, authProof := newRotateAdminTx(t, adminSigner, systemID, adminConfigID, newAdminPredicate, 0)
+		require.NoError(t, m.validateRotateAdmin(tx, attr, authProof, exeCtx))
+		sm, err := m.executeRotateAdmin(tx, attr, authProof, exeCtx)
+		require.NoError(t, err)
+		require.Equal(t, []types.UnitID{adminConfigID}, sm.TargetUnits)
+		cfg, err := m.adminConfig()
+		require.NoError(t, err)
+		require.EqualValues(t, newAdminPredicate, cfg.AdminPredicate)
+		require.EqualValues(t, 1, cfg.Counter)
+
+		// the previous admin key is not valid anymore
+		tx, attr, authProof = newRotateAdminTx(t, adminSigner, systemID, adminConfigID, adminPredicate, 1)
+		require.ErrorContains(t, m.validateRotateAdmin(tx, attr, authProof, exeCtx), "invalid owner proof")
+		fcrOwnerPredicate := templates.AlwaysTrueBytes()
+		fcrID := newFeeCreditRecordID(fcrOwnerPredicate, fcrUnitType, 10)
+		setTx, setAttr, setAuthProof, err := newSetFeeCreditTx(adminSigner, systemID, fcrID, fcrOwnerPredicate, nil, 10, nil, nil)
+		require.NoError(t, err)
+		require.ErrorContains(t, m.validateSetFC(setTx, setAttr, setAuthProof, exeCtx), "invalid owner proof")
+
+		// the new admin key is used for SetFC, DeleteFC and the next rotation
+		setTx, setAttr, setAuthProof, err = newSetFeeCreditTx(newAdminSigner, systemID, fcrID, fcrOwnerPredicate, nil, 10, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, m.validateSetFC(setTx, setAttr, setAuthProof, exeCtx))
+		delTx, delAttr, delAuthProof, err := newDeleteFeeTx(newAdminSigner, systemID, fcrID, 10, nil, nil)
+		require.NoError(t, err)
+		fcrUnit := state.NewUnit(&fc.FeeCreditRecord{Balance: 1e8, Timeout: 10, OwnerPredicate: fcrOwnerPredicate})
+		require.NoError(t, m.validateDeleteFC(delTx, delAttr, delAuthProof, testctx.NewMockExecutionContext(testctx.WithUnit(fcrUnit))))
+
+		tx, attr, authProof = newRotateAdminTx(t, newAdminSigner, systemID, adminConfigID, adminPredicate, 1)
+		require.NoError(t, m.validateRotateAdmin(tx, attr, authProof, exeCtx))
+		_, err = m.executeRotateAdmin(tx, attr, authProof, exeCtx)
+		require.NoError(t, err)
+		cfg, err = m.adminConfig()
+		require.NoError(t, err)
+		require.EqualValues(t, adminPredicate, cfg.AdminPredicate)
+		require.EqualValues(t, 2, cfg.Counter)
+	})
+
+	t.Run("invalid unit ID", func(t *testing.T) {
+		tx, attr, authProof := newRotateAdminTx(t, adminSigner, systemID, types.NewUnitID(33, nil, []byte{1}, []byte{2}), newAdminPredicate, 0)
+		require.ErrorContains(t, newModule(t).validateRotateAdmin(tx, attr, authProof, testctx.NewMockExecutionContext()), "invalid unit ID")
+	})
+
+	t.Run("new admin predicate is missing", func(t *testing.T) {
+		tx, attr, authProof := newRotateAdminTx(t, adminSigner, systemID, adminConfigID, nil, 0)
+		require.ErrorIs(t, newModule(t).validateRotateAdmin(tx, attr, authProof, testctx.NewMockExecutionContext()), ErrMissingAdminOwnerPredicate)
+	})
+
+	t.Run("invalid counter", func(t *testing.T) {
+		tx, attr, authProof := newRotateAdminTx(t, adminSigner, systemID, adminConfigID, newAdminPredicate, 1)
+		require.EqualError(t, newModule(t).validateRotateAdmin(tx, attr, authProof, testctx.NewMockExecutionContext()), "invalid counter: tx.Counter=1 admin.Counter=0")
+	})
+
+	t.Run("not signed by admin", func(t *testing.T) {
+		tx, attr, authProof := newRotateAdminTx(t, newAdminSigner, systemID, adminConfigID, newAdminPredicate, 0)
+		require.ErrorContains(t, newModule(t).validateRotateAdmin(tx, attr, authProof, testctx.NewMockExecutionContext()), "invalid owner proof")
+	})
+}
+
+func newRotateAdminTx(t *testing.T, adminSigner crypto.Signer, systemID types.SystemID, unitID types.UnitID, adminPredicate []byte, counter uint64) (*types.TransactionOrder, *RotateAdminAttributes, *RotateAdminAuthProof) {
+	attr := &RotateAdminAttributes{AdminPredicate: adminPredicate, Counter: counter}
+	payload, err := newTxPayload(systemID, TransactionTypeRotateAdmin, unitID, nil, 10, nil, attr)
+	require.NoError(t, err)
+	txo := &types.TransactionOrder{Payload: payload}
+	authProof, err := signAuthProof(txo, adminSigner, func(ownerProof []byte) *RotateAdminAuthProof {
+		return &RotateAdminAuthProof{OwnerProof: ownerProof}
+	})
+	require.NoError(t, err)
+	return txo, attr, authProof
+}
@@ -22,6 +22,7 @@ var (
 	ErrStateIsNil                     = errors.New("state is nil")
 	ErrMissingFeeCreditRecordUnitType = errors.New("fee credit record unit type is missing")
 	ErrMissingAdminOwnerPredicate     = errors.New("admin owner predicate is missing")
+	ErrMissingAdminConfigUnitID       = errors.New("admin configuration unit ID is missing")
 )
 
 /*
@@ -31,6 +32,9 @@ In permissioned mode there are two special transactions: SetFC and DeleteFC;
 these transactions can only be sent by the operator of this partition i.e. owner of the admin key.
 The SetFC transaction can be used to create new fee credit records and update existing ones.
 The DeleteFC transaction can be used to close existing fee credit records.
+When the module is configured with an admin configuration unit the admin key can be
+replaced with the rotate-admin transaction, the unit holding the admin predicate must be
+created in the genesis state of the partition (see AdminConfigData).
 All other ordinary transactions must still satisfy the fee credit records
 i.e. users must ask the owner of the partition for permission to send transactions.
 
@@ -47,6 +51,8 @@ type FeeCreditModule struct {
 	feeCreditRecordUnitType []byte
 	feeBalanceValidator     *feeModule.FeeBalanceValidator
 	adminOwnerPredicate     types.PredicateBytes
+	adminConfigUnitID       types.UnitID
+	adminRotation           bool
 	feelessMode             bool
 }
 
@@ -76,6 +82,9 @@ func NewFeeCreditModule(networkID types.NetworkID, systemID types.SystemID, stat
 	for _, o := range opts {
 		o(m)
 	}
+	if m.adminRotation && len(m.adminConfigUnitID) == 0 {
+		return nil, ErrMissingAdminConfigUnitID
+	}
 	if m.execPredicate == nil {
 		predEng, err := predicates.Dispatcher(templates.New())
 		if err != nil {
@@ -110,13 +119,20 @@ func (f *FeeCreditModule) BuyGas(maxTxCost uint64) uint64 {
 }
 
 func (f *FeeCreditModule) TxHandlers() map[uint16]txtypes.TxExecutor {
-	return map[uint16]txtypes.TxExecutor{
+	handlers := map[uint16]txtypes.TxExecutor{
 		permissioned.TransactionTypeSetFeeCredit:    txtypes.NewTxHandler[permissioned.SetFeeCreditAttributes, permissioned.SetFeeCreditAuthProof](f.validateSetFC, f.executeSetFC),
 		permissioned.TransactionTypeDeleteFeeCredit: txtypes.NewTxHandler[permissioned.DeleteFeeCreditAttributes, permissioned.DeleteFeeCreditAuthProof](f.validateDeleteFC, f.executeDeleteFC),
 	}
+	if f.adminRotation {
+		handlers[TransactionTypeRotateAdmin] = txtypes.NewTxHandler[RotateAdminAttributes, RotateAdminAuthProof](f.validateRotateAdmin, f.executeRotateAdmin)
+	}
+	return handlers
 }
 
 func (f *FeeCreditModule) IsFeeCreditTx(tx *types.TransactionOrder) bool {
+	if f.adminRotation && tx != nil && tx.Type == TransactionTypeRotateAdmin {
+		return true
+	}
 	return permissioned.IsFeeCreditTx(tx)
 }
 
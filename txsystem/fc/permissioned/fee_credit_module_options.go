@@ -2,6 +2,10 @@ package permissioned
 
 import (
 	"crypto"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+
+	"github.com/alphabill-org/alphabill/predicates"
 )
 
 type Option func(f *FeeCreditModule)
@@ -17,3 +21,30 @@ func WithFeelessMode(feelessMode bool) Option {
 		f.feelessMode = feelessMode
 	}
 }
+
+/*
+WithAdminConfigUnitID sets the ID of the admin configuration unit which holds the
+current admin predicate of the partition, the unit must exist in the genesis state.
+Without it the rotate-admin transaction is not supported and the admin predicate
+given to the constructor can't be changed.
+*/
+func WithAdminConfigUnitID(unitID types.UnitID) Option {
+	return func(f *FeeCreditModule) {
+		f.adminConfigUnitID = unitID
+		f.adminRotation = true
+	}
+}
+
+/*
+WithPredicateExecutor sets the predicate executor used to evaluate the owner predicates
+of the fee credit records (including the predicates of the sponsors) and the fee proofs.
+The default executor supports only the "builtin predicate templates", the partition
+should pass its own executor so that the same predicate engines are available.
+*/
+func WithPredicateExecutor(exec predicates.PredicateExecutor) Option {
+	return func(f *FeeCreditModule) {
+		if exec != nil {
+			f.execPredicate = predicates.NewPredicateRunner(exec)
+		}
+	}
+}
@@ -43,11 +43,8 @@ func (f *FeeCreditModule) validateDeleteFC(tx *types.TransactionOrder, attr *per
 		return fmt.Errorf("invalid counter: tx.Counter=%d fcr.Counter=%d", attr.Counter, fcr.GetCounter())
 	}
 
-	// verify tx is signed by admin key
-	if err := f.execPredicate(f.adminOwnerPredicate, authProof.OwnerProof, tx.AuthProofSigBytes, exeCtx); err != nil {
-		return fmt.Errorf("invalid owner proof: %w", err)
-	}
-	return nil
+	// verify tx is signed by the current admin key
+	return f.verifyAdminProof(tx, authProof.OwnerProof, exeCtx)
 }
 
 func (f *FeeCreditModule) executeDeleteFC(tx *types.TransactionOrder, _ *permissioned.DeleteFeeCreditAttributes, _ *permissioned.DeleteFeeCreditAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
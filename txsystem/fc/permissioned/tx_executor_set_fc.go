@@ -59,11 +59,8 @@ func (f *FeeCreditModule) validateSetFC(tx *types.TransactionOrder, attr *permis
 		}
 	}
 
-	// verify tx is signed by admin key
-	if err := f.execPredicate(f.adminOwnerPredicate, authProof.OwnerProof, tx.AuthProofSigBytes, exeCtx); err != nil {
-		return fmt.Errorf("invalid owner proof: %w", err)
-	}
-	return nil
+	// verify tx is signed by the current admin key
+	return f.verifyAdminProof(tx, authProof.OwnerProof, exeCtx)
 }
 
 func (f *FeeCreditModule) executeSetFC(tx *types.TransactionOrder, attr *permissioned.SetFeeCreditAttributes, _ *permissioned.SetFeeCreditAuthProof, exeCtx txtypes.ExecutionContext) (*types.ServerMetadata, error) {
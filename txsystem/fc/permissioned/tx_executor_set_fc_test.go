@@ -11,6 +11,7 @@ import (
 	"github.com/alphabill-org/alphabill-go-base/types"
 	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
 	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/txsystem/fc/unit"
 	testctx "github.com/alphabill-org/alphabill/txsystem/testutils/exec_context"
 	"github.com/stretchr/testify/require"
 )
@@ -53,6 +54,15 @@ func TestValidateSetFC(t *testing.T) {
 		require.ErrorContains(t, err, "fee transaction cannot contain fee authorization proof")
 	})
 
+	t.Run("sponsored", func(t *testing.T) {
+		sponsorFeeProof, err := unit.NewSponsorFeeProof(fcrID, []byte{1})
+		require.NoError(t, err)
+		tx, attr, authProof, err := newSetFeeCreditTx(adminKeySigner, systemID, fcrID, fcrOwnerPredicate, nil, timeout, nil, sponsorFeeProof)
+		require.NoError(t, err)
+		err = m.validateSetFC(tx, attr, authProof, testctx.NewMockExecutionContext())
+		require.ErrorIs(t, err, unit.ErrSponsoredTx)
+	})
+
 	t.Run("Invalid unit type byte", func(t *testing.T) {
 		// create new fcrID with invalid type byte
 		fcrUnitType := []byte{2}
@@ -20,8 +20,21 @@ func (f *FeeCreditModule) executeAddFC(tx *types.TransactionOrder, attr *fc.AddF
 	latestAdditionTime := util.BytesToUint64(data[8:])
 	fee := exeCtx.CalculateCost()
 	newBalance := addedFeeCredit - fee
+	targetUnits := []types.UnitID{unitID}
 
-	err := f.state.Apply(unit.IncrCredit(unitID, newBalance, latestAdditionTime))
+	// schedule the expiry check before the timeout of the record is updated
+	scheduleAction, scheduleID, err := f.scheduleExpiryCheck(unitID, latestAdditionTime)
+	if err != nil {
+		return nil, fmt.Errorf("addFC expiry schedule update failed: %w", err)
+	}
+	if scheduleAction != nil {
+		if err := f.state.Apply(scheduleAction); err != nil {
+			return nil, fmt.Errorf("addFC expiry schedule update failed: %w", err)
+		}
+		targetUnits = append(targetUnits, scheduleID)
+	}
+
+	err = f.state.Apply(unit.IncrCredit(unitID, newBalance, latestAdditionTime))
 	// if unable to increment credit because there unit is not found, then create one
 	if err != nil && errors.Is(err, avl.ErrNotFound) {
 		// add credit
@@ -31,7 +44,7 @@ func (f *FeeCreditModule) executeAddFC(tx *types.TransactionOrder, attr *fc.AddF
 	if err != nil {
 		return nil, fmt.Errorf("addFC state update failed: %w", err)
 	}
-	return &types.ServerMetadata{ActualFee: fee, TargetUnits: []types.UnitID{unitID}, SuccessIndicator: types.TxStatusSuccessful}, nil
+	return &types.ServerMetadata{ActualFee: fee, TargetUnits: targetUnits, SuccessIndicator: types.TxStatusSuccessful}, nil
 }
 
 func (f *FeeCreditModule) validateAddFC(tx *types.TransactionOrder, attr *fc.AddFeeCreditAttributes, authProof *fc.AddFeeCreditAuthProof, exeCtx txtypes.ExecutionContext) error {
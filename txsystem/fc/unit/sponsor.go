@@ -0,0 +1,99 @@
+package unit
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/fxamacker/cbor/v2"
+)
+
+/*
+SponsorFeeProofTag is the CBOR tag number which marks the fee proof of a sponsored
+transaction, ie transaction whose fees are paid from the fee credit record of the
+sponsor instead of the fee credit record in the client metadata of the transaction.
+Fee proof tagged with SponsorFeeProofTag is always interpreted as sponsor fee proof
+so the fee proof of the transaction must be read using DecodeSponsorFeeProof or
+FeePayer, never directly.
+*/
+const SponsorFeeProofTag = 0x4142
+
+// ErrSponsoredTx is returned for sponsored transactions whose fees can't be paid by the sponsor.
+var ErrSponsoredTx = errors.New("transaction can't be sponsored")
+
+/*
+SponsorFeeProof is the fee proof of a sponsored transaction. The sponsor authorizes
+the payment by satisfying the owner predicate of its fee credit record (the sponsor
+predicate) with SponsorProof. As the sponsor predicate is evaluated in the context
+of the transaction it can restrict what the sponsor pays for (transaction types, max
+fee, target unit types etc).
+*/
+type SponsorFeeProof struct {
+	_                 struct{}     `cbor:",toarray"`
+	FeeCreditRecordID types.UnitID // the fee credit record of the sponsor
+	SponsorProof      []byte       // input to satisfy the owner predicate of the sponsor's fee credit record
+}
+
+// NewSponsorFeeProof returns CBOR encoded (and tagged) fee proof of a sponsored transaction.
+func NewSponsorFeeProof(fcrID types.UnitID, sponsorProof []byte) ([]byte, error) {
+	return types.Cbor.Marshal(cbor.Tag{
+		Number:  SponsorFeeProofTag,
+		Content: &SponsorFeeProof{FeeCreditRecordID: fcrID, SponsorProof: sponsorProof},
+	})
+}
+
+/*
+DecodeSponsorFeeProof returns the sponsor fee proof of the transaction, nil when the
+transaction is not sponsored (ie fee proof is not tagged with SponsorFeeProofTag).
+*/
+func DecodeSponsorFeeProof(tx *types.TransactionOrder) (*SponsorFeeProof, error) {
+	// major type 6 is tagged data item
+	if len(tx.FeeProof) == 0 || tx.FeeProof[0]>>5 != 6 {
+		return nil, nil
+	}
+	var tag cbor.RawTag
+	if err := types.Cbor.Unmarshal(tx.FeeProof, &tag); err != nil {
+		return nil, fmt.Errorf("decoding fee proof tag: %w", err)
+	}
+	if tag.Number != SponsorFeeProofTag {
+		return nil, nil
+	}
+	proof := &SponsorFeeProof{}
+	if err := types.Cbor.Unmarshal(tag.Content, proof); err != nil {
+		return nil, fmt.Errorf("decoding sponsor fee proof: %w", err)
+	}
+	return proof, nil
+}
+
+/*
+FeePayer returns ID of the fee credit record which pays the fees of the transaction
+and the proof which must satisfy the owner predicate of the record: the sponsor's
+record and proof when the transaction is sponsored, the record of the client metadata
+and the fee proof of the transaction otherwise.
+*/
+func FeePayer(tx *types.TransactionOrder) (types.UnitID, []byte, error) {
+	sponsor, err := DecodeSponsorFeeProof(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sponsor != nil {
+		return sponsor.FeeCreditRecordID, sponsor.SponsorProof, nil
+	}
+	return tx.FeeCreditRecordID(), tx.FeeProof, nil
+}
+
+/*
+VerifyNotSponsored returns ErrSponsoredTx when the transaction is sponsored. The fees of
+the fee credit transactions are handled intrinsically by the transactions themselves so
+these transactions can't be sponsored.
+*/
+func VerifyNotSponsored(tx *types.TransactionOrder) error {
+	sponsor, err := DecodeSponsorFeeProof(tx)
+	if err != nil {
+		return err
+	}
+	if sponsor != nil {
+		return ErrSponsoredTx
+	}
+	return nil
+}
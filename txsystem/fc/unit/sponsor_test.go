@@ -0,0 +1,58 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeePayer(t *testing.T) {
+	clientFCR := types.UnitID{1}
+	sponsorFCR := types.UnitID{2}
+	tx := &types.TransactionOrder{
+		Payload:  types.Payload{ClientMetadata: &types.ClientMetadata{FeeCreditRecordID: clientFCR}},
+		FeeProof: []byte{0x82, 0x41, 0x01, 0x41, 0x02},
+	}
+
+	t.Run("not sponsored", func(t *testing.T) {
+		sponsor, err := DecodeSponsorFeeProof(tx)
+		require.NoError(t, err)
+		require.Nil(t, sponsor)
+		id, proof, err := FeePayer(tx)
+		require.NoError(t, err)
+		require.Equal(t, clientFCR, id)
+		require.Equal(t, tx.FeeProof, proof)
+	})
+
+	t.Run("other tag", func(t *testing.T) {
+		feeProof, err := types.Cbor.Marshal(cbor.Tag{Number: SponsorFeeProofTag + 1, Content: []byte{1}})
+		require.NoError(t, err)
+		tx := &types.TransactionOrder{Payload: tx.Payload, FeeProof: feeProof}
+		id, proof, err := FeePayer(tx)
+		require.NoError(t, err)
+		require.Equal(t, clientFCR, id)
+		require.Equal(t, feeProof, proof)
+	})
+
+	t.Run("sponsored", func(t *testing.T) {
+		feeProof, err := NewSponsorFeeProof(sponsorFCR, []byte{5, 6})
+		require.NoError(t, err)
+		tx := &types.TransactionOrder{Payload: tx.Payload, FeeProof: feeProof}
+		sponsor, err := DecodeSponsorFeeProof(tx)
+		require.NoError(t, err)
+		require.Equal(t, &SponsorFeeProof{FeeCreditRecordID: sponsorFCR, SponsorProof: []byte{5, 6}}, sponsor)
+		id, proof, err := FeePayer(tx)
+		require.NoError(t, err)
+		require.Equal(t, sponsorFCR, id)
+		require.Equal(t, []byte{5, 6}, proof)
+	})
+
+	t.Run("invalid sponsor fee proof", func(t *testing.T) {
+		feeProof, err := types.Cbor.Marshal(cbor.Tag{Number: SponsorFeeProofTag, Content: "foo"})
+		require.NoError(t, err)
+		_, _, err = FeePayer(&types.TransactionOrder{Payload: tx.Payload, FeeProof: feeProof})
+		require.ErrorContains(t, err, "decoding sponsor fee proof")
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"slices"
 
 	"go.opentelemetry.io/otel/metric"
 
@@ -15,8 +16,8 @@ import (
 	"github.com/alphabill-org/alphabill/logger"
 	"github.com/alphabill-org/alphabill/predicates"
 	"github.com/alphabill-org/alphabill/state"
-	abfc "github.com/alphabill-org/alphabill/txsystem/fc"
 	"github.com/alphabill-org/alphabill/txsystem/fc/unit"
+	"github.com/alphabill-org/alphabill/txsystem/predicatestore"
 	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
 )
 
@@ -37,6 +38,13 @@ type (
 		log                 *slog.Logger
 		pr                  predicates.PredicateRunner
 		unitIdValidator     func(types.UnitID) error
+		// unit type of the predicate storage units, nil when the storage is not enabled
+		predicateStorage []byte
+		gasSchedule      *predicates.GasSchedule
+		// gas schedules replacing the initial gas schedule from their activation round
+		gasScheduleUpdates predicates.GasScheduleUpdates
+		// modules which delete units in the begin/end block functions
+		unitsDeleters []txtypes.UnitsDeleter
 	}
 
 	Observability interface {
@@ -59,6 +67,12 @@ func NewGenericTxSystem(pdr types.PartitionDescriptionRecord, shardID types.Shar
 	for _, option := range opts {
 		option(options)
 	}
+	if err := options.gasSchedule.IsValid(); err != nil {
+		return nil, fmt.Errorf("invalid gas schedule: %w", err)
+	}
+	if err := options.gasScheduleUpdates.IsValid(); err != nil {
+		return nil, fmt.Errorf("invalid gas schedule updates: %w", err)
+	}
 	txs := &GenericTxSystem{
 		pdr:                 pdr,
 		hashAlgorithm:       options.hashAlgorithm,
@@ -71,6 +85,9 @@ func NewGenericTxSystem(pdr types.PartitionDescriptionRecord, shardID types.Shar
 		log:                 observe.Logger(),
 		pr:                  options.predicateRunner,
 		fees:                options.feeCredit,
+		predicateStorage:    options.predicateStorage,
+		gasSchedule:         options.gasSchedule,
+		gasScheduleUpdates:  options.gasScheduleUpdates,
 	}
 	txs.beginBlockFunctions = append(txs.beginBlockFunctions, txs.pruneState)
 
@@ -78,13 +95,18 @@ func NewGenericTxSystem(pdr types.PartitionDescriptionRecord, shardID types.Shar
 		if err := txs.handlers.Add(module.TxHandlers()); err != nil {
 			return nil, fmt.Errorf("registering transaction handler: %w", err)
 		}
+		if ud, ok := module.(txtypes.UnitsDeleter); ok {
+			txs.unitsDeleters = append(txs.unitsDeleters, ud)
+		}
 	}
 	// if fees are collected, then register fee tx handlers
 	if options.feeCredit != nil {
 		if err := txs.handlers.Add(options.feeCredit.TxHandlers()); err != nil {
 			return nil, fmt.Errorf("registering fee credit transaction handler: %w", err)
 		}
-
+		if ud, ok := options.feeCredit.(txtypes.UnitsDeleter); ok {
+			txs.unitsDeleters = append(txs.unitsDeleters, ud)
+		}
 	}
 	if err := txs.initMetrics(observe.Meter("txsystem")); err != nil {
 		return nil, fmt.Errorf("initializing metrics: %w", err)
@@ -141,8 +163,22 @@ func (m *GenericTxSystem) pruneState(roundNo uint64) error {
 	return m.state.Prune()
 }
 
+func (m *GenericTxSystem) predicateStorageUnitID(predicateHash, key []byte) types.UnitID {
+	idLen := int(m.pdr.TypeIdLen+m.pdr.UnitIdLen+7) / 8
+	return predicatestore.NewUnitID(idLen, m.predicateStorage, predicateHash, key)
+}
+
+/*
+GasSchedule returns the gas schedule in effect in the current round, the gas schedule
+update with the latest activation round not after the current round or the initial
+gas schedule when none of the updates is active yet.
+*/
+func (m *GenericTxSystem) GasSchedule() *predicates.GasSchedule {
+	return m.gasScheduleUpdates.ScheduleOf(m.currentRoundNumber, m.gasSchedule)
+}
+
 func (m *GenericTxSystem) snFees(_ *types.TransactionOrder, execCxt txtypes.ExecutionContext) error {
-	return execCxt.SpendGas(abfc.GeneralTxCostGasUnits)
+	return execCxt.SpendGas(m.GasSchedule().GeneralTx)
 }
 
 func (m *GenericTxSystem) Execute(tx *types.TransactionOrder) (*types.ServerMetadata, error) {
@@ -185,7 +221,17 @@ func (m *GenericTxSystem) doExecute(tx *types.TransactionOrder, exeCtx *txtypes.
 	var txExecErr error
 	result := &types.ServerMetadata{SuccessIndicator: types.TxStatusSuccessful}
 	savepointID := m.state.Savepoint()
+	var storage *predicatestore.Store
+	if m.predicateStorage != nil {
+		storage = predicatestore.New(m.state, m.predicateStorageUnitID)
+		exeCtx.SetPredicateStorage(storage)
+	}
 	defer func() {
+		// units modified by the predicates need the unit log of the tx, in case of failure
+		// the changes have been rolled back
+		if txExecErr == nil && storage != nil {
+			sm.TargetUnits = appendUnitIDs(sm.TargetUnits, storage.ModifiedUnits())
+		}
 		// set the correct success indicator
 		if txExecErr != nil {
 			m.log.Warn("transaction execute failed", logger.Error(txExecErr), logger.UnitID(tx.GetUnitID()), logger.Round(m.currentRoundNumber))
@@ -200,15 +246,19 @@ func (m *GenericTxSystem) doExecute(tx *types.TransactionOrder, exeCtx *txtypes.
 		// charge user according to gas used
 		sm.ActualFee = exeCtx.CalculateCost()
 		if sm.ActualFee > 0 {
-			// credit the cost from
-			feeCreditRecordID := tx.FeeCreditRecordID()
-			if err := m.state.Apply(unit.DecrCredit(feeCreditRecordID, sm.ActualFee)); err != nil {
+			// credit the cost from the fee payer (sponsor of the tx or the FCR of the client metadata)
+			feeCreditRecordID, _, err := unit.FeePayer(tx)
+			if err == nil {
+				err = m.state.Apply(unit.DecrCredit(feeCreditRecordID, sm.ActualFee))
+			}
+			if err != nil {
 				// Tx must not be added to block - FCR could not be credited.
 				// Otherwise, Tx would be for free, and there are no funds taken to pay validators
 				m.state.RollbackToSavepoint(savepointID)
 				// clear metadata
 				sm = nil
 				retErr = fmt.Errorf("handling transaction fee: %w", err)
+				return
 			}
 			// add fee credit record unit log
 			sm.TargetUnits = append(sm.TargetUnits, feeCreditRecordID)
@@ -345,6 +395,14 @@ func (m *GenericTxSystem) State() StateReader {
 	return m.state.Clone()
 }
 
+func (m *GenericTxSystem) StateAt(roundNumber uint64) (StateReader, error) {
+	snapshot, err := m.state.Snapshot(roundNumber)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
 func (m *GenericTxSystem) EndBlock() (StateSummary, error) {
 	for _, function := range m.endBlockFunctions {
 		if err := function(m.currentRoundNumber); err != nil {
@@ -354,6 +412,16 @@ func (m *GenericTxSystem) EndBlock() (StateSummary, error) {
 	return m.getStateSummary()
 }
 
+// DeletedUnits returns the units deleted by the modules outside of transactions
+// during the latest block (ie by the end block functions).
+func (m *GenericTxSystem) DeletedUnits() []*txtypes.DeletedUnit {
+	var units []*txtypes.DeletedUnit
+	for _, ud := range m.unitsDeleters {
+		units = append(units, ud.DeletedUnits()...)
+	}
+	return units
+}
+
 func (m *GenericTxSystem) Revert() {
 	if m.roundCommitted {
 		return
@@ -403,6 +471,16 @@ func (m *GenericTxSystem) initMetrics(mtr metric.Meter) error {
 	return nil
 }
 
+// appendUnitIDs appends the IDs which are not already in the list.
+func appendUnitIDs(ids []types.UnitID, add []types.UnitID) []types.UnitID {
+	for _, id := range add {
+		if !slices.ContainsFunc(ids, id.Eq) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 func appendServerMetadata(sm, smNew *types.ServerMetadata) *types.ServerMetadata {
 	if sm == nil {
 		return smNew
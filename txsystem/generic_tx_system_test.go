@@ -1,6 +1,7 @@
 package txsystem
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"hash"
@@ -15,7 +16,11 @@ import (
 	"github.com/alphabill-org/alphabill-go-base/types"
 	test "github.com/alphabill-org/alphabill/internal/testutils"
 	"github.com/alphabill-org/alphabill/internal/testutils/observability"
+	"github.com/alphabill-org/alphabill/predicates"
+	predtempl "github.com/alphabill-org/alphabill/predicates/templates"
 	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
+	"github.com/alphabill-org/alphabill/txsystem/fc"
 	"github.com/alphabill-org/alphabill/txsystem/testutils/transaction"
 	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
 )
@@ -72,6 +77,21 @@ func Test_NewGenericTxSystem(t *testing.T) {
 		require.EqualError(t, err, "observability must not be nil")
 	})
 
+	t.Run("invalid gas schedule", func(t *testing.T) {
+		gas := predicates.DefaultGasSchedule()
+		gas.Version = 2
+		txSys, err := NewGenericTxSystem(validPDR, types.ShardID{}, nil, nil, observability.Default(t), WithGasSchedule(gas))
+		require.Nil(t, txSys)
+		require.EqualError(t, err, `invalid gas schedule: unsupported gas schedule version 2, latest supported version is 1`)
+	})
+
+	t.Run("invalid gas schedule updates", func(t *testing.T) {
+		updates := predicates.GasScheduleUpdates{{ActivationRound: 10, Schedule: predicates.DefaultGasSchedule()}, {ActivationRound: 10, Schedule: predicates.DefaultGasSchedule()}}
+		txSys, err := NewGenericTxSystem(validPDR, types.ShardID{}, nil, nil, observability.Default(t), WithGasScheduleUpdates(updates))
+		require.Nil(t, txSys)
+		require.EqualError(t, err, `invalid gas schedule updates: gas schedule update 1: activation round 10 must be greater than the previous activation round 10`)
+	})
+
 	t.Run("success", func(t *testing.T) {
 		obs := observability.Default(t)
 		txSys, err := NewGenericTxSystem(
@@ -87,6 +107,7 @@ func Test_NewGenericTxSystem(t *testing.T) {
 		require.NotNil(t, txSys.fees)
 		// default is no fee handling, which will give you a huge gas budget
 		require.True(t, txSys.fees.BuyGas(1) == math.MaxUint64)
+		require.Equal(t, predicates.DefaultGasSchedule(), txSys.gasSchedule)
 	})
 }
 
@@ -331,6 +352,26 @@ func Test_GenericTxSystem_Execute(t *testing.T) {
 		require.NotNil(t, md)
 	})
 
+	t.Run("fee can't be charged", func(t *testing.T) {
+		// fee credit record of the tx doesn't exist so the cost can't be credited from it
+		m := NewMockTxModule(nil)
+		fcrID := types.NewUnitID(33, nil, []byte{1}, []byte{0xff})
+		txSys := NewTestGenericTxSystem(t, []txtypes.Module{m}, withFeeModule(&costlyFeeModule{}))
+		txo := transaction.NewTransactionOrder(t,
+			transaction.WithSystemID(mockTxSystemID),
+			transaction.WithTransactionType(mockTxType),
+			transaction.WithAttributes(MockTxAttributes{}),
+			transaction.WithClientMetadata(&types.ClientMetadata{
+				Timeout:           txSys.currentRoundNumber + 1,
+				FeeCreditRecordID: fcrID,
+				MaxTransactionFee: 1,
+			}),
+		)
+		md, err := txSys.Execute(txo)
+		require.ErrorContains(t, err, "handling transaction fee")
+		require.Nil(t, md)
+	})
+
 	t.Run("success", func(t *testing.T) {
 		m := NewMockTxModule(nil)
 		fcrID := types.NewUnitID(33, nil, []byte{1}, []byte{0xff})
@@ -352,6 +393,107 @@ func Test_GenericTxSystem_Execute(t *testing.T) {
 	})
 }
 
+func Test_GenericTxSystem_GasScheduleUpdates(t *testing.T) {
+	pdr := types.PartitionDescriptionRecord{
+		NetworkIdentifier: mockNetworkID,
+		SystemIdentifier:  mockTxSystemID,
+		TypeIdLen:         8,
+		UnitIdLen:         256,
+		T2Timeout:         2500 * time.Millisecond,
+	}
+	initial := predicates.DefaultGasSchedule()
+	update := predicates.DefaultGasSchedule()
+	update.GeneralTx = 2 * initial.GeneralTx
+	update.AlwaysTrue = 3 * initial.AlwaysTrue
+	txSys, err := NewGenericTxSystem(pdr, types.ShardID{}, nil, nil, observability.Default(t),
+		WithGasSchedule(initial),
+		WithGasScheduleUpdates(predicates.GasScheduleUpdates{{ActivationRound: 10, Schedule: update}}),
+	)
+	require.NoError(t, err)
+
+	alwaysTrue, err := predicates.ExtractPredicate(templates.AlwaysTrueBytes())
+	require.NoError(t, err)
+	// the engine is created with the initial schedule, the tx system provides the schedule of the round
+	engine := predtempl.New(predtempl.WithGasSchedule(initial))
+	// returns the gas spent by the transaction fee and the predicate in the round
+	gasSpent := func(t *testing.T, round uint64) uint64 {
+		require.NoError(t, txSys.BeginBlock(round))
+		txo := transaction.NewTransactionOrder(t, transaction.WithSystemID(mockTxSystemID), transaction.WithTransactionType(mockTxType))
+		exeCtx := txtypes.NewExecutionContext(txo, txSys, txSys.fees, nil, 10)
+		before := exeCtx.GasAvailable()
+		require.NoError(t, txSys.snFees(txo, exeCtx))
+		ok, err := engine.Execute(context.Background(), alwaysTrue, nil, nil, exeCtx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		return before - exeCtx.GasAvailable()
+	}
+
+	require.Same(t, initial, txSys.GasSchedule())
+	require.Equal(t, initial.GeneralTx+initial.AlwaysTrue, gasSpent(t, 9))
+	require.Same(t, initial, txSys.GasSchedule())
+	// the update takes effect from the activation round
+	require.Equal(t, update.GeneralTx+update.AlwaysTrue, gasSpent(t, 10))
+	require.Same(t, update, txSys.GasSchedule())
+	require.Equal(t, update.GeneralTx+update.AlwaysTrue, gasSpent(t, 11))
+}
+
+func Test_GenericTxSystem_PredicateStorage(t *testing.T) {
+	predicateHash, key := []byte{1, 2, 3}, []byte("key")
+	storageType := []byte{0x20}
+
+	newTx := func(t *testing.T, txSys *GenericTxSystem) *types.TransactionOrder {
+		return transaction.NewTransactionOrder(t,
+			transaction.WithSystemID(mockTxSystemID),
+			transaction.WithTransactionType(mockTxType),
+			transaction.WithAttributes(MockTxAttributes{}),
+			transaction.WithAuthProof(MockTxAuthProof{}),
+			transaction.WithClientMetadata(&types.ClientMetadata{
+				Timeout:           txSys.currentRoundNumber + 1,
+				MaxTransactionFee: 1,
+			}),
+		)
+	}
+
+	t.Run("storage not enabled", func(t *testing.T) {
+		m := &storageWriterModule{predicateHash: predicateHash, key: key, value: []byte{1}}
+		txSys := NewTestGenericTxSystem(t, []txtypes.Module{m})
+		md, err := txSys.Execute(newTx(t, txSys))
+		require.NoError(t, err)
+		require.EqualValues(t, types.TxStatusFailed, md.SuccessIndicator)
+		require.ErrorIs(t, m.writeErr, txtypes.ErrPredicateStorageNotAvailable)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		m := &storageWriterModule{predicateHash: predicateHash, key: key, value: []byte{1}}
+		txSys := NewTestGenericTxSystem(t, []txtypes.Module{m}, withPredicateStorage(storageType))
+		md, err := txSys.Execute(newTx(t, txSys))
+		require.NoError(t, err)
+		require.EqualValues(t, types.TxStatusSuccessful, md.SuccessIndicator)
+
+		unitID := txSys.predicateStorageUnitID(predicateHash, key)
+		require.True(t, unitID.HasType(storageType))
+		require.NoError(t, txSys.unitIdValidator(unitID))
+		require.Contains(t, md.TargetUnits, unitID)
+		u, err := txSys.state.GetUnit(unitID, false)
+		require.NoError(t, err)
+		require.Len(t, u.Logs(), 1)
+	})
+
+	t.Run("changes are rolled back when tx fails", func(t *testing.T) {
+		m := &storageWriterModule{predicateHash: predicateHash, key: key, value: []byte{1}, execErr: errors.New("nope")}
+		txSys := NewTestGenericTxSystem(t, []txtypes.Module{m}, withPredicateStorage(storageType))
+		md, err := txSys.Execute(newTx(t, txSys))
+		require.NoError(t, err)
+		require.EqualValues(t, types.TxStatusFailed, md.SuccessIndicator)
+		require.NoError(t, m.writeErr)
+
+		unitID := txSys.predicateStorageUnitID(predicateHash, key)
+		require.NotContains(t, md.TargetUnits, unitID)
+		_, err = txSys.state.GetUnit(unitID, false)
+		require.ErrorIs(t, err, avl.ErrNotFound)
+	})
+}
+
 func Test_GenericTxSystem_validateGenericTransaction(t *testing.T) {
 	// create valid order (in the sense of basic checks performed by the generic
 	// tx system) for "txs" transaction system
@@ -439,14 +581,64 @@ func (mm MockModule) TxHandlers() map[uint16]txtypes.TxExecutor {
 	}
 }
 
+// storageWriterModule writes into predicate storage during tx validation (as the
+// owner predicate would do).
+type storageWriterModule struct {
+	predicateHash []byte
+	key           []byte
+	value         []byte
+	execErr       error
+	writeErr      error
+}
+
+func (mm *storageWriterModule) validateTx(tx *types.TransactionOrder, _ *MockTxAttributes, _ *MockTxAuthProof, exeCtx txtypes.ExecutionContext) error {
+	storage, ok := exeCtx.(predicates.PredicateStorage)
+	if !ok {
+		return errors.New("execution context doesn't implement predicate storage")
+	}
+	mm.writeErr = storage.WritePredicateData(mm.predicateHash, mm.key, mm.value)
+	return mm.writeErr
+}
+
+func (mm *storageWriterModule) executeTx(tx *types.TransactionOrder, _ *MockTxAttributes, _ *MockTxAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
+	return &types.ServerMetadata{SuccessIndicator: types.TxStatusSuccessful}, mm.execErr
+}
+
+func (mm *storageWriterModule) TxHandlers() map[uint16]txtypes.TxExecutor {
+	return map[uint16]txtypes.TxExecutor{
+		mockTxType: txtypes.NewTxHandler[MockTxAttributes](mm.validateTx, mm.executeTx),
+	}
+}
+
+// costlyFeeModule charges fee for every transaction without checking the fee credit.
+type costlyFeeModule struct {
+	fc.NoFeeHandling
+}
+
+func (f *costlyFeeModule) CalculateCost(_ uint64) uint64 { return 1 }
+
 type txSystemTestOption func(m *GenericTxSystem) error
 
+func withFeeModule(fees txtypes.FeeCreditModule) txSystemTestOption {
+	return func(m *GenericTxSystem) error {
+		m.fees = fees
+		return nil
+	}
+}
+
 func withStateUnit(unitID []byte, data types.UnitData, lock []byte) txSystemTestOption {
 	return func(m *GenericTxSystem) error {
 		return m.state.Apply(state.AddUnitWithLock(unitID, data, lock))
 	}
 }
 
+func withPredicateStorage(unitType []byte) txSystemTestOption {
+	return func(m *GenericTxSystem) error {
+		m.predicateStorage = unitType
+		return nil
+	}
+}
+
 func withTrustBase(tb types.RootTrustBase) txSystemTestOption {
 	return func(m *GenericTxSystem) error {
 		m.trustBase = tb
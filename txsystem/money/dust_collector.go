@@ -1,14 +1,17 @@
 package money
 
 import (
+	"errors"
 	"fmt"
 
-	"github.com/alphabill-org/alphabill-go-base/hash"
+	abhash "github.com/alphabill-org/alphabill-go-base/hash"
 	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
 	"github.com/alphabill-org/alphabill-go-base/txsystem/money"
 	"github.com/alphabill-org/alphabill-go-base/types"
 
 	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
 )
 
 const defaultDustBillDeletionTimeout uint64 = 65536
@@ -18,43 +21,97 @@ var (
 	DustCollectorMoneySupplyID = money.NewBillID(nil, nil)
 
 	// Dust collector predicate
-	DustCollectorPredicate = templates.NewP2pkh256BytesFromKeyHash(hash.Sum256([]byte("dust collector")))
+	DustCollectorPredicate = templates.NewP2pkh256BytesFromKeyHash(abhash.Sum256([]byte("dust collector")))
+
+	// DustBillScheduleUnitType is the type of the units holding the IDs of the dust
+	// bills to be deleted in the end of the round.
+	DustBillScheduleUnitType = []byte{32}
+
+	// FeeCreditRecordExpiryScheduleUnitType is the type of the units holding the fee
+	// credit records to be checked for expiry in the end of the round.
+	FeeCreditRecordExpiryScheduleUnitType = []byte{34}
 )
 
-type DustCollector struct {
-	// Contains the bill identifiers transferred to the dust collector. The key of the map is the block number when the
-	// bill is deleted and its value is transferred to the dust collector.
-	dustCollectorBills map[uint64][]types.UnitID
-	state              *state.State
+/*
+NewDustBillScheduleID returns ID of the unit which holds the dust bills to be deleted
+in the end of the round "roundNumber".
+*/
+func NewDustBillScheduleID(roundNumber uint64) types.UnitID {
+	return txtypes.NewRoundScheduleID(money.UnitIDLength, DustBillScheduleUnitType, roundNumber)
 }
 
-func NewDustCollector(s *state.State) *DustCollector {
-	return &DustCollector{
-		state:              s,
-		dustCollectorBills: map[uint64][]types.UnitID{},
+/*
+NewFeeCreditRecordExpiryScheduleID returns ID of the unit which holds the fee credit
+records to be checked for expiry in the end of the round "roundNumber".
+*/
+func NewFeeCreditRecordExpiryScheduleID(roundNumber uint64) types.UnitID {
+	return txtypes.NewRoundScheduleID(money.UnitIDLength, FeeCreditRecordExpiryScheduleUnitType, roundNumber)
+}
+
+/*
+NewUnitData is the unit data constructor of the money partition, in addition to the
+units of the SDK it supports the dust bill schedule, the fee reward pool and the fee
+credit record expiry schedule units.
+*/
+func NewUnitData(unitID types.UnitID) (types.UnitData, error) {
+	if unitID.HasType(DustBillScheduleUnitType) || unitID.HasType(FeeCreditRecordExpiryScheduleUnitType) {
+		return &txtypes.RoundSchedule{}, nil
+	}
+	if unitID.HasType(FeeRewardPoolUnitType) {
+		return &FeeRewardPool{}, nil
 	}
+	return money.NewUnitData(unitID)
 }
 
-func (d *DustCollector) AddDustBill(id types.UnitID, currentBlockNumber uint64) {
-	delBlockNr := currentBlockNumber + defaultDustBillDeletionTimeout
-	d.dustCollectorBills[delBlockNr] = append(d.dustCollectorBills[delBlockNr], id)
+/*
+DustCollector deletes the bills transferred to the dust collector after the deletion
+timeout. The deletion schedule is kept in the state (unit per round) so it is part of
+the state root and survives restarts.
+*/
+type DustCollector struct {
+	state *state.State
+	// bills deleted by the latest consolidateDust call
+	deleted []*txtypes.DeletedUnit
 }
 
-func (d *DustCollector) GetDustBills(blockNumber uint64) []types.UnitID {
-	return d.dustCollectorBills[blockNumber]
+func NewDustCollector(s *state.State) *DustCollector {
+	return &DustCollector{state: s}
 }
 
-func (d *DustCollector) RemoveDustBills(blockNumber uint64) {
-	delete(d.dustCollectorBills, blockNumber)
+/*
+addDustBill returns state action which schedules the bill to be deleted in the end
+of the round currentRoundNumber+defaultDustBillDeletionTimeout and the ID of the
+schedule unit.
+*/
+func (d *DustCollector) addDustBill(id types.UnitID, currentRoundNumber uint64) (state.Action, types.UnitID, error) {
+	scheduleID := NewDustBillScheduleID(currentRoundNumber + defaultDustBillDeletionTimeout)
+	action, err := txtypes.AddToRoundSchedule(d.state, scheduleID, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("dust bill schedule: %w", err)
+	}
+	return action, scheduleID, nil
 }
 
-func (d *DustCollector) consolidateDust(currentBlockNumber uint64) error {
-	dustBills := d.GetDustBills(currentBlockNumber)
+/*
+consolidateDust deletes the dust bills scheduled to be deleted in the end of the round
+and transfers their value (if any) to the dust collector money supply.
+*/
+func (d *DustCollector) consolidateDust(currentRoundNumber uint64) error {
+	d.deleted = nil
+	bills, err := txtypes.TakeRoundSchedule(d.state, NewDustBillScheduleID(currentRoundNumber))
+	if err != nil {
+		return fmt.Errorf("dust bill schedule: %w", err)
+	}
+
 	var valueToTransfer uint64
-	for _, billID := range dustBills {
+	var deleted []*txtypes.DeletedUnit
+	for _, billID := range bills {
 		u, err := d.state.GetUnit(billID, false)
 		if err != nil {
-			return err
+			if errors.Is(err, avl.ErrNotFound) {
+				continue
+			}
+			return fmt.Errorf("reading dust bill: %w", err)
 		}
 		bd, ok := u.Data().(*money.BillData)
 		if !ok {
@@ -62,10 +119,10 @@ func (d *DustCollector) consolidateDust(currentBlockNumber uint64) error {
 			continue
 		}
 		valueToTransfer += bd.Value
-		err = d.state.Apply(state.DeleteUnit(billID))
-		if err != nil {
-			return err
+		if err := d.state.Apply(state.DeleteUnit(billID)); err != nil {
+			return fmt.Errorf("deleting dust bill: %w", err)
 		}
+		deleted = append(deleted, &txtypes.DeletedUnit{UnitID: billID, Data: bd})
 	}
 	if valueToTransfer > 0 {
 		err := d.state.Apply(state.UpdateUnitData(DustCollectorMoneySupplyID,
@@ -80,7 +137,15 @@ func (d *DustCollector) consolidateDust(currentBlockNumber uint64) error {
 		if err != nil {
 			return err
 		}
+		if err := d.state.AddUnitLog(DustCollectorMoneySupplyID, make([]byte, d.state.HashAlgorithm().Size())); err != nil {
+			return fmt.Errorf("failed to update dust collector money supply state log: %w", err)
+		}
 	}
-	d.RemoveDustBills(currentBlockNumber)
+	d.deleted = deleted
 	return nil
 }
+
+// DeletedUnits returns the dust bills deleted by the latest consolidateDust call.
+func (d *DustCollector) DeletedUnits() []*txtypes.DeletedUnit {
+	return d.deleted
+}
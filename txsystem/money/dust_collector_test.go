@@ -0,0 +1,102 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/money"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
+)
+
+func TestDustCollector_addDustBill(t *testing.T) {
+	s := state.NewEmptyState()
+	dc := NewDustCollector(s)
+	billID1 := money.NewBillID(nil, []byte{1})
+	billID2 := money.NewBillID(nil, []byte{2})
+
+	// first bill of the round creates the schedule unit
+	action, scheduleID, err := dc.addDustBill(billID1, 10)
+	require.NoError(t, err)
+	require.Equal(t, NewDustBillScheduleID(10+defaultDustBillDeletionTimeout), scheduleID)
+	require.NoError(t, s.Apply(action))
+
+	// next one is appended to the existing schedule
+	action, scheduleID2, err := dc.addDustBill(billID2, 10)
+	require.NoError(t, err)
+	require.Equal(t, scheduleID, scheduleID2)
+	require.NoError(t, s.Apply(action))
+
+	u, err := s.GetUnit(scheduleID, false)
+	require.NoError(t, err)
+	require.Equal(t, &txtypes.RoundSchedule{Units: []types.UnitID{billID1, billID2}}, u.Data())
+}
+
+func TestDustCollector_consolidateDust(t *testing.T) {
+	t.Run("nothing scheduled", func(t *testing.T) {
+		s := state.NewEmptyState()
+		dc := NewDustCollector(s)
+		require.NoError(t, dc.consolidateDust(10))
+		require.Empty(t, dc.DeletedUnits())
+	})
+
+	t.Run("bills are deleted and value moved to DC money supply", func(t *testing.T) {
+		s := state.NewEmptyState()
+		dc := NewDustCollector(s)
+		billID1 := money.NewBillID(nil, []byte{1})
+		billID2 := money.NewBillID(nil, []byte{2})
+		require.NoError(t, s.Apply(
+			state.AddUnit(DustCollectorMoneySupplyID, money.NewBillData(100, DustCollectorPredicate)),
+			state.AddUnit(billID1, money.NewBillData(0, DustCollectorPredicate)),
+			state.AddUnit(billID2, money.NewBillData(5, templates.AlwaysTrueBytes())),
+		))
+		for _, id := range []types.UnitID{billID1, billID2} {
+			action, _, err := dc.addDustBill(id, 1)
+			require.NoError(t, err)
+			require.NoError(t, s.Apply(action))
+		}
+		round := 1 + defaultDustBillDeletionTimeout
+
+		// not yet the round of the schedule
+		require.NoError(t, dc.consolidateDust(round-1))
+		require.Empty(t, dc.DeletedUnits())
+
+		require.NoError(t, dc.consolidateDust(round))
+		for _, id := range []types.UnitID{billID1, billID2, NewDustBillScheduleID(round)} {
+			_, err := s.GetUnit(id, false)
+			require.ErrorIs(t, err, avl.ErrNotFound)
+		}
+		u, err := s.GetUnit(DustCollectorMoneySupplyID, false)
+		require.NoError(t, err)
+		require.EqualValues(t, 105, u.Data().SummaryValueInput())
+		require.Len(t, u.Logs(), 1)
+
+		deleted := dc.DeletedUnits()
+		require.Len(t, deleted, 2)
+		require.Equal(t, billID1, deleted[0].UnitID)
+		require.Equal(t, billID2, deleted[1].UnitID)
+		require.EqualValues(t, templates.AlwaysTrueBytes(), deleted[1].Data.Owner())
+	})
+}
+
+func TestNewUnitData(t *testing.T) {
+	data, err := NewUnitData(NewDustBillScheduleID(1))
+	require.NoError(t, err)
+	require.IsType(t, &txtypes.RoundSchedule{}, data)
+
+	data, err = NewUnitData(NewFeeRewardPoolID(money.DefaultSystemID))
+	require.NoError(t, err)
+	require.IsType(t, &FeeRewardPool{}, data)
+
+	data, err = NewUnitData(NewFeeCreditRecordExpiryScheduleID(1))
+	require.NoError(t, err)
+	require.IsType(t, &txtypes.RoundSchedule{}, data)
+
+	data, err = NewUnitData(money.NewBillID(nil, []byte{1}))
+	require.NoError(t, err)
+	require.IsType(t, &money.BillData{}, data)
+}
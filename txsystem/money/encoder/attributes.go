@@ -7,8 +7,13 @@ import (
 	"github.com/alphabill-org/alphabill-go-base/txsystem/money"
 	"github.com/alphabill-org/alphabill-go-base/types"
 	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/encoder"
+	moneytx "github.com/alphabill-org/alphabill/txsystem/money"
 )
 
+func init() {
+	encoder.Register(RegisterTxAttributeEncoders, RegisterUnitDataEncoders)
+}
+
 func RegisterTxAttributeEncoders(reg func(id encoder.AttrEncID, enc encoder.TxAttributesEncoder) error) error {
 	key := func(attrID uint16) encoder.AttrEncID {
 		return encoder.AttrEncID{
@@ -18,6 +23,12 @@ func RegisterTxAttributeEncoders(reg func(id encoder.AttrEncID, enc encoder.TxAt
 	}
 	return errors.Join(
 		reg(key(money.TransactionTypeTransfer), txaTransferAttributes),
+		reg(key(money.TransactionTypeSplit), txaSplitAttributes),
+		reg(key(money.TransactionTypeTransDC), txaTransferDCAttributes),
+		reg(key(money.TransactionTypeSwapDC), txaSwapDCAttributes),
+		reg(key(money.TransactionTypeLock), txaLockAttributes),
+		reg(key(money.TransactionTypeUnlock), txaUnlockAttributes),
+		reg(key(moneytx.TransactionTypeRecordFees), txaRecordFeesAttributes),
 	)
 }
 
@@ -36,6 +47,9 @@ func RegisterTxAttributeEncodersF(filter func(encoder.AttrEncID) bool) func(func
 }
 
 func txaTransferAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
 	attr := &money.TransferAttributes{}
 	if err := txo.UnmarshalAttributes(attr); err != nil {
 		return nil, fmt.Errorf("reading transaction attributes: %w", err)
@@ -45,3 +59,102 @@ func txaTransferAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, err
 	buf.EncodeTagged(2, attr.Counter)
 	return buf.Bytes()
 }
+
+/*
+txaSplitAttributes encodes split attributes:
+  - ver 1: array of target units (each unit is array of amount and owner predicate) and counter;
+*/
+func txaSplitAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &money.SplitAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	units := make([]any, len(attr.TargetUnits))
+	for i, u := range attr.TargetUnits {
+		units[i] = []any{u.Amount, u.OwnerPredicate}
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, units)
+	buf.EncodeTagged(2, attr.Counter)
+	return buf.Bytes()
+}
+
+func txaTransferDCAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &money.TransferDCAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.Value)
+	buf.EncodeTagged(2, attr.TargetUnitID)
+	buf.EncodeTagged(3, attr.TargetUnitCounter)
+	buf.EncodeTagged(4, attr.Counter)
+	return buf.Bytes()
+}
+
+/*
+txaSwapDCAttributes encodes swap attributes:
+  - ver 1: array of CBOR encoded dust transfer transaction record proofs;
+*/
+func txaSwapDCAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &money.SwapDCAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	proofs := make([]any, len(attr.DustTransferProofs))
+	for i, p := range attr.DustTransferProofs {
+		proofs[i] = buf.CBORBytes(p)
+	}
+	buf.EncodeTagged(1, proofs)
+	return buf.Bytes()
+}
+
+func txaLockAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &money.LockAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.Counter)
+	buf.EncodeTagged(2, attr.LockStatus)
+	return buf.Bytes()
+}
+
+func txaUnlockAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &money.UnlockAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.Counter)
+	return buf.Bytes()
+}
+
+func txaRecordFeesAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &moneytx.RecordFeesAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, buf.CBORBytes(attr.Rounds))
+	return buf.Bytes()
+}
@@ -0,0 +1,98 @@
+package moneyenc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/money"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/encoder"
+	moneytx "github.com/alphabill-org/alphabill/txsystem/money"
+)
+
+func Test_moneyAttributesEncoding_trigger(t *testing.T) {
+	/*
+		If test here fails it's probably because some data structure (or rather
+		how it's serialized for Rust SDK) has been changed without versioning?
+	*/
+	tests := []struct {
+		name string
+		enc  encoder.TxAttributesEncoder
+		attr any
+		exp  []byte
+	}{
+		{
+			name: "Transfer",
+			enc:  txaTransferAttributes,
+			attr: money.TransferAttributes{TargetValue: 10, NewOwnerPredicate: []byte{1}, Counter: 2},
+			exp:  []byte{0x1, 0x2, 0xa, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x2, 0x2, 0x2, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0},
+		},
+		{
+			name: "Split",
+			enc:  txaSplitAttributes,
+			attr: money.SplitAttributes{TargetUnits: []*money.TargetUnit{{Amount: 10, OwnerPredicate: []byte{1}}}, Counter: 2},
+			exp:  []byte{0x1, 0x6, 0x1, 0x0, 0x0, 0x0, 0x6, 0x2, 0x0, 0x0, 0x0, 0x2, 0xa, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0, 0x1, 0x2, 0x2, 0x2, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0},
+		},
+		{
+			name: "TransferDC",
+			enc:  txaTransferDCAttributes,
+			attr: money.TransferDCAttributes{Value: 10, TargetUnitID: []byte{1, 2}, TargetUnitCounter: 3, Counter: 4},
+			exp:  []byte{0x1, 0x2, 0xa, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x2, 0x1, 0x2, 0x0, 0x0, 0x0, 0x1, 0x2, 0x3, 0x2, 0x3, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x4, 0x2, 0x4, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0},
+		},
+		{
+			name: "SwapDC",
+			enc:  txaSwapDCAttributes,
+			attr: money.SwapDCAttributes{DustTransferProofs: []*types.TxRecordProof{{}}},
+			exp:  []byte{0x1, 0x6, 0x1, 0x0, 0x0, 0x0, 0x1, 0x3, 0x0, 0x0, 0x0, 0x82, 0xf6, 0xf6},
+		},
+		{
+			name: "Lock",
+			enc:  txaLockAttributes,
+			attr: money.LockAttributes{LockStatus: 1, Counter: 2},
+			exp:  []byte{0x1, 0x2, 0x2, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x2, 0x2, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0},
+		},
+		{
+			name: "Unlock",
+			enc:  txaUnlockAttributes,
+			attr: money.UnlockAttributes{Counter: 2},
+			exp:  []byte{0x1, 0x2, 0x2, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0},
+		},
+		{
+			name: "RecordFees",
+			enc:  txaRecordFeesAttributes,
+			attr: moneytx.RecordFeesAttributes{Rounds: []*moneytx.RoundFees{{BlockHeader: &types.Header{ProposerID: "1"}, TxRootHash: []byte{5}}}},
+			exp:  []byte{0x1, 0x1, 0xc, 0x0, 0x0, 0x0, 0x81, 0x83, 0xf6, 0x84, 0x0, 0x41, 0x80, 0x61, 0x31, 0xf6, 0x41, 0x5},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			txo := &types.TransactionOrder{Payload: types.Payload{}}
+			require.NoError(t, txo.SetAttributes(tc.attr))
+			b, err := tc.enc(txo, 1)
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, b)
+
+			b, err = tc.enc(txo, 2)
+			require.EqualError(t, err, `unsupported encoding version 2, latest supported version is 1`)
+			require.Nil(t, b)
+		})
+	}
+}
+
+func Test_udeBillData(t *testing.T) {
+	b, err := udeBillData(&money.BillData{Value: 10, OwnerPredicate: []byte{1}, Counter: 2, Locked: 1}, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x1, 0x2, 0xa, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x2, 0x2, 0x2, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x3, 0x2, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, b)
+}
+
+func Test_RegisterEncoders(t *testing.T) {
+	enc, err := encoder.New(RegisterTxAttributeEncoders, RegisterUnitDataEncoders)
+	require.NoError(t, err)
+
+	txo := &types.TransactionOrder{Payload: types.Payload{SystemID: money.DefaultSystemID, Type: money.TransactionTypeUnlock}}
+	require.NoError(t, txo.SetAttributes(money.UnlockAttributes{Counter: 2}))
+	b, err := enc.TxAttributes(txo, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x1, 0x2, 0x2, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, b)
+}
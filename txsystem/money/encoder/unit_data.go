@@ -0,0 +1,27 @@
+package moneyenc
+
+import (
+	"errors"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/money"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/encoder"
+)
+
+func RegisterUnitDataEncoders(reg func(ud any, enc encoder.UnitDataEncoder) error) error {
+	return errors.Join(
+		reg(&money.BillData{}, udeBillData),
+	)
+}
+
+func udeBillData(data types.UnitData, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	value := data.(*money.BillData)
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, value.Value)
+	buf.EncodeTagged(2, value.Counter)
+	buf.EncodeTagged(3, value.Locked)
+	return buf.Bytes()
+}
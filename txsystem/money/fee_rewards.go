@@ -0,0 +1,396 @@
+package money
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+	"math/bits"
+	"slices"
+
+	abhash "github.com/alphabill-org/alphabill-go-base/hash"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/money"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill-go-base/util"
+
+	"github.com/alphabill-org/alphabill/network/protocol/genesis"
+	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
+)
+
+// TransactionTypeRecordFees is the type of the transaction which records the fees
+// earned by a partition (as certified by the UCs of the partition) for rewarding the
+// validators of the partition.
+const TransactionTypeRecordFees uint16 = 7
+
+// MaxRecordFeesRounds is the maximum number of the partition rounds recorded by a
+// single TransactionTypeRecordFees transaction.
+const MaxRecordFeesRounds = 100
+
+var (
+	// FeeRewardPoolUnitType is the type of the units holding the fees recorded (but
+	// not yet paid out) for the validators of a partition.
+	FeeRewardPoolUnitType = []byte{33}
+)
+
+var _ types.UnitData = (*FeeRewardPool)(nil)
+
+type (
+	// FeeRewardPool is the unit data of the fee reward pool of a partition.
+	FeeRewardPool struct {
+		_ struct{} `cbor:",toarray"`
+		// the round number of the latest recorded UC of the partition, the UCs
+		// must be recorded in order without gaps so no round can be skipped
+		LastRecordedRound uint64 `json:"lastRecordedRound,string"`
+		// the fees recorded and not yet paid out
+		Fees uint64 `json:"fees,string"`
+		// the number of the recorded blocks proposed by the validators during
+		// the current epoch, indexed as genesis.RewardPolicy.Validators
+		BlocksProposed []uint64 `json:"blocksProposed"`
+		// the money partition round number of the latest payout and the amounts
+		// paid to the validators (indexed as genesis.RewardPolicy.Validators)
+		LastPayoutRound uint64   `json:"lastPayoutRound,string"`
+		LastPayout      []uint64 `json:"lastPayout"`
+	}
+
+	// RecordFeesAttributes is the attributes of the TransactionTypeRecordFees transaction.
+	RecordFeesAttributes struct {
+		_ struct{} `cbor:",toarray"`
+		// consecutive rounds of the partition, starting from the round following
+		// the latest recorded round of the fee reward pool
+		Rounds []*RoundFees
+	}
+
+	// RoundFees is the certified fees earned by a partition in a round.
+	RoundFees struct {
+		_ struct{} `cbor:",toarray"`
+		// UC of the partition, certifies the sum of the fees earned in the round
+		UnicityCertificate *types.UnicityCertificate
+		// header of the certified block and the root hash of the transactions of the
+		// block, prove the proposer of the block; required by the genesis.RewardPolicyBlocksProposed
+		// unless the block is empty (zero block hash)
+		BlockHeader *types.Header
+		TxRootHash  []byte
+	}
+
+	// RecordFeesAuthProof is the auth proof of the TransactionTypeRecordFees transaction,
+	// the transaction doesn't need authorization as the data is certified by the UCs.
+	RecordFeesAuthProof struct {
+		_ struct{} `cbor:",toarray"`
+	}
+)
+
+/*
+policyRewards returns the amounts to be paid to the validators of the policy "p" out of the
+fees in the pool, the remainder of the division stays in the pool.
+*/
+func policyRewards(p *genesis.RewardPolicy, pool *FeeRewardPool) []uint64 {
+	rewards := make([]uint64, len(p.Validators))
+	switch p.Policy {
+	case genesis.RewardPolicyEqualSplit:
+		share := pool.Fees / uint64(len(p.Validators))
+		for i := range rewards {
+			rewards[i] = share
+		}
+	case genesis.RewardPolicyBlocksProposed:
+		var total uint64
+		for _, n := range pool.BlocksProposed {
+			total += n
+		}
+		if total == 0 {
+			return rewards
+		}
+		for i := range rewards {
+			if i < len(pool.BlocksProposed) {
+				// fees * blocks / total without overflow, the result can't exceed the fees
+				hi, lo := bits.Mul64(pool.Fees, pool.BlocksProposed[i])
+				rewards[i], _ = bits.Div64(hi, lo, total)
+			}
+		}
+	}
+	return rewards
+}
+
+func (p *FeeRewardPool) Write(hasher hash.Hash) error {
+	res, err := types.Cbor.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("fee reward pool encode error: %w", err)
+	}
+	_, err = hasher.Write(res)
+	return err
+}
+
+func (p *FeeRewardPool) SummaryValueInput() uint64 {
+	return 0
+}
+
+func (p *FeeRewardPool) Copy() types.UnitData {
+	return &FeeRewardPool{
+		LastRecordedRound: p.LastRecordedRound,
+		Fees:              p.Fees,
+		BlocksProposed:    slices.Clone(p.BlocksProposed),
+		LastPayoutRound:   p.LastPayoutRound,
+		LastPayout:        slices.Clone(p.LastPayout),
+	}
+}
+
+func (p *FeeRewardPool) Owner() []byte {
+	return nil
+}
+
+// NewFeeRewardPoolID returns ID of the unit which holds the fee rewards of the partition.
+func NewFeeRewardPoolID(systemID types.SystemID) types.UnitID {
+	return types.NewUnitID(money.UnitIDLength, nil, systemID.Bytes(), FeeRewardPoolUnitType)
+}
+
+// NewRewardBillID returns ID of the bill receiving the fee rewards of the validator of the partition.
+func NewRewardBillID(systemID types.SystemID, nodeID string) types.UnitID {
+	return money.NewBillID(nil, abhash.Sum256(append(systemID.Bytes(), nodeID...)))
+}
+
+func (m *Module) rewardPolicy(systemID types.SystemID) *genesis.RewardPolicy {
+	idx := slices.IndexFunc(m.rewardPolicies, func(p *genesis.RewardPolicy) bool { return p.SystemIdentifier == systemID })
+	if idx < 0 {
+		return nil
+	}
+	return m.rewardPolicies[idx]
+}
+
+// feeRewardPool returns the fee reward pool of the partition, empty pool when the unit doesn't exist yet.
+func (m *Module) feeRewardPool(id types.UnitID) (*FeeRewardPool, bool, error) {
+	u, err := m.state.GetUnit(id, false)
+	if err != nil {
+		if errors.Is(err, avl.ErrNotFound) {
+			return &FeeRewardPool{}, false, nil
+		}
+		return nil, false, fmt.Errorf("reading fee reward pool: %w", err)
+	}
+	pool, ok := u.Data().(*FeeRewardPool)
+	if !ok {
+		return nil, false, fmt.Errorf("unit %v does not contain fee reward pool", id)
+	}
+	return pool, true, nil
+}
+
+func (m *Module) validateRecordFeesTx(tx *types.TransactionOrder, attr *RecordFeesAttributes, _ *RecordFeesAuthProof, _ txtypes.ExecutionContext) error {
+	if len(attr.Rounds) == 0 {
+		return errors.New("no rounds to record")
+	}
+	if len(attr.Rounds) > MaxRecordFeesRounds {
+		return fmt.Errorf("too many rounds to record: %d, allowed %d", len(attr.Rounds), MaxRecordFeesRounds)
+	}
+	var pool *FeeRewardPool
+	var policy *genesis.RewardPolicy
+	for i, r := range attr.Rounds {
+		uc := r.UnicityCertificate
+		if uc == nil || uc.UnicityTreeCertificate == nil || uc.InputRecord == nil {
+			return fmt.Errorf("round %d: unicity certificate is missing", i)
+		}
+		systemID := uc.UnicityTreeCertificate.SystemIdentifier
+		if i == 0 {
+			if policy = m.rewardPolicy(systemID); policy == nil {
+				return fmt.Errorf("no fee reward policy for partition %s", systemID)
+			}
+			if poolID := NewFeeRewardPoolID(systemID); !tx.UnitID.Eq(poolID) {
+				return fmt.Errorf("invalid unit ID: expected fee reward pool %s, got %s", poolID, tx.UnitID)
+			}
+			var err error
+			if pool, _, err = m.feeRewardPool(tx.UnitID); err != nil {
+				return err
+			}
+		} else if systemID != policy.SystemIdentifier {
+			return fmt.Errorf("round %d: unicity certificate of partition %s, expected %s", i, systemID, policy.SystemIdentifier)
+		}
+		if expected := pool.LastRecordedRound + 1 + uint64(i); uc.InputRecord.RoundNumber != expected {
+			return fmt.Errorf("invalid round %d, expected the fees of the round %d", uc.InputRecord.RoundNumber, expected)
+		}
+		pdr := m.feeCreditTxRecorder.sdrs[systemID]
+		if err := uc.Verify(m.trustBase, m.hashAlgorithm, systemID, pdr.Hash(m.hashAlgorithm)); err != nil {
+			return fmt.Errorf("round %d: invalid unicity certificate: %w", uc.InputRecord.RoundNumber, err)
+		}
+		// empty block (zero block hash) has no proposer to prove
+		emptyBlock := bytes.Equal(uc.InputRecord.BlockHash, make([]byte, m.hashAlgorithm.Size()))
+		if (policy.Policy == genesis.RewardPolicyBlocksProposed && !emptyBlock) || r.BlockHeader != nil {
+			if _, err := m.blockProposer(policy, r); err != nil {
+				return fmt.Errorf("round %d: %w", uc.InputRecord.RoundNumber, err)
+			}
+		}
+	}
+	return nil
+}
+
+/*
+blockProposer verifies the block header against the block hash certified by the UC
+and returns the index of the proposer of the block in the validators of the policy.
+*/
+func (m *Module) blockProposer(policy *genesis.RewardPolicy, r *RoundFees) (int, error) {
+	h := r.BlockHeader
+	if h == nil {
+		return -1, errors.New("block header is missing")
+	}
+	if h.SystemID != policy.SystemIdentifier {
+		return -1, fmt.Errorf("invalid block header system identifier %s", h.SystemID)
+	}
+	// block hash is H(header hash, previous state hash, state hash, transactions root hash)
+	ir := r.UnicityCertificate.InputRecord
+	hasher := m.hashAlgorithm.New()
+	hasher.Write(h.Hash(m.hashAlgorithm))
+	hasher.Write(ir.PreviousHash)
+	hasher.Write(ir.Hash)
+	hasher.Write(r.TxRootHash)
+	if !bytes.Equal(hasher.Sum(nil), ir.BlockHash) {
+		return -1, errors.New("block header does not match the certified block hash")
+	}
+	idx := policy.ValidatorIndex(h.ProposerID)
+	if idx < 0 {
+		return -1, fmt.Errorf("block proposer %s is not a validator of the reward policy", h.ProposerID)
+	}
+	return idx, nil
+}
+
+func (m *Module) executeRecordFeesTx(tx *types.TransactionOrder, attr *RecordFeesAttributes, _ *RecordFeesAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
+	policy := m.rewardPolicy(attr.Rounds[0].UnicityCertificate.UnicityTreeCertificate.SystemIdentifier)
+	pool, exists, err := m.feeRewardPool(tx.UnitID)
+	if err != nil {
+		return nil, err
+	}
+	pool = pool.Copy().(*FeeRewardPool)
+	for _, r := range attr.Rounds {
+		ir := r.UnicityCertificate.InputRecord
+		var ok bool
+		if pool.Fees, ok = util.SafeAdd(pool.Fees, ir.SumOfEarnedFees); !ok {
+			return nil, fmt.Errorf("round %d: recorded fees overflow", ir.RoundNumber)
+		}
+		pool.LastRecordedRound = ir.RoundNumber
+		if r.BlockHeader != nil {
+			idx, err := m.blockProposer(policy, r)
+			if err != nil {
+				return nil, err
+			}
+			if len(pool.BlocksProposed) != len(policy.Validators) {
+				pool.BlocksProposed = make([]uint64, len(policy.Validators))
+			}
+			pool.BlocksProposed[idx]++
+		}
+	}
+	if err := m.state.Apply(setUnitData(tx.UnitID, pool, exists)); err != nil {
+		return nil, fmt.Errorf("record fees: failed to update state: %w", err)
+	}
+	return &types.ServerMetadata{TargetUnits: []types.UnitID{tx.UnitID}, SuccessIndicator: types.TxStatusSuccessful}, nil
+}
+
+/*
+payFeeRewards pays the fees recorded in the fee reward pools to the reward bills of the
+validators in the end of every EpochLength-th round of the money partition. The rewards
+are moved from the fee credit bill of the partition, the remainder of the division stays
+in the pool. The payout is skipped (the fees stay in the pool until the next epoch) when
+the fee credit bill doesn't have enough value.
+
+The payout is a system action of the block like the consolidation of the fees and the
+dust: the amounts are determined by the reward policy and the recorded fees only, the
+changed units get a state log entry (without transaction) and the payout is stored in
+the pool unit so it is part of the state certified by the UC of the block.
+*/
+func (m *Module) payFeeRewards(roundNumber uint64) error {
+	for _, policy := range m.rewardPolicies {
+		if roundNumber%policy.EpochLength != 0 {
+			continue
+		}
+		poolID := NewFeeRewardPoolID(policy.SystemIdentifier)
+		pool, exists, err := m.feeRewardPool(poolID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		rewards := policyRewards(policy, pool)
+		var total uint64
+		for _, r := range rewards {
+			total += r
+		}
+		if total == 0 {
+			continue
+		}
+		fcbID := m.feeCreditTxRecorder.sdrs[policy.SystemIdentifier].FeeCreditBill.UnitID
+		fcbUnit, err := m.state.GetUnit(fcbID, false)
+		if err != nil {
+			return fmt.Errorf("reading fee credit bill of partition %s: %w", policy.SystemIdentifier, err)
+		}
+		fcb, ok := fcbUnit.Data().(*money.BillData)
+		if !ok {
+			return fmt.Errorf("unit %v does not contain bill data", fcbID)
+		}
+		if fcb.Value < total {
+			continue
+		}
+
+		actions := []state.Action{
+			state.UpdateUnitData(fcbID, func(data types.UnitData) (types.UnitData, error) {
+				bd, ok := data.(*money.BillData)
+				if !ok {
+					return nil, fmt.Errorf("unit %v does not contain bill data", fcbID)
+				}
+				bd.Value -= total
+				return bd, nil
+			}),
+		}
+		changed := []types.UnitID{poolID, fcbID}
+		for i, v := range policy.Validators {
+			if rewards[i] == 0 {
+				continue
+			}
+			billID := NewRewardBillID(policy.SystemIdentifier, v.NodeIdentifier)
+			action, err := m.addReward(billID, v.OwnerPredicate, rewards[i])
+			if err != nil {
+				return err
+			}
+			actions = append(actions, action)
+			changed = append(changed, billID)
+		}
+		pool = pool.Copy().(*FeeRewardPool)
+		pool.Fees -= total
+		pool.BlocksProposed = nil
+		pool.LastPayoutRound = roundNumber
+		pool.LastPayout = rewards
+		actions = append(actions, setUnitData(poolID, pool, true))
+		if err := m.state.Apply(actions...); err != nil {
+			return fmt.Errorf("paying fee rewards of partition %s: %w", policy.SystemIdentifier, err)
+		}
+		for _, id := range changed {
+			if err := m.state.AddUnitLog(id, make([]byte, m.state.HashAlgorithm().Size())); err != nil {
+				return fmt.Errorf("failed to update fee reward state log: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// addReward returns state action which adds "value" to the reward bill, the bill is created when it doesn't exist.
+func (m *Module) addReward(billID types.UnitID, ownerPredicate []byte, value uint64) (state.Action, error) {
+	_, err := m.state.GetUnit(billID, false)
+	switch {
+	case errors.Is(err, avl.ErrNotFound):
+		return state.AddUnit(billID, money.NewBillData(value, ownerPredicate)), nil
+	case err != nil:
+		return nil, fmt.Errorf("reading reward bill: %w", err)
+	}
+	return state.UpdateUnitData(billID, func(data types.UnitData) (types.UnitData, error) {
+		bd, ok := data.(*money.BillData)
+		if !ok {
+			return nil, fmt.Errorf("unit %v does not contain bill data", billID)
+		}
+		bd.Value += value
+		return bd, nil
+	}), nil
+}
+
+func setUnitData(id types.UnitID, data types.UnitData, exists bool) state.Action {
+	if !exists {
+		return state.AddUnit(id, data)
+	}
+	return state.UpdateUnitData(id, func(types.UnitData) (types.UnitData, error) {
+		return data, nil
+	})
+}
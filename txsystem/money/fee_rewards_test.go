@@ -0,0 +1,365 @@
+package money
+
+import (
+	"crypto"
+	"math"
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/money"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/stretchr/testify/require"
+
+	testcertificates "github.com/alphabill-org/alphabill/internal/testutils/certificates"
+	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
+	testtb "github.com/alphabill-org/alphabill/internal/testutils/trustbase"
+	"github.com/alphabill-org/alphabill/network/protocol/genesis"
+	"github.com/alphabill-org/alphabill/state"
+	testctx "github.com/alphabill-org/alphabill/txsystem/testutils/exec_context"
+	testtransaction "github.com/alphabill-org/alphabill/txsystem/testutils/transaction"
+)
+
+func TestPolicyRewards(t *testing.T) {
+	validators := []*genesis.RewardRecipient{{NodeIdentifier: "1"}, {NodeIdentifier: "2"}, {NodeIdentifier: "3"}}
+
+	t.Run("equal split", func(t *testing.T) {
+		p := &genesis.RewardPolicy{Policy: genesis.RewardPolicyEqualSplit, Validators: validators}
+		require.Equal(t, []uint64{3, 3, 3}, policyRewards(p, &FeeRewardPool{Fees: 11}))
+		require.Equal(t, []uint64{0, 0, 0}, policyRewards(p, &FeeRewardPool{Fees: 2}))
+	})
+
+	t.Run("blocks proposed", func(t *testing.T) {
+		p := &genesis.RewardPolicy{Policy: genesis.RewardPolicyBlocksProposed, Validators: validators}
+		require.Equal(t, []uint64{2, 0, 7}, policyRewards(p, &FeeRewardPool{Fees: 10, BlocksProposed: []uint64{1, 0, 3}}))
+		require.Equal(t, []uint64{0, 0, 0}, policyRewards(p, &FeeRewardPool{Fees: 10}))
+		// no overflow
+		require.Equal(t, []uint64{math.MaxUint64 / 2, math.MaxUint64 / 2, 0}, policyRewards(p, &FeeRewardPool{Fees: math.MaxUint64, BlocksProposed: []uint64{2, 2, 0}}))
+	})
+}
+
+func TestModule_recordFees(t *testing.T) {
+	signer, verifier := testsig.CreateSignerAndVerifier(t)
+	tokensPDR := &types.PartitionDescriptionRecord{
+		NetworkIdentifier: 5,
+		SystemIdentifier:  2,
+		TypeIdLen:         8,
+		UnitIdLen:         256,
+		FeeCreditBill:     &types.FeeCreditBill{UnitID: money.NewBillID(nil, []byte{3})},
+	}
+	policy := &genesis.RewardPolicy{
+		SystemIdentifier: tokensPDR.SystemIdentifier,
+		Policy:           genesis.RewardPolicyBlocksProposed,
+		EpochLength:      10,
+		Validators: []*genesis.RewardRecipient{
+			{NodeIdentifier: "1", OwnerPredicate: templates.AlwaysTrueBytes()},
+			{NodeIdentifier: "2", OwnerPredicate: templates.AlwaysTrueBytes()},
+		},
+	}
+	newModule := func(t *testing.T) *Module {
+		options, err := defaultOptions()
+		require.NoError(t, err)
+		options.trustBase = testtb.NewTrustBase(t, verifier)
+		options.state = state.NewEmptyState()
+		options.systemDescriptionRecords = append(createSDRs(money.NewBillID(nil, []byte{2})), tokensPDR)
+		options.rewardPolicies = []*genesis.RewardPolicy{policy}
+		m, err := NewMoneyModule(5, money.DefaultSystemID, options)
+		require.NoError(t, err)
+		require.Contains(t, m.TxHandlers(), TransactionTypeRecordFees)
+		return m
+	}
+	newRound := func(t *testing.T, round, fees uint64, proposer string) *RoundFees {
+		header := &types.Header{SystemID: tokensPDR.SystemIdentifier, ProposerID: proposer, PreviousBlockHash: []byte{1}}
+		ir := &types.InputRecord{Version: 1, PreviousHash: []byte{1}, Hash: []byte{2}, SummaryValue: []byte{3}, RoundNumber: round, SumOfEarnedFees: fees}
+		txRoot := []byte{4}
+		hasher := crypto.SHA256.New()
+		hasher.Write(header.Hash(crypto.SHA256))
+		hasher.Write(ir.PreviousHash)
+		hasher.Write(ir.Hash)
+		hasher.Write(txRoot)
+		ir.BlockHash = hasher.Sum(nil)
+		uc := testcertificates.CreateUnicityCertificate(t, signer, ir, tokensPDR, 1, make([]byte, 32))
+		return &RoundFees{UnicityCertificate: uc, BlockHeader: header, TxRootHash: txRoot}
+	}
+	newAttr := func(t *testing.T, round, fees uint64, proposer string) *RecordFeesAttributes {
+		return &RecordFeesAttributes{Rounds: []*RoundFees{newRound(t, round, fees, proposer)}}
+	}
+	poolID := NewFeeRewardPoolID(tokensPDR.SystemIdentifier)
+	newTx := func(t *testing.T, unitID types.UnitID, attr *RecordFeesAttributes) *types.TransactionOrder {
+		return testtransaction.NewTransactionOrder(t,
+			testtransaction.WithUnitID(unitID),
+			testtransaction.WithTransactionType(TransactionTypeRecordFees),
+			testtransaction.WithAttributes(attr),
+		)
+	}
+	exeCtx := testctx.NewMockExecutionContext()
+	record := func(t *testing.T, m *Module, attr *RecordFeesAttributes) error {
+		tx := newTx(t, poolID, attr)
+		if err := m.validateRecordFeesTx(tx, attr, &RecordFeesAuthProof{}, exeCtx); err != nil {
+			return err
+		}
+		sm, err := m.executeRecordFeesTx(tx, attr, &RecordFeesAuthProof{}, exeCtx)
+		if err != nil {
+			return err
+		}
+		require.Equal(t, []types.UnitID{poolID}, sm.TargetUnits)
+		return nil
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		m := newModule(t)
+		for _, r := range []struct {
+			round    uint64
+			fees     uint64
+			proposer string
+		}{{1, 10, "1"}, {2, 2, "2"}, {3, 3, "2"}} {
+			require.NoError(t, record(t, m, newAttr(t, r.round, r.fees, r.proposer)))
+		}
+		pool, exists, err := m.feeRewardPool(poolID)
+		require.NoError(t, err)
+		require.True(t, exists)
+		require.Equal(t, &FeeRewardPool{LastRecordedRound: 3, Fees: 15, BlocksProposed: []uint64{1, 2}}, pool)
+
+		// the fees of the round can be recorded only once
+		attr := newAttr(t, 3, 3, "2")
+		require.EqualError(t, m.validateRecordFeesTx(newTx(t, poolID, attr), attr, &RecordFeesAuthProof{}, exeCtx),
+			"invalid round 3, expected the fees of the round 4")
+	})
+
+	t.Run("batch of rounds", func(t *testing.T) {
+		m := newModule(t)
+		require.NoError(t, record(t, m, &RecordFeesAttributes{Rounds: []*RoundFees{
+			newRound(t, 1, 10, "1"),
+			newRound(t, 2, 2, "2"),
+			newRound(t, 3, 3, "2"),
+		}}))
+		require.NoError(t, record(t, m, &RecordFeesAttributes{Rounds: []*RoundFees{
+			newRound(t, 4, 1, "1"),
+			newRound(t, 5, 0, "1"),
+		}}))
+		pool, _, err := m.feeRewardPool(poolID)
+		require.NoError(t, err)
+		require.Equal(t, &FeeRewardPool{LastRecordedRound: 5, Fees: 16, BlocksProposed: []uint64{3, 2}}, pool)
+	})
+
+	t.Run("rounds can not be skipped", func(t *testing.T) {
+		m := newModule(t)
+		require.NoError(t, record(t, m, newAttr(t, 1, 10, "1")))
+		// round 3 (N+2) before round 2 (N+1) is rejected
+		require.EqualError(t, record(t, m, newAttr(t, 3, 5, "2")), "invalid round 3, expected the fees of the round 2")
+		// gap inside the batch is rejected
+		require.EqualError(t, record(t, m, &RecordFeesAttributes{Rounds: []*RoundFees{
+			newRound(t, 2, 1, "1"),
+			newRound(t, 4, 5, "2"),
+		}}), "invalid round 4, expected the fees of the round 3")
+		// the rounds of the batch must be in order
+		require.EqualError(t, record(t, m, &RecordFeesAttributes{Rounds: []*RoundFees{
+			newRound(t, 3, 5, "2"),
+			newRound(t, 2, 1, "1"),
+		}}), "invalid round 3, expected the fees of the round 2")
+		// nothing was recorded by the rejected transactions
+		pool, _, err := m.feeRewardPool(poolID)
+		require.NoError(t, err)
+		require.Equal(t, &FeeRewardPool{LastRecordedRound: 1, Fees: 10, BlocksProposed: []uint64{1, 0}}, pool)
+
+		require.NoError(t, record(t, m, &RecordFeesAttributes{Rounds: []*RoundFees{
+			newRound(t, 2, 1, "1"),
+			newRound(t, 3, 5, "2"),
+		}}))
+		pool, _, err = m.feeRewardPool(poolID)
+		require.NoError(t, err)
+		require.Equal(t, &FeeRewardPool{LastRecordedRound: 3, Fees: 16, BlocksProposed: []uint64{2, 1}}, pool)
+	})
+
+	t.Run("batch size", func(t *testing.T) {
+		m := newModule(t)
+		require.EqualError(t, record(t, m, &RecordFeesAttributes{}), "no rounds to record")
+		attr := &RecordFeesAttributes{Rounds: make([]*RoundFees, MaxRecordFeesRounds+1)}
+		require.EqualError(t, record(t, m, attr), "too many rounds to record: 101, allowed 100")
+	})
+
+	t.Run("batch of rounds of different partitions", func(t *testing.T) {
+		r := newRound(t, 2, 1, "1")
+		r.UnicityCertificate.UnicityTreeCertificate.SystemIdentifier = 7
+		require.EqualError(t, record(t, newModule(t), &RecordFeesAttributes{Rounds: []*RoundFees{newRound(t, 1, 10, "1"), r}}),
+			"round 1: unicity certificate of partition 00000007, expected 00000002")
+	})
+
+	t.Run("empty block without header", func(t *testing.T) {
+		m := newModule(t)
+		ir := &types.InputRecord{Version: 1, PreviousHash: []byte{1}, Hash: []byte{1}, SummaryValue: []byte{3}, RoundNumber: 1, BlockHash: make([]byte, 32)}
+		attr := &RecordFeesAttributes{Rounds: []*RoundFees{{UnicityCertificate: testcertificates.CreateUnicityCertificate(t, signer, ir, tokensPDR, 1, make([]byte, 32))}}}
+		require.NoError(t, record(t, m, attr))
+		pool, _, err := m.feeRewardPool(poolID)
+		require.NoError(t, err)
+		require.Equal(t, &FeeRewardPool{LastRecordedRound: 1}, pool)
+	})
+
+	t.Run("invalid unit ID", func(t *testing.T) {
+		attr := newAttr(t, 1, 10, "1")
+		tx := newTx(t, NewFeeRewardPoolID(money.DefaultSystemID), attr)
+		require.ErrorContains(t, newModule(t).validateRecordFeesTx(tx, attr, &RecordFeesAuthProof{}, exeCtx), "invalid unit ID")
+	})
+
+	t.Run("unicity certificate is missing", func(t *testing.T) {
+		attr := &RecordFeesAttributes{Rounds: []*RoundFees{{}}}
+		require.EqualError(t, record(t, newModule(t), attr), "round 0: unicity certificate is missing")
+	})
+
+	t.Run("partition without reward policy", func(t *testing.T) {
+		attr := newAttr(t, 1, 10, "1")
+		attr.Rounds[0].UnicityCertificate.UnicityTreeCertificate.SystemIdentifier = 7
+		require.EqualError(t, record(t, newModule(t), attr), "no fee reward policy for partition 00000007")
+	})
+
+	t.Run("invalid unicity certificate", func(t *testing.T) {
+		attr := newAttr(t, 1, 10, "1")
+		attr.Rounds[0].UnicityCertificate.InputRecord.SumOfEarnedFees = 1000
+		require.ErrorContains(t, record(t, newModule(t), attr), "round 1: invalid unicity certificate")
+	})
+
+	t.Run("block header is missing", func(t *testing.T) {
+		attr := newAttr(t, 1, 10, "1")
+		attr.Rounds[0].BlockHeader = nil
+		require.EqualError(t, record(t, newModule(t), attr), "round 1: block header is missing")
+	})
+
+	t.Run("block header does not match", func(t *testing.T) {
+		attr := newAttr(t, 1, 10, "1")
+		attr.Rounds[0].BlockHeader.ProposerID = "2"
+		require.EqualError(t, record(t, newModule(t), attr), "round 1: block header does not match the certified block hash")
+	})
+
+	t.Run("proposer is not a validator", func(t *testing.T) {
+		require.EqualError(t, record(t, newModule(t), newAttr(t, 1, 10, "3")), "round 1: block proposer 3 is not a validator of the reward policy")
+	})
+}
+
+func TestModule_payFeeRewards(t *testing.T) {
+	_, verifier := testsig.CreateSignerAndVerifier(t)
+	fcbID := money.NewBillID(nil, []byte{2})
+	policy := &genesis.RewardPolicy{
+		SystemIdentifier: money.DefaultSystemID,
+		Policy:           genesis.RewardPolicyBlocksProposed,
+		EpochLength:      10,
+		Validators: []*genesis.RewardRecipient{
+			{NodeIdentifier: "1", OwnerPredicate: templates.AlwaysTrueBytes()},
+			{NodeIdentifier: "2", OwnerPredicate: templates.AlwaysFalseBytes()},
+		},
+	}
+	poolID := NewFeeRewardPoolID(money.DefaultSystemID)
+	rewardBill1 := NewRewardBillID(money.DefaultSystemID, "1")
+	rewardBill2 := NewRewardBillID(money.DefaultSystemID, "2")
+	newModule := func(t *testing.T, fcbValue uint64, pool *FeeRewardPool) *Module {
+		options, err := defaultOptions()
+		require.NoError(t, err)
+		options.trustBase = testtb.NewTrustBase(t, verifier)
+		options.state = state.NewEmptyState()
+		options.systemDescriptionRecords = createSDRs(fcbID)
+		options.rewardPolicies = []*genesis.RewardPolicy{policy}
+		m, err := NewMoneyModule(5, money.DefaultSystemID, options)
+		require.NoError(t, err)
+		require.NoError(t, m.state.Apply(
+			state.AddUnit(fcbID, money.NewBillData(fcbValue, templates.AlwaysTrueBytes())),
+			state.AddUnit(poolID, pool),
+		))
+		return m
+	}
+	// runs the end of block functions of the module in the round
+	endBlock := func(t *testing.T, m *Module, round uint64) {
+		for _, f := range m.EndBlockFuncs() {
+			require.NoError(t, f(round))
+		}
+	}
+
+	t.Run("rewards are paid in the end of the epoch", func(t *testing.T) {
+		m := newModule(t, 100, &FeeRewardPool{LastRecordedRound: 5, Fees: 10, BlocksProposed: []uint64{1, 3}})
+		require.NotContains(t, m.TxHandlers(), uint16(8))
+		// the epoch is not over yet
+		endBlock(t, m, 9)
+		pool, _, err := m.feeRewardPool(poolID)
+		require.NoError(t, err)
+		require.Equal(t, &FeeRewardPool{LastRecordedRound: 5, Fees: 10, BlocksProposed: []uint64{1, 3}}, pool)
+
+		endBlock(t, m, 10)
+		_, fcb := getBill(t, m.state, fcbID)
+		require.EqualValues(t, 91, fcb.Value)
+		_, bill := getBill(t, m.state, rewardBill1)
+		require.EqualValues(t, 2, bill.Value)
+		require.EqualValues(t, templates.AlwaysTrueBytes(), bill.OwnerPredicate)
+		_, bill = getBill(t, m.state, rewardBill2)
+		require.EqualValues(t, 7, bill.Value)
+		require.EqualValues(t, templates.AlwaysFalseBytes(), bill.OwnerPredicate)
+		pool, _, err = m.feeRewardPool(poolID)
+		require.NoError(t, err)
+		require.Equal(t, &FeeRewardPool{LastRecordedRound: 5, Fees: 1, LastPayoutRound: 10, LastPayout: []uint64{2, 7}}, pool)
+		// the payout is recorded in the logs of the changed units
+		for _, id := range []types.UnitID{poolID, fcbID, rewardBill1, rewardBill2} {
+			u, err := m.state.GetUnit(id, false)
+			require.NoError(t, err)
+			require.NotEmpty(t, u.Logs(), "unit %s", id)
+		}
+
+		// rewards are added to the existing reward bills
+		require.NoError(t, m.state.Apply(state.UpdateUnitData(poolID, func(types.UnitData) (types.UnitData, error) {
+			return &FeeRewardPool{Fees: 5, BlocksProposed: []uint64{1, 0}, LastPayoutRound: 10}, nil
+		})))
+		endBlock(t, m, 20)
+		_, bill = getBill(t, m.state, rewardBill1)
+		require.EqualValues(t, 7, bill.Value)
+		_, fcb = getBill(t, m.state, fcbID)
+		require.EqualValues(t, 86, fcb.Value)
+
+		// no blocks recorded during the epoch, nothing to pay
+		endBlock(t, m, 30)
+		pool, _, err = m.feeRewardPool(poolID)
+		require.NoError(t, err)
+		require.Equal(t, &FeeRewardPool{LastPayoutRound: 20, LastPayout: []uint64{5, 0}}, pool)
+	})
+
+	t.Run("fee credit bill value is not sufficient", func(t *testing.T) {
+		m := newModule(t, 5, &FeeRewardPool{Fees: 10, BlocksProposed: []uint64{1, 3}})
+		endBlock(t, m, 10)
+		_, fcb := getBill(t, m.state, fcbID)
+		require.EqualValues(t, 5, fcb.Value)
+		// the fees stay in the pool
+		pool, _, err := m.feeRewardPool(poolID)
+		require.NoError(t, err)
+		require.Equal(t, &FeeRewardPool{Fees: 10, BlocksProposed: []uint64{1, 3}}, pool)
+	})
+}
+
+func TestNewMoneyModule_RewardPolicies(t *testing.T) {
+	_, verifier := testsig.CreateSignerAndVerifier(t)
+	newOptions := func(policies ...*genesis.RewardPolicy) *Options {
+		options, err := defaultOptions()
+		require.NoError(t, err)
+		options.trustBase = testtb.NewTrustBase(t, verifier)
+		options.state = state.NewEmptyState()
+		options.systemDescriptionRecords = createSDRs(money.NewBillID(nil, []byte{2}))
+		options.rewardPolicies = policies
+		return options
+	}
+	policy := func(systemID types.SystemID) *genesis.RewardPolicy {
+		return &genesis.RewardPolicy{
+			SystemIdentifier: systemID,
+			Policy:           genesis.RewardPolicyEqualSplit,
+			EpochLength:      10,
+			Validators:       []*genesis.RewardRecipient{{NodeIdentifier: "1", OwnerPredicate: []byte{1}}},
+		}
+	}
+
+	m, err := NewMoneyModule(5, money.DefaultSystemID, newOptions())
+	require.NoError(t, err)
+	require.NotContains(t, m.TxHandlers(), TransactionTypeRecordFees)
+
+	_, err = NewMoneyModule(5, money.DefaultSystemID, newOptions(policy(2)))
+	require.EqualError(t, err, "invalid reward policy 0: partition 00000002 is not described")
+
+	_, err = NewMoneyModule(5, money.DefaultSystemID, newOptions(policy(money.DefaultSystemID), policy(money.DefaultSystemID)))
+	require.EqualError(t, err, "invalid reward policy 1: duplicate policy for partition 00000001")
+
+	p := policy(money.DefaultSystemID)
+	p.EpochLength = 0
+	_, err = NewMoneyModule(5, money.DefaultSystemID, newOptions(p))
+	require.EqualError(t, err, "invalid reward policy 0: epoch length must be greater than zero")
+}
@@ -3,17 +3,21 @@ package money
 import (
 	"crypto"
 	"errors"
+	"fmt"
+	"slices"
 
 	fcsdk "github.com/alphabill-org/alphabill-go-base/txsystem/fc"
 	"github.com/alphabill-org/alphabill-go-base/txsystem/money"
 	"github.com/alphabill-org/alphabill-go-base/types"
 	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
 
+	"github.com/alphabill-org/alphabill/network/protocol/genesis"
 	"github.com/alphabill-org/alphabill/predicates"
 	"github.com/alphabill-org/alphabill/state"
 )
 
 var _ txtypes.Module = (*Module)(nil)
+var _ txtypes.UnitsDeleter = (*Module)(nil)
 
 type (
 	Module struct {
@@ -25,6 +29,10 @@ type (
 		dustCollector       *DustCollector
 		feeCreditTxRecorder *feeCreditTxRecorder
 		execPredicate       predicates.PredicateRunner
+		// optional, audits money supply in the end of block
+		supplyAuditor *SupplyAuditor
+		// fee reward distribution policies of the partitions
+		rewardPolicies []*genesis.RewardPolicy
 	}
 )
 
@@ -36,6 +44,20 @@ func NewMoneyModule(networkID types.NetworkID, systemID types.SystemID, options
 		return nil, errors.New("state is nil")
 	}
 
+	for i, p := range options.rewardPolicies {
+		if err := p.IsValid(); err != nil {
+			return nil, fmt.Errorf("invalid reward policy %d: %w", i, err)
+		}
+		if !slices.ContainsFunc(options.systemDescriptionRecords, func(pdr *types.PartitionDescriptionRecord) bool {
+			return pdr.SystemIdentifier == p.SystemIdentifier
+		}) {
+			return nil, fmt.Errorf("invalid reward policy %d: partition %s is not described", i, p.SystemIdentifier)
+		}
+		if slices.ContainsFunc(options.rewardPolicies[:i], func(prev *genesis.RewardPolicy) bool { return prev.SystemIdentifier == p.SystemIdentifier }) {
+			return nil, fmt.Errorf("invalid reward policy %d: duplicate policy for partition %s", i, p.SystemIdentifier)
+		}
+	}
+
 	m := &Module{
 		state:               options.state,
 		networkID:           networkID,
@@ -45,12 +67,13 @@ func NewMoneyModule(networkID types.NetworkID, systemID types.SystemID, options
 		feeCreditTxRecorder: newFeeCreditTxRecorder(options.state, systemID, options.systemDescriptionRecords),
 		dustCollector:       NewDustCollector(options.state),
 		execPredicate:       predicates.NewPredicateRunner(options.exec),
+		rewardPolicies:      options.rewardPolicies,
 	}
 	return m, nil
 }
 
 func (m *Module) TxHandlers() map[uint16]txtypes.TxExecutor {
-	return map[uint16]txtypes.TxExecutor{
+	handlers := map[uint16]txtypes.TxExecutor{
 		// money partition tx handlers
 		money.TransactionTypeTransfer: txtypes.NewTxHandler[money.TransferAttributes, money.TransferAuthProof](m.validateTransferTx, m.executeTransferTx),
 		money.TransactionTypeSplit:    txtypes.NewTxHandler[money.SplitAttributes, money.SplitAuthProof](m.validateSplitTx, m.executeSplitTx),
@@ -63,6 +86,10 @@ func (m *Module) TxHandlers() map[uint16]txtypes.TxExecutor {
 		fcsdk.TransactionTypeTransferFeeCredit: txtypes.NewTxHandler[fcsdk.TransferFeeCreditAttributes, fcsdk.TransferFeeCreditAuthProof](m.validateTransferFCTx, m.executeTransferFCTx),
 		fcsdk.TransactionTypeReclaimFeeCredit:  txtypes.NewTxHandler[fcsdk.ReclaimFeeCreditAttributes, fcsdk.ReclaimFeeCreditAuthProof](m.validateReclaimFCTx, m.executeReclaimFCTx),
 	}
+	if len(m.rewardPolicies) > 0 {
+		handlers[TransactionTypeRecordFees] = txtypes.NewTxHandler[RecordFeesAttributes, RecordFeesAuthProof](m.validateRecordFeesTx, m.executeRecordFeesTx)
+	}
+	return handlers
 }
 
 func (m *Module) BeginBlockFuncs() []func(blockNr uint64) error {
@@ -75,11 +102,24 @@ func (m *Module) BeginBlockFuncs() []func(blockNr uint64) error {
 }
 
 func (m *Module) EndBlockFuncs() []func(blockNumber uint64) error {
-	return []func(blockNumber uint64) error{
-		// m.dustCollector.consolidateDust TODO AB-1133
-		// TODO AB-1133 delete bills from owner index (partition/proof_indexer.go)
-		func(blockNr uint64) error {
-			return m.feeCreditTxRecorder.consolidateFees()
-		},
+	funcs := []func(blockNumber uint64) error{
+		m.dustCollector.consolidateDust,
 	}
+	if m.supplyAuditor != nil {
+		// audit before consolidating fees, the auditor uses the recorded fee credit transfers
+		funcs = append(funcs, m.supplyAuditor.Audit)
+	}
+	funcs = append(funcs, func(blockNr uint64) error {
+		return m.feeCreditTxRecorder.consolidateFees()
+	})
+	if len(m.rewardPolicies) > 0 {
+		// pay the rewards after consolidating fees, the rewards are paid from the fee credit bills
+		funcs = append(funcs, m.payFeeRewards)
+	}
+	return funcs
+}
+
+// DeletedUnits returns the dust bills deleted in the end of the latest block.
+func (m *Module) DeletedUnits() []*txtypes.DeletedUnit {
+	return m.dustCollector.DeletedUnits()
 }
@@ -23,9 +23,14 @@ func NewTxSystem(pdr basetypes.PartitionDescriptionRecord, shardID basetypes.Sha
 	if err != nil {
 		return nil, fmt.Errorf("failed to load money module: %w", err)
 	}
+	if options.auditSupply != nil {
+		moneyModule.supplyAuditor = moneyModule.NewSupplyAuditor(*options.auditSupply, observe.Logger())
+	}
 	feeCreditModule, err := fc.NewFeeCreditModule(pdr.NetworkIdentifier, pdr.SystemIdentifier, pdr.SystemIdentifier, options.state, options.trustBase,
 		fc.WithHashAlgorithm(options.hashAlgorithm),
 		fc.WithFeeCreditRecordUnitType(money.FeeCreditRecordUnitType),
+		fc.WithPredicateExecutor(options.exec),
+		fc.WithFeeCreditRecordExpiry(NewFeeCreditRecordExpiryScheduleID, options.fcrExpiryInterval),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load fee credit module: %w", err)
@@ -38,8 +43,12 @@ func NewTxSystem(pdr basetypes.PartitionDescriptionRecord, shardID basetypes.Sha
 		observe,
 		txsystem.WithFeeCredits(feeCreditModule),
 		txsystem.WithEndBlockFunctions(moneyModule.EndBlockFuncs()...),
+		txsystem.WithEndBlockFunctions(feeCreditModule.EndBlockFuncs()...),
 		txsystem.WithBeginBlockFunctions(moneyModule.BeginBlockFuncs()...),
 		txsystem.WithHashAlgorithm(options.hashAlgorithm),
 		txsystem.WithState(options.state),
+		txsystem.WithGasSchedule(options.gasSchedule),
+		txsystem.WithGasScheduleUpdates(options.gasScheduleUpdates),
+		txsystem.WithPredicateExecutor(options.exec),
 	)
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/alphabill-org/alphabill-go-base/types"
 
+	"github.com/alphabill-org/alphabill/network/protocol/genesis"
 	"github.com/alphabill-org/alphabill/predicates"
 	"github.com/alphabill-org/alphabill/predicates/templates"
 	"github.com/alphabill-org/alphabill/state"
@@ -18,6 +19,14 @@ type (
 		trustBase                types.RootTrustBase
 		systemDescriptionRecords []*types.PartitionDescriptionRecord
 		exec                     predicates.PredicateExecutor
+		gasScheduleUpdates       predicates.GasScheduleUpdates
+		gasSchedule              *predicates.GasSchedule
+		// money supply created in genesis, nil when supply audit is disabled
+		auditSupply *uint64
+		// fee reward distribution policies, fees are not distributed when empty
+		rewardPolicies []*genesis.RewardPolicy
+		// fee credit record expiry, disabled when the check interval is zero
+		fcrExpiryInterval uint64
 	}
 
 	Option func(*Options)
@@ -32,6 +41,7 @@ func defaultOptions() (*Options, error) {
 	return &Options{
 		hashAlgorithm: crypto.SHA256,
 		exec:          predEng.Execute,
+		gasSchedule:   predicates.DefaultGasSchedule(),
 	}, nil
 }
 
@@ -60,8 +70,8 @@ func WithPartitionDescriptionRecords(records []*types.PartitionDescriptionRecord
 }
 
 /*
-WithPredicateExecutor allows to replace the default predicate executor function.
-Should be used by tests only.
+WithPredicateExecutor allows to replace the default predicate executor which
+supports only "builtin predicate templates".
 */
 func WithPredicateExecutor(exec predicates.PredicateExecutor) Option {
 	return func(g *Options) {
@@ -70,3 +80,55 @@ func WithPredicateExecutor(exec predicates.PredicateExecutor) Option {
 		}
 	}
 }
+
+/*
+WithGasSchedule sets the gas schedule of the transaction system. NB! the predicate
+executor set by WithPredicateExecutor must be created with the same gas schedule.
+*/
+func WithGasSchedule(gs *predicates.GasSchedule) Option {
+	return func(c *Options) {
+		c.gasSchedule = gs
+	}
+}
+
+/*
+WithGasScheduleUpdates sets the gas schedules replacing the gas schedule set by
+WithGasSchedule from their activation round.
+*/
+func WithGasScheduleUpdates(updates predicates.GasScheduleUpdates) Option {
+	return func(c *Options) {
+		c.gasScheduleUpdates = updates
+	}
+}
+
+/*
+WithSupplyAudit enables the money supply invariant check in the end of every block,
+"supply" is the money supply created in genesis (InitialBillValue + DCMoneySupplyValue).
+The check traverses the entire state so it is meant for debugging only.
+*/
+func WithSupplyAudit(supply uint64) Option {
+	return func(c *Options) {
+		c.auditSupply = &supply
+	}
+}
+
+/*
+WithRewardPolicies sets the fee reward distribution policies of the partitions. Every
+partition with a policy must be described by the records set by WithPartitionDescriptionRecords.
+*/
+func WithRewardPolicies(policies []*genesis.RewardPolicy) Option {
+	return func(c *Options) {
+		c.rewardPolicies = policies
+	}
+}
+
+/*
+WithFeeCreditRecordExpiry enables deleting the expired fee credit records with zero
+balance, the expired records holding value are checked again after "checkInterval"
+rounds. Zero interval (the default) disables the expiry.
+*/
+func WithFeeCreditRecordExpiry(checkInterval uint64) Option {
+	return func(c *Options) {
+		c.fcrExpiryInterval = checkInterval
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abcrypto "github.com/alphabill-org/alphabill-go-base/crypto"
+	"github.com/alphabill-org/alphabill-go-base/hash"
 	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
 	fcsdk "github.com/alphabill-org/alphabill-go-base/txsystem/fc"
 	"github.com/alphabill-org/alphabill-go-base/txsystem/money"
@@ -21,7 +22,11 @@ import (
 	"github.com/alphabill-org/alphabill/internal/testutils/observability"
 	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
 	testtb "github.com/alphabill-org/alphabill/internal/testutils/trustbase"
+	"github.com/alphabill-org/alphabill/predicates"
+	"github.com/alphabill-org/alphabill/predicates/script"
+	predtempl "github.com/alphabill-org/alphabill/predicates/templates"
 	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
 	"github.com/alphabill-org/alphabill/txsystem"
 	"github.com/alphabill-org/alphabill/txsystem/fc/testutils"
 	"github.com/alphabill-org/alphabill/txsystem/fc/unit"
@@ -122,7 +127,7 @@ func TestNewTxSystem_RecoveredState(t *testing.T) {
 	require.NoError(t, originalTxs.State().Serialize(buf, true))
 
 	// Create a recovered state and txSystem from the serialized state
-	recoveredState, err := state.NewRecoveredState(buf, money.NewUnitData, state.WithHashAlgorithm(crypto.SHA256))
+	recoveredState, err := state.NewRecoveredState(buf, NewUnitData, state.WithHashAlgorithm(crypto.SHA256))
 	require.NoError(t, err)
 	recoveredTxs, err := NewTxSystem(
 		*sdrs[0],
@@ -180,6 +185,173 @@ func TestExecute_TransferOk(t *testing.T) {
 	require.EqualValues(t, 1, data2.Counter)
 }
 
+func TestExecute_TransferMultiSigOwner(t *testing.T) {
+	rmaTree, txSystem, _ := createStateAndTxSystem(t)
+	fcrID := testutils.NewFeeCreditRecordIDAlwaysTrue()
+
+	signers := make([]abcrypto.Signer, 3)
+	pubKeyHashes := make([][]byte, len(signers))
+	for i := range signers {
+		signer, verifier := testsig.CreateSignerAndVerifier(t)
+		pubKey, err := verifier.MarshalPublicKey()
+		require.NoError(t, err)
+		signers[i] = signer
+		pubKeyHashes[i] = hash.Sum256(pubKey)
+	}
+	multiSigOwner, err := predtempl.NewMultiSig256Bytes(2, pubKeyHashes...)
+	require.NoError(t, err)
+
+	require.NoError(t, txSystem.BeginBlock(10))
+	transferTx, _, _ := createBillTransfer(t, initialBill.ID, fcrID, initialBill.Value, multiSigOwner, 0)
+	sm, err := txSystem.Execute(transferTx)
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator)
+	_, data := getBill(t, rmaTree, initialBill.ID)
+	require.EqualValues(t, multiSigOwner, data.Owner())
+
+	multiSigProof := func(tx *types.TransactionOrder, signers ...abcrypto.Signer) []byte {
+		sigBytes, err := tx.AuthProofSigBytes()
+		require.NoError(t, err)
+		sigs := make([]templates.P2pkh256Signature, len(signers))
+		for i, signer := range signers {
+			require.NoError(t, types.Cbor.Unmarshal(testsig.NewP2pkhSignature(t, signer, sigBytes), &sigs[i]))
+		}
+		return predtempl.NewMultiSig256SignatureBytes(sigs...)
+	}
+
+	// single signature is not enough
+	transferTx, _, _ = createBillTransfer(t, initialBill.ID, fcrID, initialBill.Value, templates.AlwaysTrueBytes(), 1)
+	require.NoError(t, transferTx.SetAuthProof(&money.TransferAuthProof{OwnerProof: multiSigProof(transferTx, signers[1])}))
+	sm, err = txSystem.Execute(transferTx)
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusFailed, sm.SuccessIndicator)
+
+	// 2 of 3 keys sign
+	transferTx, _, _ = createBillTransfer(t, initialBill.ID, fcrID, initialBill.Value, templates.AlwaysTrueBytes(), 1)
+	require.NoError(t, transferTx.SetAuthProof(&money.TransferAuthProof{OwnerProof: multiSigProof(transferTx, signers[0], signers[2])}))
+	sm, err = txSystem.Execute(transferTx)
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator)
+	_, data = getBill(t, rmaTree, initialBill.ID)
+	require.EqualValues(t, templates.AlwaysTrueBytes(), data.Owner())
+}
+
+func TestExecute_TransferHashLockOwner(t *testing.T) {
+	fcrID := testutils.NewFeeCreditRecordIDAlwaysTrue()
+	recipient, recipientVerifier := testsig.CreateSignerAndVerifier(t)
+	recipientPubKey, err := recipientVerifier.MarshalPublicKey()
+	require.NoError(t, err)
+	refunder, refunderVerifier := testsig.CreateSignerAndVerifier(t)
+	refunderPubKey, err := refunderVerifier.MarshalPublicKey()
+	require.NoError(t, err)
+
+	preimage := []byte("swap secret")
+	hashLockOwner, err := predtempl.NewHashLock256Bytes(hash.Sum256(preimage), 12, hash.Sum256(recipientPubKey), hash.Sum256(refunderPubKey))
+	require.NoError(t, err)
+
+	hashLockProof := func(tx *types.TransactionOrder, preimage []byte, signer abcrypto.Signer) []byte {
+		sigBytes, err := tx.AuthProofSigBytes()
+		require.NoError(t, err)
+		return predtempl.NewHashLock256ProofBytes(preimage, testsig.NewP2pkhSignature(t, signer, sigBytes))
+	}
+	lockBill := func(t *testing.T) (*state.State, *txsystem.GenericTxSystem) {
+		rmaTree, txSystem, _ := createStateAndTxSystem(t)
+		require.NoError(t, txSystem.BeginBlock(10))
+		transferTx, _, _ := createBillTransfer(t, initialBill.ID, fcrID, initialBill.Value, hashLockOwner, 0)
+		sm, err := txSystem.Execute(transferTx)
+		require.NoError(t, err)
+		require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator)
+		return rmaTree, txSystem
+	}
+
+	t.Run("recipient reveals the preimage", func(t *testing.T) {
+		rmaTree, txSystem := lockBill(t)
+		transferTx, _, _ := createBillTransfer(t, initialBill.ID, fcrID, initialBill.Value, templates.AlwaysTrueBytes(), 1)
+
+		// wrong preimage
+		require.NoError(t, transferTx.SetAuthProof(&money.TransferAuthProof{OwnerProof: hashLockProof(transferTx, []byte("guess"), recipient)}))
+		sm, err := txSystem.Execute(transferTx)
+		require.NoError(t, err)
+		require.Equal(t, types.TxStatusFailed, sm.SuccessIndicator)
+
+		// the preimage is known but the transaction is not signed by the recipient
+		require.NoError(t, transferTx.SetAuthProof(&money.TransferAuthProof{OwnerProof: hashLockProof(transferTx, preimage, refunder)}))
+		sm, err = txSystem.Execute(transferTx)
+		require.NoError(t, err)
+		require.Equal(t, types.TxStatusFailed, sm.SuccessIndicator)
+
+		// refund is not possible before the lock expires
+		require.NoError(t, transferTx.SetAuthProof(&money.TransferAuthProof{OwnerProof: hashLockProof(transferTx, nil, refunder)}))
+		sm, err = txSystem.Execute(transferTx)
+		require.NoError(t, err)
+		require.Equal(t, types.TxStatusFailed, sm.SuccessIndicator)
+
+		require.NoError(t, transferTx.SetAuthProof(&money.TransferAuthProof{OwnerProof: hashLockProof(transferTx, preimage, recipient)}))
+		sm, err = txSystem.Execute(transferTx)
+		require.NoError(t, err)
+		require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator)
+		_, data := getBill(t, rmaTree, initialBill.ID)
+		require.EqualValues(t, templates.AlwaysTrueBytes(), data.Owner())
+	})
+
+	t.Run("refund after the lock expires", func(t *testing.T) {
+		rmaTree, txSystem := lockBill(t)
+		stateSummary, err := txSystem.EndBlock()
+		require.NoError(t, err)
+		require.NoError(t, txSystem.Commit(createUC(stateSummary, 10)))
+		require.NoError(t, txSystem.BeginBlock(12))
+
+		transferTx, _, _ := createBillTransfer(t, initialBill.ID, fcrID, initialBill.Value, templates.AlwaysTrueBytes(), 1)
+		require.NoError(t, transferTx.SetAuthProof(&money.TransferAuthProof{OwnerProof: hashLockProof(transferTx, preimage, recipient)}))
+		sm, err := txSystem.Execute(transferTx)
+		require.NoError(t, err)
+		require.Equal(t, types.TxStatusFailed, sm.SuccessIndicator)
+
+		require.NoError(t, transferTx.SetAuthProof(&money.TransferAuthProof{OwnerProof: hashLockProof(transferTx, nil, refunder)}))
+		sm, err = txSystem.Execute(transferTx)
+		require.NoError(t, err)
+		require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator)
+		_, data := getBill(t, rmaTree, initialBill.ID)
+		require.EqualValues(t, templates.AlwaysTrueBytes(), data.Owner())
+	})
+}
+
+func TestExecute_TransferScriptOwner(t *testing.T) {
+	predEng, err := predicates.Dispatcher(predtempl.New(), script.New())
+	require.NoError(t, err)
+	rmaTree, txSystem, _ := createStateAndTxSystem(t, WithPredicateExecutor(predEng.Execute))
+	fcrID := testutils.NewFeeCreditRecordIDAlwaysTrue()
+
+	// spendable by revealing the preimage before round 12
+	preimage := []byte("swap secret")
+	code, err := (&script.Builder{}).
+		Op(script.OpSHA256).PushData(hash.Sum256(preimage)).Op(script.OpEqual, script.OpRound).
+		PushU64(12).Op(script.OpLess, script.OpAnd).Bytes()
+	require.NoError(t, err)
+	scriptOwner, err := script.NewPredicateBytes(code)
+	require.NoError(t, err)
+
+	require.NoError(t, txSystem.BeginBlock(10))
+	transferTx, _, _ := createBillTransfer(t, initialBill.ID, fcrID, initialBill.Value, scriptOwner, 0)
+	sm, err := txSystem.Execute(transferTx)
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator)
+
+	// wrong preimage
+	transferTx, _, _ = createBillTransfer(t, initialBill.ID, fcrID, initialBill.Value, templates.AlwaysTrueBytes(), 1)
+	require.NoError(t, transferTx.SetAuthProof(&money.TransferAuthProof{OwnerProof: script.NewOwnerProof([]byte("guess"))}))
+	sm, err = txSystem.Execute(transferTx)
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusFailed, sm.SuccessIndicator)
+
+	require.NoError(t, transferTx.SetAuthProof(&money.TransferAuthProof{OwnerProof: script.NewOwnerProof(preimage)}))
+	sm, err = txSystem.Execute(transferTx)
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator)
+	_, data := getBill(t, rmaTree, initialBill.ID)
+	require.EqualValues(t, templates.AlwaysTrueBytes(), data.Owner())
+}
+
 func TestExecute_Split2WayOk(t *testing.T) {
 	rmaTree, txSystem, _ := createStateAndTxSystem(t)
 	totalValue, _, err := rmaTree.CalculateRoot()
@@ -322,7 +494,7 @@ func TestExecuteTransferDC_OK(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, sm)
 	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator)
-	require.Equal(t, []types.UnitID{transferDCOk.UnitID, DustCollectorMoneySupplyID, fcrID}, sm.TargetUnits)
+	require.Equal(t, []types.UnitID{transferDCOk.UnitID, DustCollectorMoneySupplyID, NewDustBillScheduleID(roundNumber + defaultDustBillDeletionTimeout), fcrID}, sm.TargetUnits)
 	require.True(t, sm.ActualFee > 0)
 
 	_, transferDCBillData := getBill(t, rmaTree, billID)
@@ -378,7 +550,7 @@ func TestExecute_SwapOk(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, sm)
 		require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator)
-		require.Equal(t, []types.UnitID{dcTransferProof.UnitID(), DustCollectorMoneySupplyID, fcrID}, sm.TargetUnits)
+		require.Equal(t, []types.UnitID{dcTransferProof.UnitID(), DustCollectorMoneySupplyID, NewDustBillScheduleID(roundNumber + defaultDustBillDeletionTimeout), fcrID}, sm.TargetUnits)
 		require.True(t, sm.ActualFee > 0)
 	}
 
@@ -514,7 +686,6 @@ func TestBillData_AddToHasher(t *testing.T) {
 }
 
 func TestEndBlock_DustBillsAreRemoved(t *testing.T) {
-	t.Skip("TODO AB-1133 implement dust bills deletion")
 	rmaTree, txSystem, signer := createStateAndTxSystem(t)
 	_, initBillData := getBill(t, rmaTree, initialBill.ID)
 	remaining := initBillData.Value
@@ -527,9 +698,9 @@ func TestEndBlock_DustBillsAreRemoved(t *testing.T) {
 		roundNumber := uint64(10)
 		err := txSystem.BeginBlock(roundNumber)
 		require.NoError(t, err)
-		unitPart, err := money.HashForNewBillID(splitOk, uint32(i), crypto.SHA256)
+		unitPart, err := money.HashForNewBillID(splitOk, 0, crypto.SHA256)
 		require.NoError(t, err)
-		splitBillIDs[i] = money.NewBillID(splitOk.UnitID, unitPart)
+		splitBillIDs[i] = money.NewBillID(nil, unitPart)
 		sm, err := txSystem.Execute(splitOk)
 		require.NoError(t, err)
 		require.NotNil(t, sm)
@@ -549,7 +720,7 @@ func TestEndBlock_DustBillsAreRemoved(t *testing.T) {
 	targetBillID := initialBill.ID
 	_, targetBillData := getBill(t, rmaTree, initialBill.ID)
 
-	dcTransferProofs, swapTx := createDCTransferAndSwapTxs(t, splitBillIDs, targetBillID, fcrID, targetBillData.Counter, rmaTree, signer)
+	dcTransferProofs, swapTx := createDCTransferAndSwapTxs(t, splitBillIDs, fcrID, targetBillID, targetBillData.Counter, rmaTree, signer)
 
 	for _, dcTransferProof := range dcTransferProofs {
 		_, err := txSystem.Execute(dcTransferProof.TransactionOrder())
@@ -558,7 +729,7 @@ func TestEndBlock_DustBillsAreRemoved(t *testing.T) {
 	_, err := txSystem.Execute(swapTx)
 	require.NoError(t, err)
 	_, newBillData := getBill(t, rmaTree, swapTx.UnitID)
-	require.Equal(t, uint64(10), newBillData.Value)
+	require.Equal(t, remaining+10, newBillData.Value)
 	_, dustCollectorBill := getBill(t, rmaTree, DustCollectorMoneySupplyID)
 	require.Equal(t, initialDustCollectorMoneyAmount, dustCollectorBill.Value)
 	stateSummary, err := txSystem.EndBlock()
@@ -577,6 +748,20 @@ func TestEndBlock_DustBillsAreRemoved(t *testing.T) {
 
 	_, dustCollectorBill = getBill(t, rmaTree, DustCollectorMoneySupplyID)
 	require.Equal(t, initialDustCollectorMoneyAmount, dustCollectorBill.Value)
+	// dust bills and the deletion schedule are deleted
+	for _, id := range splitBillIDs {
+		_, err := rmaTree.GetUnit(id, true)
+		require.ErrorIs(t, err, avl.ErrNotFound)
+	}
+	_, err = rmaTree.GetUnit(NewDustBillScheduleID(defaultDustBillDeletionTimeout+10), true)
+	require.ErrorIs(t, err, avl.ErrNotFound)
+	// and reported as deleted units
+	deleted := txSystem.DeletedUnits()
+	require.Len(t, deleted, len(splitBillIDs))
+	for i, u := range deleted {
+		require.Equal(t, splitBillIDs[i], u.UnitID)
+		require.EqualValues(t, DustCollectorPredicate, u.Data.Owner())
+	}
 }
 
 // Test scenario:
@@ -1052,7 +1237,7 @@ func createTx(fromID types.UnitID, fcrID types.UnitID, transactionType uint16) *
 	return tx
 }
 
-func createStateAndTxSystem(t *testing.T) (*state.State, *txsystem.GenericTxSystem, abcrypto.Signer) {
+func createStateAndTxSystem(t *testing.T, opts ...Option) (*state.State, *txsystem.GenericTxSystem, abcrypto.Signer) {
 	sdrs := createSDRs(newBillID(2))
 	s := genesisStateWithUC(t, initialBill, sdrs)
 	signer, verifier := testsig.CreateSignerAndVerifier(t)
@@ -1063,9 +1248,11 @@ func createStateAndTxSystem(t *testing.T) (*state.State, *txsystem.GenericTxSyst
 		*sdrs[0],
 		types.ShardID{},
 		observability.Default(t),
-		WithPartitionDescriptionRecords(sdrs),
-		WithState(s),
-		WithTrustBase(trustBase),
+		append([]Option{
+			WithPartitionDescriptionRecords(sdrs),
+			WithState(s),
+			WithTrustBase(trustBase),
+		}, opts...)...,
 	)
 	require.NoError(t, err)
 	summary, err := mss.StateSummary()
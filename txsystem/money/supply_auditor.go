@@ -0,0 +1,311 @@
+package money
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/money"
+	"github.com/alphabill-org/alphabill-go-base/types"
+
+	"github.com/alphabill-org/alphabill/logger"
+	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
+)
+
+type (
+	// SupplyAuditor checks the money supply invariant - the money partition never creates
+	// nor destroys value. The sum of the values of all the bills (including the dust
+	// collector money supply and the fee credit bills of the partitions) plus the fee
+	// credit transfers recorded but not yet consolidated must always be equal to the
+	// money supply created in genesis (InitialBillValue + DCMoneySupplyValue).
+	//
+	// The auditor traverses the entire state so it is meant to be used as a debug hook.
+	SupplyAuditor struct {
+		state         *state.State
+		fcRecorder    *feeCreditTxRecorder
+		dustCollector *DustCollector
+		supply        uint64
+		log           *slog.Logger
+	}
+
+	// SupplyDrift describes the violation of the money supply invariant.
+	SupplyDrift struct {
+		Round    uint64
+		Expected uint64
+		Actual   uint64
+		// Changes is the list of the transactions of the round which changed the money
+		// supply. Nil when the changes are not known (ie when auditing state file).
+		Changes []*SupplyChange
+	}
+
+	// SupplyChange is the sum of bill value changes made by a transaction.
+	SupplyChange struct {
+		// TxRecordHash is the hash of the transaction record which made the change, nil
+		// for the changes made by the end of block functions.
+		TxRecordHash []byte
+		Added        uint64
+		Removed      uint64
+		units        []types.UnitID
+	}
+
+	// billValueSummer is the state traverser calculating the sum of the bill values.
+	billValueSummer struct {
+		sum uint64
+		err error
+	}
+
+	// unitLogCollector is the state traverser collecting the units changed in the current round.
+	unitLogCollector struct {
+		ids  []types.UnitID
+		logs [][]*state.Log
+	}
+)
+
+func (d *SupplyDrift) Error() string {
+	msg := fmt.Sprintf("money supply drift in round %d: expected %d, actual %d", d.Round, d.Expected, d.Actual)
+	for _, c := range d.Changes {
+		if c.TxRecordHash == nil {
+			msg += fmt.Sprintf("; end of block: added %d, removed %d", c.Added, c.Removed)
+		} else {
+			msg += fmt.Sprintf("; tx %X: added %d, removed %d", c.TxRecordHash, c.Added, c.Removed)
+		}
+	}
+	return msg
+}
+
+/*
+NewSupplyAuditor returns auditor for the money supply of the Module, "supply" is the
+money supply created in genesis.
+*/
+func (m *Module) NewSupplyAuditor(supply uint64, log *slog.Logger) *SupplyAuditor {
+	return &SupplyAuditor{
+		state:         m.state,
+		fcRecorder:    m.feeCreditTxRecorder,
+		dustCollector: m.dustCollector,
+		supply:        supply,
+		log:           log,
+	}
+}
+
+/*
+Audit checks the money supply invariant of the current (uncommitted) state. Drift is
+logged as an error, it doesn't fail the block.
+*/
+func (a *SupplyAuditor) Audit(roundNumber uint64) error {
+	if err := a.audit(roundNumber); err != nil {
+		var drift *SupplyDrift
+		if errors.As(err, &drift) {
+			a.log.Error("money supply invariant violated", logger.Error(err), logger.Round(roundNumber))
+			return nil
+		}
+		return fmt.Errorf("auditing money supply: %w", err)
+	}
+	return nil
+}
+
+func (a *SupplyAuditor) audit(roundNumber uint64) error {
+	billsValue, err := sumBillValues(a.state.TraverseUncommitted)
+	if err != nil {
+		return err
+	}
+	// fee credit transfers are moved to the fee credit bills in the end of the block
+	var transferred, reclaimed uint64
+	for sid := range a.fcRecorder.sdrs {
+		for _, tx := range a.fcRecorder.transferFeeCredits[sid] {
+			transferred += tx.attr.Amount
+		}
+		for _, tx := range a.fcRecorder.reclaimFeeCredits[sid] {
+			reclaimed += tx.reclaimAmount - tx.reclaimFee
+		}
+	}
+	if billsValue+transferred == a.supply+reclaimed {
+		return nil
+	}
+
+	changes, err := a.supplyChanges()
+	if err != nil {
+		return fmt.Errorf("collecting money supply changes: %w", err)
+	}
+	return &SupplyDrift{
+		Round:    roundNumber,
+		Expected: a.supply,
+		Actual:   billsValue + transferred - reclaimed,
+		Changes:  changes,
+	}
+}
+
+/*
+supplyChanges returns the transactions of the current round which changed the money
+supply and which are not explained by the fee credit transfers.
+*/
+func (a *SupplyAuditor) supplyChanges() ([]*SupplyChange, error) {
+	lc := &unitLogCollector{}
+	a.state.TraverseUncommitted(lc)
+
+	var changes []*SupplyChange
+	change := func(txHash []byte) *SupplyChange {
+		if isZeroHash(txHash) {
+			txHash = nil
+		}
+		for _, c := range changes {
+			if bytes.Equal(c.TxRecordHash, txHash) {
+				return c
+			}
+		}
+		c := &SupplyChange{TxRecordHash: txHash}
+		changes = append(changes, c)
+		return c
+	}
+
+	for i, id := range lc.ids {
+		logs := lc.logs[i]
+		// when the unit existed before the round the first log is the last change of
+		// the previous rounds
+		var prev uint64
+		_, err := a.state.GetUnit(id, true)
+		switch {
+		case err == nil:
+			prev = billValue(logs[0].NewUnitData)
+			logs = logs[1:]
+			if len(logs) == 0 {
+				// unit log of the previous round only
+				continue
+			}
+		case !errors.Is(err, avl.ErrNotFound):
+			return nil, err
+		}
+		for _, l := range logs {
+			c := change(l.TxRecordHash)
+			v := billValue(l.NewUnitData)
+			if v > prev {
+				c.Added += v - prev
+			} else {
+				c.Removed += prev - v
+			}
+			if !slices.ContainsFunc(c.units, id.Eq) {
+				c.units = append(c.units, id)
+			}
+			prev = v
+		}
+	}
+	// value of the deleted dust bills was moved to the dust collector money supply
+	if deleted := a.dustCollector.DeletedUnits(); len(deleted) > 0 {
+		c := change(nil)
+		for _, du := range deleted {
+			c.Removed += billValue(du.Data)
+		}
+	}
+
+	// fee credit transactions change the supply by the amount recorded
+	type fcTx struct {
+		unitID  types.UnitID
+		added   uint64
+		removed uint64
+	}
+	var fcTxs []*fcTx
+	for _, txs := range a.fcRecorder.transferFeeCredits {
+		for _, tx := range txs {
+			fcTxs = append(fcTxs, &fcTx{unitID: tx.tx.UnitID, removed: tx.attr.Amount})
+		}
+	}
+	for _, txs := range a.fcRecorder.reclaimFeeCredits {
+		for _, tx := range txs {
+			fcTxs = append(fcTxs, &fcTx{unitID: tx.tx.UnitID, added: tx.reclaimAmount - tx.reclaimFee})
+		}
+	}
+
+	var unbalanced []*SupplyChange
+	for _, c := range changes {
+		if c.Added == c.Removed {
+			continue
+		}
+		idx := slices.IndexFunc(fcTxs, func(fc *fcTx) bool {
+			return c.Added+fc.removed == c.Removed+fc.added && slices.ContainsFunc(c.units, fc.unitID.Eq)
+		})
+		if idx >= 0 {
+			fcTxs = slices.Delete(fcTxs, idx, idx+1)
+			continue
+		}
+		unbalanced = append(unbalanced, c)
+	}
+	return unbalanced, nil
+}
+
+/*
+AuditMoneySupply checks the money supply invariant of the state "s" loaded from state
+file (the fee credit transfers are consolidated so they are not taken into account),
+"supply" is the money supply created in genesis. When the invariant is violated
+*SupplyDrift error is returned.
+*/
+func AuditMoneySupply(s *state.State, supply uint64) error {
+	// genesis state file is not committed
+	billsValue, err := sumBillValues(s.TraverseUncommitted)
+	if err != nil {
+		return err
+	}
+	if billsValue != supply {
+		return &SupplyDrift{
+			Round:    s.CommittedUC().GetRoundNumber(),
+			Expected: supply,
+			Actual:   billsValue,
+		}
+	}
+	return nil
+}
+
+func sumBillValues(traverse func(avl.Traverser[types.UnitID, *state.Unit])) (uint64, error) {
+	bvs := &billValueSummer{}
+	traverse(bvs)
+	if bvs.err != nil {
+		return 0, fmt.Errorf("summing bill values: %w", bvs.err)
+	}
+	return bvs.sum, nil
+}
+
+func (bvs *billValueSummer) Traverse(n *avl.Node[types.UnitID, *state.Unit]) {
+	if n == nil || bvs.err != nil {
+		return
+	}
+	bvs.Traverse(n.Left())
+	bvs.Traverse(n.Right())
+
+	v := billValue(n.Value().Data())
+	if v > math.MaxUint64-bvs.sum {
+		bvs.err = fmt.Errorf("bill values overflow uint64 (unit %s)", n.Key())
+		return
+	}
+	bvs.sum += v
+}
+
+func (lc *unitLogCollector) Traverse(n *avl.Node[types.UnitID, *state.Unit]) {
+	if n == nil {
+		return
+	}
+	lc.Traverse(n.Left())
+	lc.Traverse(n.Right())
+
+	if logs := n.Value().Logs(); len(logs) > 0 {
+		lc.ids = append(lc.ids, n.Key())
+		lc.logs = append(lc.logs, logs)
+	}
+}
+
+func billValue(data types.UnitData) uint64 {
+	if bd, ok := data.(*money.BillData); ok {
+		return bd.Value
+	}
+	return 0
+}
+
+func isZeroHash(h []byte) bool {
+	for _, b := range h {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
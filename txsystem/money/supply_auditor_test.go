@@ -0,0 +1,85 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/fc"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/money"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/stretchr/testify/require"
+
+	testlogger "github.com/alphabill-org/alphabill/internal/testutils/logger"
+	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
+	testtb "github.com/alphabill-org/alphabill/internal/testutils/trustbase"
+	"github.com/alphabill-org/alphabill/state"
+	testtransaction "github.com/alphabill-org/alphabill/txsystem/testutils/transaction"
+)
+
+func TestAuditMoneySupply(t *testing.T) {
+	s := genesisStateWithUC(t, initialBill, createSDRs(newBillID(2)))
+	supply := initialBill.Value + initialDustCollectorMoneyAmount
+	require.NoError(t, AuditMoneySupply(s, supply))
+
+	err := AuditMoneySupply(s, supply+1)
+	require.EqualError(t, err, `money supply drift in round 1: expected 211, actual 210`)
+	var drift *SupplyDrift
+	require.ErrorAs(t, err, &drift)
+	require.Equal(t, &SupplyDrift{Round: 1, Expected: supply + 1, Actual: supply}, drift)
+}
+
+func TestSupplyAuditor(t *testing.T) {
+	sdrs := createSDRs(newBillID(2))
+	s := genesisStateWithUC(t, initialBill, sdrs)
+	supply := initialBill.Value + initialDustCollectorMoneyAmount
+
+	_, verifier := testsig.CreateSignerAndVerifier(t)
+	options, err := defaultOptions()
+	require.NoError(t, err)
+	options.state = s
+	options.trustBase = testtb.NewTrustBase(t, verifier)
+	options.systemDescriptionRecords = sdrs
+	module, err := NewMoneyModule(5, money.DefaultSystemID, options)
+	require.NoError(t, err)
+	auditor := module.NewSupplyAuditor(supply, testlogger.New(t))
+	require.NoError(t, auditor.audit(2))
+
+	addValue := func(id types.UnitID, v int64, txHash []byte) {
+		t.Helper()
+		require.NoError(t, s.Apply(state.UpdateUnitData(id, func(data types.UnitData) (types.UnitData, error) {
+			bd := data.(*money.BillData)
+			bd.Value = uint64(int64(bd.Value) + v)
+			return bd, nil
+		})))
+		require.NoError(t, s.AddUnitLog(id, txHash))
+	}
+
+	// balanced transaction: value moved from the initial bill to the dust collector
+	addValue(initialBill.ID, -10, []byte{1})
+	addValue(DustCollectorMoneySupplyID, 10, []byte{1})
+	require.NoError(t, auditor.audit(2))
+
+	// fee credit transfer, the value is added to the fee credit bill in the end of the block
+	addValue(initialBill.ID, -5, []byte{2})
+	module.feeCreditTxRecorder.recordTransferFC(&transferFeeCreditTx{
+		tx:   testtransaction.NewTransactionOrder(t, testtransaction.WithUnitID(initialBill.ID)),
+		fee:  1,
+		attr: &fc.TransferFeeCreditAttributes{Amount: 5, TargetSystemIdentifier: money.DefaultSystemID},
+	})
+	require.NoError(t, auditor.audit(2))
+
+	// value created out of thin air
+	addValue(DustCollectorMoneySupplyID, 7, []byte{3})
+	err = auditor.audit(2)
+	drift, ok := err.(*SupplyDrift)
+	require.True(t, ok, "expected SupplyDrift, got %v", err)
+	require.EqualValues(t, 2, drift.Round)
+	require.Equal(t, supply, drift.Expected)
+	require.Equal(t, supply+7, drift.Actual)
+	require.Len(t, drift.Changes, 1)
+	require.Equal(t, []byte{3}, drift.Changes[0].TxRecordHash)
+	require.EqualValues(t, 7, drift.Changes[0].Added)
+	require.EqualValues(t, 0, drift.Changes[0].Removed)
+
+	// the end of block hook only logs the drift
+	require.NoError(t, auditor.Audit(2))
+}
@@ -41,17 +41,22 @@ func (m *Module) executeTransferDCTx(tx *types.TransactionOrder, attr *money.Tra
 		},
 	)
 
+	// 5. record the dust bill for later deletion
+	scheduleDustBillFn, scheduleID, err := m.dustCollector.addDustBill(unitID, exeCtx.CurrentRound())
+	if err != nil {
+		return nil, fmt.Errorf("transferDC: %w", err)
+	}
+
 	if err := m.state.Apply(
 		updateUnitFn,
 		updateDCMoneySupplyFn,
+		scheduleDustBillFn,
 	); err != nil {
 		return nil, fmt.Errorf("transferDC: failed to update state: %w", err)
 	}
 
-	// record dust bills for later deletion TODO AB-1133
-	// dustCollector.AddDustBill(unitID, currentBlockNumber)
 	return &types.ServerMetadata{
-		TargetUnits:      []types.UnitID{unitID, DustCollectorMoneySupplyID},
+		TargetUnits:      []types.UnitID{unitID, DustCollectorMoneySupplyID, scheduleID},
 		SuccessIndicator: types.TxStatusSuccessful,
 	}, nil
 }
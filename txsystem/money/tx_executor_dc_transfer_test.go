@@ -11,6 +11,7 @@ import (
 	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
 	"github.com/alphabill-org/alphabill/txsystem/fc/testutils"
 	testctx "github.com/alphabill-org/alphabill/txsystem/testutils/exec_context"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -95,9 +96,14 @@ func TestModule_executeTransferDCTx(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, sm)
 	require.EqualValues(t, types.TxStatusSuccessful, sm.SuccessIndicator)
-	require.EqualValues(t, []types.UnitID{unitID, DustCollectorMoneySupplyID}, sm.TargetUnits)
+	scheduleID := NewDustBillScheduleID(6 + defaultDustBillDeletionTimeout)
+	require.EqualValues(t, []types.UnitID{unitID, DustCollectorMoneySupplyID, scheduleID}, sm.TargetUnits)
+	// the bill is scheduled for deletion
+	u, err := module.state.GetUnit(scheduleID, false)
+	require.NoError(t, err)
+	require.Equal(t, &txtypes.RoundSchedule{Units: []types.UnitID{unitID}}, u.Data())
 	// read the state and make sure all that must be updated where updated
-	u, err := module.state.GetUnit(unitID, false)
+	u, err = module.state.GetUnit(unitID, false)
 	require.NoError(t, err)
 	// bill owner is changed to dust collector
 	require.EqualValues(t, dustBill.Owner(), DustCollectorPredicate)
@@ -7,6 +7,7 @@ import (
 	"github.com/alphabill-org/alphabill-go-base/txsystem/fc"
 	"github.com/alphabill-org/alphabill-go-base/txsystem/money"
 	"github.com/alphabill-org/alphabill-go-base/types"
+	fcunit "github.com/alphabill-org/alphabill/txsystem/fc/unit"
 	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
 
 	"github.com/alphabill-org/alphabill/state"
@@ -81,6 +82,9 @@ func (m *Module) validateTransferFCTx(tx *types.TransactionOrder, attr *fc.Trans
 	if tx.FeeCreditRecordID() != nil {
 		return ErrRecordIDExists
 	}
+	if err := fcunit.VerifyNotSponsored(tx); err != nil {
+		return err
+	}
 	if tx.FeeProof != nil {
 		return ErrFeeProofExists
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/alphabill-org/alphabill-go-base/types"
 	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
 	"github.com/alphabill-org/alphabill/txsystem/fc/testutils"
+	fcunit "github.com/alphabill-org/alphabill/txsystem/fc/unit"
 	testctx "github.com/alphabill-org/alphabill/txsystem/testutils/exec_context"
 	testtransaction "github.com/alphabill-org/alphabill/txsystem/testutils/transaction"
 	"github.com/stretchr/testify/require"
@@ -119,6 +120,18 @@ func TestModule_validateTransferFCTx(t *testing.T) {
 		exeCtx := testctx.NewMockExecutionContext()
 		require.EqualError(t, module.validateTransferFCTx(tx, attr, authProof, exeCtx), "fee transaction cannot contain fee authorization proof")
 	})
+	t.Run("err - sponsored", func(t *testing.T) {
+		sponsorFeeProof, err := fcunit.NewSponsorFeeProof([]byte{1}, []byte{2})
+		require.NoError(t, err)
+		tx := testutils.NewTransferFC(t, signer, nil,
+			testtransaction.WithFeeProof(sponsorFeeProof))
+		attr := &fcsdk.TransferFeeCreditAttributes{}
+		require.NoError(t, tx.UnmarshalAttributes(attr))
+		module := newTestMoneyModule(t, verifier,
+			withStateUnit(tx.UnitID, &money.BillData{Value: 101, Counter: counter, OwnerPredicate: templates.AlwaysTrueBytes()}))
+		exeCtx := testctx.NewMockExecutionContext()
+		require.ErrorIs(t, module.validateTransferFCTx(tx, attr, authProof, exeCtx), fcunit.ErrSponsoredTx)
+	})
 	t.Run("err - bearer predicate error", func(t *testing.T) {
 		tx := testutils.NewTransferFC(t, signer, nil)
 		attr := &fcsdk.TransferFeeCreditAttributes{}
@@ -9,6 +9,7 @@ import (
 	"github.com/alphabill-org/alphabill-go-base/txsystem/money"
 	"github.com/alphabill-org/alphabill-go-base/types"
 	"github.com/alphabill-org/alphabill-go-base/util"
+	fcunit "github.com/alphabill-org/alphabill/txsystem/fc/unit"
 	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
 
 	"github.com/alphabill-org/alphabill/state"
@@ -67,6 +68,9 @@ func (m *Module) validateReclaimFCTx(tx *types.TransactionOrder, attr *fc.Reclai
 	if tx.FeeCreditRecordID() != nil {
 		return ErrRecordIDExists
 	}
+	if err := fcunit.VerifyNotSponsored(tx); err != nil {
+		return err
+	}
 	if tx.FeeProof != nil {
 		return ErrFeeProofExists
 	}
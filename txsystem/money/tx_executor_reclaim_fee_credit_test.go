@@ -12,6 +12,7 @@ import (
 	testblock "github.com/alphabill-org/alphabill/internal/testutils/block"
 	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
 	"github.com/alphabill-org/alphabill/txsystem/fc/testutils"
+	fcunit "github.com/alphabill-org/alphabill/txsystem/fc/unit"
 	testctx "github.com/alphabill-org/alphabill/txsystem/testutils/exec_context"
 	testtransaction "github.com/alphabill-org/alphabill/txsystem/testutils/transaction"
 	"github.com/stretchr/testify/require"
@@ -71,6 +72,18 @@ func TestModule_validateReclaimFCTx(t *testing.T) {
 		exeCtx := testctx.NewMockExecutionContext()
 		require.EqualError(t, module.validateReclaimFCTx(tx, attr, authProof, exeCtx), "fee transaction cannot contain fee authorization proof")
 	})
+	t.Run("Sponsored", func(t *testing.T) {
+		sponsorFeeProof, err := fcunit.NewSponsorFeeProof([]byte{1}, []byte{2})
+		require.NoError(t, err)
+		tx := testutils.NewReclaimFC(t, signer, nil,
+			testtransaction.WithFeeProof(sponsorFeeProof))
+		attr := &fcsdk.ReclaimFeeCreditAttributes{}
+		require.NoError(t, tx.UnmarshalAttributes(attr))
+		module := newTestMoneyModule(t, verifier,
+			withStateUnit(tx.UnitID, &money.BillData{Value: amount, Counter: counter, OwnerPredicate: templates.AlwaysTrueBytes()}))
+		exeCtx := testctx.NewMockExecutionContext()
+		require.ErrorIs(t, module.validateReclaimFCTx(tx, attr, authProof, exeCtx), fcunit.ErrSponsoredTx)
+	})
 	t.Run("Invalid target unit", func(t *testing.T) {
 		tx := testutils.NewReclaimFC(t, signer, nil,
 			testtransaction.WithUnitID(money.NewFeeCreditRecordID(nil, []byte{2})))
@@ -16,8 +16,12 @@ type Options struct {
 	state               *state.State
 	beginBlockFunctions []func(blockNumber uint64) error
 	endBlockFunctions   []func(blockNumber uint64) error
+	predicateExecutor   predicates.PredicateExecutor
 	predicateRunner     predicates.PredicateRunner
 	feeCredit           txtypes.FeeCreditModule
+	predicateStorage    []byte
+	gasSchedule         *predicates.GasSchedule
+	gasScheduleUpdates  predicates.GasScheduleUpdates
 }
 
 type Option func(*Options)
@@ -27,6 +31,7 @@ func DefaultOptions() *Options {
 		hashAlgorithm: crypto.SHA256,
 		state:         state.NewEmptyState(),
 		feeCredit:     abfc.NewNoFeeCreditModule(),
+		gasSchedule:   predicates.DefaultGasSchedule(),
 	}).initPredicateRunner()
 }
 
@@ -62,8 +67,59 @@ func WithFeeCredits(f txtypes.FeeCreditModule) Option {
 	}
 }
 
+/*
+WithPredicateStorage enables the persistent storage of the predicates, the storage
+entries are kept in the state as units of the type "unitType".
+*/
+func WithPredicateStorage(unitType []byte) Option {
+	return func(g *Options) {
+		g.predicateStorage = unitType
+	}
+}
+
+/*
+WithGasSchedule sets the gas schedule used to charge for the transaction processing
+and the predicate templates, by default DefaultGasSchedule is used.
+*/
+func WithGasSchedule(gs *predicates.GasSchedule) Option {
+	return func(g *Options) {
+		g.gasSchedule = gs
+		// re-init predicate runner
+		g.initPredicateRunner()
+	}
+}
+
+/*
+WithPredicateExecutor sets the predicate executor used to evaluate the state lock
+predicates, it should be the same executor the transaction system modules use so
+that all the configured predicate engines are supported. When not set the
+predicate templates (using the gas schedule) are supported only.
+*/
+func WithPredicateExecutor(exec predicates.PredicateExecutor) Option {
+	return func(g *Options) {
+		g.predicateExecutor = exec
+		// re-init predicate runner
+		g.initPredicateRunner()
+	}
+}
+
+/*
+WithGasScheduleUpdates sets the gas schedules replacing the gas schedule set by
+WithGasSchedule from their activation round. The gas schedule of the current round
+is provided to the predicate engines by the execution context.
+*/
+func WithGasScheduleUpdates(updates predicates.GasScheduleUpdates) Option {
+	return func(g *Options) {
+		g.gasScheduleUpdates = updates
+	}
+}
+
 func (o *Options) initPredicateRunner() *Options {
-	engines, err := predicates.Dispatcher(templates.New())
+	if o.predicateExecutor != nil {
+		o.predicateRunner = predicates.NewPredicateRunner(o.predicateExecutor)
+		return o
+	}
+	engines, err := predicates.Dispatcher(templates.New(templates.WithGasSchedule(o.gasSchedule)))
 	if err != nil {
 		panic(fmt.Errorf("creating predicate executor: %w", err))
 	}
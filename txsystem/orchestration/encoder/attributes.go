@@ -0,0 +1,51 @@
+package orchestrationenc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/orchestration"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/encoder"
+)
+
+func init() {
+	encoder.Register(RegisterTxAttributeEncoders, RegisterUnitDataEncoders)
+}
+
+func RegisterTxAttributeEncoders(reg func(id encoder.AttrEncID, enc encoder.TxAttributesEncoder) error) error {
+	key := func(attrID uint16) encoder.AttrEncID {
+		return encoder.AttrEncID{
+			TxSys: orchestration.DefaultSystemID,
+			Attr:  attrID,
+		}
+	}
+	return errors.Join(
+		reg(key(orchestration.TransactionTypeAddVAR), txaAddVarAttributes),
+	)
+}
+
+/*
+txaAddVarAttributes encodes validator assignment record:
+  - ver 1: epoch number, epoch switch round number, array of validators (each
+    validator is array of validator ID and stake) and quorum size;
+*/
+func txaAddVarAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &orchestration.AddVarAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	validators := make([]any, len(attr.Var.ValidatorAssignment.Validators))
+	for i, v := range attr.Var.ValidatorAssignment.Validators {
+		validators[i] = []any{v.ValidatorID, v.Stake}
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.Var.EpochNumber)
+	buf.EncodeTagged(2, attr.Var.EpochSwitchRoundNumber)
+	buf.EncodeTagged(3, validators)
+	buf.EncodeTagged(4, attr.Var.ValidatorAssignment.QuorumSize)
+	return buf.Bytes()
+}
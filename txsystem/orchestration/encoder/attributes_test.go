@@ -0,0 +1,37 @@
+package orchestrationenc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/orchestration"
+	"github.com/alphabill-org/alphabill-go-base/types"
+)
+
+func Test_txaAddVarAttributes(t *testing.T) {
+	txo := &types.TransactionOrder{Payload: types.Payload{}}
+	require.NoError(t, txo.SetAttributes(orchestration.AddVarAttributes{
+		Var: orchestration.ValidatorAssignmentRecord{
+			EpochNumber:            1,
+			EpochSwitchRoundNumber: 100,
+			ValidatorAssignment: orchestration.ValidatorAssignment{
+				Validators: []orchestration.ValidatorInfo{{ValidatorID: []byte{1}, Stake: 5}},
+				QuorumSize: 5,
+			},
+		},
+	}))
+	b, err := txaAddVarAttributes(txo, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x1, 0x2, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x2, 0x2, 0x64, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x3, 0x6, 0x1, 0x0, 0x0, 0x0, 0x6, 0x2, 0x0, 0x0, 0x0, 0x1, 0x1, 0x0, 0x0, 0x0, 0x1, 0x2, 0x5, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x4, 0x2, 0x5, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, b)
+
+	b, err = txaAddVarAttributes(txo, 2)
+	require.EqualError(t, err, `unsupported encoding version 2, latest supported version is 1`)
+	require.Nil(t, b)
+}
+
+func Test_udeVarData(t *testing.T) {
+	b, err := udeVarData(&orchestration.VarData{EpochNumber: 3}, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x1, 0x2, 0x3, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}, b)
+}
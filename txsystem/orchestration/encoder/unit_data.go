@@ -0,0 +1,25 @@
+package orchestrationenc
+
+import (
+	"errors"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/orchestration"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/encoder"
+)
+
+func RegisterUnitDataEncoders(reg func(ud any, enc encoder.UnitDataEncoder) error) error {
+	return errors.Join(
+		reg(&orchestration.VarData{}, udeVarData),
+	)
+}
+
+func udeVarData(data types.UnitData, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	value := data.(*orchestration.VarData)
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, value.EpochNumber)
+	return buf.Bytes()
+}
@@ -0,0 +1,103 @@
+package predicatestore
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/predicates"
+	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
+)
+
+var _ predicates.PredicateStorage = (*Store)(nil)
+
+/*
+Store implements predicate storage on top of the unit state - every key is stored as
+a unit so the storage is covered by the state root and changes are reverted together
+with the rest of the state changes made by the transaction.
+
+Store is meant to be used for the duration of a single transaction, ModifiedUnits
+returns the units which need the unit log of the transaction.
+*/
+type Store struct {
+	state    *state.State
+	unitID   func(predicateHash, key []byte) types.UnitID
+	modified []types.UnitID
+}
+
+func New(s *state.State, unitID func(predicateHash, key []byte) types.UnitID) *Store {
+	return &Store{state: s, unitID: unitID}
+}
+
+func (s *Store) ReadPredicateData(predicateHash, key []byte) ([]byte, error) {
+	data, err := s.getData(s.unitID(predicateHash, key))
+	if err != nil || data == nil {
+		return nil, err
+	}
+	return slices.Clone(data.Value), nil
+}
+
+func (s *Store) WritePredicateData(predicateHash, key, value []byte) error {
+	id := s.unitID(predicateHash, key)
+	data, err := s.getData(id)
+	if err != nil {
+		return err
+	}
+
+	var action state.Action
+	switch {
+	case len(value) == 0 && data == nil:
+		return nil
+	case len(value) == 0:
+		action = state.DeleteUnit(id)
+	case data == nil:
+		action = state.AddUnit(id, &Data{Value: slices.Clone(value)})
+	default:
+		action = state.UpdateUnitData(id, func(ud types.UnitData) (types.UnitData, error) {
+			d, ok := ud.(*Data)
+			if !ok {
+				return nil, fmt.Errorf("unit %s does not contain predicate storage data", id)
+			}
+			d.Value = slices.Clone(value)
+			return d, nil
+		})
+	}
+	if err := s.state.Apply(action); err != nil {
+		return fmt.Errorf("updating predicate storage unit: %w", err)
+	}
+	if !slices.ContainsFunc(s.modified, id.Eq) {
+		s.modified = append(s.modified, id)
+	}
+	return nil
+}
+
+/*
+ModifiedUnits returns IDs of the storage units which were added or updated
+(and not deleted afterwards), in the order of the first modification.
+*/
+func (s *Store) ModifiedUnits() []types.UnitID {
+	var ids []types.UnitID
+	for _, id := range s.modified {
+		if _, err := s.state.GetUnit(id, false); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (s *Store) getData(id types.UnitID) (*Data, error) {
+	u, err := s.state.GetUnit(id, false)
+	if err != nil {
+		if errors.Is(err, avl.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading predicate storage unit: %w", err)
+	}
+	data, ok := u.Data().(*Data)
+	if !ok {
+		return nil, fmt.Errorf("unit %s does not contain predicate storage data", id)
+	}
+	return data, nil
+}
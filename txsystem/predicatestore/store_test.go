@@ -0,0 +1,118 @@
+package predicatestore
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/state"
+)
+
+var testUnitType = []byte{32}
+
+func testUnitID(predicateHash, key []byte) types.UnitID {
+	return NewUnitID(tokens.UnitIDLength, testUnitType, predicateHash, key)
+}
+
+func TestStore_ReadWrite(t *testing.T) {
+	s := state.NewEmptyState()
+	store := New(s, testUnitID)
+	hashA, hashB := []byte{1}, []byte{2}
+
+	value, err := store.ReadPredicateData(hashA, []byte("key"))
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	require.NoError(t, store.WritePredicateData(hashA, []byte("key"), []byte("A")))
+	require.NoError(t, store.WritePredicateData(hashB, []byte("key"), []byte("B")))
+
+	// keys are namespaced by predicate hash
+	value, err = store.ReadPredicateData(hashA, []byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("A"), value)
+	value, err = store.ReadPredicateData(hashB, []byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("B"), value)
+
+	// update
+	require.NoError(t, store.WritePredicateData(hashA, []byte("key"), []byte("AA")))
+	value, err = store.ReadPredicateData(hashA, []byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("AA"), value)
+	require.Equal(t, []types.UnitID{testUnitID(hashA, []byte("key")), testUnitID(hashB, []byte("key"))}, store.ModifiedUnits())
+
+	// empty value deletes the unit
+	require.NoError(t, store.WritePredicateData(hashB, []byte("key"), nil))
+	value, err = store.ReadPredicateData(hashB, []byte("key"))
+	require.NoError(t, err)
+	require.Nil(t, value)
+	require.Equal(t, []types.UnitID{testUnitID(hashA, []byte("key"))}, store.ModifiedUnits())
+
+	// deleting missing key is no-op
+	require.NoError(t, store.WritePredicateData(hashB, []byte("foo"), nil))
+	require.Len(t, store.ModifiedUnits(), 1)
+}
+
+func TestStore_Savepoint(t *testing.T) {
+	s := state.NewEmptyState()
+	store := New(s, testUnitID)
+	hash, key := []byte{1}, []byte("key")
+
+	// state root covers the unit data via unit logs which are added by the tx system
+	require.NoError(t, store.WritePredicateData(hash, key, []byte{1}))
+	require.NoError(t, s.AddUnitLog(testUnitID(hash, key), []byte{1}))
+	_, root1, err := s.CalculateRoot()
+	require.NoError(t, err)
+
+	id := s.Savepoint()
+	require.NoError(t, store.WritePredicateData(hash, key, []byte{2}))
+	require.NoError(t, s.AddUnitLog(testUnitID(hash, key), []byte{2}))
+	_, root2, err := s.CalculateRoot()
+	require.NoError(t, err)
+	require.NotEqual(t, root1, root2, "storage must be covered by the state root")
+
+	s.RollbackToSavepoint(id)
+	value, err := store.ReadPredicateData(hash, key)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, value)
+}
+
+func TestStore_InvalidUnitData(t *testing.T) {
+	s := state.NewEmptyState()
+	store := New(s, testUnitID)
+	hash, key := []byte{1}, []byte("key")
+	require.NoError(t, s.Apply(state.AddUnit(testUnitID(hash, key), &tokens.FungibleTokenData{})))
+
+	_, err := store.ReadPredicateData(hash, key)
+	require.ErrorContains(t, err, "does not contain predicate storage data")
+	require.ErrorContains(t, store.WritePredicateData(hash, key, []byte{1}), "does not contain predicate storage data")
+}
+
+func TestNewUnitDataConstructor(t *testing.T) {
+	udc := NewUnitDataConstructor(tokens.NewUnitData, testUnitType)
+
+	data, err := udc(testUnitID([]byte{1}, []byte("key")))
+	require.NoError(t, err)
+	require.IsType(t, &Data{}, data)
+
+	data, err = udc(tokens.NewFungibleTokenID(nil, []byte{1}))
+	require.NoError(t, err)
+	require.IsType(t, &tokens.FungibleTokenData{}, data)
+
+	// recover state with storage units
+	s := state.NewEmptyState()
+	id := testUnitID([]byte{1}, []byte("key"))
+	require.NoError(t, New(s, testUnitID).WritePredicateData([]byte{1}, []byte("key"), []byte("value")))
+	require.NoError(t, s.AddUnitLog(id, make([]byte, 32)))
+	buf := &bytes.Buffer{}
+	require.NoError(t, s.Serialize(buf, false))
+
+	recovered, err := state.NewRecoveredState(buf, udc)
+	require.NoError(t, err)
+	u, err := recovered.GetUnit(id, false)
+	require.NoError(t, err)
+	require.Equal(t, &Data{Value: []byte("value")}, u.Data())
+}
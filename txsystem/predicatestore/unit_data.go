@@ -0,0 +1,64 @@
+package predicatestore
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"slices"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/state"
+)
+
+var _ types.UnitData = (*Data)(nil)
+
+// Data is the unit data of the predicate storage entry.
+type Data struct {
+	_     struct{} `cbor:",toarray"`
+	Value []byte
+}
+
+func (d *Data) Write(hasher hash.Hash) error {
+	res, err := types.Cbor.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("predicate storage data encode error: %w", err)
+	}
+	_, err = hasher.Write(res)
+	return err
+}
+
+func (d *Data) SummaryValueInput() uint64 {
+	return 0
+}
+
+func (d *Data) Copy() types.UnitData {
+	return &Data{Value: slices.Clone(d.Value)}
+}
+
+func (d *Data) Owner() []byte {
+	return nil
+}
+
+/*
+NewUnitID returns ID of the unit which holds the value of the "key" of the predicate
+with hash "predicateHash".
+*/
+func NewUnitID(unitIDLength int, unitType, predicateHash, key []byte) types.UnitID {
+	h := sha256.New()
+	h.Write(predicateHash)
+	h.Write(key)
+	return types.NewUnitID(unitIDLength, nil, h.Sum(nil), unitType)
+}
+
+/*
+NewUnitDataConstructor wraps the unit data constructor of the partition so that
+units of the type "unitType" are recovered as predicate storage entries.
+*/
+func NewUnitDataConstructor(udc state.UnitDataConstructor, unitType []byte) state.UnitDataConstructor {
+	return func(id types.UnitID) (types.UnitData, error) {
+		if id.HasType(unitType) {
+			return &Data{}, nil
+		}
+		return udc(id)
+	}
+}
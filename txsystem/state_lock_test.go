@@ -2,17 +2,21 @@ package txsystem
 
 import (
 	"testing"
+	"time"
 
+	"github.com/alphabill-org/alphabill-go-base/hash"
 	basetemplates "github.com/alphabill-org/alphabill-go-base/predicates/templates"
 	fcsdk "github.com/alphabill-org/alphabill-go-base/txsystem/fc"
 	"github.com/alphabill-org/alphabill-go-base/txsystem/money"
 	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/internal/testutils/observability"
 	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
 	abfc "github.com/alphabill-org/alphabill/txsystem/fc"
 	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
 	"github.com/fxamacker/cbor/v2"
 
 	"github.com/alphabill-org/alphabill/predicates"
+	"github.com/alphabill-org/alphabill/predicates/script"
 	"github.com/alphabill-org/alphabill/predicates/templates"
 	testtransaction "github.com/alphabill-org/alphabill/txsystem/testutils/transaction"
 	"github.com/stretchr/testify/require"
@@ -257,6 +261,73 @@ func TestGenericTxSystem_handleUnlockUnitState(t *testing.T) {
 	})
 }
 
+func TestGenericTxSystem_handleUnlockUnitState_scriptPredicate(t *testing.T) {
+	preimage := []byte("secret")
+	code, err := (&script.Builder{}).Op(script.OpSHA256).PushData(hash.Sum256(preimage)).Op(script.OpEqual).Bytes()
+	require.NoError(t, err)
+	lockPredicate, err := script.NewPredicateBytes(code)
+	require.NoError(t, err)
+
+	unitID := money.NewBillID(nil, []byte{2})
+	lockTx, err := cbor.Marshal(testtransaction.NewTransactionOrder(
+		t,
+		testtransaction.WithTransactionType(money.TransactionTypeTransfer),
+		testtransaction.WithUnitID(unitID),
+		testtransaction.WithSystemID(money.DefaultSystemID),
+		testtransaction.WithAttributes(&money.TransferAttributes{NewOwnerPredicate: basetemplates.AlwaysTrueBytes(), TargetValue: 1, Counter: 1}),
+		testtransaction.WithStateLock(&types.StateLock{ExecutionPredicate: lockPredicate}),
+	))
+	require.NoError(t, err)
+
+	newTxSystem := func(t *testing.T, opts ...Option) *GenericTxSystem {
+		pdr := types.PartitionDescriptionRecord{
+			NetworkIdentifier: mockNetworkID,
+			SystemIdentifier:  mockTxSystemID,
+			TypeIdLen:         8,
+			UnitIdLen:         8 * 32,
+			T2Timeout:         2500 * time.Millisecond,
+		}
+		txSys, err := NewGenericTxSystem(pdr, types.ShardID{}, nil, nil, observability.Default(t), opts...)
+		require.NoError(t, err)
+		require.NoError(t, withStateUnit(unitID, &money.BillData{Value: 1, Counter: 1, OwnerPredicate: basetemplates.AlwaysTrueBytes()}, lockTx)(txSys))
+		return txSys
+	}
+	newUnlockTx := func(t *testing.T) *types.TransactionOrder {
+		return testtransaction.NewTransactionOrder(
+			t,
+			testtransaction.WithTransactionType(money.TransactionTypeTransfer),
+			testtransaction.WithUnitID(unitID),
+			testtransaction.WithSystemID(money.DefaultSystemID),
+			testtransaction.WithAttributes(&money.TransferAttributes{}),
+			testtransaction.WithUnlockProof(append([]byte{byte(StateUnlockExecute)}, script.NewOwnerProof(preimage)...)),
+		)
+	}
+
+	t.Run("script engine not configured", func(t *testing.T) {
+		txSys := newTxSystem(t)
+		tx := newUnlockTx(t)
+		execCtx := txtypes.NewExecutionContext(tx, txSys, abfc.NewNoFeeCreditModule(), nil, 10)
+		sm, err := txSys.handleUnlockUnitState(tx, execCtx)
+		require.ErrorContains(t, err, "unlock error: state lock's execution predicate failed:")
+		require.Nil(t, sm)
+	})
+
+	t.Run("configured predicate executor", func(t *testing.T) {
+		engines, err := predicates.Dispatcher(templates.New(), script.New())
+		require.NoError(t, err)
+		txSys := newTxSystem(t, WithPredicateExecutor(engines.Execute), WithGasSchedule(predicates.DefaultGasSchedule()))
+		tx := newUnlockTx(t)
+		execCtx := txtypes.NewExecutionContext(tx, txSys, abfc.NewNoFeeCreditModule(), nil, 10)
+		// the lock is released and the tx on hold executed, no module handles it in this test
+		sm, err := txSys.handleUnlockUnitState(tx, execCtx)
+		require.EqualError(t, err, "failed to execute transaction that was on hold: unknown transaction type 1")
+		require.Nil(t, sm)
+		u, err := txSys.state.GetUnit(unitID, false)
+		require.NoError(t, err)
+		require.False(t, u.IsStateLocked())
+	})
+}
+
 func TestGenericTxSystem_executeLockUnitState(t *testing.T) {
 	t.Run("err - invalid state lock", func(t *testing.T) {
 		unitID := money.NewBillID(nil, []byte{2})
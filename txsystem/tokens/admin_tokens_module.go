@@ -0,0 +1,38 @@
+package tokens
+
+import (
+	"crypto"
+
+	"github.com/alphabill-org/alphabill/predicates"
+	"github.com/alphabill-org/alphabill/state"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
+)
+
+var _ txtypes.Module = (*AdminTokensModule)(nil)
+
+/*
+AdminTokensModule implements the administrative controls of the token types which have
+the admin predicate: freezing a token and pausing a token type.
+*/
+type AdminTokensModule struct {
+	state         *state.State
+	hashAlgorithm crypto.Hash
+	execPredicate predicates.PredicateRunner
+}
+
+func NewAdminTokensModule(options *Options) (*AdminTokensModule, error) {
+	return &AdminTokensModule{
+		state:         options.state,
+		hashAlgorithm: options.hashAlgorithm,
+		execPredicate: predicates.NewPredicateRunner(options.exec),
+	}, nil
+}
+
+func (m *AdminTokensModule) TxHandlers() map[uint16]txtypes.TxExecutor {
+	return map[uint16]txtypes.TxExecutor{
+		TransactionTypeFreezeToken:      txtypes.NewTxHandler[FreezeTokenAttributes, TokenAdminAuthProof](m.validateFreezeToken, m.executeFreezeToken),
+		TransactionTypeUnfreezeToken:    txtypes.NewTxHandler[UnfreezeTokenAttributes, TokenAdminAuthProof](m.validateUnfreezeToken, m.executeUnfreezeToken),
+		TransactionTypePauseTokenType:   txtypes.NewTxHandler[PauseTokenTypeAttributes, TokenAdminAuthProof](m.validatePauseTokenType, m.executePauseTokenType),
+		TransactionTypeUnpauseTokenType: txtypes.NewTxHandler[UnpauseTokenTypeAttributes, TokenAdminAuthProof](m.validateUnpauseTokenType, m.executeUnpauseTokenType),
+	}
+}
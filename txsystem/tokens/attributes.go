@@ -0,0 +1,188 @@
+package tokens
+
+import (
+	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/internal/cborutil"
+)
+
+const (
+	TransactionTypeBurnNFT uint16 = 13
+	// types 14..19 are used by the fee credit transactions
+	TransactionTypeFreezeToken      uint16 = 24
+	TransactionTypeUnfreezeToken    uint16 = 25
+	TransactionTypePauseTokenType   uint16 = 26
+	TransactionTypeUnpauseTokenType uint16 = 27
+	TransactionTypeApproveFT        uint16 = 28
+	TransactionTypeRevokeFT         uint16 = 29
+	TransactionTypeTransferFromFT   uint16 = 30
+	TransactionTypeBatchMintNFT     uint16 = 31
+)
+
+type (
+	// DefineFungibleTokenAttributes are the attributes of the SDK extended with the
+	// optional maximum supply of the type.
+	DefineFungibleTokenAttributes struct {
+		_                        struct{}     `cbor:",toarray"`
+		Symbol                   string       // the symbol (short name) of this token type; note that the symbols are not guaranteed to be unique
+		Name                     string       // the long name of this token type
+		Icon                     *tokens.Icon // the icon of this token type
+		ParentTypeID             types.UnitID // identifies the parent type that this type derives from; nil indicates there is no parent type
+		DecimalPlaces            uint32       // the number of decimal places to display for values of tokens of the new type
+		SubTypeCreationPredicate []byte       // the predicate clause that controls defining new subtypes of this type
+		TokenMintingPredicate    []byte       // the predicate clause that controls minting new tokens of this type
+		TokenTypeOwnerPredicate  []byte       // the predicate clause that all tokens of this type (and of subtypes of this type) inherit into their owner predicates
+		MaxSupply                uint64       // optional, the maximum circulating supply of tokens of this type; zero means unlimited
+		AdminPredicate           []byte       // optional, the predicate clause that controls freezing tokens of this type and pausing the type
+	}
+
+	// DefineNonFungibleTokenAttributes are the attributes of the SDK extended with the
+	// optional burning predicate of the type.
+	DefineNonFungibleTokenAttributes struct {
+		_                        struct{}     `cbor:",toarray"`
+		Symbol                   string       // the symbol (short name) of this token type; note that the symbols are not guaranteed to be unique
+		Name                     string       // the long name of this token type
+		Icon                     *tokens.Icon // the optional icon of this token type
+		ParentTypeID             types.UnitID // identifies the parent type that this type derives from; nil indicates there is no parent type
+		SubTypeCreationPredicate []byte       // the predicate clause that controls defining new subtypes of this type
+		TokenMintingPredicate    []byte       // the predicate clause that controls minting new tokens of this type
+		TokenTypeOwnerPredicate  []byte       // the predicate clause that all tokens of the new type (and of subtypes of it) inherit into their owner predicates
+		DataUpdatePredicate      []byte       // the clause that all tokens of this type (and of subtypes of this type) inherit into their data update predicates
+		TokenBurningPredicate    []byte       // optional, the predicate clause that controls burning tokens of this type; nil means tokens can't be burned
+		AdminPredicate           []byte       // optional, the predicate clause that controls freezing tokens of this type and pausing the type
+	}
+
+	BurnNonFungibleTokenAttributes struct {
+		_       struct{}     `cbor:",toarray"`
+		TypeID  types.UnitID // identifies the type of the token to burn
+		Counter uint64       // the current counter of the token
+	}
+
+	BurnNonFungibleTokenAuthProof struct {
+		_                  struct{} `cbor:",toarray"`
+		OwnerProof         []byte   // input to satisfy the owner predicate of the token
+		TokenBurningProofs [][]byte // inputs to satisfy the token burning predicates of the types
+	}
+
+	FreezeTokenAttributes struct {
+		_       struct{}     `cbor:",toarray"`
+		TypeID  types.UnitID // identifies the type of the token to freeze
+		Counter uint64       // the current counter of the freeze status of the token, zero if the token has never been frozen
+	}
+
+	UnfreezeTokenAttributes struct {
+		_       struct{}     `cbor:",toarray"`
+		TypeID  types.UnitID // identifies the type of the token to unfreeze
+		Counter uint64       // the current counter of the freeze status of the token
+	}
+
+	PauseTokenTypeAttributes struct {
+		_       struct{} `cbor:",toarray"`
+		Counter uint64   // the current admin counter of the token type
+	}
+
+	UnpauseTokenTypeAttributes struct {
+		_       struct{} `cbor:",toarray"`
+		Counter uint64   // the current admin counter of the token type
+	}
+
+	// TokenAdminAuthProof is the auth proof of the freeze, unfreeze, pause and unpause transactions.
+	TokenAdminAuthProof struct {
+		_          struct{} `cbor:",toarray"`
+		AdminProof []byte   // input to satisfy the admin predicate of the token type
+	}
+
+	ApproveFungibleTokenAttributes struct {
+		_                struct{}     `cbor:",toarray"`
+		TypeID           types.UnitID // identifies the type of the token
+		SpenderPredicate []byte       // the predicate clause that the spender must satisfy to transfer the token
+		Value            uint64       // the maximum total value of the token the spender is allowed to transfer
+		Deadline         uint64       // the round number before which the allowance can be used
+		Counter          uint64       // the current counter of the allowance of the token, zero if the token has never had an allowance
+	}
+
+	RevokeFungibleTokenAttributes struct {
+		_       struct{}     `cbor:",toarray"`
+		TypeID  types.UnitID // identifies the type of the token
+		Counter uint64       // the current counter of the allowance of the token
+	}
+
+	// AllowanceAuthProof is the auth proof of the approve and revoke transactions.
+	AllowanceAuthProof struct {
+		_          struct{} `cbor:",toarray"`
+		OwnerProof []byte   // input to satisfy the owner predicate of the token
+	}
+
+	TransferFromFungibleTokenAttributes struct {
+		_                 struct{}     `cbor:",toarray"`
+		TypeID            types.UnitID // identifies the type of the token
+		NewOwnerPredicate []byte       // the owner predicate of the transferred value
+		Value             uint64       // the value to transfer, if less than the value of the token then the token is split
+		Counter           uint64       // the current counter of the token
+	}
+
+	TransferFromFungibleTokenAuthProof struct {
+		_                    struct{} `cbor:",toarray"`
+		SpenderProof         []byte   // input to satisfy the spender predicate of the allowance
+		TokenTypeOwnerProofs [][]byte // inputs to satisfy the token type owner predicates
+	}
+
+	BatchMintNonFungibleTokenAttributes struct {
+		_      struct{}                     `cbor:",toarray"`
+		TypeID types.UnitID                 // the type of the new tokens
+		Tokens []*BatchMintNonFungibleToken // the new tokens, IDs of the tokens are derived from the transaction and the index of the token in the batch
+		Nonce  uint64                       // optional nonce
+	}
+
+	BatchMintNonFungibleToken struct {
+		_                   struct{} `cbor:",toarray"`
+		Name                string   // the name of the new token
+		URI                 string   // the optional URI of an external resource associated with the new token
+		Data                []byte   // the optional data associated with the new token
+		OwnerPredicate      []byte   // the initial owner predicate of the new token
+		DataUpdatePredicate []byte   // the data update predicate of the new token
+	}
+
+	BatchMintNonFungibleTokenAuthProof struct {
+		_                 struct{} `cbor:",toarray"`
+		TokenMintingProof []byte   // input to satisfy the token minting predicate of the type
+	}
+)
+
+func (a *DefineFungibleTokenAttributes) UnmarshalCBOR(data []byte) error {
+	type attributes DefineFungibleTokenAttributes
+	return cborutil.UnmarshalOptionalFields(data, 10, (*attributes)(a))
+}
+
+func (a *DefineNonFungibleTokenAttributes) UnmarshalCBOR(data []byte) error {
+	type attributes DefineNonFungibleTokenAttributes
+	return cborutil.UnmarshalOptionalFields(data, 10, (*attributes)(a))
+}
+
+// toSDK returns the attributes as defined by the SDK (without the extension fields).
+func (a *DefineFungibleTokenAttributes) toSDK() *tokens.DefineFungibleTokenAttributes {
+	return &tokens.DefineFungibleTokenAttributes{
+		Symbol:                   a.Symbol,
+		Name:                     a.Name,
+		Icon:                     a.Icon,
+		ParentTypeID:             a.ParentTypeID,
+		DecimalPlaces:            a.DecimalPlaces,
+		SubTypeCreationPredicate: a.SubTypeCreationPredicate,
+		TokenMintingPredicate:    a.TokenMintingPredicate,
+		TokenTypeOwnerPredicate:  a.TokenTypeOwnerPredicate,
+	}
+}
+
+// toSDK returns the attributes as defined by the SDK (without the extension fields).
+func (a *DefineNonFungibleTokenAttributes) toSDK() *tokens.DefineNonFungibleTokenAttributes {
+	return &tokens.DefineNonFungibleTokenAttributes{
+		Symbol:                   a.Symbol,
+		Name:                     a.Name,
+		Icon:                     a.Icon,
+		ParentTypeID:             a.ParentTypeID,
+		SubTypeCreationPredicate: a.SubTypeCreationPredicate,
+		TokenMintingPredicate:    a.TokenMintingPredicate,
+		TokenTypeOwnerPredicate:  a.TokenTypeOwnerPredicate,
+		DataUpdatePredicate:      a.DataUpdatePredicate,
+	}
+}
@@ -7,8 +7,13 @@ import (
 	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
 	"github.com/alphabill-org/alphabill-go-base/types"
 	"github.com/alphabill-org/alphabill/predicates/wasm/wvm/encoder"
+	tokenstx "github.com/alphabill-org/alphabill/txsystem/tokens"
 )
 
+func init() {
+	encoder.Register(RegisterTxAttributeEncoders, RegisterUnitDataEncoders)
+}
+
 func RegisterTxAttributeEncoders(reg func(id encoder.AttrEncID, enc encoder.TxAttributesEncoder) error) error {
 	key := func(attrID uint16) encoder.AttrEncID {
 		return encoder.AttrEncID{
@@ -21,19 +26,31 @@ func RegisterTxAttributeEncoders(reg func(id encoder.AttrEncID, enc encoder.TxAt
 		reg(key(tokens.TransactionTypeMintNFT), txaMintNonFungibleTokenAttributes),
 		reg(key(tokens.TransactionTypeTransferNFT), txaTransferNonFungibleTokenAttributes),
 		reg(key(tokens.TransactionTypeUpdateNFT), txaUpdateNonFungibleTokenAttributes),
+		reg(key(tokenstx.TransactionTypeBurnNFT), txaBurnNonFungibleTokenAttributes),
+		reg(key(tokenstx.TransactionTypeBatchMintNFT), txaBatchMintNonFungibleTokenAttributes),
+		reg(key(tokenstx.TransactionTypeFreezeToken), txaFreezeTokenAttributes),
+		reg(key(tokenstx.TransactionTypeUnfreezeToken), txaUnfreezeTokenAttributes),
+		reg(key(tokenstx.TransactionTypePauseTokenType), txaPauseTokenTypeAttributes),
+		reg(key(tokenstx.TransactionTypeUnpauseTokenType), txaUnpauseTokenTypeAttributes),
 		reg(key(tokens.TransactionTypeDefineFT), txaDefineFungibleTokenAttributes),
 		reg(key(tokens.TransactionTypeMintFT), txaMintFungibleTokenAttributes),
 		reg(key(tokens.TransactionTypeTransferFT), txaTransferFungibleTokenAttributes),
 		reg(key(tokens.TransactionTypeSplitFT), txaSplitFungibleTokenAttributes),
 		reg(key(tokens.TransactionTypeBurnFT), txaBurnFungibleTokenAttributes),
 		reg(key(tokens.TransactionTypeJoinFT), txaJoinFungibleTokenAttributes),
+		reg(key(tokenstx.TransactionTypeApproveFT), txaApproveFungibleTokenAttributes),
+		reg(key(tokenstx.TransactionTypeRevokeFT), txaRevokeFungibleTokenAttributes),
+		reg(key(tokenstx.TransactionTypeTransferFromFT), txaTransferFromFungibleTokenAttributes),
 		reg(key(tokens.TransactionTypeLockToken), txaLockTokenAttributes),
 		reg(key(tokens.TransactionTypeUnlockToken), txaUnlockTokenAttributes),
 	)
 }
 
 func txaCreateNonFungibleTokenTypeAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
-	attr := &tokens.DefineNonFungibleTokenAttributes{}
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &tokenstx.DefineNonFungibleTokenAttributes{}
 	if err := txo.UnmarshalAttributes(attr); err != nil {
 		return nil, fmt.Errorf("reading transaction attributes: %w", err)
 	}
@@ -47,6 +64,9 @@ func txaCreateNonFungibleTokenTypeAttributes(txo *types.TransactionOrder, ver ui
 }
 
 func txaMintNonFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
 	attr := &tokens.MintNonFungibleTokenAttributes{}
 	if err := txo.UnmarshalAttributes(attr); err != nil {
 		return nil, fmt.Errorf("reading transaction attributes: %w", err)
@@ -65,6 +85,9 @@ func txaMintNonFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32)
 }
 
 func txaTransferNonFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
 	attr := &tokens.TransferNonFungibleTokenAttributes{}
 	if err := txo.UnmarshalAttributes(attr); err != nil {
 		return nil, fmt.Errorf("reading transaction attributes: %w", err)
@@ -76,6 +99,9 @@ func txaTransferNonFungibleTokenAttributes(txo *types.TransactionOrder, ver uint
 }
 
 func txaUpdateNonFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
 	attr := &tokens.UpdateNonFungibleTokenAttributes{}
 	if err := txo.UnmarshalAttributes(attr); err != nil {
 		return nil, fmt.Errorf("reading transaction attributes: %w", err)
@@ -86,8 +112,40 @@ func txaUpdateNonFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32
 	return buf.Bytes()
 }
 
+func txaBurnNonFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &tokenstx.BurnNonFungibleTokenAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.TypeID)
+	buf.EncodeTagged(2, attr.Counter)
+	return buf.Bytes()
+}
+
+func txaBatchMintNonFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &tokenstx.BatchMintNonFungibleTokenAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.TypeID)
+	buf.EncodeTagged(2, uint32(len(attr.Tokens)))
+	buf.EncodeTagged(3, attr.Nonce)
+	return buf.Bytes()
+}
+
 func txaDefineFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
-	attr := &tokens.DefineFungibleTokenAttributes{}
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &tokenstx.DefineFungibleTokenAttributes{}
 	if err := txo.UnmarshalAttributes(attr); err != nil {
 		return nil, fmt.Errorf("reading transaction attributes: %w", err)
 	}
@@ -101,6 +159,9 @@ func txaDefineFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) (
 }
 
 func txaMintFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
 	attr := &tokens.MintFungibleTokenAttributes{}
 	if err := txo.UnmarshalAttributes(attr); err != nil {
 		return nil, fmt.Errorf("reading transaction attributes: %w", err)
@@ -113,6 +174,9 @@ func txaMintFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]
 }
 
 func txaTransferFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
 	attr := &tokens.TransferFungibleTokenAttributes{}
 	if err := txo.UnmarshalAttributes(attr); err != nil {
 		return nil, fmt.Errorf("reading transaction attributes: %w", err)
@@ -125,6 +189,9 @@ func txaTransferFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32)
 }
 
 func txaSplitFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
 	attr := &tokens.SplitFungibleTokenAttributes{}
 	if err := txo.UnmarshalAttributes(attr); err != nil {
 		return nil, fmt.Errorf("reading transaction attributes: %w", err)
@@ -137,6 +204,9 @@ func txaSplitFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([
 }
 
 func txaBurnFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
 	attr := &tokens.BurnFungibleTokenAttributes{}
 	if err := txo.UnmarshalAttributes(attr); err != nil {
 		return nil, fmt.Errorf("reading transaction attributes: %w", err)
@@ -150,17 +220,79 @@ func txaBurnFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]
 	return buf.Bytes()
 }
 
+/*
+txaJoinFungibleTokenAttributes encodes join attributes:
+  - ver 1: empty;
+  - ver 2: array of CBOR encoded burn transaction record proofs;
+*/
 func txaJoinFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 2); err != nil {
+		return nil, err
+	}
 	attr := &tokens.JoinFungibleTokenAttributes{}
 	if err := txo.UnmarshalAttributes(attr); err != nil {
 		return nil, fmt.Errorf("reading transaction attributes: %w", err)
 	}
 	buf := encoder.TVEnc{}
-	// register and then return handles of the txR and proofs?
+	if ver >= 2 {
+		proofs := make([]any, len(attr.BurnTokenProofs))
+		for i, p := range attr.BurnTokenProofs {
+			proofs[i] = buf.CBORBytes(p)
+		}
+		buf.EncodeTagged(1, proofs)
+	}
+	return buf.Bytes()
+}
+
+func txaApproveFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &tokenstx.ApproveFungibleTokenAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.TypeID)
+	buf.EncodeTagged(2, attr.Value)
+	buf.EncodeTagged(3, attr.Deadline)
+	buf.EncodeTagged(4, attr.Counter)
+	return buf.Bytes()
+}
+
+func txaRevokeFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &tokenstx.RevokeFungibleTokenAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.TypeID)
+	buf.EncodeTagged(2, attr.Counter)
+	return buf.Bytes()
+}
+
+func txaTransferFromFungibleTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &tokenstx.TransferFromFungibleTokenAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.TypeID)
+	buf.EncodeTagged(2, attr.Value)
+	buf.EncodeTagged(3, attr.Counter)
 	return buf.Bytes()
 }
 
 func txaLockTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
 	attr := &tokens.LockTokenAttributes{}
 	if err := txo.UnmarshalAttributes(attr); err != nil {
 		return nil, fmt.Errorf("reading transaction attributes: %w", err)
@@ -172,6 +304,9 @@ func txaLockTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, er
 }
 
 func txaUnlockTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
 	attr := &tokens.UnlockTokenAttributes{}
 	if err := txo.UnmarshalAttributes(attr); err != nil {
 		return nil, fmt.Errorf("reading transaction attributes: %w", err)
@@ -180,3 +315,57 @@ func txaUnlockTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte,
 	buf.EncodeTagged(1, attr.Counter)
 	return buf.Bytes()
 }
+
+func txaFreezeTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &tokenstx.FreezeTokenAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.TypeID)
+	buf.EncodeTagged(2, attr.Counter)
+	return buf.Bytes()
+}
+
+func txaUnfreezeTokenAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &tokenstx.UnfreezeTokenAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.TypeID)
+	buf.EncodeTagged(2, attr.Counter)
+	return buf.Bytes()
+}
+
+func txaPauseTokenTypeAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &tokenstx.PauseTokenTypeAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.Counter)
+	return buf.Bytes()
+}
+
+func txaUnpauseTokenTypeAttributes(txo *types.TransactionOrder, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
+	attr := &tokenstx.UnpauseTokenTypeAttributes{}
+	if err := txo.UnmarshalAttributes(attr); err != nil {
+		return nil, fmt.Errorf("reading transaction attributes: %w", err)
+	}
+	buf := encoder.TVEnc{}
+	buf.EncodeTagged(1, attr.Counter)
+	return buf.Bytes()
+}
@@ -0,0 +1,54 @@
+package tokenenc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
+	"github.com/alphabill-org/alphabill-go-base/types"
+
+	tokenstx "github.com/alphabill-org/alphabill/txsystem/tokens"
+)
+
+func Test_txaJoinFungibleTokenAttributes(t *testing.T) {
+	txo := &types.TransactionOrder{Payload: types.Payload{}}
+	require.NoError(t, txo.SetAttributes(tokens.JoinFungibleTokenAttributes{BurnTokenProofs: []*types.TxRecordProof{{}}}))
+
+	// ver 1 is empty
+	b, err := txaJoinFungibleTokenAttributes(txo, 1)
+	require.NoError(t, err)
+	require.Empty(t, b)
+
+	// ver 2 adds CBOR encoded burn proofs
+	b, err = txaJoinFungibleTokenAttributes(txo, 2)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x1, 0x6, 0x1, 0x0, 0x0, 0x0, 0x1, 0x3, 0x0, 0x0, 0x0, 0x82, 0xf6, 0xf6}, b)
+
+	b, err = txaJoinFungibleTokenAttributes(txo, 3)
+	require.EqualError(t, err, `unsupported encoding version 3, latest supported version is 2`)
+	require.Nil(t, b)
+}
+
+func Test_unsupportedVersion(t *testing.T) {
+	txo := &types.TransactionOrder{Payload: types.Payload{}}
+	require.NoError(t, txo.SetAttributes(tokens.UnlockTokenAttributes{Counter: 1}))
+	_, err := txaUnlockTokenAttributes(txo, 2)
+	require.EqualError(t, err, `unsupported encoding version 2, latest supported version is 1`)
+
+	_, err = udeFungibleTokenData(&tokens.FungibleTokenData{}, 2)
+	require.EqualError(t, err, `unsupported encoding version 2, latest supported version is 1`)
+}
+
+func Test_txaDefineFungibleTokenAttributes(t *testing.T) {
+	// attributes encoded using the SDK type (without max supply) must be supported
+	txo := &types.TransactionOrder{Payload: types.Payload{}}
+	require.NoError(t, txo.SetAttributes(tokens.DefineFungibleTokenAttributes{Symbol: "AB", Name: "test"}))
+	b, err := txaDefineFungibleTokenAttributes(txo, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, txo.SetAttributes(tokenstx.DefineFungibleTokenAttributes{Symbol: "AB", Name: "test", MaxSupply: 100}))
+	b2, err := txaDefineFungibleTokenAttributes(txo, 1)
+	require.NoError(t, err)
+	require.Equal(t, b, b2)
+}
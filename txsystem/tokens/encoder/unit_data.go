@@ -18,6 +18,9 @@ func RegisterUnitDataEncoders(reg func(ud any, enc encoder.UnitDataEncoder) erro
 }
 
 func udeNonFungibleTokenData(data types.UnitData, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
 	value := data.(*tokens.NonFungibleTokenData)
 	buf := encoder.TVEnc{}
 	buf.EncodeTagged(1, value.TypeID)
@@ -37,6 +40,9 @@ func udeNonFungibleTokenData(data types.UnitData, ver uint32) ([]byte, error) {
 }
 
 func udeNonFungibleTokenTypeData(data types.UnitData, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
 	value := data.(*tokens.NonFungibleTokenTypeData)
 	buf := encoder.TVEnc{}
 	if len(value.ParentTypeID) != 0 {
@@ -48,6 +54,9 @@ func udeNonFungibleTokenTypeData(data types.UnitData, ver uint32) ([]byte, error
 }
 
 func udeFungibleTokenTypeData(data types.UnitData, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
 	value := data.(*tokens.FungibleTokenTypeData)
 	buf := encoder.TVEnc{}
 	if len(value.ParentTypeID) != 0 {
@@ -60,6 +69,9 @@ func udeFungibleTokenTypeData(data types.UnitData, ver uint32) ([]byte, error) {
 }
 
 func udeFungibleTokenData(data types.UnitData, ver uint32) ([]byte, error) {
+	if err := encoder.CheckVersion(ver, 1); err != nil {
+		return nil, err
+	}
 	value := data.(*tokens.FungibleTokenData)
 	buf := encoder.TVEnc{}
 	buf.EncodeTagged(1, value.TokenType)
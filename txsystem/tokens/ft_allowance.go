@@ -0,0 +1,313 @@
+package tokens
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+
+	abhash "github.com/alphabill-org/alphabill-go-base/hash"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
+)
+
+var _ types.UnitData = (*TokenAllowanceData)(nil)
+
+/*
+TokenAllowanceData is the unit data of the fungible token allowance unit. The allowance
+lets the holder of the spender predicate transfer up to Value of the token before the
+round Deadline without the owner key. The allowance is bound to the state of the token
+it was approved for (the token counter), when the token is modified by any other
+transaction (ie transferred or split by the owner) the allowance can't be used anymore.
+*/
+type TokenAllowanceData struct {
+	_                struct{} `cbor:",toarray"`
+	TokenCounter     uint64   `json:"tokenCounter,string"` // the counter of the token the allowance is valid for
+	SpenderPredicate []byte   `json:"spenderPredicate"`    // the predicate clause that the spender must satisfy
+	Value            uint64   `json:"value,string"`        // the remaining value the spender is allowed to transfer
+	Deadline         uint64   `json:"deadline,string"`     // the round number before which the allowance can be used
+	Counter          uint64   `json:"counter,string"`      // the counter of the transactions which have modified the allowance
+}
+
+func (d *TokenAllowanceData) Write(hasher hash.Hash) error {
+	res, err := types.Cbor.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("token allowance data encode error: %w", err)
+	}
+	_, err = hasher.Write(res)
+	return err
+}
+
+func (d *TokenAllowanceData) SummaryValueInput() uint64 {
+	return zeroSummaryValue
+}
+
+func (d *TokenAllowanceData) Copy() types.UnitData {
+	return &TokenAllowanceData{
+		TokenCounter:     d.TokenCounter,
+		SpenderPredicate: bytes.Clone(d.SpenderPredicate),
+		Value:            d.Value,
+		Deadline:         d.Deadline,
+		Counter:          d.Counter,
+	}
+}
+
+func (d *TokenAllowanceData) Owner() []byte {
+	return nil
+}
+
+// NewTokenAllowanceID returns ID of the allowance unit of the fungible token "tokenID".
+func NewTokenAllowanceID(tokenID types.UnitID) types.UnitID {
+	return types.NewUnitID(tokens.UnitIDLength, nil, abhash.Sum256(tokenID), TokenAllowanceUnitType)
+}
+
+func (m *FungibleTokensModule) executeApproveFT(tx *types.TransactionOrder, attr *ApproveFungibleTokenAttributes, _ *AllowanceAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
+	tokenData, err := getFungibleTokenData(tx.UnitID, m.state)
+	if err != nil {
+		return nil, err
+	}
+	allowanceID := NewTokenAllowanceID(tx.UnitID)
+	allowance := &TokenAllowanceData{
+		TokenCounter:     tokenData.Counter,
+		SpenderPredicate: attr.SpenderPredicate,
+		Value:            attr.Value,
+		Deadline:         attr.Deadline,
+		Counter:          attr.Counter + 1,
+	}
+	_, err = m.state.GetUnit(allowanceID, false)
+	switch {
+	case errors.Is(err, avl.ErrNotFound):
+		err = m.state.Apply(state.AddUnit(allowanceID, allowance))
+	case err == nil:
+		err = m.state.Apply(state.UpdateUnitData(allowanceID, func(types.UnitData) (types.UnitData, error) {
+			return allowance, nil
+		}))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("approving allowance: %w", err)
+	}
+	return &types.ServerMetadata{TargetUnits: []types.UnitID{allowanceID}, SuccessIndicator: types.TxStatusSuccessful}, nil
+}
+
+func (m *FungibleTokensModule) validateApproveFT(tx *types.TransactionOrder, attr *ApproveFungibleTokenAttributes, authProof *AllowanceAuthProof, exeCtx txtypes.ExecutionContext) error {
+	tokenData, err := getFungibleTokenData(tx.UnitID, m.state)
+	if err != nil {
+		return err
+	}
+	if tokenData.Locked != 0 {
+		return errors.New("token is locked")
+	}
+	if !bytes.Equal(attr.TypeID, tokenData.TokenType) {
+		return fmt.Errorf("invalid type identifier: expected '%s', got '%s'", tokenData.TokenType, attr.TypeID)
+	}
+	if len(attr.SpenderPredicate) == 0 {
+		return errors.New("spender predicate is empty")
+	}
+	if attr.Value == 0 {
+		return errors.New("allowance value must be greater than zero")
+	}
+	if attr.Deadline <= exeCtx.CurrentRound() {
+		return fmt.Errorf("allowance deadline must be in the future: deadline %d, current round %d", attr.Deadline, exeCtx.CurrentRound())
+	}
+	allowance, err := m.getAllowance(tx.UnitID)
+	if err != nil {
+		return err
+	}
+	var counter uint64
+	if allowance != nil {
+		counter = allowance.Counter
+	}
+	if attr.Counter != counter {
+		return fmt.Errorf("invalid counter: expected %d, got %d", counter, attr.Counter)
+	}
+	if err = m.execPredicate(tokenData.OwnerPredicate, authProof.OwnerProof, tx.AuthProofSigBytes, exeCtx); err != nil {
+		return fmt.Errorf("evaluating owner predicate: %w", err)
+	}
+	return nil
+}
+
+func (m *FungibleTokensModule) executeRevokeFT(tx *types.TransactionOrder, _ *RevokeFungibleTokenAttributes, _ *AllowanceAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
+	// the unit is kept (instead of deleting it) so that the counter keeps increasing
+	allowanceID := NewTokenAllowanceID(tx.UnitID)
+	if err := m.state.Apply(state.UpdateUnitData(allowanceID, func(data types.UnitData) (types.UnitData, error) {
+		d, ok := data.(*TokenAllowanceData)
+		if !ok {
+			return nil, fmt.Errorf("unit %v does not contain token allowance data", allowanceID)
+		}
+		return &TokenAllowanceData{Counter: d.Counter + 1}, nil
+	})); err != nil {
+		return nil, fmt.Errorf("revoking allowance: %w", err)
+	}
+	return &types.ServerMetadata{TargetUnits: []types.UnitID{allowanceID}, SuccessIndicator: types.TxStatusSuccessful}, nil
+}
+
+func (m *FungibleTokensModule) validateRevokeFT(tx *types.TransactionOrder, attr *RevokeFungibleTokenAttributes, authProof *AllowanceAuthProof, exeCtx txtypes.ExecutionContext) error {
+	tokenData, err := getFungibleTokenData(tx.UnitID, m.state)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(attr.TypeID, tokenData.TokenType) {
+		return fmt.Errorf("invalid type identifier: expected '%s', got '%s'", tokenData.TokenType, attr.TypeID)
+	}
+	allowance, err := m.getAllowance(tx.UnitID)
+	if err != nil {
+		return err
+	}
+	if allowance == nil || allowance.SpenderPredicate == nil {
+		return errors.New("token does not have an allowance")
+	}
+	if attr.Counter != allowance.Counter {
+		return fmt.Errorf("invalid counter: expected %d, got %d", allowance.Counter, attr.Counter)
+	}
+	if err = m.execPredicate(tokenData.OwnerPredicate, authProof.OwnerProof, tx.AuthProofSigBytes, exeCtx); err != nil {
+		return fmt.Errorf("evaluating owner predicate: %w", err)
+	}
+	return nil
+}
+
+func (m *FungibleTokensModule) executeTransferFromFT(tx *types.TransactionOrder, attr *TransferFromFungibleTokenAttributes, _ *TransferFromFungibleTokenAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
+	unitID := tx.GetUnitID()
+	tokenData, err := getFungibleTokenData(unitID, m.state)
+	if err != nil {
+		return nil, err
+	}
+	allowanceID := NewTokenAllowanceID(unitID)
+	targetUnits := []types.UnitID{unitID}
+	var actions []state.Action
+
+	if attr.Value == tokenData.Value {
+		// transfer the whole token
+		actions = append(actions, state.UpdateUnitData(unitID,
+			func(data types.UnitData) (types.UnitData, error) {
+				d, ok := data.(*tokens.FungibleTokenData)
+				if !ok {
+					return nil, fmt.Errorf("unit %v does not contain fungible token data", unitID)
+				}
+				d.OwnerPredicate = attr.NewOwnerPredicate
+				d.Counter += 1
+				return d, nil
+			}))
+	} else {
+		// split the transferred value into a new token
+		unitPart, err := tokens.HashForNewTokenID(tx, m.hashAlgorithm)
+		if err != nil {
+			return nil, err
+		}
+		newTokenID := tokens.NewFungibleTokenID(unitID, unitPart)
+		targetUnits = append(targetUnits, newTokenID)
+		actions = append(actions,
+			state.AddUnit(newTokenID, &tokens.FungibleTokenData{
+				TokenType:      tokenData.TokenType,
+				Value:          attr.Value,
+				OwnerPredicate: attr.NewOwnerPredicate,
+			}),
+			state.UpdateUnitData(unitID,
+				func(data types.UnitData) (types.UnitData, error) {
+					d, ok := data.(*tokens.FungibleTokenData)
+					if !ok {
+						return nil, fmt.Errorf("unit %v does not contain fungible token data", unitID)
+					}
+					d.Value -= attr.Value
+					d.Counter += 1
+					return d, nil
+				}))
+	}
+	actions = append(actions, state.UpdateUnitData(allowanceID,
+		func(data types.UnitData) (types.UnitData, error) {
+			d, ok := data.(*TokenAllowanceData)
+			if !ok {
+				return nil, fmt.Errorf("unit %v does not contain token allowance data", allowanceID)
+			}
+			if attr.Value == tokenData.Value {
+				// the token has a new owner, the allowance is revoked
+				return &TokenAllowanceData{Counter: d.Counter + 1}, nil
+			}
+			// the remaining allowance is valid for the token modified by this transaction
+			d.TokenCounter = tokenData.Counter + 1
+			d.Value -= attr.Value
+			d.Counter += 1
+			return d, nil
+		}))
+	targetUnits = append(targetUnits, allowanceID)
+
+	if err = m.state.Apply(actions...); err != nil {
+		return nil, err
+	}
+	return &types.ServerMetadata{TargetUnits: targetUnits, SuccessIndicator: types.TxStatusSuccessful}, nil
+}
+
+func (m *FungibleTokensModule) validateTransferFromFT(tx *types.TransactionOrder, attr *TransferFromFungibleTokenAttributes, authProof *TransferFromFungibleTokenAuthProof, exeCtx txtypes.ExecutionContext) error {
+	tokenData, err := getFungibleTokenData(tx.UnitID, m.state)
+	if err != nil {
+		return err
+	}
+	if tokenData.Locked != 0 {
+		return errors.New("token is locked")
+	}
+	if err = checkTokenAdminStatus(m.state, tx.UnitID, tokenData.TokenType); err != nil {
+		return err
+	}
+	if tokenData.Counter != attr.Counter {
+		return fmt.Errorf("invalid counter: expected %d, got %d", tokenData.Counter, attr.Counter)
+	}
+	if !bytes.Equal(attr.TypeID, tokenData.TokenType) {
+		return fmt.Errorf("invalid type identifier: expected '%s', got '%s'", tokenData.TokenType, attr.TypeID)
+	}
+
+	allowance, err := m.getAllowance(tx.UnitID)
+	if err != nil {
+		return err
+	}
+	if allowance == nil || allowance.SpenderPredicate == nil {
+		return errors.New("token does not have an allowance")
+	}
+	if allowance.TokenCounter != tokenData.Counter {
+		return fmt.Errorf("allowance was approved for a different state of the token: token counter %d, allowance token counter %d", tokenData.Counter, allowance.TokenCounter)
+	}
+	if exeCtx.CurrentRound() >= allowance.Deadline {
+		return fmt.Errorf("allowance has expired: deadline %d, current round %d", allowance.Deadline, exeCtx.CurrentRound())
+	}
+	if attr.Value == 0 {
+		return errors.New("transfer value must be greater than zero")
+	}
+	if attr.Value > allowance.Value {
+		return fmt.Errorf("transfer value exceeds the allowance: value %d, allowance %d", attr.Value, allowance.Value)
+	}
+	if attr.Value > tokenData.Value {
+		return fmt.Errorf("transfer value exceeds the value of the token: value %d, token value %d", attr.Value, tokenData.Value)
+	}
+
+	if err = m.execPredicate(allowance.SpenderPredicate, authProof.SpenderProof, tx.AuthProofSigBytes, exeCtx); err != nil {
+		return fmt.Errorf("evaluating spender predicate: %w", err)
+	}
+	err = runChainedPredicates[*tokens.FungibleTokenTypeData](
+		exeCtx,
+		tx.AuthProofSigBytes,
+		tokenData.TokenType,
+		authProof.TokenTypeOwnerProofs,
+		m.execPredicate,
+		func(d *tokens.FungibleTokenTypeData) (types.UnitID, []byte) {
+			return d.ParentTypeID, d.TokenTypeOwnerPredicate
+		},
+		m.state.GetUnit,
+	)
+	if err != nil {
+		return fmt.Errorf("token type owner predicate: %w", err)
+	}
+	return nil
+}
+
+// getAllowance returns the allowance of the token "tokenID", nil when the token has never had an allowance.
+func (m *FungibleTokensModule) getAllowance(tokenID types.UnitID) (*TokenAllowanceData, error) {
+	allowance, err := getUnitData[*TokenAllowanceData](m.state.GetUnit, NewTokenAllowanceID(tokenID))
+	if err != nil {
+		if errors.Is(err, avl.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading token allowance: %w", err)
+	}
+	return allowance, nil
+}
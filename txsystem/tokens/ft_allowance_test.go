@@ -0,0 +1,224 @@
+package tokens
+
+import (
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/alphabill-org/alphabill/internal/testutils"
+	testctx "github.com/alphabill-org/alphabill/txsystem/testutils/exec_context"
+	testtransaction "github.com/alphabill-org/alphabill/txsystem/testutils/transaction"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
+)
+
+func TestApproveFungibleToken_NotOk(t *testing.T) {
+	validAttr := func() *ApproveFungibleTokenAttributes {
+		return &ApproveFungibleTokenAttributes{
+			TypeID:           existingTokenTypeID,
+			SpenderPredicate: templates.AlwaysTrueBytes(),
+			Value:            100,
+			Deadline:         20,
+		}
+	}
+	tests := []struct {
+		name       string
+		tokenID    types.UnitID
+		attr       func(a *ApproveFungibleTokenAttributes)
+		wantErrStr string
+	}{
+		{
+			name:       "token does not exist",
+			tokenID:    nonExistingTokenID,
+			wantErrStr: "does not exist",
+		},
+		{
+			name:       "token is locked",
+			tokenID:    existingLockedTokenID,
+			wantErrStr: "token is locked",
+		},
+		{
+			name:       "invalid type",
+			tokenID:    existingTokenID,
+			attr:       func(a *ApproveFungibleTokenAttributes) { a.TypeID = existingTokenTypeID2 },
+			wantErrStr: "invalid type identifier",
+		},
+		{
+			name:       "empty spender predicate",
+			tokenID:    existingTokenID,
+			attr:       func(a *ApproveFungibleTokenAttributes) { a.SpenderPredicate = nil },
+			wantErrStr: "spender predicate is empty",
+		},
+		{
+			name:       "zero value",
+			tokenID:    existingTokenID,
+			attr:       func(a *ApproveFungibleTokenAttributes) { a.Value = 0 },
+			wantErrStr: "allowance value must be greater than zero",
+		},
+		{
+			name:       "deadline in the past",
+			tokenID:    existingTokenID,
+			attr:       func(a *ApproveFungibleTokenAttributes) { a.Deadline = 10 },
+			wantErrStr: "allowance deadline must be in the future: deadline 10, current round 10",
+		},
+		{
+			name:       "invalid counter",
+			tokenID:    existingTokenID,
+			attr:       func(a *ApproveFungibleTokenAttributes) { a.Counter = 1 },
+			wantErrStr: "invalid counter: expected 0, got 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewFungibleTokensModule(defaultOpts(t))
+			require.NoError(t, err)
+			attr := validAttr()
+			if tt.attr != nil {
+				tt.attr(attr)
+			}
+			tx := createTxOrder(t, tt.tokenID, TransactionTypeApproveFT, attr)
+			err = m.validateApproveFT(tx, attr, &AllowanceAuthProof{}, testctx.NewMockExecutionContext(testctx.WithCurrentRound(10)))
+			require.ErrorContains(t, err, tt.wantErrStr)
+		})
+	}
+}
+
+func TestTransferFromFungibleToken(t *testing.T) {
+	opts := defaultOpts(t)
+	m, err := NewFungibleTokensModule(opts)
+	require.NoError(t, err)
+	txExecutors := make(txtypes.TxExecutors)
+	require.NoError(t, txExecutors.Add(m.TxHandlers()))
+	exeCtx := testctx.NewMockExecutionContext(testctx.WithCurrentRound(10))
+	allowanceID := NewTokenAllowanceID(existingTokenID)
+	newOwner := templates.NewP2pkh256BytesFromKeyHash(test.RandomBytes(32))
+
+	transferFrom := func(value, counter uint64) *types.TransactionOrder {
+		return createTxOrder(t, existingTokenID, TransactionTypeTransferFromFT, &TransferFromFungibleTokenAttributes{
+			TypeID:            existingTokenTypeID,
+			NewOwnerPredicate: newOwner,
+			Value:             value,
+			Counter:           counter,
+		}, testtransaction.WithAuthProof(&TransferFromFungibleTokenAuthProof{
+			TokenTypeOwnerProofs: [][]byte{templates.EmptyArgument()},
+		}))
+	}
+
+	// no allowance
+	_, err = txExecutors.ValidateAndExecute(transferFrom(100, 0), exeCtx)
+	require.ErrorContains(t, err, "token does not have an allowance")
+
+	// approve
+	sm, err := txExecutors.ValidateAndExecute(createTxOrder(t, existingTokenID, TransactionTypeApproveFT, &ApproveFungibleTokenAttributes{
+		TypeID:           existingTokenTypeID,
+		SpenderPredicate: templates.AlwaysTrueBytes(),
+		Value:            300,
+		Deadline:         20,
+	}), exeCtx)
+	require.NoError(t, err)
+	require.Equal(t, []types.UnitID{allowanceID}, sm.TargetUnits)
+
+	// transfer part of the token
+	tx := transferFrom(100, 0)
+	sm, err = txExecutors.ValidateAndExecute(tx, exeCtx)
+	require.NoError(t, err)
+	unitPart, err := tokens.HashForNewTokenID(tx, opts.hashAlgorithm)
+	require.NoError(t, err)
+	newTokenID := tokens.NewFungibleTokenID(existingTokenID, unitPart)
+	require.Equal(t, []types.UnitID{existingTokenID, newTokenID, allowanceID}, sm.TargetUnits)
+
+	newToken, err := getFungibleTokenData(newTokenID, opts.state)
+	require.NoError(t, err)
+	require.EqualValues(t, newOwner, newToken.OwnerPredicate)
+	require.EqualValues(t, 100, newToken.Value)
+	token, err := getFungibleTokenData(existingTokenID, opts.state)
+	require.NoError(t, err)
+	require.EqualValues(t, existingTokenValue-100, token.Value)
+	require.EqualValues(t, 1, token.Counter)
+	allowance, err := m.getAllowance(existingTokenID)
+	require.NoError(t, err)
+	require.EqualValues(t, 200, allowance.Value)
+	require.EqualValues(t, 2, allowance.Counter)
+	require.EqualValues(t, 1, allowance.TokenCounter)
+
+	// allowance exceeded
+	_, err = txExecutors.ValidateAndExecute(transferFrom(201, 1), exeCtx)
+	require.ErrorContains(t, err, "transfer value exceeds the allowance: value 201, allowance 200")
+
+	// allowance expired
+	_, err = txExecutors.ValidateAndExecute(transferFrom(100, 1), testctx.NewMockExecutionContext(testctx.WithCurrentRound(20)))
+	require.ErrorContains(t, err, "allowance has expired: deadline 20, current round 20")
+
+	// the allowance can't be used after the owner has modified the token
+	_, err = txExecutors.ValidateAndExecute(createTxOrder(t, existingTokenID, tokens.TransactionTypeSplitFT, &tokens.SplitFungibleTokenAttributes{
+		TypeID:            existingTokenTypeID,
+		TargetValue:       10,
+		NewOwnerPredicate: templates.AlwaysTrueBytes(),
+		Counter:           1,
+	}, testtransaction.WithAuthProof(&tokens.SplitFungibleTokenAuthProof{TokenTypeOwnerProofs: [][]byte{templates.EmptyArgument()}})), exeCtx)
+	require.NoError(t, err)
+	_, err = txExecutors.ValidateAndExecute(transferFrom(100, 2), exeCtx)
+	require.ErrorContains(t, err, "allowance was approved for a different state of the token: token counter 2, allowance token counter 1")
+
+	// revoke
+	_, err = txExecutors.ValidateAndExecute(createTxOrder(t, existingTokenID, TransactionTypeRevokeFT, &RevokeFungibleTokenAttributes{
+		TypeID:  existingTokenTypeID,
+		Counter: 2,
+	}), exeCtx)
+	require.NoError(t, err)
+	allowance, err = m.getAllowance(existingTokenID)
+	require.NoError(t, err)
+	require.Equal(t, &TokenAllowanceData{Counter: 3}, allowance)
+	_, err = txExecutors.ValidateAndExecute(transferFrom(100, 2), exeCtx)
+	require.ErrorContains(t, err, "token does not have an allowance")
+
+	// approve again and transfer the whole token
+	_, err = txExecutors.ValidateAndExecute(createTxOrder(t, existingTokenID, TransactionTypeApproveFT, &ApproveFungibleTokenAttributes{
+		TypeID:           existingTokenTypeID,
+		SpenderPredicate: templates.AlwaysTrueBytes(),
+		Value:            existingTokenValue,
+		Deadline:         20,
+		Counter:          3,
+	}), exeCtx)
+	require.NoError(t, err)
+	sm, err = txExecutors.ValidateAndExecute(transferFrom(existingTokenValue-110, 2), exeCtx)
+	require.NoError(t, err)
+	require.Equal(t, []types.UnitID{existingTokenID, allowanceID}, sm.TargetUnits)
+	token, err = getFungibleTokenData(existingTokenID, opts.state)
+	require.NoError(t, err)
+	require.EqualValues(t, newOwner, token.OwnerPredicate)
+	require.EqualValues(t, existingTokenValue-110, token.Value)
+
+	// the allowance is revoked when the token changes owner
+	allowance, err = m.getAllowance(existingTokenID)
+	require.NoError(t, err)
+	require.Equal(t, &TokenAllowanceData{Counter: 5}, allowance)
+	_, err = txExecutors.ValidateAndExecute(transferFrom(100, 3), exeCtx)
+	require.ErrorContains(t, err, "token does not have an allowance")
+}
+
+func TestTransferFromFungibleToken_SpenderPredicate(t *testing.T) {
+	opts := defaultOpts(t)
+	m, err := NewFungibleTokensModule(opts)
+	require.NoError(t, err)
+	txExecutors := make(txtypes.TxExecutors)
+	require.NoError(t, txExecutors.Add(m.TxHandlers()))
+	exeCtx := testctx.NewMockExecutionContext(testctx.WithCurrentRound(10))
+
+	_, err = txExecutors.ValidateAndExecute(createTxOrder(t, existingTokenID, TransactionTypeApproveFT, &ApproveFungibleTokenAttributes{
+		TypeID:           existingTokenTypeID,
+		SpenderPredicate: templates.AlwaysFalseBytes(),
+		Value:            100,
+		Deadline:         20,
+	}), exeCtx)
+	require.NoError(t, err)
+
+	_, err = txExecutors.ValidateAndExecute(createTxOrder(t, existingTokenID, TransactionTypeTransferFromFT, &TransferFromFungibleTokenAttributes{
+		TypeID:            existingTokenTypeID,
+		NewOwnerPredicate: templates.AlwaysTrueBytes(),
+		Value:             100,
+	}), exeCtx)
+	require.ErrorContains(t, err, `evaluating spender predicate: predicate evaluated to "false"`)
+}
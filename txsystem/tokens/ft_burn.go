@@ -12,7 +12,7 @@ import (
 	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
 )
 
-func (m *FungibleTokensModule) executeBurnFT(tx *types.TransactionOrder, _ *tokens.BurnFungibleTokenAttributes, _ *tokens.BurnFungibleTokenAuthProof, exeCtx txtypes.ExecutionContext) (*types.ServerMetadata, error) {
+func (m *FungibleTokensModule) executeBurnFT(tx *types.TransactionOrder, attr *tokens.BurnFungibleTokenAttributes, _ *tokens.BurnFungibleTokenAuthProof, exeCtx txtypes.ExecutionContext) (*types.ServerMetadata, error) {
 	unitID := tx.GetUnitID()
 
 	// 1. N[T.ι].D.v ← 0
@@ -30,10 +30,21 @@ func (m *FungibleTokensModule) executeBurnFT(tx *types.TransactionOrder, _ *toke
 			return ftData, nil
 		},
 	)
-	if err := m.state.Apply(updateUnitFn); err != nil {
+	targetUnits := []types.UnitID{unitID}
+	actions := []state.Action{updateUnitFn}
+	ext, err := getTokenTypeExtension(m.state, attr.TypeID)
+	if err != nil {
+		return nil, err
+	}
+	if ext != nil {
+		extID := NewTokenTypeExtensionID(attr.TypeID)
+		actions = append(actions, updateSupply(extID, 0, attr.Value, 0))
+		targetUnits = append(targetUnits, extID)
+	}
+	if err := m.state.Apply(actions...); err != nil {
 		return nil, fmt.Errorf("burnFToken: failed to update state: %w", err)
 	}
-	return &types.ServerMetadata{TargetUnits: []types.UnitID{unitID}, SuccessIndicator: types.TxStatusSuccessful}, nil
+	return &types.ServerMetadata{TargetUnits: targetUnits, SuccessIndicator: types.TxStatusSuccessful}, nil
 }
 
 func (m *FungibleTokensModule) validateBurnFT(tx *types.TransactionOrder, attr *tokens.BurnFungibleTokenAttributes, authProof *tokens.BurnFungibleTokenAuthProof, exeCtx txtypes.ExecutionContext) error {
@@ -44,6 +55,9 @@ func (m *FungibleTokensModule) validateBurnFT(tx *types.TransactionOrder, attr *
 	if tokenData.Locked != 0 {
 		return errors.New("token is locked")
 	}
+	if err = checkTokenAdminStatus(m.state, tx.UnitID, tokenData.TokenType); err != nil {
+		return err
+	}
 	if !bytes.Equal(tokenData.TokenType, attr.TypeID) {
 		return fmt.Errorf("type of token to burn does not matches the actual type of the token: expected %s, got %s", tokenData.TokenType, attr.TypeID)
 	}
@@ -11,19 +11,27 @@ import (
 	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
 )
 
-func (m *FungibleTokensModule) executeDefineFT(tx *types.TransactionOrder, attr *tokens.DefineFungibleTokenAttributes, _ *tokens.DefineFungibleTokenAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
+func (m *FungibleTokensModule) executeDefineFT(tx *types.TransactionOrder, attr *DefineFungibleTokenAttributes, _ *tokens.DefineFungibleTokenAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
 	unitID := tx.GetUnitID()
-
-	if err := m.state.Apply(
-		state.AddUnit(unitID, tokens.NewFungibleTokenTypeData(attr)),
-	); err != nil {
+	targetUnits := []types.UnitID{unitID}
+	actions := []state.Action{state.AddUnit(unitID, tokens.NewFungibleTokenTypeData(attr.toSDK()))}
+	if attr.MaxSupply > 0 || attr.AdminPredicate != nil {
+		extID := NewTokenTypeExtensionID(unitID)
+		actions = append(actions, state.AddUnit(extID, &TokenTypeExtensionData{
+			ParentTypeID:   attr.ParentTypeID,
+			MaxSupply:      attr.MaxSupply,
+			AdminPredicate: attr.AdminPredicate,
+		}))
+		targetUnits = append(targetUnits, extID)
+	}
+	if err := m.state.Apply(actions...); err != nil {
 		return nil, err
 	}
 
-	return &types.ServerMetadata{TargetUnits: []types.UnitID{unitID}, SuccessIndicator: types.TxStatusSuccessful}, nil
+	return &types.ServerMetadata{TargetUnits: targetUnits, SuccessIndicator: types.TxStatusSuccessful}, nil
 }
 
-func (m *FungibleTokensModule) validateDefineFT(tx *types.TransactionOrder, attr *tokens.DefineFungibleTokenAttributes, authProof *tokens.DefineFungibleTokenAuthProof, exeCtx txtypes.ExecutionContext) error {
+func (m *FungibleTokensModule) validateDefineFT(tx *types.TransactionOrder, attr *DefineFungibleTokenAttributes, authProof *tokens.DefineFungibleTokenAuthProof, exeCtx txtypes.ExecutionContext) error {
 	unitID := tx.GetUnitID()
 	if !unitID.HasType(tokens.FungibleTokenTypeUnitType) {
 		return fmt.Errorf(ErrStrInvalidUnitID)
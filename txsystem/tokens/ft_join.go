@@ -15,8 +15,24 @@ import (
 func (m *FungibleTokensModule) executeJoinFT(tx *types.TransactionOrder, _ *tokens.JoinFungibleTokenAttributes, _ *tokens.JoinFungibleTokenAuthProof, exeCtx txtypes.ExecutionContext) (*types.ServerMetadata, error) {
 	unitID := tx.GetUnitID()
 	sum := util.BytesToUint64(exeCtx.GetData())
+	tokenData, err := getFungibleTokenData(unitID, m.state)
+	if err != nil {
+		return nil, err
+	}
+	targetUnits := []types.UnitID{unitID}
+	var actions []state.Action
+	ext, err := getTokenTypeExtension(m.state, tokenData.TokenType)
+	if err != nil {
+		return nil, err
+	}
+	if ext != nil {
+		// value of the burned tokens returns to circulation
+		extID := NewTokenTypeExtensionID(tokenData.TokenType)
+		actions = append(actions, updateSupply(extID, 0, 0, sum-tokenData.Value))
+		targetUnits = append(targetUnits, extID)
+	}
 	// update state
-	if err := m.state.Apply(
+	if err := m.state.Apply(append([]state.Action{
 		state.UpdateUnitData(unitID,
 			func(data types.UnitData) (types.UnitData, error) {
 				tokenData, ok := data.(*tokens.FungibleTokenData)
@@ -28,11 +44,11 @@ func (m *FungibleTokensModule) executeJoinFT(tx *types.TransactionOrder, _ *toke
 				tokenData.Counter += 1
 				return tokenData, nil
 			},
-		),
+		)}, actions...)...,
 	); err != nil {
 		return nil, err
 	}
-	return &types.ServerMetadata{TargetUnits: []types.UnitID{unitID}, SuccessIndicator: types.TxStatusSuccessful}, nil
+	return &types.ServerMetadata{TargetUnits: targetUnits, SuccessIndicator: types.TxStatusSuccessful}, nil
 }
 
 func (m *FungibleTokensModule) validateJoinFT(tx *types.TransactionOrder, attr *tokens.JoinFungibleTokenAttributes, authProof *tokens.JoinFungibleTokenAuthProof, exeCtx txtypes.ExecutionContext) error {
@@ -40,6 +56,9 @@ func (m *FungibleTokensModule) validateJoinFT(tx *types.TransactionOrder, attr *
 	if err != nil {
 		return err
 	}
+	if err = checkTokenAdminStatus(m.state, tx.UnitID, tokenData.TokenType); err != nil {
+		return err
+	}
 	sum := tokenData.Value
 	for i, btx := range attr.BurnTokenProofs {
 		btxAttr := &tokens.BurnFungibleTokenAttributes{}
@@ -78,6 +97,15 @@ func (m *FungibleTokensModule) validateJoinFT(tx *types.TransactionOrder, attr *
 		}
 	}
 
+	// the burned value can't return to circulation more than once
+	ext, err := getTokenTypeExtension(m.state, tokenData.TokenType)
+	if err != nil {
+		return err
+	}
+	if ext != nil && sum-tokenData.Value > ext.Burned {
+		return fmt.Errorf("joined value exceeds the burned supply of the type: joined %d, burned %d", sum-tokenData.Value, ext.Burned)
+	}
+
 	if err = m.execPredicate(tokenData.Owner(), authProof.OwnerProof, tx.AuthProofSigBytes, exeCtx); err != nil {
 		return fmt.Errorf("evaluating owner predicate: %w", err)
 	}
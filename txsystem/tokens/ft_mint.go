@@ -6,6 +6,7 @@ import (
 
 	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
 	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill-go-base/util"
 	"github.com/alphabill-org/alphabill/state"
 	"github.com/alphabill-org/alphabill/tree/avl"
 	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
@@ -15,12 +16,21 @@ func (m *FungibleTokensModule) executeMintFT(tx *types.TransactionOrder, attr *t
 	tokenID := tx.GetUnitID()
 	typeID := attr.TypeID
 
-	if err := m.state.Apply(
-		state.AddUnit(tokenID, tokens.NewFungibleTokenData(typeID, attr.Value, attr.OwnerPredicate, tx.Timeout())),
-	); err != nil {
+	targetUnits := []types.UnitID{tokenID}
+	actions := []state.Action{state.AddUnit(tokenID, tokens.NewFungibleTokenData(typeID, attr.Value, attr.OwnerPredicate, tx.Timeout()))}
+	ext, err := getTokenTypeExtension(m.state, typeID)
+	if err != nil {
 		return nil, err
 	}
-	return &types.ServerMetadata{TargetUnits: []types.UnitID{tokenID}, SuccessIndicator: types.TxStatusSuccessful}, nil
+	if ext != nil {
+		extID := NewTokenTypeExtensionID(typeID)
+		actions = append(actions, updateSupply(extID, attr.Value, 0, 0))
+		targetUnits = append(targetUnits, extID)
+	}
+	if err := m.state.Apply(actions...); err != nil {
+		return nil, err
+	}
+	return &types.ServerMetadata{TargetUnits: targetUnits, SuccessIndicator: types.TxStatusSuccessful}, nil
 }
 
 func (m *FungibleTokensModule) validateMintFT(tx *types.TransactionOrder, attr *tokens.MintFungibleTokenAttributes, authProof *tokens.MintFungibleTokenAuthProof, exeCtx txtypes.ExecutionContext) error {
@@ -64,6 +74,17 @@ func (m *FungibleTokensModule) validateMintFT(tx *types.TransactionOrder, attr *
 		return errors.New("token must have value greater than zero")
 	}
 
+	// verify max supply of the type is not exceeded
+	ext, err := getTokenTypeExtension(m.state, tokenTypeID)
+	if err != nil {
+		return err
+	}
+	if ext != nil && ext.MaxSupply > 0 {
+		if supply, ok := util.SafeAdd(ext.CirculatingSupply(), attr.Value); !ok || supply > ext.MaxSupply {
+			return fmt.Errorf("minting %d tokens exceeds the max supply of the type: max supply %d, circulating supply %d", attr.Value, ext.MaxSupply, ext.CirculatingSupply())
+		}
+	}
+
 	// verify token id is correctly generated
 	unitPart, err := tokens.HashForNewTokenID(tx, m.hashAlgorithm)
 	if err != nil {
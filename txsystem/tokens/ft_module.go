@@ -30,11 +30,14 @@ func NewFungibleTokensModule(options *Options) (*FungibleTokensModule, error) {
 
 func (m *FungibleTokensModule) TxHandlers() map[uint16]txtypes.TxExecutor {
 	return map[uint16]txtypes.TxExecutor{
-		tokens.TransactionTypeDefineFT:   txtypes.NewTxHandler[tokens.DefineFungibleTokenAttributes, tokens.DefineFungibleTokenAuthProof](m.validateDefineFT, m.executeDefineFT),
+		tokens.TransactionTypeDefineFT:   txtypes.NewTxHandler[DefineFungibleTokenAttributes, tokens.DefineFungibleTokenAuthProof](m.validateDefineFT, m.executeDefineFT),
 		tokens.TransactionTypeMintFT:     txtypes.NewTxHandler[tokens.MintFungibleTokenAttributes, tokens.MintFungibleTokenAuthProof](m.validateMintFT, m.executeMintFT),
 		tokens.TransactionTypeTransferFT: txtypes.NewTxHandler[tokens.TransferFungibleTokenAttributes, tokens.TransferFungibleTokenAuthProof](m.validateTransferFT, m.executeTransferFT),
 		tokens.TransactionTypeSplitFT:    txtypes.NewTxHandler[tokens.SplitFungibleTokenAttributes, tokens.SplitFungibleTokenAuthProof](m.validateSplitFT, m.executeSplitFT),
 		tokens.TransactionTypeBurnFT:     txtypes.NewTxHandler[tokens.BurnFungibleTokenAttributes, tokens.BurnFungibleTokenAuthProof](m.validateBurnFT, m.executeBurnFT),
 		tokens.TransactionTypeJoinFT:     txtypes.NewTxHandler[tokens.JoinFungibleTokenAttributes, tokens.JoinFungibleTokenAuthProof](m.validateJoinFT, m.executeJoinFT),
+		TransactionTypeApproveFT:         txtypes.NewTxHandler[ApproveFungibleTokenAttributes, AllowanceAuthProof](m.validateApproveFT, m.executeApproveFT),
+		TransactionTypeRevokeFT:          txtypes.NewTxHandler[RevokeFungibleTokenAttributes, AllowanceAuthProof](m.validateRevokeFT, m.executeRevokeFT),
+		TransactionTypeTransferFromFT:    txtypes.NewTxHandler[TransferFromFungibleTokenAttributes, TransferFromFungibleTokenAuthProof](m.validateTransferFromFT, m.executeTransferFromFT),
 	}
 }
@@ -59,6 +59,9 @@ func (m *FungibleTokensModule) validateSplitFT(tx *types.TransactionOrder, attr
 	if tokenData.Locked != 0 {
 		return errors.New("token is locked")
 	}
+	if err = checkTokenAdminStatus(m.state, tx.UnitID, tokenData.TokenType); err != nil {
+		return err
+	}
 	if attr.TargetValue == 0 {
 		return errors.New("when splitting a token the value assigned to the new token must be greater than zero")
 	}
@@ -43,6 +43,9 @@ func (m *FungibleTokensModule) validateTransferFT(tx *types.TransactionOrder, at
 	if tokenData.Locked != 0 {
 		return fmt.Errorf("token is locked")
 	}
+	if err = checkTokenAdminStatus(m.state, tx.UnitID, tokenData.TokenType); err != nil {
+		return err
+	}
 	if tokenData.Value != attr.Value {
 		return fmt.Errorf("invalid token value: expected %v, got %v", tokenData.Value, attr.Value)
 	}
@@ -60,7 +60,7 @@ func TestCreateFungibleTokenType_NotOk(t *testing.T) {
 	tests := []struct {
 		name       string
 		tx         *types.TransactionOrder
-		attr       *tokens.DefineFungibleTokenAttributes
+		attr       *DefineFungibleTokenAttributes
 		authProof  *tokens.DefineFungibleTokenAuthProof
 		options    *Options
 		wantErrStr string
@@ -73,7 +73,7 @@ func TestCreateFungibleTokenType_NotOk(t *testing.T) {
 					UnitID: nil,
 				},
 			},
-			attr:       &tokens.DefineFungibleTokenAttributes{},
+			attr:       &DefineFungibleTokenAttributes{},
 			authProof:  &tokens.DefineFungibleTokenAuthProof{},
 			options:    defaultOpts(t),
 			wantErrStr: "invalid unit ID",
@@ -86,7 +86,7 @@ func TestCreateFungibleTokenType_NotOk(t *testing.T) {
 					UnitID: existingTokenID,
 				},
 			},
-			attr:       &tokens.DefineFungibleTokenAttributes{},
+			attr:       &DefineFungibleTokenAttributes{},
 			authProof:  &tokens.DefineFungibleTokenAuthProof{},
 			options:    defaultOpts(t),
 			wantErrStr: "invalid unit ID",
@@ -99,7 +99,7 @@ func TestCreateFungibleTokenType_NotOk(t *testing.T) {
 					UnitID: existingTokenTypeID,
 				},
 			},
-			attr:       &tokens.DefineFungibleTokenAttributes{ParentTypeID: existingTokenID},
+			attr:       &DefineFungibleTokenAttributes{ParentTypeID: existingTokenID},
 			authProof:  &tokens.DefineFungibleTokenAuthProof{},
 			options:    defaultOpts(t),
 			wantErrStr: "invalid parent type ID",
@@ -107,7 +107,7 @@ func TestCreateFungibleTokenType_NotOk(t *testing.T) {
 		{
 			name:       "symbol length exceeds the allowed maximum",
 			tx:         validTxOrder,
-			attr:       &tokens.DefineFungibleTokenAttributes{Symbol: invalidSymbol},
+			attr:       &DefineFungibleTokenAttributes{Symbol: invalidSymbol},
 			authProof:  &tokens.DefineFungibleTokenAuthProof{},
 			options:    defaultOpts(t),
 			wantErrStr: "symbol length exceeds the allowed maximum of 16 bytes",
@@ -115,7 +115,7 @@ func TestCreateFungibleTokenType_NotOk(t *testing.T) {
 		{
 			name:       "name length exceeds the allowed maximum",
 			tx:         validTxOrder,
-			attr:       &tokens.DefineFungibleTokenAttributes{Name: invalidName},
+			attr:       &DefineFungibleTokenAttributes{Name: invalidName},
 			authProof:  &tokens.DefineFungibleTokenAuthProof{},
 			options:    defaultOpts(t),
 			wantErrStr: "name length exceeds the allowed maximum of 256 bytes",
@@ -123,7 +123,7 @@ func TestCreateFungibleTokenType_NotOk(t *testing.T) {
 		{
 			name: "icon type length exceeds the allowed maximum",
 			tx:   validTxOrder,
-			attr: &tokens.DefineFungibleTokenAttributes{
+			attr: &DefineFungibleTokenAttributes{
 				Symbol: validSymbol,
 				Icon:   &tokens.Icon{Type: invalidIconType, Data: test.RandomBytes(maxIconDataLength)},
 			},
@@ -135,7 +135,7 @@ func TestCreateFungibleTokenType_NotOk(t *testing.T) {
 			name: "icon data length exceeds the allowed maximum",
 
 			tx: validTxOrder,
-			attr: &tokens.DefineFungibleTokenAttributes{
+			attr: &DefineFungibleTokenAttributes{
 				Symbol: validSymbol,
 				Icon:   &tokens.Icon{Type: validIconType, Data: test.RandomBytes(maxIconDataLength + 1)},
 			},
@@ -146,7 +146,7 @@ func TestCreateFungibleTokenType_NotOk(t *testing.T) {
 		{
 			name:       "decimal places > 8",
 			tx:         validTxOrder,
-			attr:       &tokens.DefineFungibleTokenAttributes{Symbol: validSymbol, DecimalPlaces: 9},
+			attr:       &DefineFungibleTokenAttributes{Symbol: validSymbol, DecimalPlaces: 9},
 			authProof:  &tokens.DefineFungibleTokenAuthProof{},
 			options:    defaultOpts(t),
 			wantErrStr: "invalid decimal places. maximum allowed value 8, got 9",
@@ -159,7 +159,7 @@ func TestCreateFungibleTokenType_NotOk(t *testing.T) {
 					UnitID: existingTokenTypeID,
 				},
 			},
-			attr:       &tokens.DefineFungibleTokenAttributes{Symbol: validSymbol, DecimalPlaces: 5},
+			attr:       &DefineFungibleTokenAttributes{Symbol: validSymbol, DecimalPlaces: 5},
 			authProof:  &tokens.DefineFungibleTokenAuthProof{},
 			options:    defaultOpts(t),
 			wantErrStr: fmt.Sprintf("unit %s exists", existingTokenTypeID),
@@ -167,7 +167,7 @@ func TestCreateFungibleTokenType_NotOk(t *testing.T) {
 		{
 			name:       "parent.decimals != tx.attributes.decimalPlaces",
 			tx:         validTxOrder,
-			attr:       &tokens.DefineFungibleTokenAttributes{Symbol: validSymbol, DecimalPlaces: 6, ParentTypeID: existingTokenTypeID},
+			attr:       &DefineFungibleTokenAttributes{Symbol: validSymbol, DecimalPlaces: 6, ParentTypeID: existingTokenTypeID},
 			authProof:  &tokens.DefineFungibleTokenAuthProof{},
 			options:    defaultOpts(t),
 			wantErrStr: "invalid decimal places. allowed 5, got 6",
@@ -175,7 +175,7 @@ func TestCreateFungibleTokenType_NotOk(t *testing.T) {
 		{
 			name:       "parent does not exist",
 			tx:         validTxOrder,
-			attr:       &tokens.DefineFungibleTokenAttributes{Symbol: validSymbol, DecimalPlaces: 6, ParentTypeID: unitID},
+			attr:       &DefineFungibleTokenAttributes{Symbol: validSymbol, DecimalPlaces: 6, ParentTypeID: unitID},
 			authProof:  &tokens.DefineFungibleTokenAuthProof{},
 			options:    defaultOpts(t),
 			wantErrStr: fmt.Sprintf("item %s does not exist", unitID),
@@ -1237,6 +1237,12 @@ func defaultAuthProof(transactionType uint16) any {
 		return &tokens.LockTokenAuthProof{}
 	case tokens.TransactionTypeUnlockToken:
 		return &tokens.UnlockTokenAuthProof{}
+	case TransactionTypeBurnNFT:
+		return &BurnNonFungibleTokenAuthProof{}
+	case TransactionTypeApproveFT, TransactionTypeRevokeFT:
+		return &AllowanceAuthProof{}
+	case TransactionTypeTransferFromFT:
+		return &TransferFromFungibleTokenAuthProof{}
 	default:
 		return nil
 	}
@@ -0,0 +1,119 @@
+package tokens
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill-go-base/util"
+	"github.com/alphabill-org/alphabill/predicates"
+	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
+)
+
+func (n *NonFungibleTokensModule) executeBatchMintNFT(tx *types.TransactionOrder, attr *BatchMintNonFungibleTokenAttributes, _ *BatchMintNonFungibleTokenAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
+	tokenIDs, err := NewBatchMintTokenIDs(tx, len(attr.Tokens), n.hashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	actions := make([]state.Action, len(attr.Tokens))
+	for i, t := range attr.Tokens {
+		actions[i] = state.AddUnit(tokenIDs[i], &tokens.NonFungibleTokenData{
+			TypeID:              attr.TypeID,
+			Name:                t.Name,
+			URI:                 t.URI,
+			Data:                t.Data,
+			OwnerPredicate:      t.OwnerPredicate,
+			DataUpdatePredicate: t.DataUpdatePredicate,
+		})
+	}
+	if err := n.state.Apply(actions...); err != nil {
+		return nil, err
+	}
+	return &types.ServerMetadata{TargetUnits: tokenIDs, SuccessIndicator: types.TxStatusSuccessful}, nil
+}
+
+func (n *NonFungibleTokensModule) validateBatchMintNFT(tx *types.TransactionOrder, attr *BatchMintNonFungibleTokenAttributes, authProof *BatchMintNonFungibleTokenAuthProof, exeCtx txtypes.ExecutionContext) error {
+	// the gas of creating the tokens is charged before doing any work with the batch
+	hi, gas := bits.Mul64(uint64(len(attr.Tokens)), predicates.ActiveGasSchedule(exeCtx, n.gasSchedule).NFTBatchMintToken)
+	if hi != 0 {
+		return errors.New("gas of the batch overflows")
+	}
+	if err := exeCtx.SpendGas(gas); err != nil {
+		return err
+	}
+	// the unit of the transaction is the type of the new tokens
+	if !attr.TypeID.HasType(tokens.NonFungibleTokenTypeUnitType) {
+		return errors.New(ErrStrInvalidTokenTypeID)
+	}
+	if !tx.UnitID.Eq(attr.TypeID) {
+		return fmt.Errorf("transaction unit ID must be the type ID of the tokens: %s", tx.UnitID)
+	}
+	if len(attr.Tokens) == 0 {
+		return errors.New("batch does not contain tokens")
+	}
+	if len(attr.Tokens) > int(n.maxBatchMintSize) {
+		return fmt.Errorf("batch contains %d tokens, allowed maximum is %d", len(attr.Tokens), n.maxBatchMintSize)
+	}
+	for i, t := range attr.Tokens {
+		if t == nil {
+			return fmt.Errorf("token %d: token is nil", i)
+		}
+		if err := validateNFTProperties(t.Name, t.URI, t.Data); err != nil {
+			return fmt.Errorf("token %d: %w", i, err)
+		}
+	}
+
+	// verify tokens do not exist yet
+	tokenIDs, err := NewBatchMintTokenIDs(tx, len(attr.Tokens), n.hashAlgorithm)
+	if err != nil {
+		return err
+	}
+	for _, id := range tokenIDs {
+		token, err := n.state.GetUnit(id, false)
+		if err != nil && !errors.Is(err, avl.ErrNotFound) {
+			return err
+		}
+		if token != nil {
+			return fmt.Errorf("token already exists: %s", id)
+		}
+	}
+
+	tokenTypeData, err := getUnitData[*tokens.NonFungibleTokenTypeData](n.state.GetUnit, attr.TypeID)
+	if err != nil {
+		if errors.Is(err, avl.ErrNotFound) {
+			return fmt.Errorf("nft type does not exist: %s", attr.TypeID)
+		}
+		return err
+	}
+
+	// the minting predicate of the type is verified once for the whole batch
+	if err := n.execPredicate(tokenTypeData.TokenMintingPredicate, authProof.TokenMintingProof, tx.AuthProofSigBytes, exeCtx); err != nil {
+		return fmt.Errorf(`executing NFT type's "TokenMintingPredicate": %w`, err)
+	}
+	return nil
+}
+
+/*
+NewBatchMintTokenIDs returns IDs of the "count" tokens created by the batch mint
+transaction "tx". The unit part of the i-th token ID is hash of the HashForNewTokenID
+of the transaction and the index "i".
+*/
+func NewBatchMintTokenIDs(tx *types.TransactionOrder, count int, hashAlgorithm crypto.Hash) ([]types.UnitID, error) {
+	txHash, err := tokens.HashForNewTokenID(tx, hashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]types.UnitID, count)
+	for i := range ids {
+		hasher := hashAlgorithm.New()
+		hasher.Write(txHash)
+		hasher.Write(util.Uint64ToBytes(uint64(i)))
+		ids[i] = tokens.NewNonFungibleTokenID(tx.UnitID, hasher.Sum(nil))
+	}
+	return ids, nil
+}
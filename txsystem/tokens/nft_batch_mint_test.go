@@ -0,0 +1,203 @@
+package tokens
+
+import (
+	"crypto"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/alphabill-org/alphabill/internal/testutils"
+	"github.com/alphabill-org/alphabill/predicates"
+	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
+	testctx "github.com/alphabill-org/alphabill/txsystem/testutils/exec_context"
+	testtransaction "github.com/alphabill-org/alphabill/txsystem/testutils/transaction"
+)
+
+func TestBatchMintNFT_Ok(t *testing.T) {
+	txs, _ := newTokenTxSystem(t)
+	typeID := tokens.NewNonFungibleTokenTypeID(nil, test.RandomBytes(32))
+	defineNFTAndMintToken(t, txs, typeID)
+
+	attr := &BatchMintNonFungibleTokenAttributes{
+		TypeID: typeID,
+		Tokens: []*BatchMintNonFungibleToken{
+			{Name: "first", URI: validNFTURI, Data: []byte{1}, OwnerPredicate: templates.AlwaysTrueBytes(), DataUpdatePredicate: templates.AlwaysTrueBytes()},
+			{Name: "second", Data: []byte{2}, OwnerPredicate: templates.AlwaysFalseBytes(), DataUpdatePredicate: templates.AlwaysTrueBytes()},
+			{Name: "third", OwnerPredicate: templates.AlwaysTrueBytes(), DataUpdatePredicate: templates.AlwaysFalseBytes()},
+		},
+	}
+	tx := createBatchMintNFTTx(t, typeID, attr)
+	tokenIDs, err := NewBatchMintTokenIDs(tx, len(attr.Tokens), crypto.SHA256)
+	require.NoError(t, err)
+	require.Len(t, tokenIDs, 3)
+
+	sm, err := txs.Execute(tx)
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator, sm.ErrDetail())
+	require.Equal(t, append(tokenIDs, feeCreditID), sm.TargetUnits)
+
+	for i, id := range tokenIDs {
+		require.True(t, id.HasType(tokens.NonFungibleTokenUnitType))
+		u, err := txs.State().GetUnit(id, false)
+		require.NoError(t, err)
+		d := u.Data().(*tokens.NonFungibleTokenData)
+		require.Equal(t, typeID, d.TypeID)
+		require.Equal(t, attr.Tokens[i].Name, d.Name)
+		require.Equal(t, attr.Tokens[i].URI, d.URI)
+		require.EqualValues(t, attr.Tokens[i].Data, d.Data)
+		require.EqualValues(t, attr.Tokens[i].OwnerPredicate, d.OwnerPredicate)
+		require.EqualValues(t, attr.Tokens[i].DataUpdatePredicate, d.DataUpdatePredicate)
+		require.Zero(t, d.Counter)
+	}
+
+	// the same batch can't be minted twice
+	sm, err = txs.Execute(tx)
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusFailed, sm.SuccessIndicator)
+	require.ErrorContains(t, sm.ErrDetail(), "token already exists")
+}
+
+func TestBatchMintNFT_NotOk(t *testing.T) {
+	validToken := func() *BatchMintNonFungibleToken {
+		return &BatchMintNonFungibleToken{Name: "token", OwnerPredicate: templates.AlwaysTrueBytes()}
+	}
+	tests := []struct {
+		name             string
+		unitID           types.UnitID
+		attr             *BatchMintNonFungibleTokenAttributes
+		mintingPredicate []byte
+		wantErrStr       string
+	}{
+		{
+			name:       "invalid type ID",
+			unitID:     existingTokenTypeID,
+			attr:       &BatchMintNonFungibleTokenAttributes{TypeID: existingTokenTypeID, Tokens: []*BatchMintNonFungibleToken{validToken()}},
+			wantErrStr: ErrStrInvalidTokenTypeID,
+		},
+		{
+			name:       "unit ID is not the type ID",
+			unitID:     nftTypeID2,
+			attr:       &BatchMintNonFungibleTokenAttributes{TypeID: nftTypeID1, Tokens: []*BatchMintNonFungibleToken{validToken()}},
+			wantErrStr: "transaction unit ID must be the type ID of the tokens",
+		},
+		{
+			name:       "empty batch",
+			unitID:     nftTypeID1,
+			attr:       &BatchMintNonFungibleTokenAttributes{TypeID: nftTypeID1},
+			wantErrStr: "batch does not contain tokens",
+		},
+		{
+			name:       "batch too big",
+			unitID:     nftTypeID1,
+			attr:       &BatchMintNonFungibleTokenAttributes{TypeID: nftTypeID1, Tokens: []*BatchMintNonFungibleToken{validToken(), validToken(), validToken()}},
+			wantErrStr: "batch contains 3 tokens, allowed maximum is 2",
+		},
+		{
+			name:       "invalid name",
+			unitID:     nftTypeID1,
+			attr:       &BatchMintNonFungibleTokenAttributes{TypeID: nftTypeID1, Tokens: []*BatchMintNonFungibleToken{validToken(), {Name: strings.Repeat("a", maxNameLength+1)}}},
+			wantErrStr: "token 1: " + ErrStrInvalidNameLength,
+		},
+		{
+			name:       "invalid URI",
+			unitID:     nftTypeID1,
+			attr:       &BatchMintNonFungibleTokenAttributes{TypeID: nftTypeID1, Tokens: []*BatchMintNonFungibleToken{{URI: "invalid_uri"}}},
+			wantErrStr: "token 0: URI invalid_uri is invalid",
+		},
+		{
+			name:       "type does not exist",
+			unitID:     nftTypeID2,
+			attr:       &BatchMintNonFungibleTokenAttributes{TypeID: nftTypeID2, Tokens: []*BatchMintNonFungibleToken{validToken()}},
+			wantErrStr: "nft type does not exist",
+		},
+		{
+			name:             "minting predicate is false",
+			unitID:           nftTypeID1,
+			attr:             &BatchMintNonFungibleTokenAttributes{TypeID: nftTypeID1, Tokens: []*BatchMintNonFungibleToken{validToken()}},
+			mintingPredicate: templates.AlwaysFalseBytes(),
+			wantErrStr:       `executing NFT type's "TokenMintingPredicate": predicate evaluated to "false"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := defaultOpts(t)
+			WithMaxBatchMintSize(2)(opts)
+			mintingPredicate := templates.AlwaysTrueBytes()
+			if tt.mintingPredicate != nil {
+				mintingPredicate = tt.mintingPredicate
+			}
+			require.NoError(t, opts.state.Apply(state.AddUnit(nftTypeID1, &tokens.NonFungibleTokenTypeData{
+				Symbol:                symbol,
+				TokenMintingPredicate: mintingPredicate,
+			})))
+			m, err := NewNonFungibleTokensModule(opts)
+			require.NoError(t, err)
+
+			tx := createBatchMintNFTTx(t, tt.unitID, tt.attr)
+			err = m.validateBatchMintNFT(tx, tt.attr, &BatchMintNonFungibleTokenAuthProof{}, testctx.NewMockExecutionContext())
+			require.ErrorContains(t, err, tt.wantErrStr)
+		})
+	}
+}
+
+func createBatchMintNFTTx(t *testing.T, typeID types.UnitID, attr *BatchMintNonFungibleTokenAttributes) *types.TransactionOrder {
+	return testtransaction.NewTransactionOrder(
+		t,
+		testtransaction.WithTransactionType(TransactionTypeBatchMintNFT),
+		testtransaction.WithUnitID(typeID),
+		testtransaction.WithSystemID(tokens.DefaultSystemID),
+		testtransaction.WithAttributes(attr),
+		testtransaction.WithAuthProof(&BatchMintNonFungibleTokenAuthProof{TokenMintingProof: templates.EmptyArgument()}),
+		testtransaction.WithClientMetadata(createClientMetadata()),
+		testtransaction.WithFeeProof(nil),
+	)
+}
+
+func TestBatchMintNFT_Gas(t *testing.T) {
+	// max fee of the tx (10 tema) buys gas for two tokens only
+	gs := predicates.DefaultGasSchedule()
+	gs.NFTBatchMintToken = 4000
+	txs, _ := newTokenTxSystem(t, WithGasSchedule(gs))
+	typeID := tokens.NewNonFungibleTokenTypeID(nil, test.RandomBytes(32))
+	defineNFTAndMintToken(t, txs, typeID)
+
+	newToken := func(name string) *BatchMintNonFungibleToken {
+		return &BatchMintNonFungibleToken{Name: name, OwnerPredicate: templates.AlwaysTrueBytes(), DataUpdatePredicate: templates.AlwaysTrueBytes()}
+	}
+	attr := &BatchMintNonFungibleTokenAttributes{TypeID: typeID, Tokens: []*BatchMintNonFungibleToken{newToken("first"), newToken("second")}}
+	sm, err := txs.Execute(createBatchMintNFTTx(t, typeID, attr))
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator, sm.ErrDetail())
+	require.EqualValues(t, 9, sm.ActualFee)
+
+	attr = &BatchMintNonFungibleTokenAttributes{TypeID: typeID, Tokens: []*BatchMintNonFungibleToken{newToken("a"), newToken("b"), newToken("c")}}
+	tx := createBatchMintNFTTx(t, typeID, attr)
+	sm, err = txs.Execute(tx)
+	require.NoError(t, err)
+	require.Equal(t, types.TxErrOutOfGas, sm.SuccessIndicator)
+	require.Equal(t, []types.UnitID{feeCreditID}, sm.TargetUnits)
+	require.EqualValues(t, 10, sm.ActualFee)
+	tokenIDs, err := NewBatchMintTokenIDs(tx, len(attr.Tokens), crypto.SHA256)
+	require.NoError(t, err)
+	for _, id := range tokenIDs {
+		_, err := txs.State().GetUnit(id, false)
+		require.ErrorIs(t, err, avl.ErrNotFound)
+	}
+
+	t.Run("gas overflow", func(t *testing.T) {
+		opts := defaultOpts(t)
+		opts.gasSchedule = predicates.DefaultGasSchedule()
+		opts.gasSchedule.NFTBatchMintToken = math.MaxUint64
+		m, err := NewNonFungibleTokensModule(opts)
+		require.NoError(t, err)
+		tx := createBatchMintNFTTx(t, typeID, attr)
+		err = m.validateBatchMintNFT(tx, attr, &BatchMintNonFungibleTokenAuthProof{}, testctx.NewMockExecutionContext())
+		require.EqualError(t, err, "gas of the batch overflows")
+	})
+}
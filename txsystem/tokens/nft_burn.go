@@ -0,0 +1,89 @@
+package tokens
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/state"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
+)
+
+func (n *NonFungibleTokensModule) executeBurnNFT(tx *types.TransactionOrder, _ *BurnNonFungibleTokenAttributes, _ *BurnNonFungibleTokenAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
+	unitID := tx.GetUnitID()
+
+	// 1. N[T.ι].D.φ ← 0
+	// 2. N[T.ι].D.c ← N[T.ι].D.c + 1
+	if err := n.state.Apply(
+		state.UpdateUnitData(unitID, func(data types.UnitData) (types.UnitData, error) {
+			d, ok := data.(*tokens.NonFungibleTokenData)
+			if !ok {
+				return nil, fmt.Errorf("unit %v does not contain non fungible token data", unitID)
+			}
+			d.OwnerPredicate = templates.AlwaysFalseBytes()
+			d.DataUpdatePredicate = templates.AlwaysFalseBytes()
+			d.Counter += 1
+			return d, nil
+		}),
+	); err != nil {
+		return nil, fmt.Errorf("burnNFToken: failed to update state: %w", err)
+	}
+	return &types.ServerMetadata{TargetUnits: []types.UnitID{unitID}, SuccessIndicator: types.TxStatusSuccessful}, nil
+}
+
+func (n *NonFungibleTokensModule) validateBurnNFT(tx *types.TransactionOrder, attr *BurnNonFungibleTokenAttributes, authProof *BurnNonFungibleTokenAuthProof, exeCtx txtypes.ExecutionContext) error {
+	unitID := tx.GetUnitID()
+	if !unitID.HasType(tokens.NonFungibleTokenUnitType) {
+		return errors.New(ErrStrInvalidUnitID)
+	}
+	data, err := getUnitData[*tokens.NonFungibleTokenData](n.state.GetUnit, unitID)
+	if err != nil {
+		return fmt.Errorf("validate nft burn: %w", err)
+	}
+	if data.Locked != 0 {
+		return errors.New("token is locked")
+	}
+	if err = checkTokenAdminStatus(n.state, unitID, data.TypeID); err != nil {
+		return err
+	}
+	if data.Counter != attr.Counter {
+		return fmt.Errorf("invalid counter: expected %d, got %d", data.Counter, attr.Counter)
+	}
+	if !bytes.Equal(attr.TypeID, data.TypeID) {
+		return fmt.Errorf("invalid type identifier: expected '%s', got '%s'", data.TypeID, attr.TypeID)
+	}
+
+	ext, err := getTokenTypeExtension(n.state, data.TypeID)
+	if err != nil {
+		return err
+	}
+	if ext == nil || ext.TokenBurningPredicate == nil {
+		return fmt.Errorf("token type %s does not allow burning tokens", data.TypeID)
+	}
+
+	if err = n.execPredicate(data.OwnerPredicate, authProof.OwnerProof, tx.AuthProofSigBytes, exeCtx); err != nil {
+		return fmt.Errorf("evaluating owner predicate: %w", err)
+	}
+	// the burning predicates are stored in the extension units of the types, all the
+	// types in the chain must define the burning predicate
+	err = runChainedPredicates[*TokenTypeExtensionData](
+		exeCtx,
+		tx.AuthProofSigBytes,
+		data.TypeID,
+		authProof.TokenBurningProofs,
+		n.execPredicate,
+		func(d *TokenTypeExtensionData) (types.UnitID, []byte) {
+			return d.ParentTypeID, d.TokenBurningPredicate
+		},
+		func(id types.UnitID, committed bool) (*state.Unit, error) {
+			return n.state.GetUnit(NewTokenTypeExtensionID(id), committed)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("token type burning predicate: %w", err)
+	}
+	return nil
+}
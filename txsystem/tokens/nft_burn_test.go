@@ -0,0 +1,145 @@
+package tokens
+
+import (
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/alphabill-org/alphabill/internal/testutils"
+	"github.com/alphabill-org/alphabill/txsystem"
+	testtransaction "github.com/alphabill-org/alphabill/txsystem/testutils/transaction"
+)
+
+func TestBurnNFT_Ok(t *testing.T) {
+	txs, _ := newTokenTxSystem(t)
+	typeID := tokens.NewNonFungibleTokenTypeID(nil, test.RandomBytes(32))
+	nftID := defineExtendedNFTAndMintToken(t, txs, typeID, templates.AlwaysTrueBytes(), nil)
+
+	sm, err := txs.Execute(createBurnNFTTx(t, nftID, typeID, 0))
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator)
+	require.Equal(t, []types.UnitID{nftID, feeCreditID}, sm.TargetUnits)
+
+	u, err := txs.State().GetUnit(nftID, false)
+	require.NoError(t, err)
+	d := u.Data().(*tokens.NonFungibleTokenData)
+	require.EqualValues(t, templates.AlwaysFalseBytes(), d.Owner())
+	require.EqualValues(t, templates.AlwaysFalseBytes(), d.DataUpdatePredicate)
+	require.EqualValues(t, 1, d.Counter)
+
+	// burned token can't be burned again
+	sm, err = txs.Execute(createBurnNFTTx(t, nftID, typeID, 1))
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusFailed, sm.SuccessIndicator)
+	require.ErrorContains(t, sm.ErrDetail(), `evaluating owner predicate: predicate evaluated to "false"`)
+}
+
+func TestBurnNFT_NotOk(t *testing.T) {
+	t.Run("type does not define burning predicate", func(t *testing.T) {
+		txs, _ := newTokenTxSystem(t)
+		nftID := defineNFTAndMintToken(t, txs, nftTypeID2)
+		sm, err := txs.Execute(createBurnNFTTx(t, nftID, nftTypeID2, 0))
+		require.NoError(t, err)
+		require.Equal(t, types.TxStatusFailed, sm.SuccessIndicator)
+		require.ErrorContains(t, sm.ErrDetail(), "does not allow burning tokens")
+	})
+
+	t.Run("invalid counter", func(t *testing.T) {
+		txs, _ := newTokenTxSystem(t)
+		typeID := tokens.NewNonFungibleTokenTypeID(nil, test.RandomBytes(32))
+		nftID := defineExtendedNFTAndMintToken(t, txs, typeID, templates.AlwaysTrueBytes(), nil)
+		sm, err := txs.Execute(createBurnNFTTx(t, nftID, typeID, 1))
+		require.NoError(t, err)
+		require.Equal(t, types.TxStatusFailed, sm.SuccessIndicator)
+		require.ErrorContains(t, sm.ErrDetail(), "invalid counter: expected 0, got 1")
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		txs, _ := newTokenTxSystem(t)
+		typeID := tokens.NewNonFungibleTokenTypeID(nil, test.RandomBytes(32))
+		nftID := defineExtendedNFTAndMintToken(t, txs, typeID, templates.AlwaysTrueBytes(), nil)
+		sm, err := txs.Execute(createBurnNFTTx(t, nftID, nftTypeID2, 0))
+		require.NoError(t, err)
+		require.Equal(t, types.TxStatusFailed, sm.SuccessIndicator)
+		require.ErrorContains(t, sm.ErrDetail(), "invalid type identifier")
+	})
+
+	t.Run("burning predicate is false", func(t *testing.T) {
+		txs, _ := newTokenTxSystem(t)
+		typeID := tokens.NewNonFungibleTokenTypeID(nil, test.RandomBytes(32))
+		nftID := defineExtendedNFTAndMintToken(t, txs, typeID, templates.AlwaysFalseBytes(), nil)
+		sm, err := txs.Execute(createBurnNFTTx(t, nftID, typeID, 0))
+		require.NoError(t, err)
+		require.Equal(t, types.TxStatusFailed, sm.SuccessIndicator)
+		require.ErrorContains(t, sm.ErrDetail(), `token type burning predicate: executing predicate [0] in the chain: predicate evaluated to "false"`)
+	})
+}
+
+func createBurnNFTTx(t *testing.T, nftID, typeID types.UnitID, counter uint64) *types.TransactionOrder {
+	return testtransaction.NewTransactionOrder(
+		t,
+		testtransaction.WithTransactionType(TransactionTypeBurnNFT),
+		testtransaction.WithUnitID(nftID),
+		testtransaction.WithSystemID(tokens.DefaultSystemID),
+		testtransaction.WithAttributes(&BurnNonFungibleTokenAttributes{TypeID: typeID, Counter: counter}),
+		testtransaction.WithAuthProof(&BurnNonFungibleTokenAuthProof{
+			OwnerProof:         templates.EmptyArgument(),
+			TokenBurningProofs: [][]byte{templates.EmptyArgument()},
+		}),
+		testtransaction.WithClientMetadata(createClientMetadata()),
+		testtransaction.WithFeeProof(nil),
+	)
+}
+
+/*
+defineExtendedNFTAndMintToken defines NFT type with the burning and admin predicates
+(at least one of them must be set so that the type extension unit is created) and mints
+a token of the type.
+*/
+func defineExtendedNFTAndMintToken(t *testing.T, txs *txsystem.GenericTxSystem, typeID types.UnitID, burningPredicate, adminPredicate []byte) types.UnitID {
+	tx := testtransaction.NewTransactionOrder(
+		t,
+		testtransaction.WithTransactionType(tokens.TransactionTypeDefineNFT),
+		testtransaction.WithUnitID(typeID),
+		testtransaction.WithSystemID(tokens.DefaultSystemID),
+		testtransaction.WithAttributes(&DefineNonFungibleTokenAttributes{
+			Symbol:                   symbol,
+			SubTypeCreationPredicate: templates.AlwaysTrueBytes(),
+			TokenMintingPredicate:    templates.AlwaysTrueBytes(),
+			TokenTypeOwnerPredicate:  templates.AlwaysTrueBytes(),
+			DataUpdatePredicate:      templates.AlwaysTrueBytes(),
+			TokenBurningPredicate:    burningPredicate,
+			AdminPredicate:           adminPredicate,
+		}),
+		testtransaction.WithAuthProof(tokens.DefineNonFungibleTokenAuthProof{}),
+		testtransaction.WithClientMetadata(createClientMetadata()),
+		testtransaction.WithFeeProof(nil),
+	)
+	sm, err := txs.Execute(tx)
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator)
+	require.Equal(t, []types.UnitID{typeID, NewTokenTypeExtensionID(typeID), feeCreditID}, sm.TargetUnits)
+
+	tx = testtransaction.NewTransactionOrder(
+		t,
+		testtransaction.WithTransactionType(tokens.TransactionTypeMintNFT),
+		testtransaction.WithSystemID(tokens.DefaultSystemID),
+		testtransaction.WithAttributes(&tokens.MintNonFungibleTokenAttributes{
+			OwnerPredicate:      templates.AlwaysTrueBytes(),
+			TypeID:              typeID,
+			Name:                nftName,
+			DataUpdatePredicate: templates.AlwaysTrueBytes(),
+		}),
+		testtransaction.WithAuthProof(tokens.MintNonFungibleTokenAuthProof{TokenMintingProof: templates.EmptyArgument()}),
+		testtransaction.WithClientMetadata(createClientMetadata()),
+		testtransaction.WithFeeProof(nil),
+	)
+	tx.UnitID = newNonFungibleTokenID(t, tx)
+	sm, err = txs.Execute(tx)
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator)
+	return tx.UnitID
+}
@@ -11,18 +11,27 @@ import (
 	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
 )
 
-func (n *NonFungibleTokensModule) executeDefineNFT(tx *types.TransactionOrder, attr *tokens.DefineNonFungibleTokenAttributes, _ *tokens.DefineNonFungibleTokenAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
+func (n *NonFungibleTokensModule) executeDefineNFT(tx *types.TransactionOrder, attr *DefineNonFungibleTokenAttributes, _ *tokens.DefineNonFungibleTokenAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
 	// update state
 	unitID := tx.GetUnitID()
-	if err := n.state.Apply(
-		state.AddUnit(unitID, tokens.NewNonFungibleTokenTypeData(attr)),
-	); err != nil {
+	targetUnits := []types.UnitID{unitID}
+	actions := []state.Action{state.AddUnit(unitID, tokens.NewNonFungibleTokenTypeData(attr.toSDK()))}
+	if attr.TokenBurningPredicate != nil || attr.AdminPredicate != nil {
+		extID := NewTokenTypeExtensionID(unitID)
+		actions = append(actions, state.AddUnit(extID, &TokenTypeExtensionData{
+			ParentTypeID:          attr.ParentTypeID,
+			TokenBurningPredicate: attr.TokenBurningPredicate,
+			AdminPredicate:        attr.AdminPredicate,
+		}))
+		targetUnits = append(targetUnits, extID)
+	}
+	if err := n.state.Apply(actions...); err != nil {
 		return nil, err
 	}
-	return &types.ServerMetadata{TargetUnits: []types.UnitID{unitID}, SuccessIndicator: types.TxStatusSuccessful}, nil
+	return &types.ServerMetadata{TargetUnits: targetUnits, SuccessIndicator: types.TxStatusSuccessful}, nil
 }
 
-func (n *NonFungibleTokensModule) validateDefineNFT(tx *types.TransactionOrder, attr *tokens.DefineNonFungibleTokenAttributes, authProof *tokens.DefineNonFungibleTokenAuthProof, exeCtx txtypes.ExecutionContext) error {
+func (n *NonFungibleTokensModule) validateDefineNFT(tx *types.TransactionOrder, attr *DefineNonFungibleTokenAttributes, authProof *tokens.DefineNonFungibleTokenAuthProof, exeCtx txtypes.ExecutionContext) error {
 	unitID := tx.GetUnitID()
 	if !unitID.HasType(tokens.NonFungibleTokenTypeUnitType) {
 		return fmt.Errorf("create nft type: %s", ErrStrInvalidUnitID)
@@ -35,20 +35,8 @@ func (n *NonFungibleTokensModule) validateMintNFT(tx *types.TransactionOrder, at
 	}
 
 	// verify max allowed sizes
-	if len(attr.Name) > maxNameLength {
-		return errors.New(ErrStrInvalidNameLength)
-	}
-	uri := attr.URI
-	if uri != "" {
-		if len(uri) > uriMaxSize {
-			return fmt.Errorf("URI exceeds the maximum allowed size of %v KB", uriMaxSize)
-		}
-		if !util.IsValidURI(uri) {
-			return fmt.Errorf("URI %s is invalid", uri)
-		}
-	}
-	if len(attr.Data) > dataMaxSize {
-		return fmt.Errorf("data exceeds the maximum allowed size of %v KB", dataMaxSize)
+	if err := validateNFTProperties(attr.Name, attr.URI, attr.Data); err != nil {
+		return err
 	}
 
 	// verify token does not exist yet
@@ -89,3 +77,22 @@ func (n *NonFungibleTokensModule) validateMintNFT(tx *types.TransactionOrder, at
 	}
 	return nil
 }
+
+// validateNFTProperties verifies the max allowed sizes (and URI format) of the properties of a new NFT.
+func validateNFTProperties(name, uri string, data []byte) error {
+	if len(name) > maxNameLength {
+		return errors.New(ErrStrInvalidNameLength)
+	}
+	if uri != "" {
+		if len(uri) > uriMaxSize {
+			return fmt.Errorf("URI exceeds the maximum allowed size of %v KB", uriMaxSize)
+		}
+		if !util.IsValidURI(uri) {
+			return fmt.Errorf("URI %s is invalid", uri)
+		}
+	}
+	if len(data) > dataMaxSize {
+		return fmt.Errorf("data exceeds the maximum allowed size of %v KB", dataMaxSize)
+	}
+	return nil
+}
@@ -12,24 +12,30 @@ import (
 var _ txtypes.Module = (*NonFungibleTokensModule)(nil)
 
 type NonFungibleTokensModule struct {
-	state         *state.State
-	hashAlgorithm crypto.Hash
-	execPredicate predicates.PredicateRunner
+	state            *state.State
+	hashAlgorithm    crypto.Hash
+	execPredicate    predicates.PredicateRunner
+	maxBatchMintSize uint32
+	gasSchedule      *predicates.GasSchedule
 }
 
 func NewNonFungibleTokensModule(options *Options) (*NonFungibleTokensModule, error) {
 	return &NonFungibleTokensModule{
-		state:         options.state,
-		hashAlgorithm: options.hashAlgorithm,
-		execPredicate: predicates.NewPredicateRunner(options.exec),
+		state:            options.state,
+		hashAlgorithm:    options.hashAlgorithm,
+		execPredicate:    predicates.NewPredicateRunner(options.exec),
+		maxBatchMintSize: options.maxBatchMintSize,
+		gasSchedule:      options.gasSchedule,
 	}, nil
 }
 
 func (n *NonFungibleTokensModule) TxHandlers() map[uint16]txtypes.TxExecutor {
 	return map[uint16]txtypes.TxExecutor{
-		tokens.TransactionTypeDefineNFT:   txtypes.NewTxHandler[tokens.DefineNonFungibleTokenAttributes, tokens.DefineNonFungibleTokenAuthProof](n.validateDefineNFT, n.executeDefineNFT),
+		tokens.TransactionTypeDefineNFT:   txtypes.NewTxHandler[DefineNonFungibleTokenAttributes, tokens.DefineNonFungibleTokenAuthProof](n.validateDefineNFT, n.executeDefineNFT),
 		tokens.TransactionTypeMintNFT:     txtypes.NewTxHandler[tokens.MintNonFungibleTokenAttributes, tokens.MintNonFungibleTokenAuthProof](n.validateMintNFT, n.executeMintNFT),
 		tokens.TransactionTypeTransferNFT: txtypes.NewTxHandler[tokens.TransferNonFungibleTokenAttributes, tokens.TransferNonFungibleTokenAuthProof](n.validateTransferNFT, n.executeTransferNFT),
 		tokens.TransactionTypeUpdateNFT:   txtypes.NewTxHandler[tokens.UpdateNonFungibleTokenAttributes, tokens.UpdateNonFungibleTokenAuthProof](n.validateUpdateNFT, n.executeUpdateNFT),
+		TransactionTypeBurnNFT:            txtypes.NewTxHandler[BurnNonFungibleTokenAttributes, BurnNonFungibleTokenAuthProof](n.validateBurnNFT, n.executeBurnNFT),
+		TransactionTypeBatchMintNFT:       txtypes.NewTxHandler[BatchMintNonFungibleTokenAttributes, BatchMintNonFungibleTokenAuthProof](n.validateBatchMintNFT, n.executeBatchMintNFT),
 	}
 }
@@ -48,6 +48,9 @@ func (n *NonFungibleTokensModule) validateTransferNFT(tx *types.TransactionOrder
 	if data.Locked != 0 {
 		return errors.New("token is locked")
 	}
+	if err = checkTokenAdminStatus(n.state, unitID, data.TypeID); err != nil {
+		return err
+	}
 	if data.Counter != attr.Counter {
 		return fmt.Errorf("invalid counter: expected %d, got %d", data.Counter, attr.Counter)
 	}
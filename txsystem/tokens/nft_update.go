@@ -50,6 +50,9 @@ func (n *NonFungibleTokensModule) validateUpdateNFT(tx *types.TransactionOrder,
 	if data.Counter != attr.Counter {
 		return fmt.Errorf("invalid counter: got %d expected %d", attr.Counter, data.Counter)
 	}
+	if err = checkTokenAdminStatus(n.state, unitID, data.TypeID); err != nil {
+		return err
+	}
 
 	if err = n.execPredicate(data.DataUpdatePredicate, authProof.TokenDataUpdateProof, tx.AuthProofSigBytes, exeCtx); err != nil {
 		return fmt.Errorf("data update predicate: %w", err)
@@ -12,6 +12,9 @@ import (
 	"github.com/alphabill-org/alphabill/state"
 )
 
+// DefaultMaxBatchMintSize is the default maximum number of NFTs minted by a single batch mint transaction.
+const DefaultMaxBatchMintSize = 100
+
 type (
 	Options struct {
 		moneySystemID       types.SystemID
@@ -21,6 +24,11 @@ type (
 		exec                predicates.PredicateExecutor
 		adminOwnerPredicate []byte
 		feelessMode         bool
+		predicateStorage    bool
+		gasScheduleUpdates  predicates.GasScheduleUpdates
+		gasSchedule         *predicates.GasSchedule
+		maxBatchMintSize    uint32
+		fcrExpiryInterval   uint64
 	}
 
 	Option func(*Options)
@@ -33,9 +41,11 @@ func defaultOptions() (*Options, error) {
 	}
 
 	return &Options{
-		moneySystemID: money.DefaultSystemID,
-		hashAlgorithm: gocrypto.SHA256,
-		exec:          predEng.Execute,
+		moneySystemID:    money.DefaultSystemID,
+		hashAlgorithm:    gocrypto.SHA256,
+		exec:             predEng.Execute,
+		gasSchedule:      predicates.DefaultGasSchedule(),
+		maxBatchMintSize: DefaultMaxBatchMintSize,
 	}, nil
 }
 
@@ -86,3 +96,59 @@ func WithPredicateExecutor(exec predicates.PredicateExecutor) Option {
 		}
 	}
 }
+
+/*
+WithPredicateStorage enables the persistent storage for the (WASM) predicates,
+storage entries are kept in the state as units of type PredicateStorageUnitType.
+When enabled the state must be recovered using the unit data constructor which
+supports the storage units (see predicatestore.NewUnitDataConstructor).
+*/
+func WithPredicateStorage(enabled bool) Option {
+	return func(c *Options) {
+		c.predicateStorage = enabled
+	}
+}
+
+/*
+WithGasSchedule sets the gas schedule of the transaction system. NB! the predicate
+executor set by WithPredicateExecutor must be created with the same gas schedule.
+*/
+func WithGasSchedule(gs *predicates.GasSchedule) Option {
+	return func(c *Options) {
+		c.gasSchedule = gs
+	}
+}
+
+/*
+WithGasScheduleUpdates sets the gas schedules replacing the gas schedule set by
+WithGasSchedule from their activation round.
+*/
+func WithGasScheduleUpdates(updates predicates.GasScheduleUpdates) Option {
+	return func(c *Options) {
+		c.gasScheduleUpdates = updates
+	}
+}
+
+/*
+WithMaxBatchMintSize sets the maximum number of NFTs a single batch mint transaction
+may create, zero means DefaultMaxBatchMintSize.
+*/
+func WithMaxBatchMintSize(size uint32) Option {
+	return func(c *Options) {
+		if size != 0 {
+			c.maxBatchMintSize = size
+		}
+	}
+}
+
+/*
+WithFeeCreditRecordExpiry enables deleting the expired fee credit records with zero
+balance, the expired records holding value are checked again after "checkInterval"
+rounds. Zero interval (the default) disables the expiry. The expiry is
+not supported in the permissioned fee credit mode.
+*/
+func WithFeeCreditRecordExpiry(checkInterval uint64) Option {
+	return func(c *Options) {
+		c.fcrExpiryInterval = checkInterval
+	}
+}
@@ -0,0 +1,194 @@
+package tokens
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
+)
+
+func (m *AdminTokensModule) executeFreezeToken(tx *types.TransactionOrder, _ *FreezeTokenAttributes, _ *TokenAdminAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
+	freezeID := NewTokenFreezeID(tx.UnitID)
+	_, err := m.state.GetUnit(freezeID, false)
+	switch {
+	case errors.Is(err, avl.ErrNotFound):
+		err = m.state.Apply(state.AddUnit(freezeID, &TokenFreezeData{Frozen: true, Counter: 1}))
+	case err == nil:
+		err = m.state.Apply(updateFreezeStatus(freezeID, true))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("freezing token: %w", err)
+	}
+	return &types.ServerMetadata{TargetUnits: []types.UnitID{freezeID}, SuccessIndicator: types.TxStatusSuccessful}, nil
+}
+
+func (m *AdminTokensModule) validateFreezeToken(tx *types.TransactionOrder, attr *FreezeTokenAttributes, authProof *TokenAdminAuthProof, exeCtx txtypes.ExecutionContext) error {
+	freeze, err := m.getFreezeStatus(tx.UnitID, attr.TypeID)
+	if err != nil {
+		return err
+	}
+	var counter uint64
+	if freeze != nil {
+		if freeze.Frozen {
+			return ErrTokenFrozen
+		}
+		counter = freeze.Counter
+	}
+	if attr.Counter != counter {
+		return fmt.Errorf("invalid counter: expected %d, got %d", counter, attr.Counter)
+	}
+	return m.execAdminPredicate(tx, attr.TypeID, authProof, exeCtx)
+}
+
+func (m *AdminTokensModule) executeUnfreezeToken(tx *types.TransactionOrder, _ *UnfreezeTokenAttributes, _ *TokenAdminAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
+	freezeID := NewTokenFreezeID(tx.UnitID)
+	if err := m.state.Apply(updateFreezeStatus(freezeID, false)); err != nil {
+		return nil, fmt.Errorf("unfreezing token: %w", err)
+	}
+	return &types.ServerMetadata{TargetUnits: []types.UnitID{freezeID}, SuccessIndicator: types.TxStatusSuccessful}, nil
+}
+
+func (m *AdminTokensModule) validateUnfreezeToken(tx *types.TransactionOrder, attr *UnfreezeTokenAttributes, authProof *TokenAdminAuthProof, exeCtx txtypes.ExecutionContext) error {
+	freeze, err := m.getFreezeStatus(tx.UnitID, attr.TypeID)
+	if err != nil {
+		return err
+	}
+	if freeze == nil || !freeze.Frozen {
+		return errors.New("token is not frozen")
+	}
+	if attr.Counter != freeze.Counter {
+		return fmt.Errorf("invalid counter: expected %d, got %d", freeze.Counter, attr.Counter)
+	}
+	return m.execAdminPredicate(tx, attr.TypeID, authProof, exeCtx)
+}
+
+func (m *AdminTokensModule) executePauseTokenType(tx *types.TransactionOrder, _ *PauseTokenTypeAttributes, _ *TokenAdminAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
+	extID := NewTokenTypeExtensionID(tx.UnitID)
+	if err := m.state.Apply(updatePauseStatus(extID, true)); err != nil {
+		return nil, fmt.Errorf("pausing token type: %w", err)
+	}
+	return &types.ServerMetadata{TargetUnits: []types.UnitID{extID}, SuccessIndicator: types.TxStatusSuccessful}, nil
+}
+
+func (m *AdminTokensModule) validatePauseTokenType(tx *types.TransactionOrder, attr *PauseTokenTypeAttributes, authProof *TokenAdminAuthProof, exeCtx txtypes.ExecutionContext) error {
+	ext, err := m.getAdminExtension(tx.UnitID)
+	if err != nil {
+		return err
+	}
+	if ext.Paused {
+		return ErrTokenTypePaused
+	}
+	if attr.Counter != ext.AdminCounter {
+		return fmt.Errorf("invalid counter: expected %d, got %d", ext.AdminCounter, attr.Counter)
+	}
+	return m.execAdminPredicate(tx, tx.UnitID, authProof, exeCtx)
+}
+
+func (m *AdminTokensModule) executeUnpauseTokenType(tx *types.TransactionOrder, _ *UnpauseTokenTypeAttributes, _ *TokenAdminAuthProof, _ txtypes.ExecutionContext) (*types.ServerMetadata, error) {
+	extID := NewTokenTypeExtensionID(tx.UnitID)
+	if err := m.state.Apply(updatePauseStatus(extID, false)); err != nil {
+		return nil, fmt.Errorf("unpausing token type: %w", err)
+	}
+	return &types.ServerMetadata{TargetUnits: []types.UnitID{extID}, SuccessIndicator: types.TxStatusSuccessful}, nil
+}
+
+func (m *AdminTokensModule) validateUnpauseTokenType(tx *types.TransactionOrder, attr *UnpauseTokenTypeAttributes, authProof *TokenAdminAuthProof, exeCtx txtypes.ExecutionContext) error {
+	ext, err := m.getAdminExtension(tx.UnitID)
+	if err != nil {
+		return err
+	}
+	if !ext.Paused {
+		return errors.New("token type is not paused")
+	}
+	if attr.Counter != ext.AdminCounter {
+		return fmt.Errorf("invalid counter: expected %d, got %d", ext.AdminCounter, attr.Counter)
+	}
+	return m.execAdminPredicate(tx, tx.UnitID, authProof, exeCtx)
+}
+
+/*
+getFreezeStatus verifies that the token "tokenID" is of type "typeID" and returns the
+freeze status of the token, nil if the token has never been frozen.
+*/
+func (m *AdminTokensModule) getFreezeStatus(tokenID, typeID types.UnitID) (*TokenFreezeData, error) {
+	u, err := m.state.GetUnit(tokenID, false)
+	if err != nil {
+		return nil, fmt.Errorf("reading token: %w", err)
+	}
+	var tokenTypeID types.UnitID
+	switch d := u.Data().(type) {
+	case *tokens.FungibleTokenData:
+		tokenTypeID = d.TokenType
+	case *tokens.NonFungibleTokenData:
+		tokenTypeID = d.TypeID
+	default:
+		return nil, fmt.Errorf("unit %s is not a token", tokenID)
+	}
+	if !bytes.Equal(tokenTypeID, typeID) {
+		return nil, fmt.Errorf("invalid type identifier: expected '%s', got '%s'", tokenTypeID, typeID)
+	}
+
+	freeze, err := getUnitData[*TokenFreezeData](m.state.GetUnit, NewTokenFreezeID(tokenID))
+	if err != nil {
+		if errors.Is(err, avl.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading token freeze status: %w", err)
+	}
+	return freeze, nil
+}
+
+// getAdminExtension returns the extension of the token type "typeID" which has the admin predicate.
+func (m *AdminTokensModule) getAdminExtension(typeID types.UnitID) (*TokenTypeExtensionData, error) {
+	if !typeID.HasType(tokens.FungibleTokenTypeUnitType) && !typeID.HasType(tokens.NonFungibleTokenTypeUnitType) {
+		return nil, errors.New(ErrStrInvalidTokenTypeID)
+	}
+	ext, err := getTokenTypeExtension(m.state, typeID)
+	if err != nil {
+		return nil, err
+	}
+	if ext == nil || ext.AdminPredicate == nil {
+		return nil, fmt.Errorf("token type %s does not have admin predicate", typeID)
+	}
+	return ext, nil
+}
+
+func (m *AdminTokensModule) execAdminPredicate(tx *types.TransactionOrder, typeID types.UnitID, authProof *TokenAdminAuthProof, exeCtx txtypes.ExecutionContext) error {
+	ext, err := m.getAdminExtension(typeID)
+	if err != nil {
+		return err
+	}
+	if err := m.execPredicate(ext.AdminPredicate, authProof.AdminProof, tx.AuthProofSigBytes, exeCtx); err != nil {
+		return fmt.Errorf("evaluating admin predicate: %w", err)
+	}
+	return nil
+}
+
+func updateFreezeStatus(freezeID types.UnitID, frozen bool) state.Action {
+	return state.UpdateUnitData(freezeID, func(data types.UnitData) (types.UnitData, error) {
+		d, ok := data.(*TokenFreezeData)
+		if !ok {
+			return nil, fmt.Errorf("unit %v does not contain token freeze data", freezeID)
+		}
+		d.Frozen = frozen
+		d.Counter += 1
+		return d, nil
+	})
+}
+
+func updatePauseStatus(extID types.UnitID, paused bool) state.Action {
+	return state.UpdateUnitData(extID, func(data types.UnitData) (types.UnitData, error) {
+		d, ok := data.(*TokenTypeExtensionData)
+		if !ok {
+			return nil, fmt.Errorf("unit %v does not contain token type extension data", extID)
+		}
+		d.Paused = paused
+		d.AdminCounter += 1
+		return d, nil
+	})
+}
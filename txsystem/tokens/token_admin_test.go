@@ -0,0 +1,272 @@
+package tokens
+
+import (
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/stretchr/testify/require"
+
+	test "github.com/alphabill-org/alphabill/internal/testutils"
+	"github.com/alphabill-org/alphabill/state"
+	testctx "github.com/alphabill-org/alphabill/txsystem/testutils/exec_context"
+	testtransaction "github.com/alphabill-org/alphabill/txsystem/testutils/transaction"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
+)
+
+func TestFreezeNFT(t *testing.T) {
+	txs, _ := newTokenTxSystem(t)
+	typeID := tokens.NewNonFungibleTokenTypeID(nil, test.RandomBytes(32))
+	nftID := defineExtendedNFTAndMintToken(t, txs, typeID, nil, templates.AlwaysTrueBytes())
+	freezeID := NewTokenFreezeID(nftID)
+
+	transferTx := func(counter uint64) *types.TransactionOrder {
+		return testtransaction.NewTransactionOrder(
+			t,
+			testtransaction.WithTransactionType(tokens.TransactionTypeTransferNFT),
+			testtransaction.WithUnitID(nftID),
+			testtransaction.WithSystemID(tokens.DefaultSystemID),
+			testtransaction.WithAttributes(&tokens.TransferNonFungibleTokenAttributes{
+				TypeID:            typeID,
+				NewOwnerPredicate: templates.AlwaysTrueBytes(),
+				Counter:           counter,
+			}),
+			testtransaction.WithAuthProof(&tokens.TransferNonFungibleTokenAuthProof{
+				TokenTypeOwnerProofs: [][]byte{templates.EmptyArgument()},
+			}),
+			testtransaction.WithClientMetadata(createClientMetadata()),
+			testtransaction.WithFeeProof(nil),
+		)
+	}
+
+	// freeze the token
+	sm, err := txs.Execute(createAdminTx(t, nftID, TransactionTypeFreezeToken, &FreezeTokenAttributes{TypeID: typeID}))
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator, sm.ErrDetail())
+	require.Equal(t, []types.UnitID{freezeID, feeCreditID}, sm.TargetUnits)
+
+	// frozen token can't be transferred nor burned
+	sm, err = txs.Execute(transferTx(0))
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusFailed, sm.SuccessIndicator)
+	require.ErrorIs(t, sm.ErrDetail(), ErrTokenFrozen)
+	sm, err = txs.Execute(createBurnNFTTx(t, nftID, typeID, 0))
+	require.NoError(t, err)
+	require.ErrorIs(t, sm.ErrDetail(), ErrTokenFrozen)
+
+	// frozen token can't be updated
+	sm, err = txs.Execute(testtransaction.NewTransactionOrder(
+		t,
+		testtransaction.WithTransactionType(tokens.TransactionTypeUpdateNFT),
+		testtransaction.WithUnitID(nftID),
+		testtransaction.WithSystemID(tokens.DefaultSystemID),
+		testtransaction.WithAttributes(&tokens.UpdateNonFungibleTokenAttributes{Data: []byte{42}}),
+		testtransaction.WithAuthProof(&tokens.UpdateNonFungibleTokenAuthProof{
+			TokenDataUpdateProof:      templates.EmptyArgument(),
+			TokenTypeDataUpdateProofs: [][]byte{templates.EmptyArgument()},
+		}),
+		testtransaction.WithClientMetadata(createClientMetadata()),
+		testtransaction.WithFeeProof(nil),
+	))
+	require.NoError(t, err)
+	require.ErrorIs(t, sm.ErrDetail(), ErrTokenFrozen)
+
+	// already frozen
+	sm, err = txs.Execute(createAdminTx(t, nftID, TransactionTypeFreezeToken, &FreezeTokenAttributes{TypeID: typeID, Counter: 1}))
+	require.NoError(t, err)
+	require.ErrorIs(t, sm.ErrDetail(), ErrTokenFrozen)
+
+	// unfreeze with invalid counter fails
+	sm, err = txs.Execute(createAdminTx(t, nftID, TransactionTypeUnfreezeToken, &UnfreezeTokenAttributes{TypeID: typeID}))
+	require.NoError(t, err)
+	require.ErrorContains(t, sm.ErrDetail(), "invalid counter: expected 1, got 0")
+
+	sm, err = txs.Execute(createAdminTx(t, nftID, TransactionTypeUnfreezeToken, &UnfreezeTokenAttributes{TypeID: typeID, Counter: 1}))
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator, sm.ErrDetail())
+	u, err := txs.State().GetUnit(freezeID, false)
+	require.NoError(t, err)
+	require.Equal(t, &TokenFreezeData{Frozen: false, Counter: 2}, u.Data())
+
+	sm, err = txs.Execute(transferTx(0))
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator, sm.ErrDetail())
+}
+
+func TestPauseNFTType(t *testing.T) {
+	txs, _ := newTokenTxSystem(t)
+	typeID := tokens.NewNonFungibleTokenTypeID(nil, test.RandomBytes(32))
+	nftID := defineExtendedNFTAndMintToken(t, txs, typeID, templates.AlwaysTrueBytes(), templates.AlwaysTrueBytes())
+
+	sm, err := txs.Execute(createAdminTx(t, typeID, TransactionTypePauseTokenType, &PauseTokenTypeAttributes{}))
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator, sm.ErrDetail())
+	require.Equal(t, []types.UnitID{NewTokenTypeExtensionID(typeID), feeCreditID}, sm.TargetUnits)
+
+	sm, err = txs.Execute(createBurnNFTTx(t, nftID, typeID, 0))
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusFailed, sm.SuccessIndicator)
+	require.ErrorIs(t, sm.ErrDetail(), ErrTokenTypePaused)
+
+	sm, err = txs.Execute(createAdminTx(t, typeID, TransactionTypeUnpauseTokenType, &UnpauseTokenTypeAttributes{Counter: 1}))
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator, sm.ErrDetail())
+
+	sm, err = txs.Execute(createBurnNFTTx(t, nftID, typeID, 0))
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator, sm.ErrDetail())
+}
+
+func TestTokenAdmin_NotOk(t *testing.T) {
+	t.Run("type without admin predicate", func(t *testing.T) {
+		txs, _ := newTokenTxSystem(t)
+		typeID := tokens.NewNonFungibleTokenTypeID(nil, test.RandomBytes(32))
+		nftID := defineExtendedNFTAndMintToken(t, txs, typeID, templates.AlwaysTrueBytes(), nil)
+
+		sm, err := txs.Execute(createAdminTx(t, nftID, TransactionTypeFreezeToken, &FreezeTokenAttributes{TypeID: typeID}))
+		require.NoError(t, err)
+		require.ErrorContains(t, sm.ErrDetail(), "does not have admin predicate")
+
+		sm, err = txs.Execute(createAdminTx(t, typeID, TransactionTypePauseTokenType, &PauseTokenTypeAttributes{}))
+		require.NoError(t, err)
+		require.ErrorContains(t, sm.ErrDetail(), "does not have admin predicate")
+	})
+
+	t.Run("admin predicate is false", func(t *testing.T) {
+		txs, _ := newTokenTxSystem(t)
+		typeID := tokens.NewNonFungibleTokenTypeID(nil, test.RandomBytes(32))
+		nftID := defineExtendedNFTAndMintToken(t, txs, typeID, nil, templates.AlwaysFalseBytes())
+
+		sm, err := txs.Execute(createAdminTx(t, nftID, TransactionTypeFreezeToken, &FreezeTokenAttributes{TypeID: typeID}))
+		require.NoError(t, err)
+		require.ErrorContains(t, sm.ErrDetail(), `evaluating admin predicate: predicate evaluated to "false"`)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		txs, _ := newTokenTxSystem(t)
+		typeID := tokens.NewNonFungibleTokenTypeID(nil, test.RandomBytes(32))
+		nftID := defineExtendedNFTAndMintToken(t, txs, typeID, nil, templates.AlwaysTrueBytes())
+
+		sm, err := txs.Execute(createAdminTx(t, nftID, TransactionTypeFreezeToken, &FreezeTokenAttributes{TypeID: nftTypeID2}))
+		require.NoError(t, err)
+		require.ErrorContains(t, sm.ErrDetail(), "invalid type identifier")
+	})
+
+	t.Run("not paused", func(t *testing.T) {
+		txs, _ := newTokenTxSystem(t)
+		typeID := tokens.NewNonFungibleTokenTypeID(nil, test.RandomBytes(32))
+		defineExtendedNFTAndMintToken(t, txs, typeID, nil, templates.AlwaysTrueBytes())
+
+		sm, err := txs.Execute(createAdminTx(t, typeID, TransactionTypeUnpauseTokenType, &UnpauseTokenTypeAttributes{}))
+		require.NoError(t, err)
+		require.ErrorContains(t, sm.ErrDetail(), "token type is not paused")
+	})
+}
+
+func TestFrozenAndPausedFungibleToken(t *testing.T) {
+	opts := defaultOpts(t)
+	require.NoError(t, opts.state.Apply(
+		state.AddUnit(NewTokenTypeExtensionID(existingTokenTypeID), &TokenTypeExtensionData{AdminPredicate: templates.AlwaysTrueBytes(), Minted: 2 * existingTokenValue}),
+		state.AddUnit(NewTokenFreezeID(existingTokenID), &TokenFreezeData{Frozen: true, Counter: 1}),
+		state.AddUnit(NewTokenFreezeID(existingLockedTokenID), &TokenFreezeData{Frozen: true, Counter: 1}),
+	))
+	m, err := NewFungibleTokensModule(opts)
+	require.NoError(t, err)
+	exeCtx := testctx.NewMockExecutionContext(testctx.WithCurrentRound(10))
+	tokenTypeOwnerProofs := [][]byte{templates.EmptyArgument()}
+
+	transferAttr := &tokens.TransferFungibleTokenAttributes{TypeID: existingTokenTypeID, Value: existingTokenValue, NewOwnerPredicate: templates.AlwaysTrueBytes()}
+	transferTx := createTxOrder(t, existingTokenID, tokens.TransactionTypeTransferFT, transferAttr)
+	err = m.validateTransferFT(transferTx, transferAttr, &tokens.TransferFungibleTokenAuthProof{TokenTypeOwnerProofs: tokenTypeOwnerProofs}, exeCtx)
+	require.ErrorIs(t, err, ErrTokenFrozen)
+
+	splitAttr := &tokens.SplitFungibleTokenAttributes{TypeID: existingTokenTypeID, TargetValue: 1, NewOwnerPredicate: templates.AlwaysTrueBytes()}
+	splitTx := createTxOrder(t, existingTokenID, tokens.TransactionTypeSplitFT, splitAttr)
+	err = m.validateSplitFT(splitTx, splitAttr, &tokens.SplitFungibleTokenAuthProof{TokenTypeOwnerProofs: tokenTypeOwnerProofs}, exeCtx)
+	require.ErrorIs(t, err, ErrTokenFrozen)
+
+	burnAttr := &tokens.BurnFungibleTokenAttributes{TypeID: existingTokenTypeID, Value: existingTokenValue}
+	burnTx := createTxOrder(t, existingTokenID, tokens.TransactionTypeBurnFT, burnAttr)
+	err = m.validateBurnFT(burnTx, burnAttr, &tokens.BurnFungibleTokenAuthProof{TokenTypeOwnerProofs: tokenTypeOwnerProofs}, exeCtx)
+	require.ErrorIs(t, err, ErrTokenFrozen)
+
+	joinAttr := &tokens.JoinFungibleTokenAttributes{}
+	joinTx := createTxOrder(t, existingTokenID, tokens.TransactionTypeJoinFT, joinAttr)
+	err = m.validateJoinFT(joinTx, joinAttr, &tokens.JoinFungibleTokenAuthProof{TokenTypeOwnerProofs: tokenTypeOwnerProofs}, exeCtx)
+	require.ErrorIs(t, err, ErrTokenFrozen)
+
+	lockModule, err := NewLockTokensModule(opts)
+	require.NoError(t, err)
+	lockAttr := &tokens.LockTokenAttributes{LockStatus: 1}
+	err = lockModule.validateLockTokenTx(createTxOrder(t, existingTokenID, tokens.TransactionTypeLockToken, lockAttr), lockAttr, &tokens.LockTokenAuthProof{}, exeCtx)
+	require.ErrorIs(t, err, ErrTokenFrozen)
+	unlockAttr := &tokens.UnlockTokenAttributes{}
+	err = lockModule.validateUnlockTokenTx(createTxOrder(t, existingLockedTokenID, tokens.TransactionTypeUnlockToken, unlockAttr), unlockAttr, &tokens.UnlockTokenAuthProof{}, exeCtx)
+	require.ErrorIs(t, err, ErrTokenFrozen)
+
+	// unfreeze the token and pause the type
+	admin, err := NewAdminTokensModule(opts)
+	require.NoError(t, err)
+	txExecutors := make(txtypes.TxExecutors)
+	require.NoError(t, txExecutors.Add(admin.TxHandlers()))
+	_, err = txExecutors.ValidateAndExecute(createAdminTx(t, existingTokenID, TransactionTypeUnfreezeToken, &UnfreezeTokenAttributes{TypeID: existingTokenTypeID, Counter: 1}), exeCtx)
+	require.NoError(t, err)
+	require.NoError(t, m.validateTransferFT(transferTx, transferAttr, &tokens.TransferFungibleTokenAuthProof{TokenTypeOwnerProofs: tokenTypeOwnerProofs}, exeCtx))
+
+	_, err = txExecutors.ValidateAndExecute(createAdminTx(t, existingTokenTypeID, TransactionTypePauseTokenType, &PauseTokenTypeAttributes{}), exeCtx)
+	require.NoError(t, err)
+	err = m.validateTransferFT(transferTx, transferAttr, &tokens.TransferFungibleTokenAuthProof{TokenTypeOwnerProofs: tokenTypeOwnerProofs}, exeCtx)
+	require.ErrorIs(t, err, ErrTokenTypePaused)
+	err = m.validateSplitFT(splitTx, splitAttr, &tokens.SplitFungibleTokenAuthProof{TokenTypeOwnerProofs: tokenTypeOwnerProofs}, exeCtx)
+	require.ErrorIs(t, err, ErrTokenTypePaused)
+	err = lockModule.validateLockTokenTx(createTxOrder(t, existingTokenID, tokens.TransactionTypeLockToken, lockAttr), lockAttr, &tokens.LockTokenAuthProof{}, exeCtx)
+	require.ErrorIs(t, err, ErrTokenTypePaused)
+}
+
+func TestPausedParentFungibleTokenType(t *testing.T) {
+	opts := defaultOpts(t)
+	subTypeID := tokens.NewFungibleTokenTypeID(nil, []byte{0xcc})
+	tokenID := tokens.NewFungibleTokenID(nil, []byte{0xcc})
+	require.NoError(t, opts.state.Apply(
+		state.AddUnit(NewTokenTypeExtensionID(existingTokenTypeID), &TokenTypeExtensionData{AdminPredicate: templates.AlwaysTrueBytes(), Paused: true}),
+		state.AddUnit(subTypeID, &tokens.FungibleTokenTypeData{
+			Symbol:                   "SUB",
+			ParentTypeID:             existingTokenTypeID,
+			SubTypeCreationPredicate: templates.AlwaysTrueBytes(),
+			TokenMintingPredicate:    templates.AlwaysTrueBytes(),
+			TokenTypeOwnerPredicate:  templates.AlwaysTrueBytes(),
+		}),
+		state.AddUnit(tokenID, &tokens.FungibleTokenData{TokenType: subTypeID, Value: 10, OwnerPredicate: templates.AlwaysTrueBytes()}),
+	))
+	m, err := NewFungibleTokensModule(opts)
+	require.NoError(t, err)
+	exeCtx := testctx.NewMockExecutionContext(testctx.WithCurrentRound(10))
+
+	// the tokens of the subtypes of the paused type can't be used
+	transferAttr := &tokens.TransferFungibleTokenAttributes{TypeID: subTypeID, Value: 10, NewOwnerPredicate: templates.AlwaysTrueBytes()}
+	transferTx := createTxOrder(t, tokenID, tokens.TransactionTypeTransferFT, transferAttr)
+	authProof := &tokens.TransferFungibleTokenAuthProof{TokenTypeOwnerProofs: [][]byte{templates.EmptyArgument(), templates.EmptyArgument()}}
+	err = m.validateTransferFT(transferTx, transferAttr, authProof, exeCtx)
+	require.ErrorIs(t, err, ErrTokenTypePaused)
+	require.ErrorContains(t, err, existingTokenTypeID.String())
+
+	require.NoError(t, opts.state.Apply(state.UpdateUnitData(NewTokenTypeExtensionID(existingTokenTypeID), func(data types.UnitData) (types.UnitData, error) {
+		data.(*TokenTypeExtensionData).Paused = false
+		return data, nil
+	})))
+	require.NoError(t, m.validateTransferFT(transferTx, transferAttr, authProof, exeCtx))
+}
+
+func createAdminTx(t *testing.T, unitID types.UnitID, txType uint16, attr any) *types.TransactionOrder {
+	return testtransaction.NewTransactionOrder(
+		t,
+		testtransaction.WithTransactionType(txType),
+		testtransaction.WithUnitID(unitID),
+		testtransaction.WithSystemID(tokens.DefaultSystemID),
+		testtransaction.WithAttributes(attr),
+		testtransaction.WithAuthProof(&TokenAdminAuthProof{AdminProof: templates.EmptyArgument()}),
+		testtransaction.WithClientMetadata(createClientMetadata()),
+		testtransaction.WithFeeProof(nil),
+	)
+}
@@ -32,6 +32,28 @@ const (
 	ErrStrInvalidIconDataLength = "icon data length exceeds the allowed maximum of 64 KiB"
 )
 
+// PredicateStorageUnitType is the type of the units holding the predicate storage entries.
+var PredicateStorageUnitType = []byte{32}
+
+// AdminConfigUnitType is the type of the unit holding the admin predicate of the
+// partition in permissioned fee credit mode.
+var AdminConfigUnitType = []byte{36}
+
+// AdminConfigUnitID is the ID of the admin configuration unit of the partition.
+var AdminConfigUnitID = basetypes.NewUnitID(tokens.UnitIDLength, nil, nil, AdminConfigUnitType)
+
+// FeeCreditRecordExpiryScheduleUnitType is the type of the units holding the fee credit
+// records to be checked for expiry in the end of the round.
+var FeeCreditRecordExpiryScheduleUnitType = []byte{37}
+
+/*
+NewFeeCreditRecordExpiryScheduleID returns ID of the unit which holds the fee credit
+records to be checked for expiry in the end of the round "roundNumber".
+*/
+func NewFeeCreditRecordExpiryScheduleID(roundNumber uint64) basetypes.UnitID {
+	return txtypes.NewRoundScheduleID(tokens.UnitIDLength, FeeCreditRecordExpiryScheduleUnitType, roundNumber)
+}
+
 func NewTxSystem(pdr basetypes.PartitionDescriptionRecord, shardID basetypes.ShardID, observe txsystem.Observability, opts ...Option) (*txsystem.GenericTxSystem, error) {
 	options, err := defaultOptions()
 	if err != nil {
@@ -57,34 +79,55 @@ func NewTxSystem(pdr basetypes.PartitionDescriptionRecord, shardID basetypes.Sha
 	if err != nil {
 		return nil, fmt.Errorf("failed to load lock tokens module: %w", err)
 	}
+	adminTokens, err := NewAdminTokensModule(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load admin tokens module: %w", err)
+	}
 
 	var feeCreditModule txtypes.FeeCreditModule
+	var endBlockFuncs []func(blockNumber uint64) error
 	if len(options.adminOwnerPredicate) > 0 {
 		feeCreditModule, err = permissioned.NewFeeCreditModule(
 			pdr.NetworkIdentifier, pdr.SystemIdentifier, options.state, tokens.FeeCreditRecordUnitType, options.adminOwnerPredicate,
 			permissioned.WithHashAlgorithm(options.hashAlgorithm),
 			permissioned.WithFeelessMode(options.feelessMode),
+			permissioned.WithAdminConfigUnitID(AdminConfigUnitID),
+			permissioned.WithPredicateExecutor(options.exec),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load permissioned fee credit module: %w", err)
 		}
 	} else {
-		feeCreditModule, err = fc.NewFeeCreditModule(pdr.NetworkIdentifier, pdr.SystemIdentifier, options.moneySystemID, options.state, options.trustBase,
+		fcModule, err := fc.NewFeeCreditModule(pdr.NetworkIdentifier, pdr.SystemIdentifier, options.moneySystemID, options.state, options.trustBase,
 			fc.WithHashAlgorithm(options.hashAlgorithm),
 			fc.WithFeeCreditRecordUnitType(tokens.FeeCreditRecordUnitType),
+			fc.WithPredicateExecutor(options.exec),
+			fc.WithFeeCreditRecordExpiry(NewFeeCreditRecordExpiryScheduleID, options.fcrExpiryInterval),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load permissionless fee credit module: %w", err)
 		}
+		feeCreditModule = fcModule
+		endBlockFuncs = fcModule.EndBlockFuncs()
+	}
+	txsOpts := []txsystem.Option{
+		txsystem.WithFeeCredits(feeCreditModule),
+		txsystem.WithEndBlockFunctions(endBlockFuncs...),
+		txsystem.WithHashAlgorithm(options.hashAlgorithm),
+		txsystem.WithState(options.state),
+		txsystem.WithGasSchedule(options.gasSchedule),
+		txsystem.WithGasScheduleUpdates(options.gasScheduleUpdates),
+		txsystem.WithPredicateExecutor(options.exec),
+	}
+	if options.predicateStorage {
+		txsOpts = append(txsOpts, txsystem.WithPredicateStorage(PredicateStorageUnitType))
 	}
 	return txsystem.NewGenericTxSystem(
 		pdr,
 		shardID,
 		options.trustBase,
-		[]txtypes.Module{nft, fungible, lockTokens},
+		[]txtypes.Module{nft, fungible, lockTokens, adminTokens},
 		observe,
-		txsystem.WithFeeCredits(feeCreditModule),
-		txsystem.WithHashAlgorithm(options.hashAlgorithm),
-		txsystem.WithState(options.state),
+		txsOpts...,
 	)
 }
@@ -20,9 +20,13 @@ import (
 	"github.com/alphabill-org/alphabill/internal/testutils/observability"
 	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
 	testtb "github.com/alphabill-org/alphabill/internal/testutils/trustbase"
+	"github.com/alphabill-org/alphabill/predicates"
+	"github.com/alphabill-org/alphabill/predicates/script"
+	predtempl "github.com/alphabill-org/alphabill/predicates/templates"
 	"github.com/alphabill-org/alphabill/state"
 	"github.com/alphabill-org/alphabill/txsystem"
 	"github.com/alphabill-org/alphabill/txsystem/fc/testutils"
+	"github.com/alphabill-org/alphabill/txsystem/fc/unit"
 	testtransaction "github.com/alphabill-org/alphabill/txsystem/testutils/transaction"
 )
 
@@ -462,6 +466,116 @@ func TestExecuteDefineNFT_InvalidTxType(t *testing.T) {
 	require.ErrorContains(t, sm.ErrDetail(), "unknown transaction type")
 }
 
+func TestExecuteDefineNFT_Sponsored(t *testing.T) {
+	txs, s := newTokenTxSystem(t)
+	sponsorID := tokens.NewFeeCreditRecordID(nil, []byte{43})
+	require.NoError(t, s.Apply(state.AddUnit(sponsorID, &fc.FeeCreditRecord{
+		Balance:        100,
+		OwnerPredicate: templates.AlwaysTrueBytes(),
+		Timeout:        1000,
+	})))
+	feeProof, err := unit.NewSponsorFeeProof(sponsorID, nil)
+	require.NoError(t, err)
+	// the client does not have fee credit record, the sponsor pays the fees
+	clientMetadata := createClientMetadata()
+	clientMetadata.FeeCreditRecordID = tokens.NewFeeCreditRecordID(nil, []byte{44})
+	tx := testtransaction.NewTransactionOrder(
+		t,
+		testtransaction.WithUnitID(nftTypeID1),
+		testtransaction.WithSystemID(tokens.DefaultSystemID),
+		testtransaction.WithAttributes(&tokens.DefineNonFungibleTokenAttributes{
+			Symbol:                   symbol,
+			SubTypeCreationPredicate: subTypeCreationPredicate,
+			TokenMintingPredicate:    tokenMintingPredicate,
+			TokenTypeOwnerPredicate:  tokenTypeOwnerPredicate,
+			DataUpdatePredicate:      dataUpdatePredicate,
+		}),
+		testtransaction.WithAuthProof(&tokens.DefineNonFungibleTokenAuthProof{}),
+		testtransaction.WithTransactionType(tokens.TransactionTypeDefineNFT),
+		testtransaction.WithClientMetadata(clientMetadata),
+		testtransaction.WithFeeProof(feeProof),
+	)
+	sm, err := txs.Execute(tx)
+	require.NoError(t, err)
+	require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator)
+	require.Equal(t, []types.UnitID{tx.UnitID, sponsorID}, sm.TargetUnits)
+
+	u, err := s.GetUnit(sponsorID, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 100-sm.ActualFee, u.Data().(*fc.FeeCreditRecord).Balance)
+	u, err = s.GetUnit(feeCreditID, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, u.Data().(*fc.FeeCreditRecord).Balance)
+}
+
+func TestExecute_SponsorPredicate(t *testing.T) {
+	// the sponsor pays only for the NFT type definitions
+	predEng, err := predicates.Dispatcher(predtempl.New(), script.New())
+	require.NoError(t, err)
+	txs, s := newTokenTxSystem(t, WithPredicateExecutor(predEng.Execute))
+	code, err := (&script.Builder{}).Op(script.OpTxType).PushU64(uint64(tokens.TransactionTypeDefineNFT)).Op(script.OpEqual).Bytes()
+	require.NoError(t, err)
+	sponsorPredicate, err := script.NewPredicateBytes(code)
+	require.NoError(t, err)
+	sponsorID := tokens.NewFeeCreditRecordID(nil, []byte{43})
+	require.NoError(t, s.Apply(state.AddUnit(sponsorID, &fc.FeeCreditRecord{
+		Balance:        100,
+		OwnerPredicate: sponsorPredicate,
+		Timeout:        1000,
+	})))
+	feeProof, err := unit.NewSponsorFeeProof(sponsorID, script.NewOwnerProof())
+	require.NoError(t, err)
+	clientMetadata := createClientMetadata()
+	clientMetadata.FeeCreditRecordID = tokens.NewFeeCreditRecordID(nil, []byte{44})
+
+	t.Run("sponsor predicate rejects the transaction type", func(t *testing.T) {
+		tx := testtransaction.NewTransactionOrder(
+			t,
+			testtransaction.WithUnitID(tokens.NewFungibleTokenTypeID(nil, []byte{1})),
+			testtransaction.WithSystemID(tokens.DefaultSystemID),
+			testtransaction.WithAttributes(&tokens.DefineFungibleTokenAttributes{
+				Symbol:                   symbol,
+				DecimalPlaces:            5,
+				SubTypeCreationPredicate: subTypeCreationPredicate,
+				TokenMintingPredicate:    tokenMintingPredicate,
+				TokenTypeOwnerPredicate:  tokenTypeOwnerPredicate,
+			}),
+			testtransaction.WithAuthProof(&tokens.DefineFungibleTokenAuthProof{}),
+			testtransaction.WithTransactionType(tokens.TransactionTypeDefineFT),
+			testtransaction.WithClientMetadata(clientMetadata),
+			testtransaction.WithFeeProof(feeProof),
+		)
+		sm, err := txs.Execute(tx)
+		require.ErrorContains(t, err, "error transaction not credible: evaluating fee proof")
+		require.Nil(t, sm)
+	})
+
+	t.Run("sponsor predicate accepts the transaction type", func(t *testing.T) {
+		tx := testtransaction.NewTransactionOrder(
+			t,
+			testtransaction.WithUnitID(nftTypeID1),
+			testtransaction.WithSystemID(tokens.DefaultSystemID),
+			testtransaction.WithAttributes(&tokens.DefineNonFungibleTokenAttributes{
+				Symbol:                   symbol,
+				SubTypeCreationPredicate: subTypeCreationPredicate,
+				TokenMintingPredicate:    tokenMintingPredicate,
+				TokenTypeOwnerPredicate:  tokenTypeOwnerPredicate,
+				DataUpdatePredicate:      dataUpdatePredicate,
+			}),
+			testtransaction.WithAuthProof(&tokens.DefineNonFungibleTokenAuthProof{}),
+			testtransaction.WithTransactionType(tokens.TransactionTypeDefineNFT),
+			testtransaction.WithClientMetadata(clientMetadata),
+			testtransaction.WithFeeProof(feeProof),
+		)
+		sm, err := txs.Execute(tx)
+		require.NoError(t, err)
+		require.Equal(t, types.TxStatusSuccessful, sm.SuccessIndicator)
+		u, err := s.GetUnit(sponsorID, false)
+		require.NoError(t, err)
+		require.EqualValues(t, 100-sm.ActualFee, u.Data().(*fc.FeeCreditRecord).Balance)
+	})
+}
+
 func TestRevertTransaction_Ok(t *testing.T) {
 	txs, _ := newTokenTxSystem(t)
 	tx := testtransaction.NewTransactionOrder(
@@ -1711,7 +1825,7 @@ func signTx(t *testing.T, tx *types.TransactionOrder, signer abcrypto.Signer, pu
 	return signature, templates.NewP2pkh256SignatureBytes(signature, pubKey)
 }
 
-func newTokenTxSystem(t *testing.T) (*txsystem.GenericTxSystem, *state.State) {
+func newTokenTxSystem(t *testing.T, opts ...Option) (*txsystem.GenericTxSystem, *state.State) {
 	_, verifier := testsig.CreateSignerAndVerifier(t)
 	s := state.NewEmptyState()
 	require.NoError(t, s.Apply(state.AddUnit(feeCreditID, &fc.FeeCreditRecord{
@@ -1739,8 +1853,7 @@ func newTokenTxSystem(t *testing.T) (*txsystem.GenericTxSystem, *state.State) {
 		pdr,
 		types.ShardID{},
 		observability.Default(t),
-		WithTrustBase(testtb.NewTrustBase(t, verifier)),
-		WithState(s),
+		append([]Option{WithTrustBase(testtb.NewTrustBase(t, verifier)), WithState(s)}, opts...)...,
 	)
 	require.NoError(t, err)
 	return txs, s
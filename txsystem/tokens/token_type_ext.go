@@ -0,0 +1,231 @@
+package tokens
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+
+	abhash "github.com/alphabill-org/alphabill-go-base/hash"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/internal/cborutil"
+	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
+	"github.com/alphabill-org/alphabill/txsystem/fc/permissioned"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
+)
+
+var (
+	// TokenTypeExtensionUnitType is the type of the units holding the token type properties
+	// which are not part of the token type data of the SDK.
+	TokenTypeExtensionUnitType = []byte{33}
+
+	// TokenFreezeUnitType is the type of the units holding the freeze status of a token.
+	TokenFreezeUnitType = []byte{34}
+
+	// TokenAllowanceUnitType is the type of the units holding the allowance of a fungible token.
+	TokenAllowanceUnitType = []byte{35}
+)
+
+var (
+	ErrTokenFrozen     = errors.New("token is frozen")
+	ErrTokenTypePaused = errors.New("token type is paused")
+)
+
+var _ types.UnitData = (*TokenTypeExtensionData)(nil)
+var _ types.UnitData = (*TokenFreezeData)(nil)
+
+/*
+TokenTypeExtensionData is the unit data of the token type extension unit. The unit is
+created together with the token type when the type defines a burning predicate (NFT
+types), a maximum supply (FT types) or an admin predicate.
+*/
+type TokenTypeExtensionData struct {
+	_ struct{} `cbor:",toarray"`
+	// ParentTypeID is copied from the type so that the chain of the burning predicates
+	// can be evaluated without reading the type units
+	ParentTypeID          types.UnitID `json:"parentTypeId"`
+	TokenBurningPredicate []byte       `json:"tokenBurningPredicate"` // the predicate clause that controls burning tokens of this NFT type
+	MaxSupply             uint64       `json:"maxSupply,string"`      // the maximum circulating supply of tokens of this FT type, zero means unlimited
+	Minted                uint64       `json:"minted,string"`         // the total value of tokens of this FT type minted
+	Burned                uint64       `json:"burned,string"`         // the total value of tokens of this FT type burned and not joined
+	AdminPredicate        []byte       `json:"adminPredicate"`        // the predicate clause that controls freezing tokens of this type and pausing the type
+	Paused                bool         `json:"paused"`                // the pause status of this type, tokens of a paused type (or its subtypes) can't be transferred, split, joined, burned, locked, unlocked nor updated
+	AdminCounter          uint64       `json:"adminCounter,string"`   // the counter of the pause and unpause transactions of this type
+}
+
+// TokenFreezeData is the unit data of the token freeze status unit.
+type TokenFreezeData struct {
+	_       struct{} `cbor:",toarray"`
+	Frozen  bool     `json:"frozen"`         // the freeze status of the token, frozen token can't be transferred, split, joined, burned, locked, unlocked nor updated
+	Counter uint64   `json:"counter,string"` // the counter of the freeze and unfreeze transactions of the token
+}
+
+func (d *TokenTypeExtensionData) UnmarshalCBOR(data []byte) error {
+	// the admin fields were added later
+	type extensionData TokenTypeExtensionData
+	return cborutil.UnmarshalOptionalFields(data, 8, (*extensionData)(d))
+}
+
+func (d *TokenTypeExtensionData) Write(hasher hash.Hash) error {
+	res, err := types.Cbor.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("token type extension data encode error: %w", err)
+	}
+	_, err = hasher.Write(res)
+	return err
+}
+
+func (d *TokenTypeExtensionData) SummaryValueInput() uint64 {
+	return zeroSummaryValue
+}
+
+func (d *TokenTypeExtensionData) Copy() types.UnitData {
+	return &TokenTypeExtensionData{
+		ParentTypeID:          bytes.Clone(d.ParentTypeID),
+		TokenBurningPredicate: bytes.Clone(d.TokenBurningPredicate),
+		MaxSupply:             d.MaxSupply,
+		Minted:                d.Minted,
+		Burned:                d.Burned,
+		AdminPredicate:        bytes.Clone(d.AdminPredicate),
+		Paused:                d.Paused,
+		AdminCounter:          d.AdminCounter,
+	}
+}
+
+func (d *TokenTypeExtensionData) Owner() []byte {
+	return nil
+}
+
+func (d *TokenFreezeData) Write(hasher hash.Hash) error {
+	res, err := types.Cbor.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("token freeze data encode error: %w", err)
+	}
+	_, err = hasher.Write(res)
+	return err
+}
+
+func (d *TokenFreezeData) SummaryValueInput() uint64 {
+	return zeroSummaryValue
+}
+
+func (d *TokenFreezeData) Copy() types.UnitData {
+	return &TokenFreezeData{Frozen: d.Frozen, Counter: d.Counter}
+}
+
+func (d *TokenFreezeData) Owner() []byte {
+	return nil
+}
+
+// CirculatingSupply returns the total value of the tokens of the FT type in circulation.
+func (d *TokenTypeExtensionData) CirculatingSupply() uint64 {
+	return d.Minted - d.Burned
+}
+
+// NewTokenTypeExtensionID returns ID of the extension unit of the token type "typeID".
+func NewTokenTypeExtensionID(typeID types.UnitID) types.UnitID {
+	return types.NewUnitID(tokens.UnitIDLength, nil, abhash.Sum256(typeID), TokenTypeExtensionUnitType)
+}
+
+// NewTokenFreezeID returns ID of the freeze status unit of the token "tokenID".
+func NewTokenFreezeID(tokenID types.UnitID) types.UnitID {
+	return types.NewUnitID(tokens.UnitIDLength, nil, abhash.Sum256(tokenID), TokenFreezeUnitType)
+}
+
+/*
+NewUnitData is the unit data constructor of the tokens partition, in addition to the
+units of the SDK it supports the token type extension, token freeze status, token
+allowance, admin configuration and fee credit record expiry schedule units.
+*/
+func NewUnitData(unitID types.UnitID) (types.UnitData, error) {
+	if unitID.HasType(TokenTypeExtensionUnitType) {
+		return &TokenTypeExtensionData{}, nil
+	}
+	if unitID.HasType(TokenFreezeUnitType) {
+		return &TokenFreezeData{}, nil
+	}
+	if unitID.HasType(TokenAllowanceUnitType) {
+		return &TokenAllowanceData{}, nil
+	}
+	if unitID.HasType(AdminConfigUnitType) {
+		return &permissioned.AdminConfigData{}, nil
+	}
+	if unitID.HasType(FeeCreditRecordExpiryScheduleUnitType) {
+		return &txtypes.RoundSchedule{}, nil
+	}
+	return tokens.NewUnitData(unitID)
+}
+
+/*
+getTokenTypeExtension returns the extension data of the token type "typeID", nil when
+the type has no extension unit.
+*/
+func getTokenTypeExtension(s *state.State, typeID types.UnitID) (*TokenTypeExtensionData, error) {
+	ext, err := getUnitData[*TokenTypeExtensionData](s.GetUnit, NewTokenTypeExtensionID(typeID))
+	if err != nil {
+		if errors.Is(err, avl.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading token type extension: %w", err)
+	}
+	return ext, nil
+}
+
+/*
+updateSupply returns state action which updates the supply counters of the FT type
+extension "extID": "minted" is added to the total minted value, "burned" to the total
+burned value and "joined" (value of the burned tokens joined back) is subtracted from it.
+*/
+func updateSupply(extID types.UnitID, minted, burned, joined uint64) state.Action {
+	return state.UpdateUnitData(extID, func(data types.UnitData) (types.UnitData, error) {
+		ext, ok := data.(*TokenTypeExtensionData)
+		if !ok {
+			return nil, fmt.Errorf("unit %v does not contain token type extension data", extID)
+		}
+		ext.Minted += minted
+		ext.Burned += burned
+		ext.Burned -= joined
+		return ext, nil
+	})
+}
+
+/*
+checkTokenAdminStatus returns error when the token "tokenID" is frozen (ErrTokenFrozen)
+or its type "typeID" or any of the parent types of it is paused (ErrTokenTypePaused).
+*/
+func checkTokenAdminStatus(s *state.State, tokenID, typeID types.UnitID) error {
+	for id := typeID; len(id) > 0; {
+		ext, err := getTokenTypeExtension(s, id)
+		if err != nil {
+			return err
+		}
+		if ext != nil && ext.Paused {
+			return fmt.Errorf("%w: %s", ErrTokenTypePaused, id)
+		}
+		u, err := s.GetUnit(id, false)
+		if err != nil {
+			return fmt.Errorf("reading token type %s: %w", id, err)
+		}
+		switch d := u.Data().(type) {
+		case *tokens.FungibleTokenTypeData:
+			id = d.ParentTypeID
+		case *tokens.NonFungibleTokenTypeData:
+			id = d.ParentTypeID
+		default:
+			return fmt.Errorf("unit %s is not a token type", id)
+		}
+	}
+	freeze, err := getUnitData[*TokenFreezeData](s.GetUnit, NewTokenFreezeID(tokenID))
+	if err != nil {
+		if errors.Is(err, avl.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("reading token freeze status: %w", err)
+	}
+	if freeze.Frozen {
+		return ErrTokenFrozen
+	}
+	return nil
+}
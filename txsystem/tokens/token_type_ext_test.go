@@ -0,0 +1,149 @@
+package tokens
+
+import (
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/predicates/templates"
+	"github.com/alphabill-org/alphabill-go-base/txsystem/tokens"
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/stretchr/testify/require"
+
+	testblock "github.com/alphabill-org/alphabill/internal/testutils/block"
+	testsig "github.com/alphabill-org/alphabill/internal/testutils/sig"
+	testtb "github.com/alphabill-org/alphabill/internal/testutils/trustbase"
+	"github.com/alphabill-org/alphabill/txsystem/fc/permissioned"
+	testctx "github.com/alphabill-org/alphabill/txsystem/testutils/exec_context"
+	testtransaction "github.com/alphabill-org/alphabill/txsystem/testutils/transaction"
+	txtypes "github.com/alphabill-org/alphabill/txsystem/types"
+)
+
+func TestDefineFungibleTokenAttributes_UnmarshalCBOR(t *testing.T) {
+	// attributes encoded using the SDK type do not have the max supply
+	buf, err := types.Cbor.Marshal(&tokens.DefineFungibleTokenAttributes{Symbol: validSymbol, DecimalPlaces: 2})
+	require.NoError(t, err)
+	attr := &DefineFungibleTokenAttributes{}
+	require.NoError(t, types.Cbor.Unmarshal(buf, attr))
+	require.Equal(t, &DefineFungibleTokenAttributes{Symbol: validSymbol, DecimalPlaces: 2}, attr)
+
+	buf, err = types.Cbor.Marshal(&DefineFungibleTokenAttributes{Symbol: validSymbol, MaxSupply: 100})
+	require.NoError(t, err)
+	attr = &DefineFungibleTokenAttributes{}
+	require.NoError(t, types.Cbor.Unmarshal(buf, attr))
+	require.EqualValues(t, 100, attr.MaxSupply)
+
+	// the SDK type can't decode the extended attributes
+	require.Error(t, types.Cbor.Unmarshal(buf, &tokens.DefineFungibleTokenAttributes{}))
+}
+
+func TestNewUnitData(t *testing.T) {
+	data, err := NewUnitData(NewTokenTypeExtensionID(existingTokenTypeID))
+	require.NoError(t, err)
+	require.IsType(t, &TokenTypeExtensionData{}, data)
+
+	data, err = NewUnitData(existingTokenTypeID)
+	require.NoError(t, err)
+	require.IsType(t, &tokens.FungibleTokenTypeData{}, data)
+
+	data, err = NewUnitData(AdminConfigUnitID)
+	require.NoError(t, err)
+	require.IsType(t, &permissioned.AdminConfigData{}, data)
+
+	data, err = NewUnitData(NewFeeCreditRecordExpiryScheduleID(1))
+	require.NoError(t, err)
+	require.IsType(t, &txtypes.RoundSchedule{}, data)
+
+	// type extension IDs of the FT and NFT types with the same unit part must differ
+	require.NotEqual(t, NewTokenTypeExtensionID(tokens.NewFungibleTokenTypeID(nil, []byte{1})), NewTokenTypeExtensionID(tokens.NewNonFungibleTokenTypeID(nil, []byte{1})))
+}
+
+func TestFungibleTokenMaxSupply(t *testing.T) {
+	signer, verifier := testsig.CreateSignerAndVerifier(t)
+	opts := defaultOpts(t)
+	opts.trustBase = testtb.NewTrustBase(t, verifier)
+	m, err := NewFungibleTokensModule(opts)
+	require.NoError(t, err)
+	txExecutors := make(txtypes.TxExecutors)
+	require.NoError(t, txExecutors.Add(m.TxHandlers()))
+	exeCtx := testctx.NewMockExecutionContext(testctx.WithCurrentRound(10))
+
+	typeID := tokens.NewFungibleTokenTypeID(nil, []byte{1, 2, 3})
+	extID := NewTokenTypeExtensionID(typeID)
+	getExt := func() *TokenTypeExtensionData {
+		t.Helper()
+		ext, err := getUnitData[*TokenTypeExtensionData](opts.state.GetUnit, extID)
+		require.NoError(t, err)
+		return ext
+	}
+
+	// define type with max supply
+	defineTx := createTxOrder(t, typeID, tokens.TransactionTypeDefineFT, &DefineFungibleTokenAttributes{
+		Symbol:                   validSymbol,
+		SubTypeCreationPredicate: templates.AlwaysTrueBytes(),
+		TokenMintingPredicate:    templates.AlwaysTrueBytes(),
+		TokenTypeOwnerPredicate:  templates.AlwaysTrueBytes(),
+		MaxSupply:                100,
+	})
+	sm, err := txExecutors.ValidateAndExecute(defineTx, exeCtx)
+	require.NoError(t, err)
+	require.Equal(t, []types.UnitID{typeID, extID}, sm.TargetUnits)
+	require.Equal(t, &TokenTypeExtensionData{MaxSupply: 100}, getExt())
+
+	mint := func(value uint64) (types.UnitID, error) {
+		tx := createTxOrder(t, nil, tokens.TransactionTypeMintFT, &tokens.MintFungibleTokenAttributes{
+			OwnerPredicate: templates.AlwaysTrueBytes(),
+			TypeID:         typeID,
+			Value:          value,
+			Nonce:          value,
+		})
+		tx.UnitID = newFungibleTokenID(t, tx)
+		sm, err := txExecutors.ValidateAndExecute(tx, exeCtx)
+		if err != nil {
+			return nil, err
+		}
+		require.Equal(t, []types.UnitID{tx.UnitID, extID}, sm.TargetUnits)
+		return tx.UnitID, nil
+	}
+
+	// mint up to the max supply
+	tokenID, err := mint(60)
+	require.NoError(t, err)
+	_, err = mint(41)
+	require.ErrorContains(t, err, "minting 41 tokens exceeds the max supply of the type: max supply 100, circulating supply 60")
+	targetTokenID, err := mint(40)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, getExt().CirculatingSupply())
+
+	// burning returns the value to the mintable supply
+	burnTxRecord := createTxRecord(t, tokenID, tokens.TransactionTypeBurnFT, &tokens.BurnFungibleTokenAttributes{
+		TypeID:        typeID,
+		Value:         60,
+		TargetTokenID: targetTokenID,
+	}, testtransaction.WithAuthProof(&tokens.BurnFungibleTokenAuthProof{TokenTypeOwnerProofs: [][]byte{templates.EmptyArgument()}}))
+	sm, err = txExecutors.ValidateAndExecute(burnTxRecord.TransactionOrder, exeCtx)
+	require.NoError(t, err)
+	require.Equal(t, []types.UnitID{tokenID, extID}, sm.TargetUnits)
+	require.Equal(t, &TokenTypeExtensionData{MaxSupply: 100, Minted: 100, Burned: 60}, getExt())
+
+	// joining the burned token returns the value to the circulation
+	joinTx := createTxOrder(t, targetTokenID, tokens.TransactionTypeJoinFT, &tokens.JoinFungibleTokenAttributes{
+		BurnTokenProofs: []*types.TxRecordProof{testblock.CreateTxRecordProof(t, burnTxRecord, signer, testblock.WithSystemIdentifier(tokens.DefaultSystemID))},
+	}, testtransaction.WithAuthProof(&tokens.JoinFungibleTokenAuthProof{TokenTypeOwnerProofs: [][]byte{templates.EmptyArgument()}}))
+	sm, err = txExecutors.ValidateAndExecute(joinTx, exeCtx)
+	require.NoError(t, err)
+	require.Equal(t, []types.UnitID{targetTokenID, extID}, sm.TargetUnits)
+	require.Equal(t, &TokenTypeExtensionData{MaxSupply: 100, Minted: 100, Burned: 0}, getExt())
+	_, err = mint(1)
+	require.ErrorContains(t, err, "minting 1 tokens exceeds the max supply of the type: max supply 100, circulating supply 100")
+
+	// splitting a token doesn't change the supply
+	splitTx := createTxOrder(t, targetTokenID, tokens.TransactionTypeSplitFT, &tokens.SplitFungibleTokenAttributes{
+		TypeID:            typeID,
+		TargetValue:       10,
+		NewOwnerPredicate: templates.AlwaysTrueBytes(),
+		Counter:           1,
+	}, testtransaction.WithAuthProof(&tokens.SplitFungibleTokenAuthProof{TokenTypeOwnerProofs: [][]byte{templates.EmptyArgument()}}))
+	sm, err = txExecutors.ValidateAndExecute(splitTx, exeCtx)
+	require.NoError(t, err)
+	require.NotContains(t, sm.TargetUnits, extID)
+	require.Equal(t, &TokenTypeExtensionData{MaxSupply: 100, Minted: 100, Burned: 0}, getExt())
+}
@@ -80,6 +80,9 @@ func (m *LockTokensModule) validateUnlockNonFungibleToken(tx *types.TransactionO
 	if err := validateUnlockToken(attr, d); err != nil {
 		return err
 	}
+	if err := checkTokenAdminStatus(m.state, tx.UnitID, d.TypeID); err != nil {
+		return err
+	}
 	if err := m.execPredicate(d.Owner(), authProof.OwnerProof, tx.AuthProofSigBytes, exeCtx); err != nil {
 		return fmt.Errorf("evaluating owner predicate: %w", err)
 	}
@@ -94,6 +97,9 @@ func (m *LockTokensModule) validateUnlockFungibleToken(tx *types.TransactionOrde
 	if err := validateUnlockToken(attr, d); err != nil {
 		return err
 	}
+	if err := checkTokenAdminStatus(m.state, tx.UnitID, d.TokenType); err != nil {
+		return err
+	}
 	if err := m.execPredicate(d.Owner(), authProof.OwnerProof, tx.AuthProofSigBytes, exeCtx); err != nil {
 		return fmt.Errorf("evaluating owner predicate: %w", err)
 	}
@@ -50,6 +50,11 @@ type (
 		// State returns clone of transaction system state
 		State() StateReader
 
+		// StateAt returns an immutable snapshot of the transaction system state committed in the given round. Unlike
+		// State it does not clone the state. Only a limited number of recent rounds are available (see
+		// state.WithSnapshotCount).
+		StateAt(roundNumber uint64) (StateReader, error)
+
 		// IsPermissionedMode returns true if permissioned mode is enabled and only transactions from approved parties
 		// are executed.
 		IsPermissionedMode() bool
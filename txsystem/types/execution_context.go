@@ -1,10 +1,15 @@
 package types
 
 import (
+	"errors"
+
 	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill/predicates"
 	"github.com/alphabill-org/alphabill/state"
 )
 
+var ErrPredicateStorageNotAvailable = errors.New("predicate storage is not available")
+
 type (
 	StateInfo interface {
 		GetUnit(id types.UnitID, committed bool) (*state.Unit, error)
@@ -25,6 +30,7 @@ type (
 		initialGas   uint64
 		remainingGas uint64
 		customData   []byte
+		storage      predicates.PredicateStorage
 	}
 )
 
@@ -51,6 +57,17 @@ func (ec *TxExecutionContext) SpendGas(gas uint64) error {
 	return nil
 }
 
+/*
+GasSchedule returns the gas schedule of the current round of the transaction system,
+nil when the transaction system doesn't provide it.
+*/
+func (ec *TxExecutionContext) GasSchedule() *predicates.GasSchedule {
+	if gs, ok := ec.txs.(predicates.GasScheduler); ok {
+		return gs.GasSchedule()
+	}
+	return nil
+}
+
 func (ec *TxExecutionContext) CalculateCost() uint64 {
 	gasUsed := ec.initialGas - ec.remainingGas
 	cost := ec.fee.CalculateCost(gasUsed)
@@ -72,6 +89,25 @@ func (ec *TxExecutionContext) SetData(data []byte) {
 	ec.customData = data
 }
 
+func (ec *TxExecutionContext) ReadPredicateData(predicateHash, key []byte) ([]byte, error) {
+	if ec.storage == nil {
+		return nil, ErrPredicateStorageNotAvailable
+	}
+	return ec.storage.ReadPredicateData(predicateHash, key)
+}
+
+func (ec *TxExecutionContext) WritePredicateData(predicateHash, key, value []byte) error {
+	if ec.storage == nil {
+		return ErrPredicateStorageNotAvailable
+	}
+	return ec.storage.WritePredicateData(predicateHash, key, value)
+}
+
+// SetPredicateStorage sets the storage used by the predicates evaluated in the context.
+func (ec *TxExecutionContext) SetPredicateStorage(storage predicates.PredicateStorage) {
+	ec.storage = storage
+}
+
 func NewExecutionContext(txo *types.TransactionOrder, txSys StateInfo, f FeeCalculation, tb types.RootTrustBase, maxCost uint64) *TxExecutionContext {
 	gasUnits := f.BuyGas(maxCost)
 	return &TxExecutionContext{
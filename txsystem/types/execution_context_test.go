@@ -38,3 +38,30 @@ func Test_newExecutionContext(t *testing.T) {
 		require.Nil(t, u)
 	})
 }
+
+func Test_executionContext_predicateStorage(t *testing.T) {
+	execCtx := NewExecutionContext(nil, &stateInfo{}, NewMockFeeModule(), nil, 10)
+	_, err := execCtx.ReadPredicateData([]byte{1}, []byte{2})
+	require.ErrorIs(t, err, ErrPredicateStorageNotAvailable)
+	require.ErrorIs(t, execCtx.WritePredicateData([]byte{1}, []byte{2}, []byte{3}), ErrPredicateStorageNotAvailable)
+
+	storage := &mockPredicateStorage{data: map[string][]byte{}}
+	execCtx.SetPredicateStorage(storage)
+	require.NoError(t, execCtx.WritePredicateData([]byte{1}, []byte{2}, []byte{3}))
+	v, err := execCtx.ReadPredicateData([]byte{1}, []byte{2})
+	require.NoError(t, err)
+	require.Equal(t, []byte{3}, v)
+}
+
+type mockPredicateStorage struct {
+	data map[string][]byte
+}
+
+func (s *mockPredicateStorage) ReadPredicateData(predicateHash, key []byte) ([]byte, error) {
+	return s.data[string(predicateHash)+string(key)], nil
+}
+
+func (s *mockPredicateStorage) WritePredicateData(predicateHash, key, value []byte) error {
+	s.data[string(predicateHash)+string(key)] = value
+	return nil
+}
@@ -12,6 +12,19 @@ type (
 		TxHandlers() map[uint16]TxExecutor
 	}
 
+	// UnitsDeleter is optionally implemented by the modules which delete units outside
+	// of transactions (ie in the end of block functions).
+	UnitsDeleter interface {
+		// DeletedUnits returns the units deleted by the latest block outside of transactions.
+		DeletedUnits() []*DeletedUnit
+	}
+
+	// DeletedUnit is the ID and the last data of the unit deleted from the state.
+	DeletedUnit struct {
+		UnitID types.UnitID
+		Data   types.UnitData
+	}
+
 	TxHandler[A any, P any] struct {
 		Execute  func(tx *types.TransactionOrder, attributes *A, authProof *P, exeCtx ExecutionContext) (*types.ServerMetadata, error)
 		Validate func(tx *types.TransactionOrder, attributes *A, authProof *P, exeCtx ExecutionContext) error
@@ -0,0 +1,102 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"slices"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/alphabill-org/alphabill-go-base/util"
+
+	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
+)
+
+var _ types.UnitData = (*RoundSchedule)(nil)
+
+/*
+RoundSchedule is the unit data of the schedule units which hold the IDs of the units
+to be processed in the end of a round (ie the dust bills to be deleted). The schedule
+is kept in the state (unit per round) so it is part of the state root and survives
+restarts.
+*/
+type RoundSchedule struct {
+	_     struct{} `cbor:",toarray"`
+	Units []types.UnitID
+}
+
+func (r *RoundSchedule) Write(hasher hash.Hash) error {
+	res, err := types.Cbor.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("round schedule encode error: %w", err)
+	}
+	_, err = hasher.Write(res)
+	return err
+}
+
+func (r *RoundSchedule) SummaryValueInput() uint64 {
+	return 0
+}
+
+func (r *RoundSchedule) Copy() types.UnitData {
+	return &RoundSchedule{Units: slices.Clone(r.Units)}
+}
+
+func (r *RoundSchedule) Owner() []byte {
+	return nil
+}
+
+/*
+NewRoundScheduleID returns ID of the schedule unit of the type "unitType" for the round
+"roundNumber", "unitIDLength" is the unit ID length of the partition.
+*/
+func NewRoundScheduleID(unitIDLength int, unitType []byte, roundNumber uint64) types.UnitID {
+	return types.NewUnitID(unitIDLength, nil, util.Uint64ToBytes(roundNumber), unitType)
+}
+
+/*
+AddToRoundSchedule returns state action which adds the units "ids" to the schedule unit
+"scheduleID", the schedule unit is created when it doesn't exist yet. The caller must
+add the unit log (of the transaction or of the end of the block) to the schedule unit.
+*/
+func AddToRoundSchedule(s *state.State, scheduleID types.UnitID, ids ...types.UnitID) (state.Action, error) {
+	_, err := s.GetUnit(scheduleID, false)
+	switch {
+	case errors.Is(err, avl.ErrNotFound):
+		return state.AddUnit(scheduleID, &RoundSchedule{Units: ids}), nil
+	case err != nil:
+		return nil, fmt.Errorf("reading round schedule: %w", err)
+	}
+	return state.UpdateUnitData(scheduleID,
+		func(data types.UnitData) (types.UnitData, error) {
+			schedule, ok := data.(*RoundSchedule)
+			if !ok {
+				return nil, fmt.Errorf("unit %v does not contain round schedule", scheduleID)
+			}
+			schedule.Units = append(schedule.Units, ids...)
+			return schedule, nil
+		}), nil
+}
+
+/*
+TakeRoundSchedule deletes the schedule unit "scheduleID" and returns the units it held,
+nil is returned when the schedule unit doesn't exist (nothing is scheduled).
+*/
+func TakeRoundSchedule(s *state.State, scheduleID types.UnitID) ([]types.UnitID, error) {
+	u, err := s.GetUnit(scheduleID, false)
+	if err != nil {
+		if errors.Is(err, avl.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading round schedule: %w", err)
+	}
+	schedule, ok := u.Data().(*RoundSchedule)
+	if !ok {
+		return nil, fmt.Errorf("unit %v does not contain round schedule", scheduleID)
+	}
+	if err := s.Apply(state.DeleteUnit(scheduleID)); err != nil {
+		return nil, fmt.Errorf("deleting round schedule: %w", err)
+	}
+	return schedule.Units, nil
+}
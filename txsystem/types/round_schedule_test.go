@@ -0,0 +1,60 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/alphabill-org/alphabill-go-base/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alphabill-org/alphabill/state"
+	"github.com/alphabill-org/alphabill/tree/avl"
+)
+
+func TestNewRoundScheduleID(t *testing.T) {
+	unitType := []byte{34}
+	id := NewRoundScheduleID(33, unitType, 10)
+	require.True(t, id.HasType(unitType))
+	require.Len(t, id, 33)
+	require.NotEqual(t, id, NewRoundScheduleID(33, unitType, 11))
+	require.NotEqual(t, id, NewRoundScheduleID(33, []byte{35}, 10))
+}
+
+func TestRoundSchedule(t *testing.T) {
+	s := state.NewEmptyState()
+	scheduleID := NewRoundScheduleID(33, []byte{34}, 10)
+	id1 := types.UnitID{1}
+	id2 := types.UnitID{2}
+	id3 := types.UnitID{3}
+
+	// nothing scheduled
+	ids, err := TakeRoundSchedule(s, scheduleID)
+	require.NoError(t, err)
+	require.Nil(t, ids)
+
+	// first call creates the schedule unit, next ones append to it
+	action, err := AddToRoundSchedule(s, scheduleID, id1)
+	require.NoError(t, err)
+	require.NoError(t, s.Apply(action))
+	action, err = AddToRoundSchedule(s, scheduleID, id2, id3)
+	require.NoError(t, err)
+	require.NoError(t, s.Apply(action))
+
+	u, err := s.GetUnit(scheduleID, false)
+	require.NoError(t, err)
+	require.Equal(t, &RoundSchedule{Units: []types.UnitID{id1, id2, id3}}, u.Data())
+
+	// taking the schedule deletes the unit
+	ids, err = TakeRoundSchedule(s, scheduleID)
+	require.NoError(t, err)
+	require.Equal(t, []types.UnitID{id1, id2, id3}, ids)
+	_, err = s.GetUnit(scheduleID, false)
+	require.ErrorIs(t, err, avl.ErrNotFound)
+}
+
+func TestRoundSchedule_Copy(t *testing.T) {
+	src := &RoundSchedule{Units: []types.UnitID{{1}}}
+	cpy := src.Copy().(*RoundSchedule)
+	require.Equal(t, src, cpy)
+	cpy.Units[0] = types.UnitID{2}
+	require.Equal(t, types.UnitID{1}, src.Units[0])
+}